@@ -0,0 +1,65 @@
+// gestioncar-admin agrupa las tareas de mantenimiento que no pasan por la API:
+// usuarios, datos iniciales y migraciones. Usa la misma URI_DB que la API.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/DanielChachagua/GestionCar/config"
+	"github.com/DanielChachagua/GestionCar/database"
+	"github.com/DanielChachagua/GestionCar/repositories"
+)
+
+const usage = `uso: gestioncar-admin <comando> [opciones]
+
+comandos:
+  user create -username U -email E -first-name N -last-name A -role R [-password P]
+  user list
+  user reset-password -username U [-password P]
+  user set-role -username U -role R
+  seed                 crea el admin configurado, los workplaces, roles y permisos por defecto
+  migrate up           aplica las migraciones pendientes
+  migrate down [n]     revierte las últimas n migraciones (1 por defecto)
+  migrate status       muestra el estado de las migraciones
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		exitUsage()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := database.Connect(cfg.DatabaseURI)
+	if err != nil {
+		log.Fatalf("Error al conectar con la base de datos: %v", err)
+	}
+	defer database.CloseDB(db)
+
+	repo := &repositories.Repository{DB: db}
+
+	switch os.Args[1] {
+	case "user":
+		err = runUser(repo, os.Args[2:])
+	case "seed":
+		err = database.Seed(db, cfg.Admin)
+	case "migrate":
+		err = runMigrate(db, os.Args[2:])
+	default:
+		exitUsage()
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func exitUsage() {
+	fmt.Fprint(os.Stderr, usage)
+	os.Exit(2)
+}
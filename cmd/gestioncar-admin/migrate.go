@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/DanielChachagua/GestionCar/database"
+	"gorm.io/gorm"
+)
+
+func runMigrate(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		exitUsage()
+	}
+
+	switch args[0] {
+	case "up":
+		return database.Migrate(db)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("cantidad de pasos inválida: %s", args[1])
+			}
+			steps = n
+		}
+		return database.Rollback(db, steps)
+	case "status":
+		status, err := database.Status(db)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSIÓN\tNOMBRE\tAPLICADA")
+		for _, migration := range status {
+			applied := "pendiente"
+			if migration.Applied {
+				applied = migration.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", migration.Version, migration.Name, applied)
+		}
+		return w.Flush()
+	default:
+		exitUsage()
+	}
+	return nil
+}
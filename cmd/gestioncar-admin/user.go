@@ -0,0 +1,186 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/repositories"
+	"github.com/DanielChachagua/GestionCar/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func runUser(repo *repositories.Repository, args []string) error {
+	if len(args) == 0 {
+		exitUsage()
+	}
+
+	switch args[0] {
+	case "create":
+		return createUser(repo, args[1:])
+	case "list":
+		return listUsers(repo)
+	case "reset-password":
+		return resetPassword(repo, args[1:])
+	case "set-role":
+		return setRole(repo, args[1:])
+	default:
+		exitUsage()
+	}
+	return nil
+}
+
+func createUser(repo *repositories.Repository, args []string) error {
+	fs := flag.NewFlagSet("user create", flag.ExitOnError)
+	username := fs.String("username", "", "nombre de usuario")
+	email := fs.String("email", "", "email")
+	firstName := fs.String("first-name", "", "nombre")
+	lastName := fs.String("last-name", "", "apellido")
+	role := fs.String("role", "", "rol (super_admin, admin, ...)")
+	password := fs.String("password", "", "contraseña; si se omite se genera una")
+	fs.Parse(args)
+
+	if *username == "" || *firstName == "" || *lastName == "" {
+		return errors.New("username, first-name y last-name son obligatorios")
+	}
+	if err := validator.New().Var(*email, "required,email"); err != nil {
+		return fmt.Errorf("email inválido: %q", *email)
+	}
+	if err := checkRole(repo, *role); err != nil {
+		return err
+	}
+
+	exist, err := repo.GetUserByUsernameEmail(*username, *email)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return errors.New("el usuario o email ya existe")
+	}
+
+	pass, generated, err := passwordOrGenerate(*password)
+	if err != nil {
+		return err
+	}
+	hash, err := utils.HashPassword(pass)
+	if err != nil {
+		return err
+	}
+
+	user := &models.User{
+		ID:        uuid.NewString(),
+		FirstName: *firstName,
+		LastName:  *lastName,
+		Username:  *username,
+		Email:     *email,
+		Password:  hash,
+		Role:      *role,
+	}
+	if err := repo.CreateUser(user, models.Actor{}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Usuario %s creado (%s)\n", user.Username, user.ID)
+	if generated {
+		fmt.Printf("Contraseña generada: %s\n", pass)
+	}
+	return nil
+}
+
+func listUsers(repo *repositories.Repository) error {
+	users, err := repo.GetAllUsers()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tUSUARIO\tNOMBRE\tEMAIL\tROL\tACTIVO")
+	for _, user := range *users {
+		fmt.Fprintf(w, "%s\t%s\t%s %s\t%s\t%s\t%t\n", user.ID, user.Username, user.FirstName, user.LastName, user.Email, user.Role, user.Active)
+	}
+	return w.Flush()
+}
+
+func resetPassword(repo *repositories.Repository, args []string) error {
+	fs := flag.NewFlagSet("user reset-password", flag.ExitOnError)
+	username := fs.String("username", "", "nombre de usuario")
+	password := fs.String("password", "", "nueva contraseña; si se omite se genera una")
+	fs.Parse(args)
+
+	user, err := repo.GetUserByUsername(*username)
+	if err != nil {
+		return fmt.Errorf("usuario %q: %w", *username, err)
+	}
+
+	pass, generated, err := passwordOrGenerate(*password)
+	if err != nil {
+		return err
+	}
+	hash, err := utils.HashPassword(pass)
+	if err != nil {
+		return err
+	}
+	// igual que desde la API: las sesiones abiertas no pueden renovarse
+	if err := repo.ChangePassword(user.ID, hash, models.Actor{}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Contraseña de %s actualizada, sesiones revocadas\n", user.Username)
+	if generated {
+		fmt.Printf("Contraseña generada: %s\n", pass)
+	}
+	return nil
+}
+
+func setRole(repo *repositories.Repository, args []string) error {
+	fs := flag.NewFlagSet("user set-role", flag.ExitOnError)
+	username := fs.String("username", "", "nombre de usuario")
+	role := fs.String("role", "", "nuevo rol")
+	fs.Parse(args)
+
+	user, err := repo.GetUserByUsername(*username)
+	if err != nil {
+		return fmt.Errorf("usuario %q: %w", *username, err)
+	}
+	if err := checkRole(repo, *role); err != nil {
+		return err
+	}
+	if err := repo.UpdateUserRole(user.ID, *role, models.Actor{}); err != nil {
+		return err
+	}
+
+	fmt.Printf("Rol de %s cambiado de %s a %s\n", user.Username, user.Role, *role)
+	return nil
+}
+
+func checkRole(repo *repositories.Repository, role string) error {
+	if role == "" {
+		return errors.New("el rol es obligatorio")
+	}
+	if _, err := repo.GetRoleByName(role); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("el rol %q no existe (¿falta ejecutar seed?)", role)
+		}
+		return err
+	}
+	return nil
+}
+
+// passwordOrGenerate devuelve la contraseña recibida o una aleatoria si está vacía.
+func passwordOrGenerate(password string) (string, bool, error) {
+	if password != "" {
+		return password, false, nil
+	}
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", false, err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), true, nil
+}
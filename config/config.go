@@ -0,0 +1,334 @@
+// Package config carga la configuración de la API desde un YAML opcional,
+// el archivo .env y las variables de entorno, en ese orden de prioridad creciente.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// MinSecretLength es el largo mínimo de las claves de firma de JWT.
+const MinSecretLength = 32
+
+type Config struct {
+	DatabaseURI string `yaml:"uri_db"`
+	ListenAddr  string `yaml:"listen_addr"`
+	// ProxyHeader es el header del que se toma la IP del cliente cuando la
+	// API corre detrás de un proxy (X-Forwarded-For, X-Real-Ip). Solo se
+	// confía en él si la request viene de una de TrustedProxies, que es
+	// obligatorio si se define.
+	ProxyHeader    string   `yaml:"proxy_header"`
+	TrustedProxies []string `yaml:"trusted_proxies"`
+	Auth           Auth     `yaml:"auth"`
+	Login          Login    `yaml:"login"`
+	Admin          Admin    `yaml:"admin"`
+	Mail           Mail     `yaml:"mail"`
+}
+
+// Auth contiene las claves con las que se firman los tokens y su duración.
+// Las duraciones usan el formato de time.ParseDuration (15m, 12h, ...).
+type Auth struct {
+	SecretKey          string        `yaml:"secret_key"`
+	SecretKeyWorkplace string        `yaml:"secret_key_workplace"`
+	AccessTokenTTL     time.Duration `yaml:"access_token_ttl"`
+	RefreshTokenTTL    time.Duration `yaml:"refresh_token_ttl"`
+	WorkplaceTokenTTL  time.Duration `yaml:"workplace_token_ttl"`
+	PasswordResetTTL   time.Duration `yaml:"password_reset_ttl"`
+}
+
+// Login limita los intentos de login fallidos. Un username con MaxAttempts
+// fallos dentro de Window, o una IP con MaxAttemptsPerIP, queda bloqueado
+// hasta que pase Lockout desde el último fallo.
+type Login struct {
+	MaxAttempts      int           `yaml:"max_attempts"`
+	MaxAttemptsPerIP int           `yaml:"max_attempts_per_ip"`
+	Window           time.Duration `yaml:"window"`
+	Lockout          time.Duration `yaml:"lockout"`
+}
+
+// Admin es el usuario inicial que crea database.Seed. Si Email está vacío no se crea.
+type Admin struct {
+	Email     string `yaml:"email"`
+	Password  string `yaml:"password"`
+	Username  string `yaml:"username"`
+	FirstName string `yaml:"first_name"`
+	LastName  string `yaml:"last_name"`
+	Role      string `yaml:"role"`
+}
+
+// MailDrivers son los valores admitidos para Mail.Driver: smtp envía de
+// verdad, file deja cada correo como .eml en OutboxDir y log lo escribe en el
+// log. Los dos últimos son para desarrollo y tests.
+var MailDrivers = []string{"smtp", "file", "log"}
+
+// Mail configura el envío de correos. ResetURL es el link del frontend al que
+// se le agrega ?token=... en el correo de recuperación de contraseña; si está
+// vacío el correo lleva solo el token.
+type Mail struct {
+	Driver       string `yaml:"driver"`
+	From         string `yaml:"from"`
+	SMTPHost     string `yaml:"smtp_host"`
+	SMTPPort     int    `yaml:"smtp_port"`
+	SMTPUsername string `yaml:"smtp_username"`
+	SMTPPassword string `yaml:"smtp_password"`
+	OutboxDir    string `yaml:"outbox_dir"`
+	ResetURL     string `yaml:"reset_url"`
+}
+
+// Load lee el YAML indicado en CONFIG_FILE (si existe), el .env y las variables
+// de entorno. No valida: eso lo hace Validate.
+func Load() (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error al leer .env: %w", err)
+	}
+
+	cfg := &Config{
+		ListenAddr: ":3000",
+		Auth: Auth{
+			AccessTokenTTL:    15 * time.Minute,
+			RefreshTokenTTL:   30 * 24 * time.Hour,
+			WorkplaceTokenTTL: 12 * time.Hour,
+			PasswordResetTTL:  time.Hour,
+		},
+		Login: Login{
+			MaxAttempts:      5,
+			MaxAttemptsPerIP: 20,
+			Window:           15 * time.Minute,
+			Lockout:          15 * time.Minute,
+		},
+		Admin: Admin{Role: "super_admin"},
+		Mail: Mail{
+			Driver:    "log",
+			From:      "GestionCar <no-reply@gestioncar.local>",
+			SMTPPort:  587,
+			OutboxDir: "outbox",
+		},
+	}
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer %s: %w", path, err)
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("error al leer %s: %w", path, err)
+		}
+	}
+
+	fromEnv(&cfg.DatabaseURI, "URI_DB")
+	fromEnv(&cfg.ListenAddr, "LISTEN_ADDR")
+	fromEnv(&cfg.ProxyHeader, "PROXY_HEADER")
+	if value, ok := os.LookupEnv("TRUSTED_PROXIES"); ok && value != "" {
+		cfg.TrustedProxies = nil
+		for _, proxy := range strings.Split(value, ",") {
+			if proxy = strings.TrimSpace(proxy); proxy != "" {
+				cfg.TrustedProxies = append(cfg.TrustedProxies, proxy)
+			}
+		}
+	}
+	fromEnv(&cfg.Auth.SecretKey, "SECRET_KEY")
+	fromEnv(&cfg.Auth.SecretKeyWorkplace, "SECRET_KEY_WORKPLACE")
+	for _, ttl := range []struct {
+		target *time.Duration
+		key    string
+	}{
+		{&cfg.Auth.AccessTokenTTL, "ACCESS_TOKEN_TTL"},
+		{&cfg.Auth.RefreshTokenTTL, "REFRESH_TOKEN_TTL"},
+		{&cfg.Auth.WorkplaceTokenTTL, "WORKPLACE_TOKEN_TTL"},
+		{&cfg.Auth.PasswordResetTTL, "PASSWORD_RESET_TTL"},
+		{&cfg.Login.Window, "LOGIN_WINDOW"},
+		{&cfg.Login.Lockout, "LOGIN_LOCKOUT"},
+	} {
+		if err := durationFromEnv(ttl.target, ttl.key); err != nil {
+			return nil, err
+		}
+	}
+	if err := intFromEnv(&cfg.Login.MaxAttempts, "LOGIN_MAX_ATTEMPTS"); err != nil {
+		return nil, err
+	}
+	if err := intFromEnv(&cfg.Login.MaxAttemptsPerIP, "LOGIN_MAX_ATTEMPTS_PER_IP"); err != nil {
+		return nil, err
+	}
+	fromEnv(&cfg.Admin.Email, "ADMIN_EMAIL")
+	fromEnv(&cfg.Admin.Password, "ADMIN_PASSWORD")
+	fromEnv(&cfg.Admin.Username, "ADMIN_USERNAME")
+	fromEnv(&cfg.Admin.FirstName, "FIRSTNAME_ADMIN")
+	fromEnv(&cfg.Admin.LastName, "LASTNAME_ADMIN")
+	fromEnv(&cfg.Admin.Role, "ROLE_ADMIN")
+	fromEnv(&cfg.Mail.Driver, "MAIL_DRIVER")
+	fromEnv(&cfg.Mail.From, "MAIL_FROM")
+	fromEnv(&cfg.Mail.SMTPHost, "SMTP_HOST")
+	if err := intFromEnv(&cfg.Mail.SMTPPort, "SMTP_PORT"); err != nil {
+		return nil, err
+	}
+	fromEnv(&cfg.Mail.SMTPUsername, "SMTP_USERNAME")
+	fromEnv(&cfg.Mail.SMTPPassword, "SMTP_PASSWORD")
+	fromEnv(&cfg.Mail.OutboxDir, "MAIL_OUTBOX_DIR")
+	fromEnv(&cfg.Mail.ResetURL, "PASSWORD_RESET_URL")
+
+	return cfg, nil
+}
+
+func fromEnv(target *string, key string) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		*target = value
+	}
+}
+
+func durationFromEnv(target *time.Duration, key string) error {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%s inválida: %w", key, err)
+	}
+	*target = d
+	return nil
+}
+
+func intFromEnv(target *int, key string) error {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%s inválido: %w", key, err)
+	}
+	*target = n
+	return nil
+}
+
+// Validate devuelve todos los problemas de configuración juntos.
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.DatabaseURI == "" {
+		problems = append(problems, "URI_DB es obligatoria")
+	}
+	if c.ListenAddr == "" {
+		problems = append(problems, "LISTEN_ADDR no puede estar vacía")
+	}
+	if len(c.TrustedProxies) > 0 && c.ProxyHeader == "" {
+		problems = append(problems, "PROXY_HEADER es obligatorio si se define TRUSTED_PROXIES")
+	}
+	if c.ProxyHeader != "" && len(c.TrustedProxies) == 0 {
+		// sin proxies de confianza cualquier cliente podría elegir su IP y
+		// saltear el límite de intentos de login por IP
+		problems = append(problems, "TRUSTED_PROXIES es obligatorio si se define PROXY_HEADER")
+	}
+	problems = append(problems, c.Auth.validate()...)
+	problems = append(problems, c.Login.validate()...)
+	problems = append(problems, c.Admin.validate()...)
+	problems = append(problems, c.Mail.validate()...)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("configuración inválida:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+func (a Auth) validate() []string {
+	var problems []string
+	secrets := []struct{ name, value string }{
+		{"SECRET_KEY", a.SecretKey},
+		{"SECRET_KEY_WORKPLACE", a.SecretKeyWorkplace},
+	}
+	for _, secret := range secrets {
+		switch {
+		case secret.value == "":
+			problems = append(problems, secret.name+" es obligatoria")
+		case len(secret.value) < MinSecretLength:
+			problems = append(problems, fmt.Sprintf("%s debe tener al menos %d caracteres", secret.name, MinSecretLength))
+		}
+	}
+	if a.SecretKey != "" && a.SecretKey == a.SecretKeyWorkplace {
+		problems = append(problems, "SECRET_KEY y SECRET_KEY_WORKPLACE deben ser distintas")
+	}
+	ttls := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"ACCESS_TOKEN_TTL", a.AccessTokenTTL},
+		{"REFRESH_TOKEN_TTL", a.RefreshTokenTTL},
+		{"WORKPLACE_TOKEN_TTL", a.WorkplaceTokenTTL},
+		{"PASSWORD_RESET_TTL", a.PasswordResetTTL},
+	}
+	for _, ttl := range ttls {
+		if ttl.value <= 0 {
+			problems = append(problems, ttl.name+" debe ser mayor a 0")
+		}
+	}
+	if a.AccessTokenTTL > 0 && a.RefreshTokenTTL > 0 && a.RefreshTokenTTL <= a.AccessTokenTTL {
+		problems = append(problems, "REFRESH_TOKEN_TTL debe ser mayor que ACCESS_TOKEN_TTL")
+	}
+	return problems
+}
+
+func (l Login) validate() []string {
+	var problems []string
+	if l.MaxAttempts <= 0 {
+		problems = append(problems, "LOGIN_MAX_ATTEMPTS debe ser mayor a 0")
+	}
+	if l.MaxAttemptsPerIP < l.MaxAttempts {
+		problems = append(problems, "LOGIN_MAX_ATTEMPTS_PER_IP no puede ser menor que LOGIN_MAX_ATTEMPTS")
+	}
+	if l.Window <= 0 {
+		problems = append(problems, "LOGIN_WINDOW debe ser mayor a 0")
+	}
+	if l.Lockout <= 0 {
+		problems = append(problems, "LOGIN_LOCKOUT debe ser mayor a 0")
+	}
+	return problems
+}
+
+func (a Admin) validate() []string {
+	if a.Email == "" {
+		return nil
+	}
+	var problems []string
+	if a.Username == "" {
+		problems = append(problems, "ADMIN_USERNAME es obligatorio si se define ADMIN_EMAIL")
+	}
+	if len(a.Password) < 8 {
+		problems = append(problems, "ADMIN_PASSWORD debe tener al menos 8 caracteres")
+	}
+	if a.Role == "" {
+		problems = append(problems, "ROLE_ADMIN es obligatorio si se define ADMIN_EMAIL")
+	}
+	return problems
+}
+
+func (m Mail) validate() []string {
+	if !slices.Contains(MailDrivers, m.Driver) {
+		return []string{fmt.Sprintf("MAIL_DRIVER debe ser uno de %s", strings.Join(MailDrivers, ", "))}
+	}
+	var problems []string
+	if m.From == "" {
+		problems = append(problems, "MAIL_FROM es obligatorio")
+	}
+	switch m.Driver {
+	case "smtp":
+		if m.SMTPHost == "" {
+			problems = append(problems, "SMTP_HOST es obligatorio con MAIL_DRIVER=smtp")
+		}
+		if m.SMTPPort <= 0 || m.SMTPPort > 65535 {
+			problems = append(problems, "SMTP_PORT debe estar entre 1 y 65535")
+		}
+	case "file":
+		if m.OutboxDir == "" {
+			problems = append(problems, "MAIL_OUTBOX_DIR es obligatorio con MAIL_DRIVER=file")
+		}
+	}
+	return problems
+}
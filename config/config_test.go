@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validConfig() *Config {
+	return &Config{
+		DatabaseURI: "sqlite://gestioncar.db",
+		ListenAddr:  ":3000",
+		Auth: Auth{
+			SecretKey:          strings.Repeat("a", MinSecretLength),
+			SecretKeyWorkplace: strings.Repeat("b", MinSecretLength),
+			AccessTokenTTL:     15 * time.Minute,
+			RefreshTokenTTL:    24 * time.Hour,
+			WorkplaceTokenTTL:  12 * time.Hour,
+			PasswordResetTTL:   time.Hour,
+		},
+		Login: Login{MaxAttempts: 5, MaxAttemptsPerIP: 20, Window: 15 * time.Minute, Lockout: 15 * time.Minute},
+		Mail:  Mail{Driver: "log", From: "no-reply@gestioncar.test"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("configuración válida rechazada: %v", err)
+	}
+
+	cases := map[string]func(c *Config){
+		"sin URI_DB":         func(c *Config) { c.DatabaseURI = "" },
+		"secret vacío":       func(c *Config) { c.Auth.SecretKey = "" },
+		"secret corto":       func(c *Config) { c.Auth.SecretKeyWorkplace = "corto" },
+		"secrets iguales":    func(c *Config) { c.Auth.SecretKeyWorkplace = c.Auth.SecretKey },
+		"access sin ttl":     func(c *Config) { c.Auth.AccessTokenTTL = 0 },
+		"refresh corto":      func(c *Config) { c.Auth.RefreshTokenTTL = time.Minute },
+		"admin sin password": func(c *Config) { c.Admin = Admin{Email: "a@b.com", Username: "admin", Role: "super_admin"} },
+		"admin sin username": func(c *Config) { c.Admin = Admin{Email: "a@b.com", Password: "12345678", Role: "super_admin"} },
+		"reset sin ttl":      func(c *Config) { c.Auth.PasswordResetTTL = 0 },
+		"mail sin driver":    func(c *Config) { c.Mail.Driver = "" },
+		"smtp sin host":      func(c *Config) { c.Mail.Driver = "smtp"; c.Mail.SMTPPort = 587 },
+		"file sin outbox":    func(c *Config) { c.Mail.Driver = "file" },
+		"login sin intentos": func(c *Config) { c.Login.MaxAttempts = 0 },
+		"ip menos que user":  func(c *Config) { c.Login.MaxAttemptsPerIP = 3 },
+		"login sin lockout":  func(c *Config) { c.Login.Lockout = 0 },
+		"proxies sin header": func(c *Config) { c.TrustedProxies = []string{"10.0.0.1"} },
+		"header sin proxies": func(c *Config) { c.ProxyHeader = "X-Forwarded-For" },
+	}
+	for name, mutate := range cases {
+		cfg := validConfig()
+		mutate(cfg)
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: se esperaba error", name)
+		}
+	}
+}
+
+func TestLoadYAMLAndEnv(t *testing.T) {
+	t.Chdir(t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := "uri_db: sqlite://desde-yaml.db\nlisten_addr: \":8080\"\nauth:\n  secret_key: desde-yaml\n  access_token_ttl: 5m\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("URI_DB", "")
+	t.Setenv("LISTEN_ADDR", "")
+	t.Setenv("SECRET_KEY", "desde-env")
+	t.Setenv("REFRESH_TOKEN_TTL", "48h")
+	t.Setenv("MAIL_DRIVER", "smtp")
+	t.Setenv("SMTP_HOST", "smtp.gestioncar.test")
+	t.Setenv("SMTP_PORT", "2525")
+	t.Setenv("LOGIN_MAX_ATTEMPTS", "3")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.1, 10.0.0.2")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DatabaseURI != "sqlite://desde-yaml.db" || cfg.ListenAddr != ":8080" {
+		t.Errorf("no se tomaron los valores del YAML: %+v", cfg)
+	}
+	if cfg.Auth.SecretKey != "desde-env" {
+		t.Errorf("la variable de entorno debe tener prioridad sobre el YAML, secret_key = %s", cfg.Auth.SecretKey)
+	}
+	if cfg.Auth.AccessTokenTTL != 5*time.Minute || cfg.Auth.RefreshTokenTTL != 48*time.Hour || cfg.Auth.WorkplaceTokenTTL != 12*time.Hour {
+		t.Errorf("duraciones de tokens: %+v", cfg.Auth)
+	}
+	if cfg.Auth.PasswordResetTTL != time.Hour || cfg.Mail.Driver != "smtp" || cfg.Mail.SMTPHost != "smtp.gestioncar.test" || cfg.Mail.SMTPPort != 2525 || cfg.Mail.From == "" {
+		t.Errorf("configuración de correo: %+v", cfg.Mail)
+	}
+	if cfg.Login.MaxAttempts != 3 || cfg.Login.MaxAttemptsPerIP != 20 || cfg.Login.Lockout != 15*time.Minute {
+		t.Errorf("configuración de login: %+v", cfg.Login)
+	}
+	if len(cfg.TrustedProxies) != 2 || cfg.TrustedProxies[1] != "10.0.0.2" {
+		t.Errorf("trusted proxies = %v", cfg.TrustedProxies)
+	}
+
+	t.Setenv("SMTP_PORT", "veinticinco")
+	if _, err := Load(); err == nil {
+		t.Error("se esperaba error con SMTP_PORT inválido")
+	}
+	t.Setenv("SMTP_PORT", "")
+
+	t.Setenv("ACCESS_TOKEN_TTL", "quince minutos")
+	if _, err := Load(); err == nil {
+		t.Error("se esperaba error con ACCESS_TOKEN_TTL inválida")
+	}
+}
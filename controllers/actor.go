@@ -0,0 +1,19 @@
+package controllers
+
+import (
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// auditActor arma el models.Actor de la request para el AuditLog: el usuario
+// logueado y, si la ruta es de un workplace, ese workplace.
+func auditActor(c *fiber.Ctx) models.Actor {
+	var actor models.Actor
+	if user, ok := c.Locals("user").(*models.User); ok && user != nil {
+		actor.UserID = user.ID
+	}
+	if workplace, ok := c.Locals("workplace").(*models.Workplace); ok && workplace != nil {
+		actor.WorkplaceID = workplace.ID
+	}
+	return actor
+}
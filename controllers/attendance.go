@@ -6,6 +6,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type AttendanceController struct {
+	service *services.AttendanceService
+}
+
+func NewAttendanceController(service *services.AttendanceService) *AttendanceController {
+	return &AttendanceController{service: service}
+}
+
 // GetAttendanceByID godoc
 //	@Summary		Get Attendance By ID
 //	@Description	Get Attendance by ID
@@ -15,58 +23,34 @@ import (
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string	true	"Workplace Token"
 //	@Param			id					path		string	true	"ID of Attendance"
-//	@Success		200					{object}	models.Response{body=models.AttendanceLaundry}
+//	@Success		200					{object}	models.Response{body=models.Attendance}
+//	@Header			200					{string}	ETag	"Versión del registro, para enviar en If-Match"
 //	@Failure		400					{object}	models.Response
 //	@Failure		401					{object}	models.Response
 //	@Failure		404					{object}	models.Response
 //	@Failure		500					{object}	models.Response
 //	@Router			/attendance/{id} [get]
-func GetAttendanceByID(c *fiber.Ctx) error {
+func (ctrl *AttendanceController) GetAttendanceByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetAttendanceByID(id, workplace.Identifier)
+	attendance, err := ctrl.service.GetAttendanceByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Asistencia obtenida con éxito",
-		})
-	}
+	setETag(c, attendance.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    attendance,
 		Message: "Asistencia obtenida con éxito",
 	})
 }
@@ -79,6 +63,9 @@ func GetAttendanceByID(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string	true	"Workplace Token"
+//	@Param			page				query		int		false	"Página, desde 1"
+//	@Param			page_size			query		int		false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string	false	"Campos de orden separados por coma, con - para descendente"
 //	@Success		200					{object}	models.Response
 //	@Failure		400					{object}	models.Response
 //	@Failure		401					{object}	models.Response
@@ -86,44 +73,27 @@ func GetAttendanceByID(c *fiber.Ctx) error {
 //	@Failure		404					{object}	models.Response
 //	@Failure		500					{object}	models.Response
 //	@Router			/attendance/get_all [get]
-func GetAllAttendances(c *fiber.Ctx) error {
+func (ctrl *AttendanceController) GetAllAttendances(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundries, workshops, err := services.GetAllAttendances(workplace.Identifier)
+	params, err := listParams(c)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundries != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundries,
-			Message: "Asistencia obtenida con éxito",
-		})
+	attendances, pagination, err := ctrl.service.GetAllAttendances(workplace.ID, params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshops,
+		Body:    attendances,
 		Message: "Asistencia obtenida con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -136,66 +106,35 @@ func GetAllAttendances(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string				true	"Workplace Token"
 //	@Param			dateFrom			body		models.DateBetween	true	"Date Between"
-//	@Success		200					{object}	models.Response{body=[]models.AttendanceLaundry}
+//	@Success		200					{object}	models.Response{body=[]models.Attendance}
 //	@Failure		400					{object}	models.Response
 //	@Failure		401					{object}	models.Response
 //	@Failure		403					{object}	models.Response
 //	@Failure		422					{object}	models.Response
 //	@Failure		500					{object}	models.Response
 //	@Router			/attendance/get_by_date [post]
-func GetAllAttendancesByDate(c *fiber.Ctx) error {
+func (ctrl *AttendanceController) GetAllAttendancesByDate(c *fiber.Ctx) error {
 	var dateBeetwen models.DateBetween
 	if err := c.BodyParser(&dateBeetwen); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := dateBeetwen.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundries, workshops, err := services.GetAllAttendancesByDate(dateBeetwen.DateFrom, dateBeetwen.DateTo,workplace.Identifier)
+	attendances, err := ctrl.service.GetAllAttendancesByDate(dateBeetwen.DateFrom, dateBeetwen.DateTo,workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
-	}
-
-	if laundries != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundries,
-			Message: "Asistencias obtenidas con éxito",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshops,
+		Body:    attendances,
 		Message: "Asistencias obtenidas con éxito",
 	})
 }
@@ -216,52 +155,25 @@ func GetAllAttendancesByDate(c *fiber.Ctx) error {
 //	@Failure		404					{object}	models.Response
 //	@Failure		500					{object}	models.Response
 //	@Router			/attendance/get_by_employee/{employee_id} [get]
-func GetAttendanceByEmployeeID(c *fiber.Ctx) error {
+func (ctrl *AttendanceController) GetAttendanceByEmployeeID(c *fiber.Ctx) error {
 	employee_id := c.Params("employee_id")
 	if employee_id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetAttendanceByEmployeeID(employee_id, workplace.Identifier)
+	attendances, err := ctrl.service.GetAttendanceByEmployeeID(employee_id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
-	}
-
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Asistencias obtenidas con éxito",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    attendances,
 		Message: "Asistencias obtenidas con éxito",
 	})
 }
@@ -275,54 +187,33 @@ func GetAttendanceByEmployeeID(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string					true	"Workplace Token"
 //	@Param			attendanceCreate	body		models.AttendanceCreate	true	"Employee body"
+//	@Param			Idempotency-Key		header		string					false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200					{object}	models.Response
 //	@Failure		400					{object}	models.Response
 //	@Failure		401					{object}	models.Response
 //	@Failure		403					{object}	models.Response
 //	@Failure		404					{object}	models.Response
+//	@Failure		409					{object}	models.Response	"Idempotency-Key in use by a request still in progress"
 //	@Failure		422					{object}	models.Response
 //	@Failure		500					{object}	models.Response
 //	@Router			/attendance/create [post]
-func CreateAttendance(c *fiber.Ctx) error {
+func (ctrl *AttendanceController) CreateAttendance(c *fiber.Ctx) error {
 	var attendanceCreate models.AttendanceCreate
 	if err := c.BodyParser(&attendanceCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := attendanceCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	id, err := services.CreateAttendance(&attendanceCreate, workplace.Identifier)
+	id, err := ctrl.service.CreateAttendance(&attendanceCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -341,54 +232,37 @@ func CreateAttendance(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string					true	"Workplace Token"
 //	@Param			attendanceUpdate	body		models.AttendanceUpdate	true	"Employee body"
+//	@Param			If-Match			header		string					false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
 //	@Success		200					{object}	models.Response
 //	@Failure		400					{object}	models.Response
 //	@Failure		401					{object}	models.Response
 //	@Failure		403					{object}	models.Response
 //	@Failure		404					{object}	models.Response
+//	@Failure		409					{object}	models.Response{body=models.Attendance}	"Version conflict, body has the current state"
 //	@Failure		422					{object}	models.Response
+//	@Failure		428					{object}	models.Response	"Version missing: send version or If-Match"
 //	@Failure		500					{object}	models.Response
 //	@Router			/attendance/update [put]
-func UpdateAttendance(c *fiber.Ctx) error {
+func (ctrl *AttendanceController) UpdateAttendance(c *fiber.Ctx) error {
 	var attendanceUpdate models.AttendanceUpdate
 	if err := c.BodyParser(&attendanceUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &attendanceUpdate.Version); err != nil {
+		return err
 	}
 	if err := attendanceUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.UpdateAttendance(&attendanceUpdate, workplace.Identifier)
+	err := ctrl.service.UpdateAttendance(&attendanceUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -415,40 +289,21 @@ func UpdateAttendance(c *fiber.Ctx) error {
 //	@Failure		422					{object}	models.Response
 //	@Failure		500					{object}	models.Response
 //	@Router			/attendance/delete/{id} [delete]
-func DeleteAttendance(c *fiber.Ctx) error {
+func (ctrl *AttendanceController) DeleteAttendance(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.DeleteAttendance(id, workplace.Identifier)
+	err := ctrl.service.DeleteAttendance(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
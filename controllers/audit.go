@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditController struct {
+	service *services.AuditService
+}
+
+func NewAuditController(service *services.AuditService) *AuditController {
+	return &AuditController{service: service}
+}
+
+// AuditGetAll godoc
+//	@Summary		List audit trail
+//	@Description	Lists the recorded changes, newest first. Filters: entity_type, entity_id, user_id, workplace_id, action (create, update, delete, restore, purge), created_at_from / created_at_to.
+//	@Tags			Audit
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int		false	"Página, desde 1"
+//	@Param			page_size	query		int		false	"Elementos por página (máximo 100)"
+//	@Param			sort		query		string	false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200			{object}	models.Response{body=[]models.AuditLog,meta=models.Pagination}
+//	@Failure		400			{object}	models.Response	"Bad Request"
+//	@Failure		401			{object}	models.Response	"Auth is required"
+//	@Failure		403			{object}	models.Response	"Not Authorized"
+//	@Failure		500			{object}	models.Response
+//	@Router			/audit/get_all [get]
+func (ctrl *AuditController) AuditGetAll(c *fiber.Ctx) error {
+	params, err := listParams(c)
+	if err != nil {
+		return err
+	}
+
+	audits, pagination, err := ctrl.service.AuditGetAll(params)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    audits,
+		Message: "Auditoría obtenida con éxito",
+		Meta:    pagination,
+	})
+}
+
+// AuditGetByID godoc
+//	@Summary		Get audit entry by id
+//	@Description	Get one recorded change with its before and after snapshots
+//	@Tags			Audit
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Id del registro de auditoría"
+//	@Success		200	{object}	models.Response{body=models.AuditLog}
+//	@Failure		400	{object}	models.Response
+//	@Failure		401	{object}	models.Response
+//	@Failure		403	{object}	models.Response
+//	@Failure		404	{object}	models.Response
+//	@Failure		500	{object}	models.Response
+//	@Router			/audit/{id} [get]
+func (ctrl *AuditController) AuditGetByID(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	audit, err := ctrl.service.AuditGetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    audit,
+		Message: "Registro de auditoría obtenido con éxito",
+	})
+}
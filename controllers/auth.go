@@ -4,66 +4,128 @@ import (
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/DanielChachagua/GestionCar/services"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 )
 
+type AuthController struct {
+	service *services.AuthService
+}
+
+func NewAuthController(service *services.AuthService) *AuthController {
+	return &AuthController{service: service}
+}
+
 //  Login godoc
 //	@Summary		Login user
-//	@Description	Login user required identifier and password
+//	@Description	Login user required identifier and password. Unknown user and wrong password give the same 401; too many failures for the username or the IP lock them temporarily (429 with Retry-After).
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			credentials	body		models.AuthLogin	true	"Credentials"
-//	@Success		200			{object}	models.Response
+//	@Success		200			{object}	models.Response{body=models.AuthTokens}
 //	@Failure		400			{object}	models.Response
-//	@Failure		401			{object}	models.Response
+//	@Failure		401			{object}	models.Response	"Invalid credentials"
 //	@Failure		422			{object}	models.Response
-//	@Failure		404			{object}	models.Response
+//	@Failure		429			{object}	models.Response	"Too many failed attempts"
 //	@Failure		500			{object}	models.Response
 //	@Router			/auth/login [post]
-func AuthLogin(c *fiber.Ctx) error {
+func (ctrl *AuthController) AuthLogin(c *fiber.Ctx) error {
 	var loginRequest models.AuthLogin
 	if err := c.BodyParser(&loginRequest); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status: false, 
-			Body: nil, 
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 
 	if err := loginRequest.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status: false, 
-			Body: nil, 
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
-	token, err := services.AuthLogin(loginRequest.Username, loginRequest.Password)
+	tokens, err := ctrl.service.AuthLogin(loginRequest.Username, loginRequest.Password, services.LoginClient{
+		IP:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	})
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    token,
+		Body:    tokens,
 		Message: "Token obtenido con éxito",
 	})
 }
 
+//  Refresh godoc
+//	@Summary		Refresh token
+//	@Description	Exchange a refresh token for a new access token; the refresh token is rotated
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			refresh	body		models.RefreshRequest	true	"Refresh token"
+//	@Success		200		{object}	models.Response{body=models.AuthTokens}
+//	@Failure		400		{object}	models.Response
+//	@Failure		401		{object}	models.Response
+//	@Failure		422		{object}	models.Response
+//	@Failure		500		{object}	models.Response
+//	@Router			/auth/refresh [post]
+func (ctrl *AuthController) AuthRefresh(c *fiber.Ctx) error {
+	var refreshRequest models.RefreshRequest
+	if err := c.BodyParser(&refreshRequest); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+
+	if err := refreshRequest.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	tokens, err := ctrl.service.AuthRefresh(refreshRequest.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    tokens,
+		Message: "Token renovado con éxito",
+	})
+}
+
+//  Logout godoc
+//	@Summary		Logout
+//	@Description	Revoke the current access token and, if sent, the refresh token
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			logout	body		models.LogoutRequest	false	"Refresh token"
+//	@Success		200		{object}	models.Response
+//	@Failure		400		{object}	models.Response
+//	@Failure		401		{object}	models.Response
+//	@Failure		403		{object}	models.Response
+//	@Failure		500		{object}	models.Response
+//	@Router			/auth/logout [post]
+func (ctrl *AuthController) AuthLogout(c *fiber.Ctx) error {
+	var logoutRequest models.LogoutRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&logoutRequest); err != nil {
+			return models.BadRequest("Invalid request", err)
+		}
+	}
+
+	claims := c.Locals("claims").(jwt.MapClaims)
+	if err := ctrl.service.AuthLogout(claims, logoutRequest.RefreshToken); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Sesión cerrada con éxito",
+	})
+}
+
 //  Login godoc
 //	@Summary		Login Workplace
-//	@Description	Login workplace required workplace_id
+//	@Description	Login workplace required workplace_id; the user's role must have access to the workplace
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
@@ -72,27 +134,18 @@ func AuthLogin(c *fiber.Ctx) error {
 //	@Success		200				{object}	models.Response
 //	@Failure		400				{object}	models.Response
 //	@Failure		401				{object}	models.Response
+//	@Failure		403				{object}	models.Response
 //	@Failure		422				{object}	models.Response
 //	@Failure		404				{object}	models.Response
 //	@Failure		500				{object}	models.Response
 //	@Router			/auth/workplace_login/{workplace_id} [get]
-func AuthWorkplace(c *fiber.Ctx) error {
+func (ctrl *AuthController) AuthWorkplace(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
 	id := c.Params("workplace_id")
 
-	token, err := services.AuthWorkplace(id)
+	token, err := ctrl.service.AuthWorkplace(user, id)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -100,4 +153,39 @@ func AuthWorkplace(c *fiber.Ctx) error {
 		Body:    token,
 		Message: "Token obtenido con éxito",
 	})
-}
\ No newline at end of file
+}
+
+// LoginHistory godoc
+//	@Summary		Login history
+//	@Description	Lists login attempts, newest first. Filters: user_id, username, ip, user_agent, success, result (success, invalid_credentials, inactive, locked), created_at_from / created_at_to.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int		false	"Página, desde 1"
+//	@Param			page_size	query		int		false	"Elementos por página (máximo 100)"
+//	@Param			sort		query		string	false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200			{object}	models.Response{body=[]models.LoginAttempt,meta=models.Pagination}
+//	@Failure		400			{object}	models.Response	"Bad Request"
+//	@Failure		401			{object}	models.Response	"Auth is required"
+//	@Failure		403			{object}	models.Response	"Not Authorized"
+//	@Failure		500			{object}	models.Response
+//	@Router			/auth/login_history [get]
+func (ctrl *AuthController) LoginHistory(c *fiber.Ctx) error {
+	params, err := listParams(c)
+	if err != nil {
+		return err
+	}
+
+	attempts, pagination, err := ctrl.service.AuthLoginHistory(params)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    attempts,
+		Message: "Historial de login obtenido con éxito",
+		Meta:    pagination,
+	})
+}
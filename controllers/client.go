@@ -6,6 +6,13 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type ClientController struct {
+	service *services.ClientService
+}
+
+func NewClientController(service *services.ClientService) *ClientController {
+	return &ClientController{service: service}
+}
 
 // ClientGetByID godoc
 //	@Summary		Get client by id
@@ -16,38 +23,26 @@ import (
 //	@Security		BearerAuth
 //	@Param			id	path		string	true	"Id del cliente"
 //	@Success		200	{object}	models.Response{body=models.Client}
+//	@Header			200	{string}	ETag	"Versión del registro, para enviar en If-Match"
 //	@Failure		400	{object}	models.Response
 //	@Failure		401	{object}	models.Response
 //	@Failure		403	{object}	models.Response
 //	@Failure		404	{object}	models.Response
 //	@Failure		500	{object}	models.Response
 //	@Router			/client/{id} [get]
-func ClientGetByID(c *fiber.Ctx) error {
+func (ctrl *ClientController) ClientGetByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
-	client, err := services.ClientGetByID(id)
+	client, err := ctrl.service.ClientGetByID(id)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
+	setETag(c, client.Version)
+
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
 		Body:    client,
@@ -62,34 +57,32 @@ func ClientGetByID(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Success		200	{object}	models.Response{body=[]models.Client}
-//	@Failure		400	{object}	models.Response
-//	@Failure		401	{object}	models.Response
-//	@Failure		403	{object}	models.Response
-//	@Failure		404	{object}	models.Response
-//	@Failure		500	{object}	models.Response
+//	@Param			page		query		int		false	"Página, desde 1"
+//	@Param			page_size	query		int		false	"Elementos por página (máximo 100)"
+//	@Param			sort		query		string	false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200			{object}	models.Response{body=[]models.Client,meta=models.Pagination}
+//	@Failure		400			{object}	models.Response
+//	@Failure		401			{object}	models.Response
+//	@Failure		403			{object}	models.Response
+//	@Failure		404			{object}	models.Response
+//	@Failure		500			{object}	models.Response
 //	@Router			/client/get_all [get]
-func ClientGetAll(c *fiber.Ctx) error {
-	clients, err := services.ClientGetAll()
+func (ctrl *ClientController) ClientGetAll(c *fiber.Ctx) error {
+	params, err := listParams(c)
+	if err != nil {
+		return err
+	}
+
+	clients, pagination, err := ctrl.service.ClientGetAll(params)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
 		Body:    clients,
 		Message: "Clientes obtenidos con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -108,30 +101,15 @@ func ClientGetAll(c *fiber.Ctx) error {
 //	@Failure		404		{object}	models.Response
 //	@Failure		500		{object}	models.Response
 //	@Router			/client/get_by_name [get]
-func ClientGetByName(c *fiber.Ctx) error {
+func (ctrl *ClientController) ClientGetByName(c *fiber.Ctx) error {
 	name := c.Query("name")
 	if name == "" || len(name) < 3 {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "El valor no debe de ser vacio o menor a 3 caracteres",
-		})
+		return models.BadRequest("El valor no debe de ser vacio o menor a 3 caracteres", nil)
 	}
 
-	clients, err := services.ClientGetByName(name)
+	clients, err := ctrl.service.ClientGetByName(name)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -149,44 +127,31 @@ func ClientGetByName(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			ClientUpdate	body		models.ClientUpdate	true	"Cliente a actualizar"
+//	@Param			If-Match		header		string				false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
 //	@Success		200				{object}	models.Response
 //	@Failure		400				{object}	models.Response
 //	@Failure		401				{object}	models.Response
 //	@Failure		403				{object}	models.Response
 //	@Failure		404				{object}	models.Response
+//	@Failure		409				{object}	models.Response{body=models.Client}	"Version conflict (body has the current state), or CUIL/DNI already taken, maybe by a client in the trash"
 //	@Failure		422				{object}	models.Response
+//	@Failure		428				{object}	models.Response	"Version missing: send version or If-Match"
 //	@Failure		500				{object}	models.Response
 //	@Router			/client/update [put]
-func ClientUpdate(c *fiber.Ctx) error {
+func (ctrl *ClientController) ClientUpdate(c *fiber.Ctx) error {
 	var clientUpdate models.ClientUpdate
 	if err := c.BodyParser(&clientUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &clientUpdate.Version); err != nil {
+		return err
 	}
 	if err := clientUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
-	clientCreated, err := services.ClientUpdate(&clientUpdate)
+	clientCreated, err := ctrl.service.ClientUpdate(&clientUpdate, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -211,30 +176,15 @@ func ClientUpdate(c *fiber.Ctx) error {
 //	@Failure		404	{object}	models.Response
 //	@Failure		500	{object}	models.Response
 //	@Router			/client/delete/{id} [delete]
-func ClientDelete(c *fiber.Ctx) error {
+func (ctrl *ClientController) ClientDelete(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
-	client, err := services.ClientDelete(id)
+	client, err := ctrl.service.ClientDelete(id, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -252,43 +202,26 @@ func ClientDelete(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			clientCreate	body		models.ClientCreate	true	"Información del cliente"
+//	@Param			Idempotency-Key	header		string				false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200				{object}	models.Response
 //	@Failure		400				{object}	models.Response
 //	@Failure		401				{object}	models.Response
 //	@Failure		403				{object}	models.Response
+//	@Failure		409				{object}	models.Response	"Idempotency-Key in use by a request still in progress, or CUIL/DNI already taken, maybe by a client in the trash"
 //	@Failure		422				{object}	models.Response
 //	@Failure		500				{object}	models.Response
 //	@Router			/client/create [post]
-func CreateClient(c *fiber.Ctx) error {
+func (ctrl *ClientController) CreateClient(c *fiber.Ctx) error {
 	var clientCreate models.ClientCreate
 	if err := c.BodyParser(&clientCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := clientCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
-	clientCreated, err := services.ClientCreate(&clientCreate)
+	clientCreated, err := ctrl.service.ClientCreate(&clientCreate, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -6,6 +6,13 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type EmployeeController struct {
+	service *services.EmployeeService
+}
+
+func NewEmployeeController(service *services.EmployeeService) *EmployeeController {
+	return &EmployeeController{service: service}
+}
 
 // GetEmployeeByID godoc
 //	@Summary		Get Employee By ID
@@ -14,61 +21,37 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
-//	@Param			id					path		string											true	"ID of Employee"
-//	@Success		200					{object}	models.Response{body=models.EmployeeLaundry}	"Employee obtained successfully"
-//	@Failure		400					{object}	models.Response									"Bad Request"
-//	@Failure		401					{object}	models.Response									"Auth is required"
-//	@Failure		403					{object}	models.Response									"Not Authorized"
-//	@Failure		404					{object}	models.Response									"Employee not found"
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			id					path		string									true	"ID of Employee"
+//	@Success		200					{object}	models.Response{body=models.Employee}	"Employee obtained successfully"
+//	@Header			200					{string}	ETag									"Versión del registro, para enviar en If-Match"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		404					{object}	models.Response							"Employee not found"
 //	@Failure		500					{object}	models.Response
 //	@Router			/employee/{id} [get]
-func GetEmployeeByID(c *fiber.Ctx) error {
+func (ctrl *EmployeeController) GetEmployeeByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetEmployeeByID(id, workplace.Identifier)
+	employee, err := ctrl.service.GetEmployeeByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Empleado obtenido con éxito",
-		})
-	}
+	setETag(c, employee.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    employee,
 		Message: "Empleado obtenido con éxito",
 	})
 }
@@ -80,51 +63,37 @@ func GetEmployeeByID(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
-//	@Success		200					{object}	models.Response{body=[]models.EmployeeLaundry}	"List of employees"
-//	@Failure		400					{object}	models.Response									"Bad request"
-//	@Failure		401					{object}	models.Response									"Auth is required"
-//	@Failure		403					{object}	models.Response									"Not Authorized"
-//	@Failure		500					{object}	models.Response									"Internal server error"
+//	@Param			X-Workplace-Token	header		string															true	"Workplace Token"
+//	@Param			page				query		int																false	"Página, desde 1"
+//	@Param			page_size			query		int																false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string															false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{body=[]models.Employee,meta=models.Pagination}	"List of employees"
+//	@Failure		400					{object}	models.Response													"Bad request"
+//	@Failure		401					{object}	models.Response													"Auth is required"
+//	@Failure		403					{object}	models.Response													"Not Authorized"
+//	@Failure		500					{object}	models.Response													"Internal server error"
 //	@Router			/employee/get_all [get]
-func GetAllEmployees(c *fiber.Ctx) error {
+func (ctrl *EmployeeController) GetAllEmployees(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetAllEmployees(workplace.Identifier)
+	params, err := listParams(c)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Empleados obtenidos con éxito",
-		})
+	employees, pagination, err := ctrl.service.GetAllEmployees(workplace.ID, params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    employees,
 		Message: "Empleados obtenidos con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -137,58 +106,31 @@ func GetAllEmployees(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
 //	@Param			name				query		string											true	"Name of the Employee"
-//	@Success		200					{object}	models.Response{body=[]models.EmployeeLaundry}	"List of laundry employees"
+//	@Success		200					{object}	models.Response{body=[]models.Employee}	"List of laundry employees"
 //	@Failure		400					{object}	models.Response									"Bad request"
 //	@Failure		401					{object}	models.Response									"Auth is required"
 //	@Failure		403					{object}	models.Response									"Not Authorized"
 //	@Failure		500					{object}	models.Response									"Internal server error"
 //	@Router			/employee/get_by_name [get]
-func GetEmployeeByName(c *fiber.Ctx) error {
+func (ctrl *EmployeeController) GetEmployeeByName(c *fiber.Ctx) error {
 	name := c.Query("name")
 	if name == "" || len(name) < 3 {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "El valor no debe de ser vacio o menor a 3 caracteres",
-		})
+		return models.BadRequest("El valor no debe de ser vacio o menor a 3 caracteres", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetEmployeeByName(name, workplace.Identifier)
+	employees, err := ctrl.service.GetEmployeeByName(name, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
-	}
-
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Empleados obtenidos con éxito",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    employees,
 		Message: "Empleados obtenidos con éxito",
 	})
 }
@@ -202,53 +144,32 @@ func GetEmployeeByName(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
 //	@Param			employeeCreate		body		models.EmployeeCreate			true	"Employee information"
+//	@Param			Idempotency-Key		header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200					{object}	models.Response{body=string}	"Employee created"
 //	@Failure		400					{object}	models.Response					"Bad request"
 //	@Failure		401					{object}	models.Response					"Auth is required"
 //	@Failure		403					{object}	models.Response					"Not Authorized"
+//	@Failure		409					{object}	models.Response					"Idempotency-Key in use by a request still in progress"
 //	@Failure		422					{object}	models.Response					"Model Invalid"
 //	@Failure		500					{object}	models.Response					"Internal server error"
 //	@Router			/employee/create [post]
-func CreateEmployee(c *fiber.Ctx) error {
+func (ctrl *EmployeeController) CreateEmployee(c *fiber.Ctx) error {
 	var employeeCreate models.EmployeeCreate
 	if err := c.BodyParser(&employeeCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := employeeCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	id, err := services.CreateEmployee(&employeeCreate, workplace.Identifier)
+	id, err := ctrl.service.CreateEmployee(&employeeCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -265,56 +186,39 @@ func CreateEmployee(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string					true	"Workplace Token"
-//	@Param			employeeUpdate		body		models.EmployeeUpdate	true	"Employee data to update"
-//	@Success		200					{object}	models.Response			"Empleado editado con éxito"
-//	@Failure		400					{object}	models.Response			"Invalid request or Workplace is required"
-//	@Failure		401					{object}	models.Response			"Auth is required"
-//	@Failure		403					{object}	models.Response			"Not Authorized"
-//	@Failure		404					{object}	models.Response			"Not Found"
-//	@Failure		422					{object}	models.Response			"Model Invalid"
-//	@Failure		500					{object}	models.Response			"Error interno"
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			employeeUpdate		body		models.EmployeeUpdate					true	"Employee data to update"
+//	@Param			If-Match			header		string									false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response							"Empleado editado con éxito"
+//	@Failure		400					{object}	models.Response							"Invalid request or Workplace is required"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		404					{object}	models.Response							"Not Found"
+//	@Failure		409					{object}	models.Response{body=models.Employee}	"Version conflict, body has the current state"
+//	@Failure		422					{object}	models.Response							"Model Invalid"
+//	@Failure		428					{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response							"Error interno"
 //	@Router			/employee/update [put]
-func UpdateEmployee(c *fiber.Ctx) error {
+func (ctrl *EmployeeController) UpdateEmployee(c *fiber.Ctx) error {
 	var employeeUpdate models.EmployeeUpdate
 	if err := c.BodyParser(&employeeUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &employeeUpdate.Version); err != nil {
+		return err
 	}
 	if err := employeeUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.UpdateEmployee(&employeeUpdate, workplace.Identifier)
+	err := ctrl.service.UpdateEmployee(&employeeUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -340,39 +244,20 @@ func UpdateEmployee(c *fiber.Ctx) error {
 //	@Failure		404					{object}	models.Response	"Not Found"
 //	@Failure		500					{object}	models.Response	"Error interno"
 //	@Router			/employee/delete/{id} [delete]
-func DeleteEmployee(c *fiber.Ctx) error {
+func (ctrl *EmployeeController) DeleteEmployee(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.DeleteEmployee(id, workplace.Identifier)
+	err := ctrl.service.DeleteEmployee(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -6,6 +6,13 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type ExpenseController struct {
+	service *services.ExpenseService
+}
+
+func NewExpenseController(service *services.ExpenseService) *ExpenseController {
+	return &ExpenseController{service: service}
+}
 
 // GetExpenseByID godoc
 //	@Summary		Get Expense By ID
@@ -14,61 +21,37 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string										true	"Workplace Token"
-//	@Param			id					path		string										true	"ID of Expense"
-//	@Success		200					{object}	models.Response{body=models.ExpenseLaundry}	"Expense obtained successfully"
-//	@Failure		400					{object}	models.Response								"Bad Request"
-//	@Failure		401					{object}	models.Response								"Auth is required"
-//	@Failure		403					{object}	models.Response								"Not Authorized"
-//	@Failure		404					{object}	models.Response								"Expense not found"
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			id					path		string									true	"ID of Expense"
+//	@Success		200					{object}	models.Response{body=models.Expense}	"Expense obtained successfully"
+//	@Header			200					{string}	ETag									"Versión del registro, para enviar en If-Match"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		404					{object}	models.Response							"Expense not found"
 //	@Failure		500					{object}	models.Response
 //	@Router			/expense/{id} [get]
-func GetExpenseByID(c *fiber.Ctx) error {
+func (ctrl *ExpenseController) GetExpenseByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetExpenseByID(id, workplace.Identifier)
+	expense, err := ctrl.service.GetExpenseByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Egreso obtenido con éxito",
-		})
-	}
+	setETag(c, expense.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    expense,
 		Message: "Egreso obtenido con éxito",
 	})
 }
@@ -80,51 +63,37 @@ func GetExpenseByID(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
-//	@Success		200					{object}	models.Response{body=[]models.ExpenseLaundry}	"List of expenses"
-//	@Failure		400					{object}	models.Response									"Bad Request"
-//	@Failure		401					{object}	models.Response									"Auth is required"
-//	@Failure		403					{object}	models.Response									"Not Authorized"
-//	@Failure		500					{object}	models.Response									"Internal server error"
+//	@Param			X-Workplace-Token	header		string															true	"Workplace Token"
+//	@Param			page				query		int																false	"Página, desde 1"
+//	@Param			page_size			query		int																false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string															false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{body=[]models.Expense,meta=models.Pagination}	"List of expenses"
+//	@Failure		400					{object}	models.Response													"Bad Request"
+//	@Failure		401					{object}	models.Response													"Auth is required"
+//	@Failure		403					{object}	models.Response													"Not Authorized"
+//	@Failure		500					{object}	models.Response													"Internal server error"
 //	@Router			/expense/get_all [get]
-func GetAllExpenses(c *fiber.Ctx) error {
+func (ctrl *ExpenseController) GetAllExpenses(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetAllExpenses(workplace.Identifier)
+	params, err := listParams(c)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Egresos obtenidos con éxito",
-		})
+	expenses, pagination, err := ctrl.service.GetAllExpenses(workplace.ID, params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    expenses,
 		Message: "Egresos obtenidos con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -136,49 +105,26 @@ func GetAllExpenses(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
-//	@Success		200					{object}	models.Response{body=[]models.ExpenseLaundry}	"List of laundry expenses"
+//	@Success		200					{object}	models.Response{body=[]models.Expense}	"List of laundry expenses"
 //	@Failure		400					{object}	models.Response									"Bad Request"
 //	@Failure		401					{object}	models.Response									"Auth is required"
 //	@Failure		403					{object}	models.Response									"Not Authorized"
 //	@Failure		500					{object}	models.Response									"Internal server error"
 //	@Router			/expense/get_today [get]
-func GetExpenseToday(c *fiber.Ctx) error {
+func (ctrl *ExpenseController) GetExpenseToday(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetExpenseToday(workplace.Identifier)
+	expenses, err := ctrl.service.GetExpenseToday(workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
-	}
-
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Egresos obtenidos con éxito",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    expenses,
 		Message: "Egresos obtenidos con éxito",
 	})
 }
@@ -192,53 +138,32 @@ func GetExpenseToday(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
 //	@Param			expenseCreate		body		models.ExpenseCreate			true	"Expense information"
+//	@Param			Idempotency-Key		header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200					{object}	models.Response{body=string}	"Expense created successfully"
 //	@Failure		400					{object}	models.Response					"Bad Request"
 //	@Failure		401					{object}	models.Response					"Auth is required"
 //	@Failure		403					{object}	models.Response					"Not Authorized"
+//	@Failure		409					{object}	models.Response					"Idempotency-Key in use by a request still in progress"
 //	@Failure		422					{object}	models.Response					"Model Invalid"
 //	@Failure		500					{object}	models.Response					"Internal server error"
 //	@Router			/expense/create [post]
-func CreateExpense(c *fiber.Ctx) error {
+func (ctrl *ExpenseController) CreateExpense(c *fiber.Ctx) error {
 	var expenseCreate models.ExpenseCreate
 	if err := c.BodyParser(&expenseCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := expenseCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	id, err := services.CreateExpense(&expenseCreate, workplace.Identifier)
+	id, err := ctrl.service.CreateExpense(&expenseCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -255,55 +180,38 @@ func CreateExpense(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string					true	"Workplace Token"
-//	@Param			expenseUpdate		body		models.ExpenseUpdate	true	"Expense data to update"
-//	@Success		200					{object}	models.Response			"Expense updated successfully"
-//	@Failure		400					{object}	models.Response			"Bad Request"
-//	@Failure		401					{object}	models.Response			"Auth is required"
-//	@Failure		403					{object}	models.Response			"Not Authorized"
-//	@Failure		422					{object}	models.Response			"Model Invalid"
-//	@Failure		500					{object}	models.Response			"Internal server error"
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			expenseUpdate		body		models.ExpenseUpdate					true	"Expense data to update"
+//	@Param			If-Match			header		string									false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response							"Expense updated successfully"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		409					{object}	models.Response{body=models.Expense}	"Version conflict, body has the current state"
+//	@Failure		422					{object}	models.Response							"Model Invalid"
+//	@Failure		428					{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response							"Internal server error"
 //	@Router			/expense/update [put]
-func UpdateExpense(c *fiber.Ctx) error {
+func (ctrl *ExpenseController) UpdateExpense(c *fiber.Ctx) error {
 	var expenseUpdate models.ExpenseUpdate
 	if err := c.BodyParser(&expenseUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &expenseUpdate.Version); err != nil {
+		return err
 	}
 	if err := expenseUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.UpdateExpense(&expenseUpdate, workplace.Identifier)
+	err := ctrl.service.UpdateExpense(&expenseUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -328,39 +236,20 @@ func UpdateExpense(c *fiber.Ctx) error {
 //	@Failure		403					{object}	models.Response	"Not Authorized"
 //	@Failure		500					{object}	models.Response	"Internal server error"
 //	@Router			/expense/delete/{id} [delete]
-func DeleteExpense(c *fiber.Ctx) error {
+func (ctrl *ExpenseController) DeleteExpense(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.DeleteExpense(id, workplace.Identifier)
+	err := ctrl.service.DeleteExpense(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -6,6 +6,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type IncomeController struct {
+	service *services.IncomeService
+}
+
+func NewIncomeController(service *services.IncomeService) *IncomeController {
+	return &IncomeController{service: service}
+}
+
 // GetIncomeByID godoc
 //	@Summary		Get Income By ID
 //	@Description	Fetches income details from either laundry or workshop based on the provided ID and workplace context.
@@ -13,61 +21,37 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string										true	"Workplace Token"
-//	@Param			id					path		string										true	"ID of the income"
-//	@Success		200					{object}	models.Response{body=models.IncomeLaundry}	"Income details fetched successfully"
-//	@Failure		400					{object}	models.Response								"Bad Request"
-//	@Failure		401					{object}	models.Response								"Auth is required"
-//	@Failure		403					{object}	models.Response								"Not Authorized"
-//	@Failure		404					{object}	models.Response								"Expense not found"
-//	@Failure		500					{object}	models.Response								"Internal server error"
+//	@Param			X-Workplace-Token	header		string								true	"Workplace Token"
+//	@Param			id					path		string								true	"ID of the income"
+//	@Success		200					{object}	models.Response{body=models.Income}	"Income details fetched successfully"
+//	@Header			200					{string}	ETag								"Versión del registro, para enviar en If-Match"
+//	@Failure		400					{object}	models.Response						"Bad Request"
+//	@Failure		401					{object}	models.Response						"Auth is required"
+//	@Failure		403					{object}	models.Response						"Not Authorized"
+//	@Failure		404					{object}	models.Response						"Expense not found"
+//	@Failure		500					{object}	models.Response						"Internal server error"
 //	@Router			/income/{id} [get]
-func GetIncomeByID(c *fiber.Ctx) error {
+func (ctrl *IncomeController) GetIncomeByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetIncomeByID(id, workplace.Identifier)
+	income, err := ctrl.service.GetIncomeByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Ingreso obtenido con éxito",
-		})
-	}
+	setETag(c, income.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    income,
 		Message: "Ingreso obtenido con éxito",
 	})
 }
@@ -79,52 +63,38 @@ func GetIncomeByID(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
-//	@Success		200					{object}	models.Response{body=[]models.IncomeLaundry}	"List of incomes"
-//	@Failure		400					{object}	models.Response									"Bad Request"
-//	@Failure		401					{object}	models.Response									"Auth is required"
-//	@Failure		403					{object}	models.Response									"Not Authorized"
-//	@Failure		404					{object}	models.Response									"Expense not found"
-//	@Failure		500					{object}	models.Response									"Internal server error"
+//	@Param			X-Workplace-Token	header		string															true	"Workplace Token"
+//	@Param			page				query		int																false	"Página, desde 1"
+//	@Param			page_size			query		int																false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string															false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{body=[]models.Income,meta=models.Pagination}	"List of incomes"
+//	@Failure		400					{object}	models.Response													"Bad Request"
+//	@Failure		401					{object}	models.Response													"Auth is required"
+//	@Failure		403					{object}	models.Response													"Not Authorized"
+//	@Failure		404					{object}	models.Response													"Expense not found"
+//	@Failure		500					{object}	models.Response													"Internal server error"
 //	@Router			/income/get_all [get]
-func GetAllIncomes(c *fiber.Ctx) error {
+func (ctrl *IncomeController) GetAllIncomes(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetAllIncomes(workplace.Identifier)
+	params, err := listParams(c)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Ingresos obtenidos con éxito",
-		})
+	incomes, pagination, err := ctrl.service.GetAllIncomes(workplace.ID, params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    incomes,
 		Message: "Ingresos obtenidos con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -136,111 +106,67 @@ func GetAllIncomes(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
-//	@Success		200					{object}	models.Response{body=[]models.IncomeLaundry}	"List of laundry incomes"
+//	@Success		200					{object}	models.Response{body=[]models.Income}	"List of laundry incomes"
 //	@Failure		400					{object}	models.Response									"Bad Request"
 //	@Failure		401					{object}	models.Response									"Auth is required"
 //	@Failure		403					{object}	models.Response									"Not Authorized"
 //	@Failure		404					{object}	models.Response									"Expense not found"
 //	@Failure		500					{object}	models.Response									"Internal server error"
 //	@Router			/income/get_today [get]
-func GetIncomeToday(c *fiber.Ctx) error {
+func (ctrl *IncomeController) GetIncomeToday(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetIncomeToday(workplace.Identifier)
+	incomes, err := ctrl.service.GetIncomeToday(workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
-	}
-
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Ingresos obtenidos con éxito",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    incomes,
 		Message: "Ingresos obtenidos con éxito",
 	})
 }
 
 // CreateIncome godoc
 //	@Summary		Create Income
-//	@Description	Parses the request body to create a new income entry for either laundry or workshop.
+//	@Description	Creates an income with one line per service at its current price for the vehicle's category (or the general price). The amount is computed: the sum of the lines minus the discount, which requires a discount_reason. 400 if the vehicle does not exist, a service has no current price or the discount exceeds the subtotal.
 //	@Tags			Income
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
 //	@Param			incomeCreate		body		models.IncomeCreate				true	"Income information"
+//	@Param			Idempotency-Key		header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200					{object}	models.Response{body=string}	"Income created successfully"
 //	@Failure		400					{object}	models.Response					"Bad Request"
 //	@Failure		401					{object}	models.Response					"Auth is required"
 //	@Failure		403					{object}	models.Response					"Not Authorized"
 //	@Failure		404					{object}	models.Response					"Expense not found"
+//	@Failure		409					{object}	models.Response					"Idempotency-Key in use by a request still in progress"
 //	@Failure		422					{object}	models.Response					"Model Invalid"
 //	@Failure		500					{object}	models.Response					"Internal server error"
 //	@Router			/income/create [post]
-func CreateIncome(c *fiber.Ctx) error {
+func (ctrl *IncomeController) CreateIncome(c *fiber.Ctx) error {
 	var incomeCreate models.IncomeCreate
 	if err := c.BodyParser(&incomeCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := incomeCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	id, err := services.CreateIncome(&incomeCreate, workplace.Identifier)
+	id, err := ctrl.service.CreateIncome(&incomeCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -252,61 +178,44 @@ func CreateIncome(c *fiber.Ctx) error {
 
 // UpdateIncome godoc
 //	@Summary		Update Income
-//	@Description	Updates the details of an income based on the provided data.
+//	@Description	Updates an income. Lines that stay keep the price they were charged at; new ones take the current price. The amount is recomputed.
 //	@Tags			Income
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string				true	"Workplace Token"
-//	@Param			incomeUpdate		body		models.IncomeUpdate	true	"Income data to update"
-//	@Success		200					{object}	models.Response		"Income updated successfully"
-//	@Failure		400					{object}	models.Response		"Bad Request"
-//	@Failure		401					{object}	models.Response		"Auth is required"
-//	@Failure		403					{object}	models.Response		"Not Authorized"
-//	@Failure		404					{object}	models.Response		"Expense not found"
-//	@Failure		422					{object}	models.Response		"Model Invalid"
-//	@Failure		500					{object}	models.Response		"Internal server error"
+//	@Param			X-Workplace-Token	header		string								true	"Workplace Token"
+//	@Param			incomeUpdate		body		models.IncomeUpdate					true	"Income data to update"
+//	@Param			If-Match			header		string								false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response						"Income updated successfully"
+//	@Failure		400					{object}	models.Response						"Bad Request"
+//	@Failure		401					{object}	models.Response						"Auth is required"
+//	@Failure		403					{object}	models.Response						"Not Authorized"
+//	@Failure		404					{object}	models.Response						"Expense not found"
+//	@Failure		409					{object}	models.Response{body=models.Income}	"Version conflict (body has the current state), or income of a delivered work order"
+//	@Failure		422					{object}	models.Response						"Model Invalid"
+//	@Failure		428					{object}	models.Response						"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response						"Internal server error"
 //	@Router			/income/update [put]
-func UpdateIncome(c *fiber.Ctx) error {
+func (ctrl *IncomeController) UpdateIncome(c *fiber.Ctx) error {
 	var incomeUpdate models.IncomeUpdate
 	if err := c.BodyParser(&incomeUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &incomeUpdate.Version); err != nil {
+		return err
 	}
 	if err := incomeUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.UpdateIncome(&incomeUpdate, workplace.Identifier)
+	err := ctrl.service.UpdateIncome(&incomeUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -330,41 +239,23 @@ func UpdateIncome(c *fiber.Ctx) error {
 //	@Failure		401					{object}	models.Response	"Auth is required"
 //	@Failure		403					{object}	models.Response	"Not Authorized"
 //	@Failure		404					{object}	models.Response	"Expense not found"
+//	@Failure		409					{object}	models.Response	"Income of a delivered work order"
 //	@Failure		500					{object}	models.Response	"Error interno"
 //	@Router			/income/delete/{id} [delete]
-func DeleteIncome(c *fiber.Ctx) error {
+func (ctrl *IncomeController) DeleteIncome(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.DeleteIncome(id, workplace.Identifier)
+	err := ctrl.service.DeleteIncome(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
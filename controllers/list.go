@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// listParams lee los parámetros comunes de los get_all: page, page_size y
+// sort. El resto de la query, salvo las claves en ignore, se toma como
+// filtros por campo y los valida el repositorio.
+func listParams(c *fiber.Ctx, ignore ...string) (*models.ListParams, error) {
+	params := &models.ListParams{
+		Page:     1,
+		PageSize: models.DefaultPageSize,
+		Sort:     c.Query("sort"),
+		Filters:  map[string]string{},
+	}
+
+	if page := c.Query("page"); page != "" {
+		n, err := strconv.Atoi(page)
+		if err != nil || n < 1 {
+			return nil, models.BadRequest("page debe ser un número mayor a 0", err)
+		}
+		params.Page = n
+	}
+	if pageSize := c.Query("page_size"); pageSize != "" {
+		n, err := strconv.Atoi(pageSize)
+		if err != nil || n < 1 || n > models.MaxPageSize {
+			return nil, models.BadRequest("page_size debe ser un número entre 1 y "+strconv.Itoa(models.MaxPageSize), err)
+		}
+		params.PageSize = n
+	}
+
+	for key, value := range c.Queries() {
+		if key == "page" || key == "page_size" || key == "sort" || slices.Contains(ignore, key) || value == "" {
+			continue
+		}
+		params.Filters[key] = value
+	}
+
+	return params, nil
+}
@@ -8,6 +8,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type MovementTypeController struct {
+	service *services.MovementTypeService
+}
+
+func NewMovementTypeController(service *services.MovementTypeService) *MovementTypeController {
+	return &MovementTypeController{service: service}
+}
+
 // GetMovementTypeByID godoc
 //	@Summary		Get Movement Type By ID
 //	@Description	Get Movement Type By ID
@@ -15,61 +23,37 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string												true	"Workplace Token"
-//	@Param			id					path		string												true	"ID of the movement type"
-//	@Success		200					{object}	models.Response{body=models.MovementTypeLaundry}	"Movement type details"
-//	@Failure		400					{object}	models.Response										"Bad Request"
-//	@Failure		401					{object}	models.Response										"Auth is required"
-//	@Failure		403					{object}	models.Response										"Not Authorized"
-//	@Failure		404					{object}	models.Response										"Expense not found"
-//	@Failure		500					{object}	models.Response										"Internal server error"
+//	@Param			X-Workplace-Token	header		string										true	"Workplace Token"
+//	@Param			id					path		string										true	"ID of the movement type"
+//	@Success		200					{object}	models.Response{body=models.MovementType}	"Movement type details"
+//	@Header			200					{string}	ETag										"Versión del registro, para enviar en If-Match"
+//	@Failure		400					{object}	models.Response								"Bad Request"
+//	@Failure		401					{object}	models.Response								"Auth is required"
+//	@Failure		403					{object}	models.Response								"Not Authorized"
+//	@Failure		404					{object}	models.Response								"Expense not found"
+//	@Failure		500					{object}	models.Response								"Internal server error"
 //	@Router			/movement/{id} [get]
-func GetMovementTypeByID(c *fiber.Ctx) error {
+func (ctrl *MovementTypeController) GetMovementTypeByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetMovementTypeByID(id, workplace.Identifier)
+	movementType, err := ctrl.service.GetMovementTypeByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Movimiento obtenido con éxito",
-		})
-	}
+	setETag(c, movementType.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    movementType,
 		Message: "Movimiento obtenido con éxito",
 	})
 }
@@ -81,67 +65,49 @@ func GetMovementTypeByID(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string												true	"Workplace Token"
-//	@Param			isIncome			query		bool												true	"Is income movement type"
-//	@Success		200					{object}	models.Response{body=[]models.MovementTypeLaundry}	"List of movement types"
-//	@Failure		400					{object}	models.Response										"Bad Request"
-//	@Failure		401					{object}	models.Response										"Auth is required"
-//	@Failure		403					{object}	models.Response										"Not Authorized"
-//	@Failure		404					{object}	models.Response										"Expense not found"
-//	@Failure		500					{object}	models.Response										"Internal server error"
+//	@Param			X-Workplace-Token	header		string																true	"Workplace Token"
+//	@Param			isIncome			query		bool																true	"Is income movement type"
+//	@Param			page				query		int																	false	"Página, desde 1"
+//	@Param			page_size			query		int																	false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string																false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{body=[]models.MovementType,meta=models.Pagination}	"List of movement types"
+//	@Failure		400					{object}	models.Response														"Bad Request"
+//	@Failure		401					{object}	models.Response														"Auth is required"
+//	@Failure		403					{object}	models.Response														"Not Authorized"
+//	@Failure		404					{object}	models.Response														"Expense not found"
+//	@Failure		500					{object}	models.Response														"Internal server error"
 //	@Router			/movement/get_all [get]
-func GetAllMovementTypes(c *fiber.Ctx) error {
+func (ctrl *MovementTypeController) GetAllMovementTypes(c *fiber.Ctx) error {
 	isIncomeStr := c.Query("isIncome")
 isIncome := false
 if isIncomeStr != "" {
     var err error
     isIncome, err = strconv.ParseBool(isIncomeStr)
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-            Status:  false,
-            Body:    nil,
-            Message: "Invalid value for isIncome",
-        })
+        return models.BadRequest("Invalid value for isIncome", nil)
     }
 }
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.GetAllMovementTypes(isIncome, workplace.Identifier)
+	params, err := listParams(c, "isIncome")
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Movimientos obtenidos con éxito",
-		})
+	movementTypes, pagination, err := ctrl.service.GetAllMovementTypes(isIncome, workplace.ID, params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    movementTypes,
 		Message: "Movimientos obtenidos con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -154,54 +120,33 @@ if isIncomeStr != "" {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
 //	@Param			movementType		body		models.MovementTypeCreate		true	"Movement Type Details"
+//	@Param			Idempotency-Key		header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200					{object}	models.Response{body=string}	"Movement created successfully"
 //	@Failure		400					{object}	models.Response					"Bad Request"
 //	@Failure		401					{object}	models.Response					"Auth is required"
 //	@Failure		403					{object}	models.Response					"Not Authorized"
 //	@Failure		404					{object}	models.Response					"Expense not found"
+//	@Failure		409					{object}	models.Response					"Idempotency-Key in use by a request still in progress"
 //	@Failure		422					{object}	models.Response					"Model invalid"
 //	@Failure		500					{object}	models.Response					"Internal server error"
 //	@Router			/movement/create [post]
-func MovementTypeCreate(c *fiber.Ctx) error {
+func (ctrl *MovementTypeController) MovementTypeCreate(c *fiber.Ctx) error {
 	var movementCreate models.MovementTypeCreate
 	if err := c.BodyParser(&movementCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := movementCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	id, err := services.MovementTypeCreate(&movementCreate, workplace.Identifier)
+	id, err := ctrl.service.MovementTypeCreate(&movementCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -218,56 +163,39 @@ func MovementTypeCreate(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string						true	"Workplace Token"
-//	@Param			movementType		body		models.MovementTypeUpdate	true	"Movement Type Details"
-//	@Success		200					{object}	models.Response				"Movement updated successfully"
-//	@Failure		400					{object}	models.Response				"Bad Request"
-//	@Failure		401					{object}	models.Response				"Auth is required"
-//	@Failure		403					{object}	models.Response				"Not Authorized"
-//	@Failure		404					{object}	models.Response				"Expense not found"
-//	@Failure		422					{object}	models.Response				"Model invalid"
-//	@Failure		500					{object}	models.Response				"Internal server error"
+//	@Param			X-Workplace-Token	header		string										true	"Workplace Token"
+//	@Param			movementType		body		models.MovementTypeUpdate					true	"Movement Type Details"
+//	@Param			If-Match			header		string										false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response								"Movement updated successfully"
+//	@Failure		400					{object}	models.Response								"Bad Request"
+//	@Failure		401					{object}	models.Response								"Auth is required"
+//	@Failure		403					{object}	models.Response								"Not Authorized"
+//	@Failure		404					{object}	models.Response								"Expense not found"
+//	@Failure		409					{object}	models.Response{body=models.MovementType}	"Version conflict, body has the current state"
+//	@Failure		422					{object}	models.Response								"Model invalid"
+//	@Failure		428					{object}	models.Response								"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response								"Internal server error"
 //	@Router			/movement/update [put]
-func MovementTypeUpdate(c *fiber.Ctx) error {
+func (ctrl *MovementTypeController) MovementTypeUpdate(c *fiber.Ctx) error {
 	var movementUpdate models.MovementTypeUpdate
 	if err := c.BodyParser(&movementUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &movementUpdate.Version); err != nil {
+		return err
 	}
 	if err := movementUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.MovementTypeUpdate(&movementUpdate, workplace.Identifier)
+	err := ctrl.service.MovementTypeUpdate(&movementUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -293,39 +221,20 @@ func MovementTypeUpdate(c *fiber.Ctx) error {
 //	@Failure		404					{object}	models.Response	"Expense not found"
 //	@Failure		500					{object}	models.Response	"Internal server error"
 //	@Router			/movement/delete/{id} [delete]
-func MovementTypeDelete(c *fiber.Ctx) error {
+func (ctrl *MovementTypeController) MovementTypeDelete(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.MovementTypeDelete(id, workplace.Identifier)
+	err := ctrl.service.MovementTypeDelete(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PasswordController struct {
+	service *services.PasswordService
+}
+
+func NewPasswordController(service *services.PasswordService) *PasswordController {
+	return &PasswordController{service: service}
+}
+
+// ChangePassword godoc
+//	@Summary		Change password
+//	@Description	Changes the password of the logged user. The refresh tokens of all its sessions are revoked.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			password	body		models.ChangePasswordRequest	true	"Current and new password"
+//	@Success		200			{object}	models.Response
+//	@Failure		400			{object}	models.Response	"Wrong current password"
+//	@Failure		401			{object}	models.Response	"Auth is required"
+//	@Failure		422			{object}	models.Response	"Validation error"
+//	@Failure		500			{object}	models.Response
+//	@Router			/auth/change_password [post]
+func (ctrl *PasswordController) ChangePassword(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var request models.ChangePasswordRequest
+	if err := c.BodyParser(&request); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := request.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	if err := ctrl.service.ChangePassword(user, request.CurrentPassword, request.NewPassword, auditActor(c)); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Contraseña actualizada con éxito",
+	})
+}
+
+// ForgotPassword godoc
+//	@Summary		Forgot password
+//	@Description	Sends a single-use password reset token to the email, if it belongs to an active user. The response is the same either way.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.ForgotPasswordRequest	true	"Email of the account"
+//	@Success		200		{object}	models.Response
+//	@Failure		400		{object}	models.Response	"Bad Request"
+//	@Failure		422		{object}	models.Response	"Validation error"
+//	@Router			/auth/forgot_password [post]
+func (ctrl *PasswordController) ForgotPassword(c *fiber.Ctx) error {
+	var request models.ForgotPasswordRequest
+	if err := c.BodyParser(&request); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := request.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	ctrl.service.ForgotPassword(request.Email)
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Si el email está registrado vas a recibir un correo para restablecer la contraseña",
+	})
+}
+
+// ResetPassword godoc
+//	@Summary		Reset password
+//	@Description	Sets a new password with a reset token. The token can be used once and expires.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.ResetPasswordRequest	true	"Reset token and new password"
+//	@Success		200		{object}	models.Response
+//	@Failure		400		{object}	models.Response	"Invalid or expired token"
+//	@Failure		422		{object}	models.Response	"Validation error"
+//	@Failure		500		{object}	models.Response
+//	@Router			/auth/reset_password [post]
+func (ctrl *PasswordController) ResetPassword(c *fiber.Ctx) error {
+	var request models.ResetPasswordRequest
+	if err := c.BodyParser(&request); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := request.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	if err := ctrl.service.ResetPassword(request.Token, request.NewPassword); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Contraseña restablecida con éxito",
+	})
+}
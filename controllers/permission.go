@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PermissionController struct {
+	service *services.PermissionService
+}
+
+func NewPermissionController(service *services.PermissionService) *PermissionController {
+	return &PermissionController{service: service}
+}
+
+// GetAllPermissions godoc
+//	@Summary		List permissions
+//	@Description	Lists the permission catalog
+//	@Tags			Permission
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	models.Response{body=[]models.Permission}	"List of permissions"
+//	@Failure		401	{object}	models.Response								"Auth is required"
+//	@Failure		403	{object}	models.Response								"Not Authorized"
+//	@Failure		500	{object}	models.Response								"Internal server error"
+//	@Router			/permission/get_all [get]
+func (ctrl *PermissionController) GetAllPermissions(c *fiber.Ctx) error {
+	permissions, err := ctrl.service.GetAllPermissions()
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    permissions,
+		Message: "Permisos obtenidos con éxito",
+	})
+}
+
+// GetRolePermissions godoc
+//	@Summary		Get role permissions
+//	@Description	Lists the permissions assigned to a role
+//	@Tags			Permission
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			role	path		string											true	"Role name"
+//	@Success		200		{object}	models.Response{body=models.RolePermissions}	"Role permissions"
+//	@Failure		401		{object}	models.Response									"Auth is required"
+//	@Failure		403		{object}	models.Response									"Not Authorized"
+//	@Failure		404		{object}	models.Response									"Role not found"
+//	@Failure		500		{object}	models.Response									"Internal server error"
+//	@Router			/permission/role/{role} [get]
+func (ctrl *PermissionController) GetRolePermissions(c *fiber.Ctx) error {
+	rolePermissions, err := ctrl.service.GetRolePermissions(c.Params("role"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    rolePermissions,
+		Message: "Permisos del rol obtenidos con éxito",
+	})
+}
+
+// UpdateRolePermissions godoc
+//	@Summary		Update role permissions
+//	@Description	Replaces the permissions of a role. Only roles below the caller's hierarchy can be changed, and only with permissions the caller's role has.
+//	@Tags			Permission
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			role		path		string											true	"Role name"
+//	@Param			permissions	body		models.RolePermissionsUpdate					true	"Permissions of the role"
+//	@Success		200			{object}	models.Response{body=models.RolePermissions}	"Role permissions updated"
+//	@Failure		400			{object}	models.Response									"Bad Request"
+//	@Failure		401			{object}	models.Response									"Auth is required"
+//	@Failure		403			{object}	models.Response									"Not Authorized"
+//	@Failure		404			{object}	models.Response									"Role not found"
+//	@Failure		422			{object}	models.Response									"Validation error"
+//	@Failure		500			{object}	models.Response									"Internal server error"
+//	@Router			/permission/role/{role} [put]
+func (ctrl *PermissionController) UpdateRolePermissions(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var permissionsUpdate models.RolePermissionsUpdate
+	if err := c.BodyParser(&permissionsUpdate); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+
+	if err := permissionsUpdate.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	rolePermissions, err := ctrl.service.UpdateRolePermissions(user, c.Params("role"), permissionsUpdate.Permissions)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    rolePermissions,
+		Message: "Permisos del rol actualizados con éxito",
+	})
+}
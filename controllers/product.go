@@ -6,6 +6,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type ProductController struct {
+	service *services.ProductService
+}
+
+func NewProductController(service *services.ProductService) *ProductController {
+	return &ProductController{service: service}
+}
+
 // ProductGetByID godoc
 //	@Summary		Get Product By ID
 //	@Description	Get a product or part by its ID within a specified workplace.
@@ -13,62 +21,38 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string										true	"Workplace Token"
-//	@Param			id					path		string										true	"ID of the product"
-//	@Success		200					{object}	models.Response{body=models.ProductLaundry}	"Product obtained with success"
-//	@Failure		400					{object}	models.Response								"Bad Request"
-//	@Failure		401					{object}	models.Response								"Auth is required"
-//	@Failure		403					{object}	models.Response								"Not Authorized"
-//	@Failure		404					{object}	models.Response								"Expense not found"
-//	@Failure		500					{object}	models.Response								"Internal server error"
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			id					path		string									true	"ID of the product"
+//	@Success		200					{object}	models.Response{body=models.Product}	"Product obtained with success"
+//	@Header			200					{string}	ETag									"Versión del registro, para enviar en If-Match"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		404					{object}	models.Response							"Expense not found"
+//	@Failure		500					{object}	models.Response							"Internal server error"
 //	@Router			/product/{id} [get]
-func ProductGetByID(c *fiber.Ctx) error {
+func (ctrl *ProductController) ProductGetByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.ProductGetByID(id, workplace.Identifier)
+	product, err := ctrl.service.ProductGetByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-		Status:  true,
-		Body:    laundry,
-		Message: "Producto obtenido con éxito",
-	})
-	}
+	setETag(c, product.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
-		Message: "Parte obtenida con éxito",
+		Body:    product,
+		Message: "Producto obtenido con éxito",
 	})
 }
 
@@ -79,51 +63,37 @@ func ProductGetByID(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
-//	@Success		200					{object}	models.Response{body=[]models.ProductLaundry}	"Products obtained with success"
-//	@Failure		400					{object}	models.Response									"Bad Request"
-//	@Failure		401					{object}	models.Response									"Auth is required"
-//	@Failure		403					{object}	models.Response									"Not Authorized"
-//	@Failure		500					{object}	models.Response									"Internal server error"
+//	@Param			X-Workplace-Token	header		string															true	"Workplace Token"
+//	@Param			page				query		int																false	"Página, desde 1"
+//	@Param			page_size			query		int																false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string															false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{body=[]models.Product,meta=models.Pagination}	"Products obtained with success"
+//	@Failure		400					{object}	models.Response													"Bad Request"
+//	@Failure		401					{object}	models.Response													"Auth is required"
+//	@Failure		403					{object}	models.Response													"Not Authorized"
+//	@Failure		500					{object}	models.Response													"Internal server error"
 //	@Router			/product/get_all [get]
-func ProductGetAll(c *fiber.Ctx) error {
+func (ctrl *ProductController) ProductGetAll(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.ProductGetAll(workplace.Identifier)
+	params, err := listParams(c)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Productos obtenidos con éxito",
-		})
+	products, pagination, err := ctrl.service.ProductGetAll(workplace.ID, params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
-		Message: "Partes obtenidas con éxito",
+		Body:    products,
+		Message: "Productos obtenidos con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -136,59 +106,32 @@ func ProductGetAll(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
 //	@Param			name				query		string											true	"Name of the Product"
-//	@Success		200					{object}	models.Response{body=[]models.ProductLaundry}	"List of products"
+//	@Success		200					{object}	models.Response{body=[]models.Product}	"List of products"
 //	@Failure		400					{object}	models.Response									"Bad Request"
 //	@Failure		401					{object}	models.Response									"Auth is required"
 //	@Failure		403					{object}	models.Response									"Not Authorized"
 //	@Failure		500					{object}	models.Response									"Internal server error"
 //	@Router			/product/get_by_name [get]
-func ProductGetByName(c *fiber.Ctx) error {
+func (ctrl *ProductController) ProductGetByName(c *fiber.Ctx) error {
 	name := c.Query("name")
 	if name == "" || len(name) < 3 {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "El valor no debe de ser vacio o menor a 3 caracteres",
-		})
+		return models.BadRequest("El valor no debe de ser vacio o menor a 3 caracteres", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.ProductGetByName(name, workplace.Identifier)
+	products, err := ctrl.service.ProductGetByName(name, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
-	}
-
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Productos obtenidos con éxito",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
-		Message: "Partes obtenidas con éxito",
+		Body:    products,
+		Message: "Productos obtenidos con éxito",
 	})
 }
 
@@ -201,65 +144,38 @@ func ProductGetByName(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
 //	@Param			identifier			query		string											true	"Identifier of product"
-//	@Success		200					{object}	models.Response{body=[]models.ProductLaundry}	"Products obtained with success"
+//	@Success		200					{object}	models.Response{body=[]models.Product}	"Products obtained with success"
 //	@Failure		400					{object}	models.Response									"Bad Request"
 //	@Failure		401					{object}	models.Response									"Auth is required"
 //	@Failure		403					{object}	models.Response									"Not Authorized"
 //	@Failure		500					{object}	models.Response									"Internal server error"
 //	@Router			/product/get_by_identifier [get]
-func ProductGetByIdentifier(c *fiber.Ctx) error {
+func (ctrl *ProductController) ProductGetByIdentifier(c *fiber.Ctx) error {
 	name := c.Query("identifier")
 	if name == "" || len(name) < 3 {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "El valor no debe de ser vacio o menor a 3 caracteres",
-		})
+		return models.BadRequest("El valor no debe de ser vacio o menor a 3 caracteres", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.ProductGetByIdentifier(name, workplace.Identifier)
+	products, err := ctrl.service.ProductGetByIdentifier(name, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
-	}
-
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Productos obtenidos con éxito",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
-		Message: "Partes obtenidas con éxito",
+		Body:    products,
+		Message: "Productos obtenidos con éxito",
 	})
 }
 
 // ProductUpdateStock godoc
 //	@Summary		Update Product Stock
-//	@Description	Updates the stock of a product based on the given method (add, subtract, update).
+//	@Description	Updates the stock of a product based on the given method (add, subtract, update). The stock cannot go below what is reserved by work orders.
 //	@Tags			Product
 //	@Accept			json
 //	@Produce		json
@@ -273,67 +189,37 @@ func ProductGetByIdentifier(c *fiber.Ctx) error {
 //	@Failure		401					{object}	models.Response		"Auth is required"
 //	@Failure		403					{object}	models.Response		"Not Authorized"
 //	@Failure		404					{object}	models.Response		"Product not found"
+//	@Failure		409					{object}	models.Response		"Stock below reserved in work orders"
 //	@Failure		422					{object}	models.Response		"Model invalid"
 //	@Failure		500					{object}	models.Response		"Internal server error"
 //	@Router			/product/update_stock/{id} [put]
-func ProductUpdateStock(c *fiber.Ctx) error {
+func (ctrl *ProductController) ProductUpdateStock(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	method := c.Query("method")
 	if method == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Method is required",
-		})
+		return models.BadRequest("Method is required", nil)
 	}
 
 	var stockUpdate models.StockUpdate
 	if err := c.BodyParser(&stockUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := stockUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.ProductUpdateStock(id, &stockUpdate, method, workplace.Identifier)
+	err := ctrl.service.ProductUpdateStock(id, &stockUpdate, method, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -350,56 +236,39 @@ func ProductUpdateStock(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string					true	"Workplace Token"
-//	@Param			product				body		models.ProductUpdate	true	"Product update details"
-//	@Success		200					{object}	models.Response			"Product updated successfully"
-//	@Failure		400					{object}	models.Response			"Bad Request"
-//	@Failure		401					{object}	models.Response			"Auth is required"
-//	@Failure		403					{object}	models.Response			"Not Authorized"
-//	@Failure		404					{object}	models.Response			"Product not found"
-//	@Failure		422					{object}	models.Response			"Model invalid"
-//	@Failure		500					{object}	models.Response			"Internal server error"
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			product				body		models.ProductUpdate					true	"Product update details"
+//	@Param			If-Match			header		string									false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response							"Product updated successfully"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		404					{object}	models.Response							"Product not found"
+//	@Failure		409					{object}	models.Response{body=models.Product}	"Version conflict (body has the current state), or identifier already taken, maybe by a product in the trash"
+//	@Failure		422					{object}	models.Response							"Model invalid"
+//	@Failure		428					{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response							"Internal server error"
 //	@Router			/product/update [put]
-func ProductUpdate(c *fiber.Ctx) error {
+func (ctrl *ProductController) ProductUpdate(c *fiber.Ctx) error {
 	var productUpdate models.ProductUpdate
 	if err := c.BodyParser(&productUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &productUpdate.Version); err != nil {
+		return err
 	}
 	if err := productUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.ProductUpdate(&productUpdate, workplace.Identifier)
+	err := ctrl.service.ProductUpdate(&productUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -423,41 +292,23 @@ func ProductUpdate(c *fiber.Ctx) error {
 //	@Failure		401					{object}	models.Response	"Auth is required"
 //	@Failure		403					{object}	models.Response	"Not Authorized"
 //	@Failure		404					{object}	models.Response	"Product not found"
+//	@Failure		409					{object}	models.Response	"Product reserved in work orders"
 //	@Failure		500					{object}	models.Response	"Internal server error"
 //	@Router			/product/delete/{id} [delete]
-func ProductDelete(c *fiber.Ctx) error {
+func (ctrl *ProductController) ProductDelete(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.ProductDelete(id, workplace.Identifier)
+	err := ctrl.service.ProductDelete(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -476,53 +327,32 @@ func ProductDelete(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string					true	"Workplace Token"
 //	@Param			product				body		models.ProductCreate	true	"Details of the product to create"
+//	@Param			Idempotency-Key		header		string					false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200					{object}	models.Response			"Product created successfully"
 //	@Failure		400					{object}	models.Response			"Bad Request"
 //	@Failure		401					{object}	models.Response			"Auth is required"
 //	@Failure		403					{object}	models.Response			"Not Authorized"
+//	@Failure		409					{object}	models.Response			"Idempotency-Key in use by a request still in progress, or identifier already taken, maybe by a product in the trash"
 //	@Failure		422					{object}	models.Response			"Model invalid"
 //	@Failure		500					{object}	models.Response			"Internal server error"
 //	@Router			/product/create [post]
-func ProductCreate(c *fiber.Ctx) error {
+func (ctrl *ProductController) ProductCreate(c *fiber.Ctx) error {
 	var productCreate models.ProductCreate
 	if err := c.BodyParser(&productCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := productCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	productCreated, err := services.ProductCreate(&productCreate, workplace.Identifier)
+	productCreated, err := ctrl.service.ProductCreate(&productCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -6,6 +6,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type PurchaseOrderController struct {
+	service *services.PurchaseOrderService
+}
+
+func NewPurchaseOrderController(service *services.PurchaseOrderService) *PurchaseOrderController {
+	return &PurchaseOrderController{service: service}
+}
+
 // PurchaseOrderGetByID godoc
 //	@Summary		Get Purchase Order By ID
 //	@Description	Retrieves a specific purchase order by its ID.
@@ -14,61 +22,37 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string												true	"Workplace Token"
-//	@Param			id					path		string												true	"ID of Purchase Order"
-//	@Success		200					{object}	models.Response{body=models.PurchaseOrderLaundry}	"Laundry order obtained successfully"
-//	@Failure		400					{object}	models.Response										"Bad Request"
-//	@Failure		401					{object}	models.Response										"Auth is required"
-//	@Failure		403					{object}	models.Response										"Not Authorized"
-//	@Failure		404					{object}	models.Response										"Purchase Order not found"
-//	@Failure		500					{object}	models.Response										"Internal server error"
+//	@Param			X-Workplace-Token	header		string										true	"Workplace Token"
+//	@Param			id					path		string										true	"ID of Purchase Order"
+//	@Success		200					{object}	models.Response{body=models.PurchaseOrder}	"Purchase order obtained successfully"
+//	@Header			200					{string}	ETag										"Versión del registro, para enviar en If-Match"
+//	@Failure		400					{object}	models.Response								"Bad Request"
+//	@Failure		401					{object}	models.Response								"Auth is required"
+//	@Failure		403					{object}	models.Response								"Not Authorized"
+//	@Failure		404					{object}	models.Response								"Purchase Order not found"
+//	@Failure		500					{object}	models.Response								"Internal server error"
 //	@Router			/purchase_order/{id} [get]
-func PurchaseOrderGetByID(c *fiber.Ctx) error {
+func (ctrl *PurchaseOrderController) PurchaseOrderGetByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.PurchaseOrderGetByID(id, workplace.Identifier)
+	purchaseOrder, err := ctrl.service.PurchaseOrderGetByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Orden de compra obtenida con éxito",
-		})
-	}
+	setETag(c, purchaseOrder.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    purchaseOrder,
 		Message: "Orden de compra obtenida con éxito",
 	})
 }
@@ -80,52 +64,38 @@ func PurchaseOrderGetByID(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string												true	"Workplace Token"
-//	@Success		200					{object}	models.Response{body=[]models.PurchaseOrderLaundry}	"Purchase Orders obtained with success"
-//	@Failure		400					{object}	models.Response										"Bad Request"
-//	@Failure		401					{object}	models.Response										"Auth is required"
-//	@Failure		403					{object}	models.Response										"Not Authorized"
-//	@Failure		500					{object}	models.Response										"Internal server error"
+//	@Param			X-Workplace-Token	header		string																true	"Workplace Token"
+//	@Param			page				query		int																	false	"Página, desde 1"
+//	@Param			page_size			query		int																	false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string																false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{body=[]models.PurchaseOrder,meta=models.Pagination}	"Purchase Orders obtained with success"
+//	@Failure		400					{object}	models.Response														"Bad Request"
+//	@Failure		401					{object}	models.Response														"Auth is required"
+//	@Failure		403					{object}	models.Response														"Not Authorized"
+//	@Failure		500					{object}	models.Response														"Internal server error"
 //	@Router			/purchase_order/get_all [get]
 //	@Security		BearerAuth
-func PurchaseOrderGetAll(c *fiber.Ctx) error {
+func (ctrl *PurchaseOrderController) PurchaseOrderGetAll(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.PurchaseOrderGetAll(workplace.Identifier)
+	params, err := listParams(c)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Orden de compra obtenida con éxito",
-		})
+	purchaseOrders, pagination, err := ctrl.service.PurchaseOrderGetAll(workplace.ID, params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    purchaseOrders,
 		Message: "Orden de compra obtenida con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -138,54 +108,33 @@ func PurchaseOrderGetAll(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
 //	@Param			purchaseOrderCreate	body		models.PurchaseOrderCreate		true	"Purchase order creation data"
+//	@Param			Idempotency-Key		header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200					{object}	models.Response{body=string}	"Purchase order created successfully"
 //	@Failure		400					{object}	models.Response					"Bad Request"
 //	@Failure		401					{object}	models.Response					"Auth is required"
 //	@Failure		403					{object}	models.Response					"Not Authorized"
+//	@Failure		409					{object}	models.Response					"Idempotency-Key in use by a request still in progress"
 //	@Failure		422					{object}	models.Response					"Model invalid"
 //	@Failure		500					{object}	models.Response					"Internal server error"
 //	@Router			/purchase_order/create     [post]
 //	@Security		BearerAuth
-func PurchaseOrderCreate(c *fiber.Ctx) error {
+func (ctrl *PurchaseOrderController) PurchaseOrderCreate(c *fiber.Ctx) error {
 	var purchaseOrderCreate models.PurchaseOrderCreate
 	if err := c.BodyParser(&purchaseOrderCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := purchaseOrderCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	id, err := services.PurchaseOrderCreate(&purchaseOrderCreate, workplace.Identifier)
+	id, err := ctrl.service.PurchaseOrderCreate(&purchaseOrderCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -204,55 +153,38 @@ func PurchaseOrderCreate(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string						true	"Workplace Token"
-//	@Param			purchaseOrderUpdate	body		models.PurchaseOrderUpdate	true	"Purchase order update data"
-//	@Success		200					{object}	models.Response				"Purchase order updated successfully"
-//	@Failure		400					{object}	models.Response				"Bad Request"
-//	@Failure		401					{object}	models.Response				"Auth is required"
-//	@Failure		403					{object}	models.Response				"Not Authorized"
-//	@Failure		422					{object}	models.Response				"Model invalid"
-//	@Failure		500					{object}	models.Response				"Internal server error"
+//	@Param			X-Workplace-Token	header		string										true	"Workplace Token"
+//	@Param			purchaseOrderUpdate	body		models.PurchaseOrderUpdate					true	"Purchase order update data"
+//	@Param			If-Match			header		string										false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response								"Purchase order updated successfully"
+//	@Failure		400					{object}	models.Response								"Bad Request"
+//	@Failure		401					{object}	models.Response								"Auth is required"
+//	@Failure		403					{object}	models.Response								"Not Authorized"
+//	@Failure		409					{object}	models.Response{body=models.PurchaseOrder}	"Version conflict, body has the current state"
+//	@Failure		422					{object}	models.Response								"Model invalid"
+//	@Failure		428					{object}	models.Response								"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response								"Internal server error"
 //	@Router			/purchase_order/update [put]
-func PurchaseOrderUpdate(c *fiber.Ctx) error {
+func (ctrl *PurchaseOrderController) PurchaseOrderUpdate(c *fiber.Ctx) error {
 	var purchaseOrderUpdate models.PurchaseOrderUpdate
 	if err := c.BodyParser(&purchaseOrderUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &purchaseOrderUpdate.Version); err != nil {
+		return err
 	}
 	if err := purchaseOrderUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.PurchaseOrderUpdate(&purchaseOrderUpdate, workplace.Identifier)
+	err := ctrl.service.PurchaseOrderUpdate(&purchaseOrderUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -278,39 +210,20 @@ func PurchaseOrderUpdate(c *fiber.Ctx) error {
 //	@Failure		404					{object}	models.Response	"Purchase order not found"
 //	@Failure		500					{object}	models.Response	"Internal server error"
 //	@Router			/purchase_order/delete/{id} [delete]
-func PurchaseOrderDelete(c *fiber.Ctx) error {
+func (ctrl *PurchaseOrderController) PurchaseOrderDelete(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.PurchaseOrderDelete(id, workplace.Identifier)
+	err := ctrl.service.PurchaseOrderDelete(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -6,6 +6,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type PurchaseProductController struct {
+	service *services.PurchaseProductService
+}
+
+func NewPurchaseProductController(service *services.PurchaseProductService) *PurchaseProductController {
+	return &PurchaseProductController{service: service}
+}
+
 // PurchaseProductGetByID godoc
 //	@Summary		Get Purchase Product By ID
 //	@Description	Retrieves a specific purchase product by its ID for a given workplace.
@@ -13,61 +21,37 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string												true	"Workplace Token"
-//	@Param			id					path		string												true	"ID of the purchase product"
-//	@Success		200					{object}	models.Response{body=models.PurchaseProductLaundry}	"Product obtained successfully"
-//	@Failure		400					{object}	models.Response										"Bad Request"
-//	@Failure		401					{object}	models.Response										"Auth is required"
-//	@Failure		403					{object}	models.Response										"Not Authorized"
-//	@Failure		404					{object}	models.Response										"Purchase Product not found"
-//	@Failure		500					{object}	models.Response										"Internal server error"
+//	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
+//	@Param			id					path		string											true	"ID of the purchase product"
+//	@Success		200					{object}	models.Response{body=models.PurchaseProduct}	"Product obtained successfully"
+//	@Header			200					{string}	ETag											"Versión del registro, para enviar en If-Match"
+//	@Failure		400					{object}	models.Response									"Bad Request"
+//	@Failure		401					{object}	models.Response									"Auth is required"
+//	@Failure		403					{object}	models.Response									"Not Authorized"
+//	@Failure		404					{object}	models.Response									"Purchase Product not found"
+//	@Failure		500					{object}	models.Response									"Internal server error"
 //	@Router			/purchase_product/{id} [get]
-func PurchaseProductGetByID(c *fiber.Ctx) error {
+func (ctrl *PurchaseProductController) PurchaseProductGetByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.PurchaseProductGetByID(id, workplace.Identifier)
+	purchaseProduct, err := ctrl.service.PurchaseProductGetByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Producto de compra obtenida con éxito",
-		})
-	}
+	setETag(c, purchaseProduct.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    purchaseProduct,
 		Message: "Producto de compra obtenida con éxito",
 	})
 }
@@ -81,59 +65,32 @@ func PurchaseProductGetByID(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string													true	"Workplace Token"
 //	@Param			purchase_id			path		string													true	"ID of Purchase Order"
-//	@Success		200					{object}	models.Response{body=[]models.PurchaseProductLaundry}	"Products obtained with success"
+//	@Success		200					{object}	models.Response{body=[]models.PurchaseProduct}	"Products obtained with success"
 //	@Failure		400					{object}	models.Response											"Bad Request"
 //	@Failure		401					{object}	models.Response											"Auth is required"
 //	@Failure		403					{object}	models.Response											"Not Authorized"
 //	@Failure		404					{object}	models.Response											"Purchase Order not found"
 //	@Failure		500					{object}	models.Response											"Internal server error"
 //	@Router			/purchase_product/get_purchase/{purchase_id} [get]
-func PurchaseProductGetAllByPurhcaseID(c *fiber.Ctx) error {
+func (ctrl *PurchaseProductController) PurchaseProductGetAllByPurhcaseID(c *fiber.Ctx) error {
 	purchaseId := c.Params("purchase_id")
 	if purchaseId == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.PurchaseProductGetAllByPurhcaseID(purchaseId, workplace.Identifier)
+	purchaseProducts, err := ctrl.service.PurchaseProductGetAllByPurhcaseID(purchaseId, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
-	}
-
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Productos de orden de compra obtenida con éxito",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    purchaseProducts,
 		Message: "Productos de orden de compra obtenida con éxito",
 	})
 }
@@ -148,53 +105,32 @@ func PurchaseProductGetAllByPurhcaseID(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token		header		string							true	"Workplace Token"
 //	@Param			purchaseProductCreate	body		models.PurchaseProductCreate	true	"Purchase product creation data"
+//	@Param			Idempotency-Key			header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200						{object}	models.Response{body=string}	"Purchase product created successfully"
 //	@Failure		400						{object}	models.Response					"Bad Request"
 //	@Failure		401						{object}	models.Response					"Auth is required"
 //	@Failure		403						{object}	models.Response					"Not Authorized"
+//	@Failure		409						{object}	models.Response					"Idempotency-Key in use by a request still in progress"
 //	@Failure		422						{object}	models.Response					"Model is invalid"
 //	@Failure		500						{object}	models.Response					"Internal server error"
 //	@Router			/purchase_product/create   [post]
-func PurchaseProductCreate(c *fiber.Ctx) error {
+func (ctrl *PurchaseProductController) PurchaseProductCreate(c *fiber.Ctx) error {
 	var purchaseProductCreate models.PurchaseProductCreate
 	if err := c.BodyParser(&purchaseProductCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := purchaseProductCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	id, err := services.PurchaseProductCreate(&purchaseProductCreate, workplace.Identifier)
+	id, err := ctrl.service.PurchaseProductCreate(&purchaseProductCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -211,57 +147,40 @@ func PurchaseProductCreate(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
-//	@Param			id					path		string							true	"ID of the purchase product"
-//	@Param			product				body		models.PurchaseProductUpdate	true	"Purchase product update details"
-//	@Success		200					{object}	models.Response					"Purchase product updated successfully"
-//	@Failure		400					{object}	models.Response					"Bad Request"
-//	@Failure		401					{object}	models.Response					"Auth is required"
-//	@Failure		403					{object}	models.Response					"Not Authorized"
-//	@Failure		404					{object}	models.Response					"Purchase Product not found"
-//	@Failure		422					{object}	models.Response					"Model is invalid"
-//	@Failure		500					{object}	models.Response					"Internal server error"
+//	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
+//	@Param			id					path		string											true	"ID of the purchase product"
+//	@Param			product				body		models.PurchaseProductUpdate					true	"Purchase product update details"
+//	@Param			If-Match			header		string											false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response									"Purchase product updated successfully"
+//	@Failure		400					{object}	models.Response									"Bad Request"
+//	@Failure		401					{object}	models.Response									"Auth is required"
+//	@Failure		403					{object}	models.Response									"Not Authorized"
+//	@Failure		404					{object}	models.Response									"Purchase Product not found"
+//	@Failure		409					{object}	models.Response{body=models.PurchaseProduct}	"Version conflict, body has the current state"
+//	@Failure		422					{object}	models.Response									"Model is invalid"
+//	@Failure		428					{object}	models.Response									"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response									"Internal server error"
 //	@Router			/purchase_product/update/{id} [put]
-func PurchaseProductUpdate(c *fiber.Ctx) error {
+func (ctrl *PurchaseProductController) PurchaseProductUpdate(c *fiber.Ctx) error {
 	var purchaseProductUpdate models.PurchaseProductUpdate
 	if err := c.BodyParser(&purchaseProductUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &purchaseProductUpdate.Version); err != nil {
+		return err
 	}
 	if err := purchaseProductUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.PurchaseProductUpdate(&purchaseProductUpdate, workplace.Identifier)
+	err := ctrl.service.PurchaseProductUpdate(&purchaseProductUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -287,39 +206,20 @@ func PurchaseProductUpdate(c *fiber.Ctx) error {
 //	@Failure		404					{object}	models.Response	"Purchase Product not found"
 //	@Failure		500					{object}	models.Response	"Internal server error"
 //	@Router			/purchase_product/{id} [delete]
-func PurchaseProductDelete(c *fiber.Ctx) error {
+func (ctrl *PurchaseProductController) PurchaseProductDelete(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.PurchaseProductDelete(id, workplace.Identifier)
+	err := ctrl.service.PurchaseProductDelete(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -6,6 +6,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type RoleController struct {
+	service *services.RoleService
+}
+
+func NewRoleController(service *services.RoleService) *RoleController {
+	return &RoleController{service: service}
+}
+
 // GetRolesWorkplace godoc
 //	@Summary		Retrieve roles for a user in a specific workplace
 //	@Description	This function fetches roles based on the user's role and workplace identifier
@@ -19,42 +27,25 @@ import (
 //	@Success		200					{object}	models.Response	"Roles retrieved successfully"
 //	@Failure		400					{object}	models.Response	"Bad request if user or workplace is missing"
 //	@Failure		500					{object}	models.Response	"Internal server error on failure"
-func GetRolesWorkplace( c*fiber.Ctx) error {
+func (ctrl *RoleController) GetRolesWorkplace(c *fiber.Ctx) error {
 	user := c.Locals("user").(*models.User)
 	if user == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  false,
-			"message": "User is required",
-		})
+		return models.BadRequest("User is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"status":  false,
-			"message": "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	roles, err := services.GetRoleAll(user.Role, workplace.Identifier)
+	roles, err := ctrl.service.GetRoleAll(user.Role, workplace.Identifier)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 	
-	return c.JSON(fiber.Map{
-		"status":  true,
-		"message": "Roles retrieved successfully",
-		"data":    roles,
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    roles,
+		Message: "Roles retrieved successfully",
 	})
 }
\ No newline at end of file
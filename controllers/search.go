@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SearchController struct {
+	service *services.SearchService
+}
+
+func NewSearchController(service *services.SearchService) *SearchController {
+	return &SearchController{service: service}
+}
+
+// Search godoc
+//	@Summary		Global search
+//	@Description	Searches clients (name, CUIL, DNI, email), vehicles (domain, brand, model) and the workplace's products and suppliers. Accents and case are ignored; hits are ranked by relevance.
+//	@Tags			Search
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string										true	"Workplace Token"
+//	@Param			q					query		string										true	"Texto a buscar (mínimo 2 caracteres)"
+//	@Param			limit				query		int											false	"Máximo de resultados (máximo 100)"
+//	@Success		200					{object}	models.Response{body=[]models.SearchHit}	"Resultados ordenados por relevancia"
+//	@Failure		400					{object}	models.Response								"Bad Request"
+//	@Failure		401					{object}	models.Response								"Auth is required"
+//	@Failure		422					{object}	models.Response								"Query too short"
+//	@Failure		500					{object}	models.Response								"Internal server error"
+//	@Router			/search [get]
+func (ctrl *SearchController) Search(c *fiber.Ctx) error {
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	limit := models.DefaultPageSize
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > models.MaxPageSize {
+			return models.BadRequest("limit debe ser un número entre 1 y "+strconv.Itoa(models.MaxPageSize), err)
+		}
+		limit = n
+	}
+
+	hits, err := ctrl.service.Search(c.Query("q"), workplace.ID, limit)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    hits,
+		Message: "Búsqueda realizada con éxito",
+	})
+}
@@ -6,6 +6,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type ServiceController struct {
+	service *services.ServiceService
+}
+
+func NewServiceController(service *services.ServiceService) *ServiceController {
+	return &ServiceController{service: service}
+}
+
 // ServiceGetByID godoc
 //	@Summary		Get a service by id
 //	@Description	Get a service by id
@@ -15,59 +23,35 @@ import (
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string	true	"Workplace Token"
 //	@Param			id					path		string	true	"ID of the income to get"
-//	@Success		200					{object}	models.Response{body=models.ServiceLaundry}
+//	@Success		200					{object}	models.Response{body=models.Service}
+//	@Header			200					{string}	ETag			"Versión del registro, para enviar en If-Match"
 //	@Failure		400					{object}	models.Response	"Bad Request"
 //	@Failure		401					{object}	models.Response	"Auth is required"
 //	@Failure		403					{object}	models.Response	"Not Authorized"
 //	@Failure		404					{object}	models.Response	"Service not found"
 //	@Failure		500					{object}	models.Response
 //	@Router			/service/{id} [get]
-func ServiceGetByID(c *fiber.Ctx) error {
+func (ctrl *ServiceController) ServiceGetByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.ServiceGetByID(id, workplace.Identifier)
+	service, err := ctrl.service.ServiceGetByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Servicio obtenido con éxito",
-		})
-	}
+	setETag(c, service.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    service,
 		Message: "Servicio obtenido con éxito",
 	})
 }
@@ -80,50 +64,36 @@ func ServiceGetByID(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string	true	"Workplace Token"
-//	@Success		200					{object}	models.Response{body=[]models.ServiceLaundry}
+//	@Param			page				query		int		false	"Página, desde 1"
+//	@Param			page_size			query		int		false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string	false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{body=[]models.Service,meta=models.Pagination}
 //	@Failure		400					{object}	models.Response	"Bad Request"
 //	@Failure		401					{object}	models.Response	"Auth is required"
 //	@Failure		403					{object}	models.Response	"Not Authorized"
 //	@Failure		500					{object}	models.Response
 //	@Router			/service/get_all [get]
-func ServiceGetAll(c *fiber.Ctx) error {
+func (ctrl *ServiceController) ServiceGetAll(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.ServiceGetAll(workplace.Identifier)
+	params, err := listParams(c)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Servicios obtenidos con éxito",
-		})
+	serviceList, pagination, err := ctrl.service.ServiceGetAll(workplace.ID, params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    serviceList,
 		Message: "Servicios obtenidos con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -136,53 +106,32 @@ func ServiceGetAll(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
 //	@Param			serviceCreate		body		models.ServiceCreate			true	"Service creation data"
+//	@Param			Idempotency-Key		header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200					{object}	models.Response{body=string}	"Service created successfully"
 //	@Failure		400					{object}	models.Response					"Bad Request"
 //	@Failure		401					{object}	models.Response					"Auth is required"
 //	@Failure		403					{object}	models.Response					"Not Authorized"
+//	@Failure		409					{object}	models.Response					"Idempotency-Key in use by a request still in progress, or name already taken, maybe by a service in the trash"
 //	@Failure		422					{object}	models.Response					"Model is invalid"
 //	@Failure		500					{object}	models.Response					"Internal server error"
 //	@Router			/service/create      [post]
-func ServiceCreate(c *fiber.Ctx) error {
+func (ctrl *ServiceController) ServiceCreate(c *fiber.Ctx) error {
 	var serviceCreate models.ServiceCreate
 	if err := c.BodyParser(&serviceCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := serviceCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	id, err := services.ServiceCreate(&serviceCreate, workplace.Identifier)
+	id, err := ctrl.service.ServiceCreate(&serviceCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -199,57 +148,40 @@ func ServiceCreate(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string					true	"Workplace Token"
-//	@Param			serviceUpdate		body		models.ServiceUpdate	true	"Service data to update"
-//	@Success		200					{object}	models.Response			"Servicio editado con éxito"
-//	@Failure		400					{object}	models.Response			"Bad Request"
-//	@Failure		401					{object}	models.Response			"Auth is required"
-//	@Failure		403					{object}	models.Response			"Not Authorized"
-//	@Failure		404					{object}	models.Response			"Expense not found"
-//	@Failure		422					{object}	models.Response			"Model is invalid"
-//	@Failure		500					{object}	models.Response			"Error interno"
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			serviceUpdate		body		models.ServiceUpdate					true	"Service data to update"
+//	@Param			If-Match			header		string									false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response							"Servicio editado con éxito"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		404					{object}	models.Response							"Expense not found"
+//	@Failure		409					{object}	models.Response{body=models.Service}	"Version conflict (body has the current state), or name already taken, maybe by a service in the trash"
+//	@Failure		422					{object}	models.Response							"Model is invalid"
+//	@Failure		428					{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response							"Error interno"
 //	@Router			/service/update [put]
 //	@Security		BearerAuth
-func ServiceUpdate(c *fiber.Ctx) error {
+func (ctrl *ServiceController) ServiceUpdate(c *fiber.Ctx) error {
 	var serviceUpdate models.ServiceUpdate
 	if err := c.BodyParser(&serviceUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &serviceUpdate.Version); err != nil {
+		return err
 	}
 	if err := serviceUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.ServiceUpdate(&serviceUpdate, workplace.Identifier)
+	err := ctrl.service.ServiceUpdate(&serviceUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -275,39 +207,20 @@ func ServiceUpdate(c *fiber.Ctx) error {
 //	@Failure		404					{object}	models.Response	"Service not found"
 //	@Failure		500					{object}	models.Response	"Error interno"
 //	@Router			/service/delete/{id} [delete]
-func ServiceDeleteByID(c *fiber.Ctx) error {
+func (ctrl *ServiceController) ServiceDeleteByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.ServiceDeleteByID(id, workplace.Identifier)
+	err := ctrl.service.ServiceDeleteByID(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -316,3 +229,92 @@ func ServiceDeleteByID(c *fiber.Ctx) error {
 		Message: "Servicio eliminado con éxito",
 	})
 }
+
+// ServicePrices godoc
+//	@Summary		Service price history
+//	@Description	Lists the prices of a service by vehicle category (empty is the general price), latest effective date first. The current price is the latest one already in effect; later ones are scheduled.
+//	@Tags			Service
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string	true	"Workplace Token"
+//	@Param			id					path		string	true	"ID of the service"
+//	@Success		200					{object}	models.Response{body=[]models.ServicePrice}
+//	@Failure		400					{object}	models.Response	"Bad Request"
+//	@Failure		401					{object}	models.Response	"Auth is required"
+//	@Failure		403					{object}	models.Response	"Not Authorized"
+//	@Failure		404					{object}	models.Response	"Service not found"
+//	@Failure		500					{object}	models.Response
+//	@Router			/service/prices/{id} [get]
+func (ctrl *ServiceController) ServicePrices(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	prices, err := ctrl.service.ServicePrices(id, workplace.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    prices,
+		Message: "Precios obtenidos con éxito",
+	})
+}
+
+// ServiceSetPrice godoc
+//	@Summary		Set service price
+//	@Description	Adds a price to the service history, effective now or from effective_from (which cannot be in the past). With vehicle_category the price applies only to vehicles of that category, and only in workplaces with category_prices; without it, it is the general price. Existing incomes keep the price they were charged.
+//	@Tags			Service
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
+//	@Param			id					path		string							true	"ID of the service"
+//	@Param			price				body		models.ServicePriceCreate		true	"New price"
+//	@Success		200					{object}	models.Response{body=string}	"Price created, body has its ID"
+//	@Failure		400					{object}	models.Response					"Bad Request, or vehicle_category in a workplace without category prices"
+//	@Failure		401					{object}	models.Response					"Auth is required"
+//	@Failure		403					{object}	models.Response					"Not Authorized"
+//	@Failure		404					{object}	models.Response					"Service not found"
+//	@Failure		409					{object}	models.Response					"The service already has a price from that date"
+//	@Failure		422					{object}	models.Response					"Model is invalid"
+//	@Failure		500					{object}	models.Response					"Internal server error"
+//	@Router			/service/set_price/{id} [post]
+func (ctrl *ServiceController) ServiceSetPrice(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	var price models.ServicePriceCreate
+	if err := c.BodyParser(&price); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := price.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	priceID, err := ctrl.service.ServiceSetPrice(id, &price, workplace.ID, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    priceID,
+		Message: "Precio cargado con éxito",
+	})
+}
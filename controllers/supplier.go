@@ -6,6 +6,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type SupplierController struct {
+	service *services.SupplierService
+}
+
+func NewSupplierController(service *services.SupplierService) *SupplierController {
+	return &SupplierController{service: service}
+}
+
 // SupplierGetByID godoc
 //	@Summary		Get Supplier By ID
 //	@Description	Get a supplier by its ID within a specified workplace.
@@ -13,61 +21,37 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
-//	@Param			id					path		string											true	"ID of the supplier"
-//	@Success		200					{object}	models.Response{body=models.SupplierLaundry}	"Supplier obtained with success"
-//	@Failure		400					{object}	models.Response									"Bad Request"
-//	@Failure		401					{object}	models.Response									"Auth is required"
-//	@Failure		403					{object}	models.Response									"Not Authorized"
-//	@Failure		404					{object}	models.Response									"Supplier not found"
-//	@Failure		500					{object}	models.Response									"Internal server error"
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			id					path		string									true	"ID of the supplier"
+//	@Success		200					{object}	models.Response{body=models.Supplier}	"Supplier obtained with success"
+//	@Header			200					{string}	ETag									"Versión del registro, para enviar en If-Match"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		404					{object}	models.Response							"Supplier not found"
+//	@Failure		500					{object}	models.Response							"Internal server error"
 //	@Router			/supplier/{id} [get]
-func SupplierGetByID(c *fiber.Ctx) error {
+func (ctrl *SupplierController) SupplierGetByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.SupplierGetByID(id, workplace.Identifier)
+	supplier, err := ctrl.service.SupplierGetByID(id, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Proveedor obtenido con éxito",
-		})
-	}
+	setETag(c, supplier.Version)
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    supplier,
 		Message: "Proveedor obtenido con éxito",
 	})
 }
@@ -79,51 +63,37 @@ func SupplierGetByID(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
-//	@Success		200					{object}	models.Response{body=[]models.SupplierLaundry}	"Suppliers obtained with success"
-//	@Failure		400					{object}	models.Response									"Bad Request"
-//	@Failure		401					{object}	models.Response									"Auth is required"
-//	@Failure		403					{object}	models.Response									"Not Authorized"
-//	@Failure		500					{object}	models.Response									"Internal server error"
+//	@Param			X-Workplace-Token	header		string															true	"Workplace Token"
+//	@Param			page				query		int																false	"Página, desde 1"
+//	@Param			page_size			query		int																false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string															false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{body=[]models.Supplier,meta=models.Pagination}	"Suppliers obtained with success"
+//	@Failure		400					{object}	models.Response													"Bad Request"
+//	@Failure		401					{object}	models.Response													"Auth is required"
+//	@Failure		403					{object}	models.Response													"Not Authorized"
+//	@Failure		500					{object}	models.Response													"Internal server error"
 //	@Router			/supplier/get_all [get]
-func SupplierGetAll(c *fiber.Ctx) error {
+func (ctrl *SupplierController) SupplierGetAll(c *fiber.Ctx) error {
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.SupplierGetAll(workplace.Identifier)
+	params, err := listParams(c)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Proveedores obtenidos con éxito",
-		})
+	suppliers, pagination, err := ctrl.service.SupplierGetAll(workplace.ID, params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    suppliers,
 		Message: "Proveedores obtenidos con éxito",
+		Meta:    pagination,
 	})
 }
 
@@ -136,59 +106,32 @@ func SupplierGetAll(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string											true	"Workplace Token"
 //	@Param			name				query		string											true	"Name of the Supplier"
-//	@Success		200					{object}	models.Response{body=[]models.SupplierLaundry}	"List of suppliers"
+//	@Success		200					{object}	models.Response{body=[]models.Supplier}	"List of suppliers"
 //	@Failure		400					{object}	models.Response									"Bad Request"
 //	@Failure		401					{object}	models.Response									"Auth is required"
 //	@Failure		403					{object}	models.Response									"Not Authorized"
 //	@Failure		500					{object}	models.Response									"Internal server error"
 //	@Router			/supplier/get_by_name [get]
 //	@Security		BearerAuth
-func SupplierGetByName(c *fiber.Ctx) error {
+func (ctrl *SupplierController) SupplierGetByName(c *fiber.Ctx) error {
 	name := c.Query("name")
 	if name == "" || len(name) < 3 {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "El valor no debe de ser vacio o menor a 3 caracteres",
-		})
+		return models.BadRequest("El valor no debe de ser vacio o menor a 3 caracteres", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	laundry, workshop, err := services.SupplierGetByName(name, workplace.Identifier)
+	suppliers, err := ctrl.service.SupplierGetByName(name, workplace.ID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
-	}
-
-	if laundry != nil {
-		return c.Status(200).JSON(models.Response{
-			Status:  true,
-			Body:    laundry,
-			Message: "Proveedores obtenidos con éxito",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
 		Status:  true,
-		Body:    workshop,
+		Body:    suppliers,
 		Message: "Proveedores obtenidos con éxito",
 	})
 }
@@ -202,53 +145,32 @@ func SupplierGetByName(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
 //	@Param			supplier			body		models.SupplierCreate			true	"Details of the supplier to create"
+//	@Param			Idempotency-Key		header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
 //	@Success		200					{object}	models.Response{body=string}	"Supplier created successfully"
 //	@Failure		400					{object}	models.Response					"Bad Request"
 //	@Failure		401					{object}	models.Response					"Auth is required"
 //	@Failure		403					{object}	models.Response					"Not Authorized"
+//	@Failure		409					{object}	models.Response					"Idempotency-Key in use by a request still in progress"
 //	@Failure		422					{object}	models.Response					"Model is invalid"
 //	@Failure		500					{object}	models.Response					"Internal server error"
 //	@Router			/supplier/create [post]
-func SupplierCreate(c *fiber.Ctx) error {
+func (ctrl *SupplierController) SupplierCreate(c *fiber.Ctx) error {
 	var supplierCreate models.SupplierCreate
 	if err := c.BodyParser(&supplierCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := supplierCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	id, err := services.SupplierCreate(&supplierCreate, workplace.Identifier)
+	id, err := ctrl.service.SupplierCreate(&supplierCreate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -266,56 +188,39 @@ func SupplierCreate(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			X-Workplace-Token	header		string					true	"Workplace Token"
-//	@Param			body				body		models.SupplierUpdate	true	"Supplier information"
-//	@Success		200					{object}	models.Response			"Supplier updated with success"
-//	@Failure		400					{object}	models.Response			"Bad Request"
-//	@Failure		401					{object}	models.Response			"Auth is required"
-//	@Failure		403					{object}	models.Response			"Not Authorized"
-//	@Failure		404					{object}	models.Response			"Supplier not found"
-//	@Failure		422					{object}	models.Response			"Model is invalid"
-//	@Failure		500					{object}	models.Response			"Internal server error"
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			body				body		models.SupplierUpdate					true	"Supplier information"
+//	@Param			If-Match			header		string									false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response							"Supplier updated with success"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		404					{object}	models.Response							"Supplier not found"
+//	@Failure		409					{object}	models.Response{body=models.Supplier}	"Version conflict, body has the current state"
+//	@Failure		422					{object}	models.Response							"Model is invalid"
+//	@Failure		428					{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response							"Internal server error"
 //	@Router			/supplier/update [put]
-func SupplierUpdate(c *fiber.Ctx) error {
+func (ctrl *SupplierController) SupplierUpdate(c *fiber.Ctx) error {
 	var supplierUpdate models.SupplierUpdate
 	if err := c.BodyParser(&supplierUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &supplierUpdate.Version); err != nil {
+		return err
 	}
 	if err := supplierUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.SupplierUpdate(&supplierUpdate, workplace.Identifier)
+	err := ctrl.service.SupplierUpdate(&supplierUpdate, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -341,39 +246,20 @@ func SupplierUpdate(c *fiber.Ctx) error {
 //	@Failure		404					{object}	models.Response	"Supplier not found"
 //	@Failure		500					{object}	models.Response	"Internal server error"
 //	@Router			/supplier/delete/{id} [delete]
-func SupplierDeleteByID(c *fiber.Ctx) error {
+func (ctrl *SupplierController) SupplierDeleteByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
 	workplace := c.Locals("workplace").(*models.Workplace)
 	if workplace == nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Workplace is required",
-		})
+		return models.BadRequest("Workplace is required", nil)
 	}
 
-	err := services.SupplierDeleteByID(id, workplace.Identifier)
+	err := ctrl.service.SupplierDeleteByID(id, workplace.ID, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
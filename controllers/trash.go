@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type TrashController struct {
+	service *services.TrashService
+}
+
+func NewTrashController(service *services.TrashService) *TrashController {
+	return &TrashController{service: service}
+}
+
+// GetTrash godoc
+//	@Summary		List trash
+//	@Description	Lists the soft-deleted records of an entity type visible from the workplace. Clients and vehicles are global. Accepts the same filters as the entity's get_all plus deleted_at_from / deleted_at_to.
+//	@Tags			Trash
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			entity				path		string									true	"Entity type"	Enums(attendance, client, employee, expense, income, movement_type, product, purchase_order, purchase_product, service, supplier, vehicle)
+//	@Param			page				query		int										false	"Página, desde 1"
+//	@Param			page_size			query		int										false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string									false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{meta=models.Pagination}	"Deleted records, most recent first"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized"
+//	@Failure		500					{object}	models.Response							"Internal server error"
+//	@Router			/trash/{entity} [get]
+func (ctrl *TrashController) GetTrash(c *fiber.Ctx) error {
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	params, err := listParams(c)
+	if err != nil {
+		return err
+	}
+
+	items, pagination, err := ctrl.service.GetTrash(c.Params("entity"), workplace.ID, params)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    items,
+		Message: "Papelera obtenida con éxito",
+		Meta:    pagination,
+	})
+}
+
+// RestoreTrash godoc
+//	@Summary		Restore from trash
+//	@Description	Restores a soft-deleted record. A client's vehicles and a purchase order's lines deleted with it are restored too. Records whose parent is still in the trash cannot be restored until the parent is.
+//	@Tags			Trash
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string			true	"Workplace Token"
+//	@Param			entity				path		string			true	"Entity type"
+//	@Param			id					path		string			true	"ID of the record"
+//	@Success		200					{object}	models.Response	"Record restored"
+//	@Failure		400					{object}	models.Response	"Bad Request"
+//	@Failure		401					{object}	models.Response	"Auth is required"
+//	@Failure		403					{object}	models.Response	"Not Authorized"
+//	@Failure		404					{object}	models.Response	"Not in trash"
+//	@Failure		409					{object}	models.Response	"A parent record is still in trash"
+//	@Failure		500					{object}	models.Response	"Internal server error"
+//	@Router			/trash/{entity}/restore/{id} [put]
+func (ctrl *TrashController) RestoreTrash(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	if err := ctrl.service.RestoreTrash(c.Params("entity"), id, workplace.ID, auditActor(c)); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Registro restaurado con éxito",
+	})
+}
+
+// PurgeTrash godoc
+//	@Summary		Purge from trash
+//	@Description	Permanently deletes a record that is in the trash, together with its child rows. Records still referenced by others, even from the trash, cannot be purged. This cannot be undone.
+//	@Tags			Trash
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string			true	"Workplace Token"
+//	@Param			entity				path		string			true	"Entity type"
+//	@Param			id					path		string			true	"ID of the record"
+//	@Success		200					{object}	models.Response	"Record permanently deleted"
+//	@Failure		400					{object}	models.Response	"Bad Request"
+//	@Failure		401					{object}	models.Response	"Auth is required"
+//	@Failure		403					{object}	models.Response	"Not Authorized"
+//	@Failure		404					{object}	models.Response	"Not in trash"
+//	@Failure		409					{object}	models.Response	"Product reserved in work orders, or record still referenced"
+//	@Failure		500					{object}	models.Response	"Internal server error"
+//	@Router			/trash/{entity}/purge/{id} [delete]
+func (ctrl *TrashController) PurgeTrash(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	if err := ctrl.service.PurgeTrash(c.Params("entity"), id, workplace.ID, auditActor(c)); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Registro eliminado definitivamente",
+	})
+}
@@ -6,54 +6,327 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type UserController struct {
+	service *services.UserService
+}
+
+func NewUserController(service *services.UserService) *UserController {
+	return &UserController{service: service}
+}
+
 // CreateUser godoc
 //	@Summary		Create User
-//	@Description	Creates a new user.
+//	@Description	Creates a new user. The role must exist in the roles table and be below the caller's role hierarchy.
 //	@Tags			User
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			userCreate	body		models.UserCreate	true	"User information"
-//	@Success		201			{object}	models.Response
-//	@Failure		400			{object}	models.Response	"Bad Request"
-//	@Failure		401			{object}	models.Response	"Auth is required"
-//	@Failure		403			{object}	models.Response	"Not Authorized"
-//	@Failure		500			{object}	models.Response
+//	@Param			userCreate		body		models.UserCreate	true	"User information"
+//	@Param			Idempotency-Key	header		string				false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
+//	@Success		201				{object}	models.Response
+//	@Failure		400				{object}	models.Response	"Bad Request, or role does not exist"
+//	@Failure		401				{object}	models.Response	"Auth is required"
+//	@Failure		403				{object}	models.Response	"Not Authorized"
+//	@Failure		409				{object}	models.Response	"Idempotency-Key in use by a request still in progress"
+//	@Failure		500				{object}	models.Response
 //	@Router			/user/create [post]
-func CreateUser(c *fiber.Ctx) error {
+func (ctrl *UserController) CreateUser(c *fiber.Ctx) error {
 	var userCreate models.UserCreate
 	if err := c.BodyParser(&userCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := userCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
-	}
-	userCreated, err := services.UserCreate(&userCreate)
-	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return models.Validation("Datos inválidos", err)
+	}
+	user := c.Locals("user").(*models.User)
+	userCreated, err := ctrl.service.UserCreate(user, &userCreate)
+	if err != nil {
+		return err
 	}
 	return c.Status(fiber.StatusCreated).JSON(models.Response{
 		Status:  true,
 		Body:    userCreated,
 		Message: "User created",
 	})
-}
\ No newline at end of file
+}
+
+// GetMe godoc
+//	@Summary		Get current user
+//	@Description	Returns the profile of the logged user
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	models.Response{body=models.UserDTO}
+//	@Failure		401	{object}	models.Response	"Auth is required"
+//	@Failure		500	{object}	models.Response
+//	@Router			/user/me [get]
+func (ctrl *UserController) GetMe(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    user.DTO(),
+		Message: "Usuario obtenido con éxito",
+	})
+}
+
+// UpdateMe godoc
+//	@Summary		Update current user
+//	@Description	Updates the profile of the logged user; the id of the body is ignored
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			userUpdate	body		models.UserUpdate	true	"Profile data"
+//	@Success		200	{object}	models.Response{body=models.UserDTO}
+//	@Failure		400	{object}	models.Response	"Bad Request"
+//	@Failure		401	{object}	models.Response	"Auth is required"
+//	@Failure		409	{object}	models.Response	"Username or email in use"
+//	@Failure		422	{object}	models.Response	"Validation error"
+//	@Failure		500	{object}	models.Response
+//	@Router			/user/me [put]
+func (ctrl *UserController) UpdateMe(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var userUpdate models.UserUpdate
+	if err := c.BodyParser(&userUpdate); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	userUpdate.ID = user.ID
+	if err := userUpdate.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	updated, err := ctrl.service.UserUpdate(user, &userUpdate)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    updated,
+		Message: "Usuario actualizado con éxito",
+	})
+}
+
+// GetAllUsers godoc
+//	@Summary		Get all users
+//	@Description	Lists users. Filters: username, first_name, last_name, email, role, active, created_at_from / created_at_to.
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int		false	"Página, desde 1"
+//	@Param			page_size	query		int		false	"Elementos por página (máximo 100)"
+//	@Param			sort		query		string	false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200			{object}	models.Response{body=[]models.UserDTO,meta=models.Pagination}
+//	@Failure		400			{object}	models.Response	"Bad Request"
+//	@Failure		401			{object}	models.Response	"Auth is required"
+//	@Failure		403			{object}	models.Response	"Not Authorized"
+//	@Failure		500			{object}	models.Response
+//	@Router			/user/get_all [get]
+func (ctrl *UserController) GetAllUsers(c *fiber.Ctx) error {
+	params, err := listParams(c)
+	if err != nil {
+		return err
+	}
+
+	users, pagination, err := ctrl.service.UserGetAll(params)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    users,
+		Message: "Usuarios obtenidos con éxito",
+		Meta:    pagination,
+	})
+}
+
+// GetUserByID godoc
+//	@Summary		Get user by id
+//	@Description	Get user by id
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"ID of the user"
+//	@Success		200	{object}	models.Response{body=models.UserDTO}
+//	@Failure		401	{object}	models.Response	"Auth is required"
+//	@Failure		403	{object}	models.Response	"Not Authorized"
+//	@Failure		404	{object}	models.Response	"User not found"
+//	@Failure		500	{object}	models.Response
+//	@Router			/user/{id} [get]
+func (ctrl *UserController) GetUserByID(c *fiber.Ctx) error {
+	user, err := ctrl.service.UserGetByID(c.Params("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    user,
+		Message: "Usuario obtenido con éxito",
+	})
+}
+
+// UpdateUser godoc
+//	@Summary		Update user
+//	@Description	Updates the profile of a user whose role is below the caller's
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			userUpdate	body		models.UserUpdate	true	"Profile data"
+//	@Success		200	{object}	models.Response{body=models.UserDTO}
+//	@Failure		400	{object}	models.Response	"Bad Request"
+//	@Failure		401	{object}	models.Response	"Auth is required"
+//	@Failure		403	{object}	models.Response	"Not Authorized"
+//	@Failure		404	{object}	models.Response	"User not found"
+//	@Failure		409	{object}	models.Response	"Username or email in use"
+//	@Failure		422	{object}	models.Response	"Validation error"
+//	@Failure		500	{object}	models.Response
+//	@Router			/user/update [put]
+func (ctrl *UserController) UpdateUser(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var userUpdate models.UserUpdate
+	if err := c.BodyParser(&userUpdate); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := userUpdate.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	updated, err := ctrl.service.UserUpdate(user, &userUpdate)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    updated,
+		Message: "Usuario actualizado con éxito",
+	})
+}
+
+// UpdateUserRole godoc
+//	@Summary		Change user role
+//	@Description	Changes the role of a user. Both the current and the new role must be below the caller's role hierarchy.
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string					true	"ID of the user"
+//	@Param			roleUpdate	body		models.UserRoleUpdate	true	"New role"
+//	@Success		200			{object}	models.Response{body=models.UserDTO}
+//	@Failure		400			{object}	models.Response	"Bad Request"
+//	@Failure		401			{object}	models.Response	"Auth is required"
+//	@Failure		403			{object}	models.Response	"Not Authorized"
+//	@Failure		404			{object}	models.Response	"User not found"
+//	@Failure		422			{object}	models.Response	"Validation error"
+//	@Failure		500			{object}	models.Response
+//	@Router			/user/update_role/{id} [put]
+func (ctrl *UserController) UpdateUserRole(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	var roleUpdate models.UserRoleUpdate
+	if err := c.BodyParser(&roleUpdate); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := roleUpdate.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	updated, err := ctrl.service.UserUpdateRole(user, c.Params("id"), roleUpdate.Role)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    updated,
+		Message: "Rol actualizado con éxito",
+	})
+}
+
+// ActivateUser godoc
+//	@Summary		Reactivate user
+//	@Description	Reactivates a deactivated user whose role is below the caller's
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"ID of the user"
+//	@Success		200	{object}	models.Response{body=models.UserDTO}
+//	@Failure		401	{object}	models.Response	"Auth is required"
+//	@Failure		403	{object}	models.Response	"Not Authorized"
+//	@Failure		404	{object}	models.Response	"User not found"
+//	@Failure		500	{object}	models.Response
+//	@Router			/user/activate/{id} [put]
+func (ctrl *UserController) ActivateUser(c *fiber.Ctx) error {
+	return ctrl.setActive(c, true, "Usuario activado con éxito")
+}
+
+// DeactivateUser godoc
+//	@Summary		Deactivate user
+//	@Description	Deactivates a user whose role is below the caller's: it can no longer log in and its tokens stop working
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"ID of the user"
+//	@Success		200	{object}	models.Response{body=models.UserDTO}
+//	@Failure		401	{object}	models.Response	"Auth is required"
+//	@Failure		403	{object}	models.Response	"Not Authorized"
+//	@Failure		404	{object}	models.Response	"User not found"
+//	@Failure		500	{object}	models.Response
+//	@Router			/user/deactivate/{id} [put]
+func (ctrl *UserController) DeactivateUser(c *fiber.Ctx) error {
+	return ctrl.setActive(c, false, "Usuario desactivado con éxito")
+}
+
+func (ctrl *UserController) setActive(c *fiber.Ctx, active bool, message string) error {
+	user := c.Locals("user").(*models.User)
+
+	updated, err := ctrl.service.UserSetActive(user, c.Params("id"), active)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    updated,
+		Message: message,
+	})
+}
+
+// DeleteUser godoc
+//	@Summary		Delete user
+//	@Description	Deletes a user whose role is below the caller's
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"ID of the user"
+//	@Success		200	{object}	models.Response
+//	@Failure		401	{object}	models.Response	"Auth is required"
+//	@Failure		403	{object}	models.Response	"Not Authorized"
+//	@Failure		404	{object}	models.Response	"User not found"
+//	@Failure		500	{object}	models.Response
+//	@Router			/user/delete/{id} [delete]
+func (ctrl *UserController) DeleteUser(c *fiber.Ctx) error {
+	user := c.Locals("user").(*models.User)
+
+	if err := ctrl.service.UserDelete(user, c.Params("id")); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Usuario eliminado con éxito",
+	})
+}
@@ -2,11 +2,17 @@ package controllers
 
 import (
 	"github.com/DanielChachagua/GestionCar/models"
-	"github.com/DanielChachagua/GestionCar/repositories"
 	"github.com/DanielChachagua/GestionCar/services"
 	"github.com/gofiber/fiber/v2"
 )
 
+type VehicleController struct {
+	service *services.VehicleService
+}
+
+func NewVehicleController(service *services.VehicleService) *VehicleController {
+	return &VehicleController{service: service}
+}
 
 // VehicleCreate godoc
 //	@Summary		Create Vehicle
@@ -15,45 +21,28 @@ import (
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			vehicleCreate		body		models.VehicleCreate	true	"Vehicle information"
-//	@Success		201					{object}	models.Response
-//	@Failure		400					{object}	models.Response	"Bad Request"
-//	@Failure		401					{object}	models.Response	"Auth is required"
-//	@Failure		403					{object}	models.Response	"Not Authorized"
-//	@Failure		422					{object}	models.Response	"Model is invalid"
-//	@Failure		500					{object}	models.Response
+//	@Param			vehicleCreate	body		models.VehicleCreate	true	"Vehicle information"
+//	@Param			Idempotency-Key	header		string					false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
+//	@Success		201				{object}	models.Response
+//	@Failure		400				{object}	models.Response	"Bad Request"
+//	@Failure		401				{object}	models.Response	"Auth is required"
+//	@Failure		403				{object}	models.Response	"Not Authorized"
+//	@Failure		409				{object}	models.Response	"Idempotency-Key in use by a request still in progress, or domain already taken, maybe by a vehicle in the trash"
+//	@Failure		422				{object}	models.Response	"Model is invalid"
+//	@Failure		500				{object}	models.Response
 //	@Router			/vehicle/create [post]
-func VehicleCreate(c *fiber.Ctx) error{
+func (ctrl *VehicleController) VehicleCreate(c *fiber.Ctx) error{
 	var vehicleCreate models.VehicleCreate
 	if err := c.BodyParser(&vehicleCreate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
 	}
 	if err := vehicleCreate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
-	vehicle, err := services.VehicleCreate(&vehicleCreate)
+	vehicle, err := ctrl.service.VehicleCreate(&vehicleCreate, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(models.Response{
 		Status:  true,
@@ -69,33 +58,31 @@ func VehicleCreate(c *fiber.Ctx) error{
 //	@Tags			Vehicle
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Success		200					{object}	[]models.Vehicle	"List of vehicles retrieved successfully"
-//	@Failure		400					{object}	models.Response		"Bad Request"
-//	@Failure		401					{object}	models.Response		"Auth is required"
-//	@Failure		403					{object}	models.Response		"Not Authorized"
-//	@Failure		500					{object}	models.Response		"Internal server error"
+//	@Param			page		query		int																false	"Página, desde 1"
+//	@Param			page_size	query		int																false	"Elementos por página (máximo 100)"
+//	@Param			sort		query		string															false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200			{object}	models.Response{body=[]models.Vehicle,meta=models.Pagination}	"List of vehicles retrieved successfully"
+//	@Failure		400			{object}	models.Response													"Bad Request"
+//	@Failure		401			{object}	models.Response													"Auth is required"
+//	@Failure		403			{object}	models.Response													"Not Authorized"
+//	@Failure		500			{object}	models.Response													"Internal server error"
 //	@Router			/vehicle/get_all [get]
-func VehicleGetAll(c *fiber.Ctx) error {
-	vehicles, err := services.VehicleGetAll()
+func (ctrl *VehicleController) VehicleGetAll(c *fiber.Ctx) error {
+	params, err := listParams(c)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
+	}
+
+	vehicles, pagination, err := ctrl.service.VehicleGetAll(params)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(models.Response{
 		Status:  true,
 		Body:    vehicles,
 		Message: "Vehiculos obtenidos con exito",
+		Meta:    pagination,
 	})
 }
 
@@ -106,39 +93,26 @@ func VehicleGetAll(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id					path		string			true	"ID of Vehicle"
-//	@Success		200					{object}	models.Vehicle	"Vehicle retrieved successfully"
-//	@Failure		400					{object}	models.Response	"Bad Request"
-//	@Failure		401					{object}	models.Response	"Auth is required"
-//	@Failure		403					{object}	models.Response	"Not Authorized"
-//	@Failure		404					{object}	models.Response	"Vehicle not found"
-//	@Failure		500					{object}	models.Response
+//	@Param			id	path		string			true	"ID of Vehicle"
+//	@Success		200	{object}	models.Vehicle	"Vehicle retrieved successfully"
+//	@Header			200	{string}	ETag			"Versión del registro, para enviar en If-Match"
+//	@Failure		400	{object}	models.Response	"Bad Request"
+//	@Failure		401	{object}	models.Response	"Auth is required"
+//	@Failure		403	{object}	models.Response	"Not Authorized"
+//	@Failure		404	{object}	models.Response	"Vehicle not found"
+//	@Failure		500	{object}	models.Response
 //	@Router			/vehicle/{id} [get]
-func VehicleGetByID(c *fiber.Ctx) error {
+func (ctrl *VehicleController) VehicleGetByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
-	vehicle, err := services.VehicleGetByID(id)
+	vehicle, err := ctrl.service.VehicleGetByID(id)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
+	setETag(c, vehicle.Version)
 	return c.Status(fiber.StatusOK).JSON(models.Response{
 		Status:  true,
 		Body:    vehicle,
@@ -153,36 +127,21 @@ func VehicleGetByID(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			domain				query		string				true	"Domain string"
-//	@Success		200					{object}	[]models.Vehicle	"List of vehicles retrieved successfully"
+//	@Success		200					{object}	models.Response{body=[]models.Vehicle,meta=models.Pagination}	"List of vehicles retrieved successfully"
 //	@Failure		400					{object}	models.Response		"Bad Request"
 //	@Failure		401					{object}	models.Response		"Auth is required"
 //	@Failure		403					{object}	models.Response		"Not Authorized"
 //	@Failure		500					{object}	models.Response		"Internal server error"
 //	@Router			/vehicle/get_by_domain [get]
-func VehicleGetByDomain(c *fiber.Ctx) error {
+func (ctrl *VehicleController) VehicleGetByDomain(c *fiber.Ctx) error {
 	domain := c.Query("domain")
 	if domain == "" || len(domain) < 3 {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Dominio requerido o debe de tener al menos 3 caracteres",
-		})
+		return models.BadRequest("Dominio requerido o debe de tener al menos 3 caracteres", nil)
 	}
 
-	vehicles, err := repositories.Repo.GetVehicleByDomain(domain)
+	vehicles, err := ctrl.service.VehicleGetByDomain(domain)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(models.Response{
@@ -192,6 +151,23 @@ func VehicleGetByDomain(c *fiber.Ctx) error {
 	})
 }
 
+// VehicleCategories godoc
+//	@Summary		List vehicle categories
+//	@Description	Lists the vehicle categories. Services can have a price per category; a vehicle without category pays the general price.
+//	@Tags			Vehicle
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	models.Response{body=[]string}
+//	@Failure		401	{object}	models.Response	"Auth is required"
+//	@Router			/vehicle/categories [get]
+func (ctrl *VehicleController) VehicleCategories(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(models.Response{
+		Status:  true,
+		Body:    models.VehicleCategories,
+		Message: "Categorias obtenidas con exito",
+	})
+}
+
 // VehicleGetByClientID godoc
 //	@Summary		Get Vehicles By Client ID
 //	@Description	Fetches all vehicles that belong to the given client.
@@ -199,36 +175,21 @@ func VehicleGetByDomain(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			client_id			path		string				true	"Client ID"
-//	@Success		200					{object}	[]models.Vehicle	"List of vehicles retrieved successfully"
+//	@Success		200					{object}	models.Response{body=[]models.Vehicle,meta=models.Pagination}	"List of vehicles retrieved successfully"
 //	@Failure		400					{object}	models.Response		"Bad Request"
 //	@Failure		401					{object}	models.Response		"Auth is required"
 //	@Failure		403					{object}	models.Response		"Not Authorized"
 //	@Failure		500					{object}	models.Response		"Internal server error"
 //	@Router			/vehicle/get_by_client/{client_id} [get]
-func VehicleGetByClientID(c *fiber.Ctx) error {
+func (ctrl *VehicleController) VehicleGetByClientID(c *fiber.Ctx) error {
 	clientID := c.Params("client_id")
 	if clientID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Client ID is required",
-		})
+		return models.BadRequest("Client ID is required", nil)
 	}
 
-	vehicles, err := repositories.Repo.GetVehicleByClientID(clientID)
+	vehicles, err := ctrl.service.VehicleGetByClientID(clientID)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(models.Response{
@@ -245,47 +206,34 @@ func VehicleGetByClientID(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			vehicleUpdate		body		models.VehicleUpdate	true	"VehicleUpdate"
-//	@Success		200					{object}	models.Response			"Vehicle updated successfully"
-//	@Failure		400					{object}	models.Response			"Bad Request"
-//	@Failure		401					{object}	models.Response			"Auth is required"
-//	@Failure		403					{object}	models.Response			"Not Authorized"
-//	@Failure		404					{object}	models.Response			"Vehicle not found"
-//	@Failure		422					{object}	models.Response			"Model is invalid"
-//	@Failure		500					{object}	models.Response
+//	@Param			vehicleUpdate	body		models.VehicleUpdate					true	"VehicleUpdate"
+//	@Param			If-Match		header		string									false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200				{object}	models.Response							"Vehicle updated successfully"
+//	@Failure		400				{object}	models.Response							"Bad Request"
+//	@Failure		401				{object}	models.Response							"Auth is required"
+//	@Failure		403				{object}	models.Response							"Not Authorized"
+//	@Failure		404				{object}	models.Response							"Vehicle not found"
+//	@Failure		409				{object}	models.Response{body=models.Vehicle}	"Version conflict (body has the current state), or domain already taken, maybe by a vehicle in the trash"
+//	@Failure		422				{object}	models.Response							"Model is invalid"
+//	@Failure		428				{object}	models.Response	"Version missing: send version or If-Match"
+//	@Failure		500				{object}	models.Response
 //	@Router			/vehicle/update [put]
-func VehicleUpdate(c *fiber.Ctx) error {
+func (ctrl *VehicleController) VehicleUpdate(c *fiber.Ctx) error {
 	var vehicleUpdate models.VehicleUpdate
 	if err := c.BodyParser(&vehicleUpdate); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Invalid request",
-		})
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &vehicleUpdate.Version); err != nil {
+		return err
 	}
 
 	if err := vehicleUpdate.Validate(); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: err.Error(),
-		})
+		return models.Validation("Datos inválidos", err)
 	}
 
-	err := services.VehicleUpdate(&vehicleUpdate)
+	err := ctrl.service.VehicleUpdate(&vehicleUpdate, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(models.Response{
@@ -310,30 +258,15 @@ func VehicleUpdate(c *fiber.Ctx) error {
 //	@Failure		404					{object}	models.Response	"Vehicle not found"
 //	@Failure		500					{object}	models.Response
 //	@Router			/vehicle/delete/{id} [delete]
-func VehicleDelete(c *fiber.Ctx) error {
+func (ctrl *VehicleController) VehicleDelete(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "ID is required",
-		})
+		return models.BadRequest("ID is required", nil)
 	}
 
-	err := services.VehicleDelete(id)
+	err := ctrl.service.VehicleDelete(id, auditActor(c))
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(models.Response{
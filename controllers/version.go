@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// setETag agrega el ETag de una entidad versionada: su versión entre comillas.
+func setETag(c *fiber.Ctx, version int64) {
+	c.Set(fiber.HeaderETag, strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatch toma la versión esperada del header If-Match, alternativa al campo
+// version del body. Sin header queda lo que vino en el body, y si tampoco
+// viene ahí la request se rechaza con 428. If-Match: * pide explícitamente
+// actualizar sin controlar la versión. Si vienen los dos tienen que coincidir.
+func ifMatch(c *fiber.Ctx, version *int64) error {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "*" {
+		return nil
+	}
+	if header == "" {
+		if *version == 0 {
+			return models.VersionRequired("Falta la versión: enviá el campo version o el header If-Match con el ETag del GET", nil)
+		}
+		return nil
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	expected, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || expected < 1 {
+		return models.BadRequest("If-Match debe ser el ETag devuelto por el GET", err)
+	}
+	if *version != 0 && *version != expected {
+		return models.BadRequest("If-Match no coincide con la versión del body", nil)
+	}
+	*version = expected
+	return nil
+}
@@ -0,0 +1,500 @@
+package controllers
+
+import (
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WorkOrderController struct {
+	service *services.WorkOrderService
+}
+
+func NewWorkOrderController(service *services.WorkOrderService) *WorkOrderController {
+	return &WorkOrderController{service: service}
+}
+
+// GetWorkOrderByID godoc
+//	@Summary		Get Work Order By ID
+//	@Description	Fetches a work order of the workplace with its status history, oldest first.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			id					path		string									true	"ID of the work order"
+//	@Success		200					{object}	models.Response{body=models.WorkOrder}	"Work order fetched successfully"
+//	@Header			200					{string}	ETag									"Versión del registro, para enviar en If-Match"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		404					{object}	models.Response							"Work order not found"
+//	@Failure		500					{object}	models.Response							"Internal server error"
+//	@Router			/work_order/{id} [get]
+func (ctrl *WorkOrderController) GetWorkOrderByID(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	workOrder, err := ctrl.service.GetWorkOrderByID(id, workplace.ID)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, workOrder.Version)
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    workOrder,
+		Message: "Orden de trabajo obtenida con éxito",
+	})
+}
+
+// GetAllWorkOrders godoc
+//	@Summary		Get all work orders
+//	@Description	Fetches the work orders of the workplace, newest first. Filters: status, client_id, vehicle_id, employee_id, description, status_changed_at, created_at.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string															true	"Workplace Token"
+//	@Param			page				query		int																false	"Página, desde 1"
+//	@Param			page_size			query		int																false	"Elementos por página (máximo 100)"
+//	@Param			sort				query		string															false	"Campos de orden separados por coma, con - para descendente"
+//	@Success		200					{object}	models.Response{body=[]models.WorkOrder,meta=models.Pagination}	"List of work orders"
+//	@Failure		400					{object}	models.Response													"Bad Request"
+//	@Failure		401					{object}	models.Response													"Auth is required"
+//	@Failure		403					{object}	models.Response													"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		500					{object}	models.Response													"Internal server error"
+//	@Router			/work_order/get_all [get]
+func (ctrl *WorkOrderController) GetAllWorkOrders(c *fiber.Ctx) error {
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	params, err := listParams(c)
+	if err != nil {
+		return err
+	}
+
+	workOrders, pagination, err := ctrl.service.GetAllWorkOrders(workplace.ID, params)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    workOrders,
+		Message: "Órdenes de trabajo obtenidas con éxito",
+		Meta:    pagination,
+	})
+}
+
+// CreateWorkOrder godoc
+//	@Summary		Create Work Order
+//	@Description	Opens a work order in status recibido. 400 if the vehicle does not belong to the client or the employee is not from the workplace.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string							true	"Workplace Token"
+//	@Param			workOrderCreate		body		models.WorkOrderCreate			true	"Work order information"
+//	@Param			Idempotency-Key		header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
+//	@Success		200					{object}	models.Response{body=string}	"Work order created successfully"
+//	@Failure		400					{object}	models.Response					"Bad Request"
+//	@Failure		401					{object}	models.Response					"Auth is required"
+//	@Failure		403					{object}	models.Response					"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		409					{object}	models.Response					"Idempotency-Key in use by a request still in progress"
+//	@Failure		422					{object}	models.Response					"Model Invalid"
+//	@Failure		500					{object}	models.Response					"Internal server error"
+//	@Router			/work_order/create [post]
+func (ctrl *WorkOrderController) CreateWorkOrder(c *fiber.Ctx) error {
+	var workOrderCreate models.WorkOrderCreate
+	if err := c.BodyParser(&workOrderCreate); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := workOrderCreate.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	id, err := ctrl.service.CreateWorkOrder(&workOrderCreate, workplace.ID, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    id,
+		Message: "Orden de trabajo creada con éxito",
+	})
+}
+
+// UpdateWorkOrder godoc
+//	@Summary		Update Work Order
+//	@Description	Updates the employee, description and diagnosis of a work order. Client and vehicle cannot change. 409 if the order was already delivered.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			workOrderUpdate		body		models.WorkOrderUpdate					true	"Work order data to update"
+//	@Param			If-Match			header		string									false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response							"Work order updated successfully"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		404					{object}	models.Response							"Work order not found"
+//	@Failure		409					{object}	models.Response{body=models.WorkOrder}	"Version conflict, body has the current state; or order already delivered"
+//	@Failure		422					{object}	models.Response							"Model Invalid"
+//	@Failure		428					{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response							"Internal server error"
+//	@Router			/work_order/update [put]
+func (ctrl *WorkOrderController) UpdateWorkOrder(c *fiber.Ctx) error {
+	var workOrderUpdate models.WorkOrderUpdate
+	if err := c.BodyParser(&workOrderUpdate); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &workOrderUpdate.Version); err != nil {
+		return err
+	}
+	if err := workOrderUpdate.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	err := ctrl.service.UpdateWorkOrder(&workOrderUpdate, workplace.ID, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Orden de trabajo editada con éxito",
+	})
+}
+
+// ChangeWorkOrderStatus godoc
+//	@Summary		Change Work Order Status
+//	@Description	Moves a work order to another status and records the transition. Allowed: recibido → diagnostico; diagnostico → esperando_repuestos, en_reparacion; esperando_repuestos → en_reparacion; en_reparacion → esperando_repuestos, listo; listo → en_reparacion. entregado is reached only through /work_order/deliver. 409 for any other change.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token		header		string									true	"Workplace Token"
+//	@Param			id						path		string									true	"ID of the work order"
+//	@Param			workOrderStatusUpdate	body		models.WorkOrderStatusUpdate			true	"New status"
+//	@Param			If-Match				header		string									false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200						{object}	models.Response							"Status changed successfully"
+//	@Failure		400						{object}	models.Response							"Bad Request"
+//	@Failure		401						{object}	models.Response							"Auth is required"
+//	@Failure		403						{object}	models.Response							"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		404						{object}	models.Response							"Work order not found"
+//	@Failure		409						{object}	models.Response{body=models.WorkOrder}	"Version conflict, body has the current state; or transition not allowed"
+//	@Failure		422						{object}	models.Response							"Model Invalid"
+//	@Failure		428						{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500						{object}	models.Response							"Internal server error"
+//	@Router			/work_order/status/{id} [put]
+func (ctrl *WorkOrderController) ChangeWorkOrderStatus(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	var statusUpdate models.WorkOrderStatusUpdate
+	if err := c.BodyParser(&statusUpdate); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &statusUpdate.Version); err != nil {
+		return err
+	}
+	if err := statusUpdate.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	err := ctrl.service.ChangeWorkOrderStatus(id, &statusUpdate, workplace.ID, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Estado de la orden de trabajo actualizado con éxito",
+	})
+}
+
+// DeliverWorkOrder godoc
+//	@Summary		Deliver Work Order
+//	@Description	Delivers a work order in status listo: consumes the reserved parts from stock, creates the income for the order's client, vehicle and employee with the given services (priced as in /income/create) plus the part lines, and moves the order to entregado, all in one transaction. Returns the income ID. 409 if the order is not listo.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			id					path		string									true	"ID of the work order"
+//	@Param			workOrderDeliver	body		models.WorkOrderDeliver					true	"Income data"
+//	@Param			If-Match			header		string									false	"ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response{body=string}			"Work order delivered, body is the income ID"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		404					{object}	models.Response							"Work order not found"
+//	@Failure		409					{object}	models.Response{body=models.WorkOrder}	"Version conflict, body has the current state; or order not ready"
+//	@Failure		422					{object}	models.Response							"Model Invalid"
+//	@Failure		428					{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response							"Internal server error"
+//	@Router			/work_order/deliver/{id} [post]
+func (ctrl *WorkOrderController) DeliverWorkOrder(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	var deliver models.WorkOrderDeliver
+	if err := c.BodyParser(&deliver); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &deliver.Version); err != nil {
+		return err
+	}
+	if err := deliver.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	incomeID, err := ctrl.service.DeliverWorkOrder(id, &deliver, workplace.ID, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    incomeID,
+		Message: "Orden de trabajo entregada con éxito",
+	})
+}
+
+// DeleteWorkOrder godoc
+//	@Summary		Delete Work Order
+//	@Description	Sends a work order to the trash. 409 if it was already delivered or still has part lines.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string			true	"Workplace Token"
+//	@Param			id					path		string			true	"ID of the work order"
+//	@Success		200					{object}	models.Response	"Work order deleted successfully"
+//	@Failure		400					{object}	models.Response	"Bad Request"
+//	@Failure		401					{object}	models.Response	"Auth is required"
+//	@Failure		403					{object}	models.Response	"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		404					{object}	models.Response	"Work order not found"
+//	@Failure		409					{object}	models.Response	"Order already delivered"
+//	@Failure		500					{object}	models.Response	"Error interno"
+//	@Router			/work_order/delete/{id} [delete]
+func (ctrl *WorkOrderController) DeleteWorkOrder(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	err := ctrl.service.DeleteWorkOrder(id, workplace.ID, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Orden de trabajo eliminada con éxito",
+	})
+}
+
+// AddWorkOrderPart godoc
+//	@Summary		Add Work Order Part
+//	@Description	Adds a part line (product, quantity, sale price per unit) to a work order and reserves the quantity from the product's stock. The stock is consumed when the order is delivered and the lines are added to the income. 400 if the product has not enough stock available (stock minus reserved); 409 if the product is already on the order or the order was delivered.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			id					path		string									true	"ID of the work order"
+//	@Param			workOrderPartCreate	body		models.WorkOrderPartCreate				true	"Part line"
+//	@Param			If-Match			header		string									false	"ETag del GET de la orden; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response{body=string}			"Part added, body is the line ID"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		404					{object}	models.Response							"Work order not found"
+//	@Failure		409					{object}	models.Response{body=models.WorkOrder}	"Version conflict, body has the current state; part already on the order; or order delivered"
+//	@Failure		422					{object}	models.Response							"Model Invalid"
+//	@Failure		428					{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response							"Internal server error"
+//	@Router			/work_order/add_part/{id} [post]
+func (ctrl *WorkOrderController) AddWorkOrderPart(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	var part models.WorkOrderPartCreate
+	if err := c.BodyParser(&part); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &part.Version); err != nil {
+		return err
+	}
+	if err := part.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	partID, err := ctrl.service.AddWorkOrderPart(id, &part, workplace.ID, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    partID,
+		Message: "Repuesto agregado con éxito",
+	})
+}
+
+// UpdateWorkOrderPart godoc
+//	@Summary		Update Work Order Part
+//	@Description	Changes the quantity or sale price of a part line. The reservation is adjusted by the difference; 400 if the product has not enough stock available for the increase.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			id					path		string									true	"ID of the work order"
+//	@Param			workOrderPartUpdate	body		models.WorkOrderPartUpdate				true	"Part line"
+//	@Param			If-Match			header		string									false	"ETag del GET de la orden; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión"
+//	@Success		200					{object}	models.Response							"Part updated successfully"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		404					{object}	models.Response							"Work order or part not found"
+//	@Failure		409					{object}	models.Response{body=models.WorkOrder}	"Version conflict, body has the current state; or order delivered"
+//	@Failure		422					{object}	models.Response							"Model Invalid"
+//	@Failure		428					{object}	models.Response							"Version missing: send version or If-Match"
+//	@Failure		500					{object}	models.Response							"Internal server error"
+//	@Router			/work_order/update_part/{id} [put]
+func (ctrl *WorkOrderController) UpdateWorkOrderPart(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	var part models.WorkOrderPartUpdate
+	if err := c.BodyParser(&part); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := ifMatch(c, &part.Version); err != nil {
+		return err
+	}
+	if err := part.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	err := ctrl.service.UpdateWorkOrderPart(id, &part, workplace.ID, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Repuesto editado con éxito",
+	})
+}
+
+// RemoveWorkOrderPart godoc
+//	@Summary		Remove Work Order Part
+//	@Description	Removes a part line from a work order and releases its reservation.
+//	@Tags			WorkOrder
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Workplace-Token	header		string									true	"Workplace Token"
+//	@Param			id					path		string									true	"ID of the work order"
+//	@Param			part_id				path		string									true	"ID of the part line"
+//	@Param			If-Match			header		string									true	"ETag del GET de la orden. * quita la línea sin controlar la versión"
+//	@Success		200					{object}	models.Response							"Part removed successfully"
+//	@Failure		400					{object}	models.Response							"Bad Request"
+//	@Failure		401					{object}	models.Response							"Auth is required"
+//	@Failure		403					{object}	models.Response							"Not Authorized, or work orders are disabled in the workplace"
+//	@Failure		404					{object}	models.Response							"Work order or part not found"
+//	@Failure		409					{object}	models.Response{body=models.WorkOrder}	"Version conflict, body has the current state; or order delivered"
+//	@Failure		428					{object}	models.Response							"Version missing: send If-Match"
+//	@Failure		500					{object}	models.Response							"Internal server error"
+//	@Router			/work_order/remove_part/{id}/{part_id} [delete]
+func (ctrl *WorkOrderController) RemoveWorkOrderPart(c *fiber.Ctx) error {
+	id := c.Params("id")
+	partID := c.Params("part_id")
+	if id == "" || partID == "" {
+		return models.BadRequest("ID is required", nil)
+	}
+
+	var version int64
+	if err := ifMatch(c, &version); err != nil {
+		return err
+	}
+
+	workplace := c.Locals("workplace").(*models.Workplace)
+	if workplace == nil {
+		return models.BadRequest("Workplace is required", nil)
+	}
+
+	err := ctrl.service.RemoveWorkOrderPart(id, partID, version, workplace.ID, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(models.Response{
+		Status:  true,
+		Body:    nil,
+		Message: "Repuesto quitado con éxito",
+	})
+}
@@ -6,6 +6,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+type WorkplaceController struct {
+	service *services.WorkplaceService
+}
+
+func NewWorkplaceController(service *services.WorkplaceService) *WorkplaceController {
+	return &WorkplaceController{service: service}
+}
+
 //  Workplace GetAll
 //	@Summary		Workplace GetAll
 //	@Description	Workplace GetAll required auth token
@@ -19,22 +27,11 @@ import (
 //	@Failure		403	{object}	models.Response	"Not Authorized"
 //	@Failure		500	{object}	models.Response
 //	@Router			/workplace/get_all [get]
-func GetWorkplaces(c *fiber.Ctx) error {
+func (ctrl *WorkplaceController) GetWorkplaces(c *fiber.Ctx) error {
 	user := c.Locals("user").(*models.User)
-	workplaces, err := services.GetWorkplaceAll(user.Role)
+	workplaces, err := ctrl.service.GetWorkplaceAll(user.Role)
 	if err != nil {
-		if errResp, ok := err.(*models.ErrorStruc); ok {
-			return c.Status(errResp.StatusCode).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: errResp.Message,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-			Status:  false,
-			Body:    nil,
-			Message: "Error interno",
-		})
+		return err
 	}
 
 	return c.Status(200).JSON(models.Response{
@@ -42,4 +39,40 @@ func GetWorkplaces(c *fiber.Ctx) error {
 		Body:    workplaces,
 		Message: "Workplaces obtenidos con éxito",
 	})
-}
\ No newline at end of file
+}
+
+//  CreateWorkplace godoc
+//	@Summary		Create Workplace
+//	@Description	Creates a new workplace (business line or branch).
+//	@Tags			Workplace
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			workplaceCreate	body		models.WorkplaceCreate			true	"Workplace information"
+//	@Param			Idempotency-Key	header		string							false	"Clave única del intento: si se repite con el mismo body se devuelve la respuesta original"
+//	@Success		201				{object}	models.Response{body=string}	"Workplace creado con éxito"
+//	@Failure		400				{object}	models.Response					"Bad Request"
+//	@Failure		401				{object}	models.Response					"Auth is required"
+//	@Failure		403				{object}	models.Response					"Not Authorized"
+//	@Failure		409				{object}	models.Response					"Idempotency-Key in use by a request still in progress"
+//	@Failure		500				{object}	models.Response
+//	@Router			/workplace/create [post]
+func (ctrl *WorkplaceController) CreateWorkplace(c *fiber.Ctx) error {
+	var workplaceCreate models.WorkplaceCreate
+	if err := c.BodyParser(&workplaceCreate); err != nil {
+		return models.BadRequest("Invalid request", err)
+	}
+	if err := workplaceCreate.Validate(); err != nil {
+		return models.Validation("Datos inválidos", err)
+	}
+	id, err := ctrl.service.CreateWorkplace(&workplaceCreate, auditActor(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.Response{
+		Status:  true,
+		Body:    id,
+		Message: "Workplace creado con éxito",
+	})
+}
@@ -2,79 +2,72 @@ package database
 
 import (
 	"log"
-	"os"
+	"slices"
 
+	"github.com/DanielChachagua/GestionCar/config"
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/DanielChachagua/GestionCar/utils"
 	"github.com/google/uuid"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
+// Connect abre la conexión. El esquema se crea con Migrate y los datos iniciales con Seed.
 func Connect(uri string) (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open(uri), &gorm.Config{})
+	dialector, err := Dialector(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
 
 	if err != nil {
 		return nil, err
 	}
 
-	db.AutoMigrate(
-		&models.User{}, 
-		&models.Client{}, 
-		&models.Vehicle{}, 
-		&models.Workplace{}, 
-		&models.Role{}, 
-		&models.AuditLog{},
-	)
-	
-	db.AutoMigrate(
-		&models.AttendanceLaundry{},
-		&models.EmployeeLaundry{},
-		&models.ExpenseResumeLaundry{},
-		&models.ExpenseLaundry{},
-		&models.IncomeResumeLaundry{},
-		&models.IncomeLaundry{},
-		&models.IncomeServiceLaundry{},
-		&models.MovementTypeLaundry{},
-		&models.ProductLaundry{},
-		&models.PurchaseOrderLaundry{},
-		&models.PurchaseProductLaundry{},
-		&models.ServiceLaundry{},
-		&models.SupplierLaundry{},
-	)
-	db.AutoMigrate(
-		&models.AttendanceWorkshop{},
-		&models.EmployeeWorkshop{},
-		&models.ExpenseResumeWorkshop{},
-		&models.ExpenseWorkshop{},
-		&models.IncomeResumeWorkshop{},
-		&models.IncomeWorkshop{},
-		&models.IncomeServiceWorkshop{},
-		&models.MovementTypeWorkshop{},
-		&models.PartWorkshop{},
-		&models.PurchaseOrderWorkshop{},
-		&models.PurchasePartWorkshop{},
-		&models.ServiceWorkshop{},
-		&models.SupplierWorkshop{},
-	)
+	return db, nil
+}
+
+// Seed crea el admin de la configuración, los workplaces, los roles y los permisos por defecto si no existen.
+func Seed(db *gorm.DB, admin config.Admin) error {
+	if err := SeedAdmin(db, admin); err != nil {
+		return err
+	}
+
+	if err := SeedWorkplaces(db); err != nil {
+		return err
+	}
+
+	if err := SeedRoles(db); err != nil {
+		return err
+	}
+
+	return SeedPermissions(db)
+}
+
+// SeedAdmin crea el usuario inicial si se configuró ADMIN_EMAIL y todavía no existe.
+func SeedAdmin(db *gorm.DB, admin config.Admin) error {
+	if admin.Email == "" {
+		return nil
+	}
 
 	var email string
-	db.Model(&models.User{}).Select("email").Where("email = ?", os.Getenv("ADMIN_EMAIL")).Scan(&email)
+	db.Model(&models.User{}).Select("email").Where("email = ?", admin.Email).Scan(&email)
 
 	if email != "" {
 		log.Println("El admin ya existe")
-		return db, nil
+		return nil
 	}
-	newId := uuid.NewString()
-
-	pass, err := utils.HashPassword(os.Getenv("ADMIN_PASSWORD"))
 
+	pass, err := utils.HashPassword(admin.Password)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	db.Create(&models.User{ID: newId, FirstName: os.Getenv("FIRSTNAME_ADMIN"), LastName: os.Getenv("LASTNAME_ADMIN"),Username: os.Getenv("ADMIN_USERNAME"), Email: os.Getenv("ADMIN_EMAIL"), Password: pass, Role: os.Getenv("ROLE_ADMIN")})
+	return db.Create(&models.User{ID: uuid.NewString(), FirstName: admin.FirstName, LastName: admin.LastName, Username: admin.Username, Email: admin.Email, Password: pass, Role: admin.Role}).Error
+}
 
+// SeedWorkplaces crea la lavandería y el taller si no existen.
+func SeedWorkplaces(db *gorm.DB) error {
 	var laundry string
 	db.Model(&models.Workplace{}).Select("identifier").Where("identifier = ?", "laundry").Scan(&laundry)
 
@@ -82,26 +75,111 @@ func Connect(uri string) (*gorm.DB, error) {
 	db.Model(&models.Workplace{}).Select("identifier").Where("identifier = ?", "workshop").Scan(&workshop)
 	if laundry == "" {
 		log.Println("Creando lavanderia")
-		db.Create(&models.Workplace{ID: uuid.NewString(), Name: "Lavanderia", Address: "Av. Los Olivos", Phone: "123456789", Email: "laundry@example.com",Identifier: "laundry"})
+		if err := db.Create(&models.Workplace{ID: uuid.NewString(), Name: "Lavanderia", Address: "Av. Los Olivos", Phone: "123456789", Email: "laundry@example.com",Identifier: "laundry", CategoryPrices: true}).Error; err != nil {
+			return err
+		}
 	}
 	if workshop == "" {
 		log.Println("Creando taller")
-		db.Create(&models.Workplace{ID: uuid.NewString(), Name: "Taller", Address: "Av. Los Olivos", Phone: "123456789", Email: "workshop@example.com", Identifier: "workshop"})
+		if err := db.Create(&models.Workplace{ID: uuid.NewString(), Name: "Taller", Address: "Av. Los Olivos", Phone: "123456789", Email: "workshop@example.com", Identifier: "workshop", WorkOrders: true}).Error; err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// SeedRoles crea los roles por defecto si no existe ninguno de ellos.
+func SeedRoles(db *gorm.DB) error {
 	var roles []models.Role
 	db.Model(&models.Role{}).Select("name").Where("name IN ?", []string{"super_admin", "admin", "admin_laundry", "admin_workshop", "employee_laundry", "employee_workshop"}).Scan(&roles)
 	if len(roles) == 0 {
 		log.Println("Creando roles")
-		db.Create(&models.Role{ID: uuid.NewString(), Name: "super_admin", Hierarchy: 1, Workplace: "all"})
-		db.Create(&models.Role{ID: uuid.NewString(), Name: "admin", Hierarchy: 2, Workplace: "all"})
-		db.Create(&models.Role{ID: uuid.NewString(), Name: "admin_laundry", Hierarchy: 3, Workplace: "laundry"})
-		db.Create(&models.Role{ID: uuid.NewString(), Name: "admin_workshop", Hierarchy: 3, Workplace: "workshop"})
-		db.Create(&models.Role{ID: uuid.NewString(), Name: "employee_laundry", Hierarchy: 4, Workplace: "laundry"})
-		db.Create(&models.Role{ID: uuid.NewString(), Name: "employee_workshop", Hierarchy: 4, Workplace: "workshop"})
+		return db.Create(&[]models.Role{
+			{ID: uuid.NewString(), Name: "super_admin", Hierarchy: 1, Workplace: "all"},
+			{ID: uuid.NewString(), Name: "admin", Hierarchy: 2, Workplace: "all"},
+			{ID: uuid.NewString(), Name: "admin_laundry", Hierarchy: 3, Workplace: "laundry"},
+			{ID: uuid.NewString(), Name: "admin_workshop", Hierarchy: 3, Workplace: "workshop"},
+			{ID: uuid.NewString(), Name: "employee_laundry", Hierarchy: 4, Workplace: "laundry"},
+			{ID: uuid.NewString(), Name: "employee_workshop", Hierarchy: 4, Workplace: "workshop"},
+		}).Error
 	}
+	return nil
+}
 
-	return db, nil
+// adminOnlyPermissions son los permisos que por defecto tienen solo
+// super_admin y admin; employeePermissions, los que también tienen los
+// empleados. El resto lo tienen además los admin de cada workplace.
+var (
+	adminOnlyPermissions = []string{
+		models.PermTrashManage,
+		models.PermUserRead,
+		models.PermUserCreate,
+		models.PermUserUpdate,
+		models.PermUserDelete,
+		models.PermLoginHistoryRead,
+		models.PermAuditRead,
+		models.PermWorkplaceCreate,
+		models.PermPermissionManage,
+	}
+	employeePermissions = []string{
+		models.PermAttendanceCreate,
+		models.PermClientCreate,
+		models.PermClientUpdate,
+		models.PermExpenseCreate,
+		models.PermIncomeCreate,
+		models.PermIncomeUpdate,
+		models.PermVehicleCreate,
+		models.PermVehicleUpdate,
+		models.PermWorkOrderCreate,
+		models.PermWorkOrderUpdate,
+		models.PermWorkOrderDeliver,
+	}
+)
+
+func defaultPermissionRoles(permission string) []string {
+	roles := []string{"super_admin", "admin"}
+	if !slices.Contains(adminOnlyPermissions, permission) {
+		roles = append(roles, "admin_laundry", "admin_workshop")
+	}
+	if slices.Contains(employeePermissions, permission) {
+		roles = append(roles, "employee_laundry", "employee_workshop")
+	}
+	return roles
+}
+
+// SeedPermissions carga los permisos del catálogo que falten y los asigna a
+// sus roles por defecto. Los permisos que ya existían no se tocan, así no se
+// pisan los cambios hechos desde /permission.
+func SeedPermissions(db *gorm.DB) error {
+	var existing []string
+	if err := db.Model(&models.Permission{}).Pluck("name", &existing).Error; err != nil {
+		return err
+	}
+
+	for _, permission := range models.Permissions {
+		if slices.Contains(existing, permission.Name) {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&permission).Error; err != nil {
+				return err
+			}
+			var roleIDs []string
+			if err := tx.Model(&models.Role{}).Where("name IN ?", defaultPermissionRoles(permission.Name)).Pluck("id", &roleIDs).Error; err != nil {
+				return err
+			}
+			for _, roleID := range roleIDs {
+				if err := tx.Create(&models.RolePermission{RoleID: roleID, Permission: permission.Name}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func CloseDB(db *gorm.DB) error {
@@ -116,4 +194,4 @@ func CloseDB(db *gorm.DB) error {
 		}
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// legacyTable describe una tabla duplicada por lavadero/taller que se reemplaza
+// por una tabla única con workplace_id.
+type legacyTable struct {
+	laundry  string
+	workshop string
+	target   string
+	// columnas en la tabla destino, y su nombre en la tabla vieja
+	// (lavadero, taller) cuando cambia
+	columns []string
+	renamed map[string][2]string
+	// si es false la tabla destino no tiene workplace_id (ej: detalle de una orden)
+	scoped bool
+}
+
+var legacyTables = []legacyTable{
+	{"employee_laundries", "employee_workshops", "employees",
+		[]string{"id", "name", "phone", "email", "address", "created_at", "updated_at"}, nil, true},
+	{"movement_type_laundries", "movement_type_workshops", "movement_types",
+		[]string{"id", "name", "is_income", "created_at", "updated_at"}, nil, true},
+	{"supplier_laundries", "supplier_workshops", "suppliers",
+		[]string{"id", "name", "address", "phone", "email", "created_at", "updated_at"}, nil, true},
+	{"service_laundries", "service_workshops", "services",
+		[]string{"id", "name", "created_at", "updated_at"}, nil, true},
+	{"product_laundries", "part_workshops", "products",
+		[]string{"id", "identifier", "name", "stock", "created_at", "updated_at"}, nil, true},
+	{"attendance_laundries", "attendance_workshops", "attendances",
+		[]string{"id", "employee_id", "attendance", "hours", "date", "amount", "is_holiday", "created_at", "updated_at"}, nil, true},
+	{"expense_laundries", "expense_workshops", "expenses",
+		[]string{"id", "details", "supplier_id", "movement_type_id", "amount", "created_at", "updated_at"}, nil, true},
+	{"income_laundries", "income_workshops", "incomes",
+		[]string{"id", "ticket", "details", "client_id", "vehicle_id", "employee_id", "amount", "movement_type_id", "created_at", "updated_at"}, nil, true},
+	{"income_service_laundries", "income_service_workshops", "income_services",
+		[]string{"id", "income_id", "service_id"}, map[string][2]string{"income_id": {"income_laundry_id", "income_workshop_id"}}, false},
+	{"purchase_order_laundries", "purchase_order_workshops", "purchase_orders",
+		[]string{"id", "order_number", "order_date", "amount", "supplier_id", "created_at", "updated_at"}, nil, true},
+	{"purchase_product_laundries", "purchase_part_workshops", "purchase_products",
+		[]string{"id", "product_id", "purchase_order_id", "expired_at", "unit_price", "quantity", "total_price", "created_at", "updated_at"}, map[string][2]string{"product_id": {"product_id", "part_id"}}, false},
+	{"income_resume_laundries", "income_resume_workshops", "income_resumes",
+		[]string{"id", "data", "date", "created_at", "updated_at"}, nil, true},
+	{"expense_resume_laundries", "expense_resume_workshops", "expense_resumes",
+		[]string{"id", "data", "date", "created_at", "updated_at"}, nil, true},
+}
+
+// seedLegacyWorkplaces crea la lavandería y el taller, a los que van las filas
+// de las tablas viejas, si todavía no existen.
+func seedLegacyWorkplaces(db *gorm.DB) error {
+	workplaces := []workplaceV1{
+		{Name: "Lavanderia", Address: "Av. Los Olivos", Phone: "123456789", Email: "laundry@example.com", Identifier: "laundry"},
+		{Name: "Taller", Address: "Av. Los Olivos", Phone: "123456789", Email: "workshop@example.com", Identifier: "workshop"},
+	}
+	for _, workplace := range workplaces {
+		var count int64
+		if err := db.Model(&workplaceV1{}).Where("identifier = ?", workplace.Identifier).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		workplace.ID = uuid.NewString()
+		if err := db.Create(&workplace).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateLegacyTables mueve las filas de las tablas *_laundries / *_workshops a las
+// tablas únicas por workplace y elimina las tablas viejas.
+func migrateLegacyTables(db *gorm.DB) error {
+	sources := map[string]string{"laundry": "", "workshop": ""}
+	for identifier := range sources {
+		var workplace workplaceV1
+		if err := db.Where("identifier = ?", identifier).First(&workplace).Error; err == nil {
+			sources[identifier] = workplace.ID
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range legacyTables {
+			for identifier, oldTable := range map[string]string{"laundry": table.laundry, "workshop": table.workshop} {
+				if !tx.Migrator().HasTable(oldTable) {
+					continue
+				}
+				workplaceID := sources[identifier]
+				if workplaceID == "" {
+					return fmt.Errorf("no existe el workplace %q para migrar %s", identifier, oldTable)
+				}
+				if err := tx.Exec(legacyInsertSQL(table, identifier, oldTable), legacyArgs(table, workplaceID)...).Error; err != nil {
+					return fmt.Errorf("migrando %s: %w", oldTable, err)
+				}
+				if err := tx.Migrator().DropTable(oldTable); err != nil {
+					return err
+				}
+				log.Printf("Tabla %s migrada a %s", oldTable, table.target)
+			}
+		}
+		return nil
+	})
+}
+
+func legacyInsertSQL(table legacyTable, identifier string, oldTable string) string {
+	targetCols := append([]string{}, table.columns...)
+	sourceCols := make([]string, 0, len(table.columns)+1)
+	for _, column := range table.columns {
+		if old, ok := table.renamed[column]; ok {
+			if identifier == "laundry" {
+				column = old[0]
+			} else {
+				column = old[1]
+			}
+		}
+		sourceCols = append(sourceCols, column)
+	}
+	if table.scoped {
+		targetCols = append(targetCols, "workplace_id")
+		sourceCols = append(sourceCols, "?")
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s",
+		table.target, strings.Join(targetCols, ", "), strings.Join(sourceCols, ", "), oldTable)
+}
+
+func legacyArgs(table legacyTable, workplaceID string) []interface{} {
+	if table.scoped {
+		return []interface{}{workplaceID}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration es un cambio de esquema versionado. Las versiones se aplican en el
+// orden de la lista migrations y se registran en la tabla schema_migrations.
+type Migration struct {
+	Version string
+	Name    string
+	Up      func(tx *gorm.DB) error
+	// Down puede ser nil si la migración no se puede revertir
+	Down func(tx *gorm.DB) error
+}
+
+type schemaMigration struct {
+	Version   string    `gorm:"primaryKey;size:14"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus indica si una migración conocida ya fue aplicada.
+type MigrationStatus struct {
+	Version   string
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+func ensureSchemaMigrations(db *gorm.DB) error {
+	return db.AutoMigrate(&schemaMigration{})
+}
+
+func appliedMigrations(db *gorm.DB) (map[string]schemaMigration, error) {
+	if err := ensureSchemaMigrations(db); err != nil {
+		return nil, err
+	}
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[string]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// Migrate aplica, en orden y cada una en su propia transacción, las migraciones pendientes.
+func Migrate(db *gorm.DB) error {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migración %s_%s: %w", migration.Version, migration.Name, err)
+		}
+		log.Printf("Migración %s_%s aplicada", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+// Rollback revierte las últimas steps migraciones aplicadas.
+func Rollback(db *gorm.DB, steps int) error {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+		migration := migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == nil {
+			return fmt.Errorf("la migración %s_%s no se puede revertir", migration.Version, migration.Name)
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, "version = ?", migration.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("revirtiendo %s_%s: %w", migration.Version, migration.Name, err)
+		}
+		log.Printf("Migración %s_%s revertida", migration.Version, migration.Name)
+		steps--
+	}
+	return nil
+}
+
+// Status lista todas las migraciones conocidas y si están aplicadas.
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		state := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			state.Applied = true
+			appliedAt := row.AppliedAt
+			state.AppliedAt = &appliedAt
+		}
+		status = append(status, state)
+	}
+	return status, nil
+}
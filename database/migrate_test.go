@@ -0,0 +1,89 @@
+package database
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestMigrateAndRollback(t *testing.T) {
+	db, err := Connect("sqlite://" + filepath.Join(t.TempDir(), "migrate.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer CloseDB(db)
+
+	if err := Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	// aplicar de nuevo no debe hacer nada
+	if err := Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	status, err := Status(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, migration := range status {
+		if !migration.Applied {
+			t.Errorf("la migración %s debería estar aplicada", migration.Version)
+		}
+	}
+	if !db.Migrator().HasTable("incomes") {
+		t.Error("falta la tabla incomes")
+	}
+
+	// soft_delete (y lo posterior) se puede revertir y volver a aplicar
+	steps := 0
+	for i, migration := range migrations {
+		if migration.Name == "soft_delete" {
+			steps = len(migrations) - i
+		}
+	}
+	if err := Rollback(db, steps); err != nil {
+		t.Fatal(err)
+	}
+	if db.Migrator().HasColumn("clients", "deleted_at") {
+		t.Error("clients.deleted_at debería haberse eliminado")
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	if !db.Migrator().HasColumn("clients", "deleted_at") {
+		t.Error("falta clients.deleted_at")
+	}
+
+	if err := Rollback(db, len(migrations)); err != nil {
+		t.Fatal(err)
+	}
+	if db.Migrator().HasTable("incomes") {
+		t.Error("la tabla incomes debería haberse eliminado")
+	}
+	status, err = Status(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, migration := range status {
+		if migration.Applied {
+			t.Errorf("la migración %s debería estar pendiente", migration.Version)
+		}
+	}
+}
+
+// Las migraciones usan copias congeladas de los modelos: si importaran
+// models, cambiar un modelo cambiaría el SQL de una migración ya publicada.
+func TestMigrationsDoNotUseModels(t *testing.T) {
+	for _, file := range []string{"migrations.go", "migration_models.go", "legacy.go", "search.go"} {
+		parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, spec := range parsed.Imports {
+			if path, _ := strconv.Unquote(spec.Path.Value); path == "github.com/DanielChachagua/GestionCar/models" {
+				t.Errorf("%s importa %s", file, path)
+			}
+		}
+	}
+}
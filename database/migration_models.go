@@ -0,0 +1,530 @@
+package database
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Copias congeladas de los modelos tal como los usa cada migración. Una
+// migración publicada tiene que correr siempre el mismo SQL, así que nunca
+// usa models.*: si un modelo cambia, la migración nueva declara sus propias
+// copias (o solo las columnas que agrega) con el sufijo de su número.
+
+// Tablas de initial_schema (1).
+
+type userV1 struct {
+	ID        string    `gorm:"primaryKey"`
+	FirstName string    `gorm:"not null;size:30"`
+	LastName  string    `gorm:"not null;size:30"`
+	Username  string    `gorm:"unique;size:30;not null"`
+	Email     string    `gorm:"unique;not null"`
+	Password  string    `gorm:"not null"`
+	Role      string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (userV1) TableName() string {
+	return "users"
+}
+
+type clientV1 struct {
+	ID        string      `gorm:"primaryKey"`
+	FirstName string      `gorm:"not null;size:30"`
+	LastName  string      `gorm:"not null;size:30"`
+	CUIL      string      `gorm:"unique;size:30"`
+	DNI       string      `gorm:"unique;size:30"`
+	Email     string      `gorm:"unique"`
+	CreatedAt time.Time   `gorm:"autoCreateTime"`
+	UpdatedAt time.Time   `gorm:"autoUpdateTime"`
+	Vehicles  []vehicleV1 `gorm:"foreignKey:ClientID"`
+}
+
+func (clientV1) TableName() string {
+	return "clients"
+}
+
+type vehicleV1 struct {
+	ID        string `gorm:"primaryKey"`
+	Brand     string `gorm:"not null"`
+	Model     string
+	Color     string `gorm:"not null"`
+	Year      string
+	Domain    string    `gorm:"not null;unique"`
+	ClientID  string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	Client    clientV1  `gorm:"foreignKey:ClientID"`
+}
+
+func (vehicleV1) TableName() string {
+	return "vehicles"
+}
+
+type workplaceV1 struct {
+	ID         string    `gorm:"primaryKey"`
+	Name       string    `gorm:"not null"`
+	Address    string    `gorm:"not null"`
+	Phone      string    `gorm:"not null"`
+	Email      string    `gorm:"not null"`
+	Identifier string    `gorm:"not null;unique"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (workplaceV1) TableName() string {
+	return "workplaces"
+}
+
+type roleV1 struct {
+	ID        string `gorm:"primaryKey"`
+	Name      string `gorm:"not null"`
+	Hierarchy int    `gorm:"not null"`
+	Workplace string `gorm:"not null"`
+}
+
+func (roleV1) TableName() string {
+	return "roles"
+}
+
+// auditLogV1 guardaba método y ruta de cada request; audit_trail (12) la
+// reemplaza por la auditoría por entidad.
+type auditLogV1 struct {
+	ID        string `gorm:"primaryKey"`
+	UserID    string `gorm:"not null"`
+	Method    string `gorm:"not null"`
+	Path      string `gorm:"not null"`
+	CreatedAt string `gorm:"autoCreateTime"`
+	UpdatedAt string `gorm:"autoUpdateTime"`
+}
+
+func (auditLogV1) TableName() string {
+	return "audit_logs"
+}
+
+type employeeV1 struct {
+	ID          string    `gorm:"primaryKey"`
+	WorkplaceID string    `gorm:"not null;index"`
+	Name        string    `gorm:"not null"`
+	Phone       string    `gorm:"not null"`
+	Email       string    `gorm:"not null"`
+	Address     string    `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func (employeeV1) TableName() string {
+	return "employees"
+}
+
+type movementTypeV1 struct {
+	ID          string    `gorm:"primaryKey"`
+	WorkplaceID string    `gorm:"not null;index"`
+	Name        string    `gorm:"not null"`
+	IsIncome    bool      `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func (movementTypeV1) TableName() string {
+	return "movement_types"
+}
+
+type supplierV1 struct {
+	ID          string    `gorm:"primaryKey"`
+	WorkplaceID string    `gorm:"not null;index"`
+	Name        string    `gorm:"not null"`
+	Address     string    `gorm:"not null"`
+	Phone       string    `gorm:"not null"`
+	Email       string    `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func (supplierV1) TableName() string {
+	return "suppliers"
+}
+
+type serviceV1 struct {
+	ID          string    `gorm:"primaryKey"`
+	WorkplaceID string    `gorm:"not null;uniqueIndex:idx_service_workplace_name"`
+	Name        string    `gorm:"not null;uniqueIndex:idx_service_workplace_name"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func (serviceV1) TableName() string {
+	return "services"
+}
+
+type productV1 struct {
+	ID          string    `gorm:"primaryKey"`
+	WorkplaceID string    `gorm:"not null;uniqueIndex:idx_product_workplace_identifier"`
+	Identifier  string    `gorm:"not null;uniqueIndex:idx_product_workplace_identifier"`
+	Name        string    `gorm:"not null"`
+	Stock       int32     `gorm:"not null;min:0;default:0"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func (productV1) TableName() string {
+	return "products"
+}
+
+type attendanceV1 struct {
+	ID          string     `gorm:"primaryKey"`
+	WorkplaceID string     `gorm:"not null;index"`
+	EmployeeID  string     `gorm:"not null"`
+	Attendance  string     `gorm:"not null"`
+	Hours       int        `gorm:"not null;"`
+	Date        string     `gorm:"not null"`
+	Amount      float32    `gorm:"not null"`
+	IsHoliday   bool       `gorm:"not null;default:false"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime"`
+	Employee    employeeV1 `gorm:"foreignKey:EmployeeID;references:ID"`
+}
+
+func (attendanceV1) TableName() string {
+	return "attendances"
+}
+
+type expenseResumeV1 struct {
+	ID          string    `gorm:"primaryKey"`
+	WorkplaceID string    `gorm:"not null;index"`
+	Data        string    `gorm:"not null;size:100000"`
+	Date        time.Time `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func (expenseResumeV1) TableName() string {
+	return "expense_resumes"
+}
+
+type expenseV1 struct {
+	ID             string `gorm:"primaryKey"`
+	WorkplaceID    string `gorm:"not null;index"`
+	Details        string
+	SupplierID     string
+	MovementTypeID string         `gorm:"not null"`
+	Amount         float32        `gorm:"not null"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	Supplier       supplierV1     `gorm:"foreignKey:SupplierID"`
+	MovementType   movementTypeV1 `gorm:"foreignKey:MovementTypeID;references:ID"`
+}
+
+func (expenseV1) TableName() string {
+	return "expenses"
+}
+
+type incomeResumeV1 struct {
+	ID          string    `gorm:"primaryKey"`
+	WorkplaceID string    `gorm:"not null;index"`
+	Data        string    `gorm:"not null;size:100000"`
+	Date        time.Time `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+func (incomeResumeV1) TableName() string {
+	return "income_resumes"
+}
+
+type incomeV1 struct {
+	ID             string `gorm:"primaryKey"`
+	WorkplaceID    string `gorm:"not null;index"`
+	Ticket         string
+	Details        string
+	ClientID       string `gorm:"not null"`
+	VehicleID      string
+	EmployeeID     string
+	Amount         float32        `gorm:"not null"`
+	MovementTypeID string         `gorm:"not null"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime"`
+	Client         clientV1       `gorm:"foreignKey:ClientID"`
+	Vehicle        vehicleV1      `gorm:"foreignKey:VehicleID"`
+	Employee       employeeV1     `gorm:"foreignKey:EmployeeID"`
+	MovementType   movementTypeV1 `gorm:"foreignKey:MovementTypeID;references:ID"`
+}
+
+func (incomeV1) TableName() string {
+	return "incomes"
+}
+
+type incomeServiceV1 struct {
+	ID        string    `gorm:"primaryKey"`
+	IncomeID  string    `gorm:"not null;index"`
+	ServiceID string    `gorm:"not null"`
+	Income    incomeV1  `gorm:"foreignKey:IncomeID;references:ID"`
+	Service   serviceV1 `gorm:"foreignKey:ServiceID;references:ID"`
+}
+
+func (incomeServiceV1) TableName() string {
+	return "income_services"
+}
+
+type purchaseOrderV1 struct {
+	ID               string              `gorm:"not null;primaryKey"`
+	WorkplaceID      string              `gorm:"not null;index"`
+	OrderNumber      string              `gorm:"not null"`
+	OrderDate        string              `gorm:"not null"`
+	Amount           float32             `gorm:"not null"`
+	SupplierID       string              `gorm:"not null"`
+	CreatedAt        time.Time           `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time           `gorm:"autoUpdateTime"`
+	Supplier         supplierV1          `gorm:"foreignKey:SupplierID;references:ID"`
+	PurchaseProducts []purchaseProductV1 `gorm:"foreignKey:PurchaseOrderID;references:ID"`
+}
+
+func (purchaseOrderV1) TableName() string {
+	return "purchase_orders"
+}
+
+type purchaseProductV1 struct {
+	ID              string          `gorm:"primaryKey"`
+	ProductID       string          `gorm:"not null"`
+	PurchaseOrderID string          `gorm:"not null;index"`
+	ExpiredAt       string          `gorm:"not null"`
+	UnitPrice       float32         `gorm:"not null"`
+	Quantity        int             `gorm:"not null"`
+	TotalPrice      float32         `gorm:"not null"`
+	CreatedAt       time.Time       `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time       `gorm:"autoUpdateTime"`
+	Product         productV1       `gorm:"foreignKey:ProductID;references:ID"`
+	PurchaseOrder   purchaseOrderV1 `gorm:"foreignKey:PurchaseOrderID;references:ID"`
+}
+
+func (purchaseProductV1) TableName() string {
+	return "purchase_products"
+}
+
+// Columnas de soft_delete (4) y row_version (5) en las entidades de dominio.
+
+type softDeleteV4 struct {
+	DeletedAt gorm.DeletedAt
+}
+
+type rowVersionV5 struct {
+	Version int64 `gorm:"not null;default:1"`
+}
+
+type idempotencyKeyV6 struct {
+	ID           string `gorm:"primaryKey"`
+	UserID       string `gorm:"not null;uniqueIndex:idx_idempotency_user_key"`
+	Key          string `gorm:"column:idempotency_key;not null;size:255;uniqueIndex:idx_idempotency_user_key"`
+	WorkplaceID  string
+	Method       string `gorm:"not null"`
+	Path         string `gorm:"not null"`
+	RequestHash  string `gorm:"not null"`
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	CompletedAt  *time.Time
+}
+
+func (idempotencyKeyV6) TableName() string {
+	return "idempotency_keys"
+}
+
+type refreshTokenV7 struct {
+	ID         string    `gorm:"primaryKey"`
+	UserID     string    `gorm:"not null;index"`
+	TokenHash  string    `gorm:"not null;uniqueIndex"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+	ReplacedBy string
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (refreshTokenV7) TableName() string {
+	return "refresh_tokens"
+}
+
+type revokedTokenV7 struct {
+	JTI       string    `gorm:"primaryKey"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func (revokedTokenV7) TableName() string {
+	return "revoked_tokens"
+}
+
+type permissionV8 struct {
+	Name        string `gorm:"primaryKey"`
+	Description string `gorm:"not null"`
+}
+
+func (permissionV8) TableName() string {
+	return "permissions"
+}
+
+type rolePermissionV8 struct {
+	RoleID     string `gorm:"primaryKey"`
+	Permission string `gorm:"primaryKey"`
+}
+
+func (rolePermissionV8) TableName() string {
+	return "role_permissions"
+}
+
+type userActiveV9 struct {
+	Active bool `gorm:"not null;default:true"`
+}
+
+type passwordResetTokenV10 struct {
+	ID        string    `gorm:"primaryKey"`
+	UserID    string    `gorm:"not null;index"`
+	TokenHash string    `gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (passwordResetTokenV10) TableName() string {
+	return "password_reset_tokens"
+}
+
+type loginAttemptV11 struct {
+	ID        string  `gorm:"primaryKey"`
+	UserID    *string `gorm:"index"`
+	Username  string  `gorm:"not null;index"`
+	IP        string  `gorm:"not null;index"`
+	UserAgent string
+	Success   bool      `gorm:"not null"`
+	Result    string    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index"`
+}
+
+func (loginAttemptV11) TableName() string {
+	return "login_attempts"
+}
+
+type auditLogV12 struct {
+	ID          string    `gorm:"primaryKey"`
+	UserID      string    `gorm:"index"`
+	WorkplaceID string    `gorm:"index"`
+	EntityType  string    `gorm:"not null;index:idx_audit_logs_entity"`
+	EntityID    string    `gorm:"not null;index:idx_audit_logs_entity"`
+	Action      string    `gorm:"not null"`
+	Before      string    `gorm:"type:text"`
+	After       string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;index"`
+}
+
+func (auditLogV12) TableName() string {
+	return "audit_logs"
+}
+
+// Tablas y columnas de service_prices (13).
+
+type servicePriceV13 struct {
+	ID            string    `gorm:"primaryKey"`
+	ServiceID     string    `gorm:"not null;uniqueIndex:idx_service_price_effective"`
+	Price         float32   `gorm:"not null"`
+	EffectiveFrom time.Time `gorm:"not null;uniqueIndex:idx_service_price_effective"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+func (servicePriceV13) TableName() string {
+	return "service_prices"
+}
+
+type incomeTotalsV13 struct {
+	Subtotal       float32 `gorm:"not null;default:0"`
+	Discount       float32 `gorm:"not null;default:0"`
+	DiscountReason string
+}
+
+type incomeServicePriceV13 struct {
+	Price float32 `gorm:"not null;default:0"`
+}
+
+// Columnas de vehicle_categories (14).
+
+type vehicleCategoryV14 struct {
+	Category string
+}
+
+type servicePriceCategoryV14 struct {
+	VehicleCategory string `gorm:"not null;default:''"`
+}
+
+// Tablas de work_orders (15).
+
+type workOrderV15 struct {
+	ID              string `gorm:"primaryKey"`
+	WorkplaceID     string `gorm:"not null;index"`
+	ClientID        string `gorm:"not null;index"`
+	VehicleID       string `gorm:"not null;index"`
+	EmployeeID      string `gorm:"index"`
+	Description     string `gorm:"not null"`
+	Diagnosis       string
+	Status          string    `gorm:"not null;index"`
+	StatusChangedAt time.Time `gorm:"not null"`
+	IncomeID        string
+	CreatedAt       time.Time                `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time                `gorm:"autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt           `gorm:"index"`
+	Version         int64                    `gorm:"not null;default:1"`
+	Client          clientV1                 `gorm:"foreignKey:ClientID"`
+	Vehicle         vehicleV1                `gorm:"foreignKey:VehicleID"`
+	Employee        employeeV1               `gorm:"foreignKey:EmployeeID"`
+	Transitions     []workOrderTransitionV15 `gorm:"foreignKey:WorkOrderID;references:ID"`
+}
+
+func (workOrderV15) TableName() string {
+	return "work_orders"
+}
+
+type workOrderTransitionV15 struct {
+	ID          string `gorm:"primaryKey"`
+	WorkOrderID string `gorm:"not null;index"`
+	FromStatus  string
+	ToStatus    string `gorm:"not null"`
+	Note        string
+	UserID      string
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (workOrderTransitionV15) TableName() string {
+	return "work_order_transitions"
+}
+
+// Tablas y columnas de work_order_parts (16).
+
+type productReservedV16 struct {
+	Reserved int32 `gorm:"not null;default:0"`
+}
+
+type workOrderPartV16 struct {
+	ID          string       `gorm:"primaryKey"`
+	WorkOrderID string       `gorm:"not null;uniqueIndex:idx_work_order_part"`
+	ProductID   string       `gorm:"not null;uniqueIndex:idx_work_order_part"`
+	Quantity    int32        `gorm:"not null"`
+	Price       float32      `gorm:"not null"`
+	CreatedAt   time.Time    `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time    `gorm:"autoUpdateTime"`
+	WorkOrder   workOrderV15 `gorm:"foreignKey:WorkOrderID"`
+	Product     productV1    `gorm:"foreignKey:ProductID"`
+}
+
+func (workOrderPartV16) TableName() string {
+	return "work_order_parts"
+}
+
+// Columna de workplaces (17).
+
+type workplaceWorkOrdersV17 struct {
+	WorkOrders bool `gorm:"not null;default:false"`
+}
+
+// Columna de workplaces (18).
+
+type workplaceCategoryPricesV18 struct {
+	CategoryPrices bool `gorm:"not null;default:false"`
+}
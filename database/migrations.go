@@ -0,0 +1,413 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// migrations es la lista ordenada de cambios de esquema. Nunca se modifica una
+// migración ya publicada: se agrega una nueva al final con una versión mayor.
+// Las migraciones no usan models.*, ver migration_models.go.
+var migrations = []Migration{
+	{
+		// Esquema base. En bases creadas con AutoMigrate no hace cambios.
+		Version: "20261018000001",
+		Name:    "initial_schema",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(initialModels()...)
+		},
+		Down: func(tx *gorm.DB) error {
+			models := initialModels()
+			for i := len(models) - 1; i >= 0; i-- {
+				if err := tx.Migrator().DropTable(models[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// Pasa las tablas *_laundries / *_workshops a las tablas por workplace.
+		// Al revertir los datos quedan en las tablas nuevas: no se recrean las viejas.
+		Version: "20261018000002",
+		Name:    "unify_workplace_tables",
+		Up: func(tx *gorm.DB) error {
+			if !hasLegacyTables(tx) {
+				return nil
+			}
+			if err := seedLegacyWorkplaces(tx); err != nil {
+				return err
+			}
+			return migrateLegacyTables(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			return nil
+		},
+	},
+	{
+		// Índice FTS5 para /search (solo SQLite, ver search.go).
+		Version: "20261018000003",
+		Name:    "search_index",
+		Up:      createSearchIndex,
+		Down:    dropSearchIndex,
+	},
+	{
+		// Borrado lógico: deleted_at en las entidades de dominio. El índice de
+		// búsqueda se rearma para que ignore lo que está en la papelera.
+		Version: "20261018000004",
+		Name:    "soft_delete",
+		Up: func(tx *gorm.DB) error {
+			for _, table := range domainTables {
+				if err := addColumns(tx, table, &softDeleteV4{}, "DeletedAt"); err != nil {
+					return err
+				}
+				if err := createIndex(tx, table, "idx_"+table+"_deleted_at", false, "deleted_at"); err != nil {
+					return err
+				}
+			}
+			if err := dropSearchIndex(tx); err != nil {
+				return err
+			}
+			return createSearchIndex(tx)
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropSearchIndex(tx); err != nil {
+				return err
+			}
+			for _, table := range domainTables {
+				if err := dropIndex(tx, table, "idx_"+table+"_deleted_at"); err != nil {
+					return err
+				}
+				if err := dropColumns(tx, table, &softDeleteV4{}, "DeletedAt"); err != nil {
+					return err
+				}
+			}
+			return createSearchIndex(tx)
+		},
+	},
+	{
+		// Control de concurrencia optimista: las filas existentes quedan en la versión 1.
+		Version: "20261018000005",
+		Name:    "row_version",
+		Up: func(tx *gorm.DB) error {
+			for _, table := range domainTables {
+				if err := addColumns(tx, table, &rowVersionV5{}, "Version"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, table := range domainTables {
+				if err := dropColumns(tx, table, &rowVersionV5{}, "Version"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: "20261018000006",
+		Name:    "idempotency_keys",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&idempotencyKeyV6{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&idempotencyKeyV6{})
+		},
+	},
+	{
+		Version: "20261018000007",
+		Name:    "auth_tokens",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&refreshTokenV7{}, &revokedTokenV7{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&revokedTokenV7{}, &refreshTokenV7{})
+		},
+	},
+	{
+		// Permisos por rol. El catálogo y las asignaciones por defecto los
+		// carga SeedPermissions, porque en una base nueva los roles se crean
+		// después de migrar.
+		Version: "20261018000008",
+		Name:    "role_permissions",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&permissionV8{}, &rolePermissionV8{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&rolePermissionV8{}, &permissionV8{})
+		},
+	},
+	{
+		// Usuarios activos o desactivados; los existentes quedan activos.
+		Version: "20261018000009",
+		Name:    "user_active",
+		Up: func(tx *gorm.DB) error {
+			return addColumns(tx, "users", &userActiveV9{}, "Active")
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, "users", &userActiveV9{}, "Active")
+		},
+	},
+	{
+		Version: "20261018000010",
+		Name:    "password_reset_tokens",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&passwordResetTokenV10{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&passwordResetTokenV10{})
+		},
+	},
+	{
+		Version: "20261018000011",
+		Name:    "login_attempts",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&loginAttemptV11{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&loginAttemptV11{})
+		},
+	},
+	{
+		// Auditoría de cambios por entidad. La tabla vieja (método y ruta de
+		// cada request) no tiene nada que conservar.
+		Version: "20261018000012",
+		Name:    "audit_trail",
+		Up: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn("audit_logs", "method") {
+				if err := tx.Migrator().DropTable("audit_logs"); err != nil {
+					return err
+				}
+			}
+			return tx.AutoMigrate(&auditLogV12{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&auditLogV12{})
+		},
+	},
+	{
+		// Precios de servicios y totales calculados. Los servicios existentes
+		// quedan sin precio hasta que se les cargue uno; los ingresos
+		// existentes toman su amount como subtotal, sin descuento.
+		Version: "20261018000013",
+		Name:    "service_prices",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&servicePriceV13{}); err != nil {
+				return err
+			}
+			if err := addColumns(tx, "incomes", &incomeTotalsV13{}, "Subtotal", "Discount", "DiscountReason"); err != nil {
+				return err
+			}
+			if err := addColumns(tx, "income_services", &incomeServicePriceV13{}, "Price"); err != nil {
+				return err
+			}
+			return tx.Exec("UPDATE incomes SET subtotal = amount WHERE subtotal = 0").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := dropColumns(tx, "income_services", &incomeServicePriceV13{}, "Price"); err != nil {
+				return err
+			}
+			if err := dropColumns(tx, "incomes", &incomeTotalsV13{}, "Subtotal", "Discount", "DiscountReason"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&servicePriceV13{})
+		},
+	},
+	{
+		// Categorías de vehículo y precios por categoría. Los vehículos
+		// existentes quedan sin categoría y pagan el precio general.
+		Version: "20261018000014",
+		Name:    "vehicle_categories",
+		Up: func(tx *gorm.DB) error {
+			if err := addColumns(tx, "vehicles", &vehicleCategoryV14{}, "Category"); err != nil {
+				return err
+			}
+			if err := createIndex(tx, "vehicles", "idx_vehicles_category", false, "category"); err != nil {
+				return err
+			}
+			if err := dropIndex(tx, "service_prices", "idx_service_price_effective"); err != nil {
+				return err
+			}
+			if err := addColumns(tx, "service_prices", &servicePriceCategoryV14{}, "VehicleCategory"); err != nil {
+				return err
+			}
+			return createIndex(tx, "service_prices", "idx_service_price_category_effective", true,
+				"service_id", "vehicle_category", "effective_from")
+		},
+		Down: func(tx *gorm.DB) error {
+			// los precios por categoría no tienen sentido sin la columna
+			if err := tx.Exec("DELETE FROM service_prices WHERE vehicle_category <> ''").Error; err != nil {
+				return err
+			}
+			if err := dropIndex(tx, "service_prices", "idx_service_price_category_effective"); err != nil {
+				return err
+			}
+			if err := dropColumns(tx, "service_prices", &servicePriceCategoryV14{}, "VehicleCategory"); err != nil {
+				return err
+			}
+			if err := createIndex(tx, "service_prices", "idx_service_price_effective", true, "service_id", "effective_from"); err != nil {
+				return err
+			}
+			if err := dropIndex(tx, "vehicles", "idx_vehicles_category"); err != nil {
+				return err
+			}
+			return dropColumns(tx, "vehicles", &vehicleCategoryV14{}, "Category")
+		},
+	},
+	{
+		// Órdenes de trabajo del taller y su historial de estados.
+		Version: "20261018000015",
+		Name:    "work_orders",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&workOrderV15{}, &workOrderTransitionV15{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&workOrderTransitionV15{}, &workOrderV15{})
+		},
+	},
+	{
+		// Repuestos de las órdenes de trabajo y stock reservado de los productos.
+		Version: "20261018000016",
+		Name:    "work_order_parts",
+		Up: func(tx *gorm.DB) error {
+			if err := addColumns(tx, "products", &productReservedV16{}, "Reserved"); err != nil {
+				return err
+			}
+			return tx.AutoMigrate(&workOrderPartV16{})
+		},
+		Down: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&workOrderPartV16{}); err != nil {
+				return err
+			}
+			return dropColumns(tx, "products", &productReservedV16{}, "Reserved")
+		},
+	},
+	{
+		// Las órdenes de trabajo pasan a ser una opción del workplace; hasta
+		// ahora eran solo del taller.
+		Version: "20261018000017",
+		Name:    "workplace_work_orders",
+		Up: func(tx *gorm.DB) error {
+			if err := addColumns(tx, "workplaces", &workplaceWorkOrdersV17{}, "WorkOrders"); err != nil {
+				return err
+			}
+			return tx.Exec("UPDATE workplaces SET work_orders = ? WHERE identifier = ?", true, "workshop").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, "workplaces", &workplaceWorkOrdersV17{}, "WorkOrders")
+		},
+	},
+	{
+		Version: "20261018000018",
+		Name:    "workplace_category_prices",
+		Up: func(tx *gorm.DB) error {
+			if err := addColumns(tx, "workplaces", &workplaceCategoryPricesV18{}, "CategoryPrices"); err != nil {
+				return err
+			}
+			return tx.Exec("UPDATE workplaces SET category_prices = ? WHERE identifier = ?", true, "laundry").Error
+		},
+		Down: func(tx *gorm.DB) error {
+			return dropColumns(tx, "workplaces", &workplaceCategoryPricesV18{}, "CategoryPrices")
+		},
+	},
+}
+
+func initialModels() []interface{} {
+	return []interface{}{
+		&userV1{},
+		&clientV1{},
+		&vehicleV1{},
+		&workplaceV1{},
+		&roleV1{},
+		&auditLogV1{},
+		&employeeV1{},
+		&movementTypeV1{},
+		&supplierV1{},
+		&serviceV1{},
+		&productV1{},
+		&attendanceV1{},
+		&expenseResumeV1{},
+		&expenseV1{},
+		&incomeResumeV1{},
+		&incomeV1{},
+		&incomeServiceV1{},
+		&purchaseOrderV1{},
+		&purchaseProductV1{},
+	}
+}
+
+// domainTables son las entidades de negocio: tienen borrado lógico y versión.
+var domainTables = []string{
+	"attendances",
+	"clients",
+	"employees",
+	"expenses",
+	"incomes",
+	"movement_types",
+	"products",
+	"purchase_orders",
+	"purchase_products",
+	"services",
+	"suppliers",
+	"vehicles",
+}
+
+// addColumns agrega a table los campos de model que todavía no tiene. model
+// es un struct congelado con solo las columnas nuevas.
+func addColumns(tx *gorm.DB, table string, model interface{}, fields ...string) error {
+	for _, field := range fields {
+		migrator := tx.Table(table).Migrator()
+		if migrator.HasColumn(model, field) {
+			continue
+		}
+		if err := migrator.AddColumn(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropColumns es la inversa de addColumns.
+func dropColumns(tx *gorm.DB, table string, model interface{}, fields ...string) error {
+	for _, field := range fields {
+		migrator := tx.Table(table).Migrator()
+		if !migrator.HasColumn(model, field) {
+			continue
+		}
+		if err := migrator.DropColumn(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createIndex(tx *gorm.DB, table string, name string, unique bool, columns ...string) error {
+	if tx.Migrator().HasIndex(table, name) {
+		return nil
+	}
+	statement := "CREATE INDEX"
+	if unique {
+		statement = "CREATE UNIQUE INDEX"
+	}
+	return tx.Exec(fmt.Sprintf("%s %s ON %s (%s)", statement, name, table, strings.Join(columns, ", "))).Error
+}
+
+func dropIndex(tx *gorm.DB, table string, name string) error {
+	if !tx.Migrator().HasIndex(table, name) {
+		return nil
+	}
+	return tx.Migrator().DropIndex(table, name)
+}
+
+func hasLegacyTables(db *gorm.DB) bool {
+	for _, table := range legacyTables {
+		if db.Migrator().HasTable(table.laundry) || db.Migrator().HasTable(table.workshop) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// El índice de búsqueda es una tabla FTS5 de SQLite que se mantiene con
+// triggers, así cualquier escritura (API, gestioncar-admin o SQL a mano)
+// queda reflejada. unicode61 con remove_diacritics hace que "Perez" encuentre
+// "Pérez". go-sqlite3 solo trae FTS5 con -tags sqlite_fts5 (ver Makefile):
+// sin él, igual que en PostgreSQL/MySQL, no se crea nada y la búsqueda usa
+// LIKE, sin ranking ni plegado de acentos.
+const searchIndexTable = "search_index"
+
+// searchSource describe cómo indexar una tabla: workplace, title y content son
+// expresiones SQL donde {row} es la fila (new, old o la tabla) y un workplace
+// vacío significa que la entidad es global. GORM nombra la columna CUIL c_ui_l.
+type searchSource struct {
+	entity    string
+	table     string
+	workplace string
+	title     string
+	content   string
+}
+
+var searchSources = []searchSource{
+	{
+		entity:    "client",
+		table:     "clients",
+		workplace: "''",
+		title:     "coalesce({row}.first_name, '') || ' ' || coalesce({row}.last_name, '')",
+		content:   "coalesce({row}.c_ui_l, '') || ' ' || coalesce({row}.dni, '') || ' ' || coalesce({row}.email, '')",
+	},
+	{
+		entity:    "vehicle",
+		table:     "vehicles",
+		workplace: "''",
+		title:     "coalesce({row}.domain, '')",
+		content:   "coalesce({row}.brand, '') || ' ' || coalesce({row}.model, '')",
+	},
+	{
+		entity:    "product",
+		table:     "products",
+		workplace: "{row}.workplace_id",
+		title:     "coalesce({row}.name, '')",
+		content:   "coalesce({row}.identifier, '')",
+	},
+	{
+		entity:    "supplier",
+		table:     "suppliers",
+		workplace: "{row}.workplace_id",
+		title:     "coalesce({row}.name, '')",
+		content:   "coalesce({row}.email, '')",
+	},
+}
+
+// insert indexa row. Si la tabla tiene borrado lógico, las filas en la
+// papelera no se indexan: borrar es un UPDATE de deleted_at y el trigger de
+// UPDATE las saca del índice.
+func (s searchSource) insert(row string, softDelete bool) string {
+	expr := strings.NewReplacer("{row}", row)
+	statement := fmt.Sprintf("INSERT INTO %s (entity_type, entity_id, workplace_id, title, content) SELECT '%s', %s.id, %s, %s, %s",
+		searchIndexTable, s.entity, row,
+		expr.Replace(s.workplace), expr.Replace(s.title), expr.Replace(s.content))
+	if row == s.table {
+		statement += " FROM " + s.table
+	}
+	if softDelete {
+		statement += " WHERE " + row + ".deleted_at IS NULL"
+	}
+	return statement
+}
+
+func (s searchSource) delete(row string) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE entity_type = '%s' AND entity_id = %s.id", searchIndexTable, s.entity, row)
+}
+
+func (s searchSource) triggers(softDelete bool) []string {
+	return []string{
+		fmt.Sprintf("CREATE TRIGGER %[1]s_search_ai AFTER INSERT ON %[1]s BEGIN %[2]s; END",
+			s.table, s.insert("new", softDelete)),
+		fmt.Sprintf("CREATE TRIGGER %[1]s_search_au AFTER UPDATE ON %[1]s BEGIN %[2]s; %[3]s; END",
+			s.table, s.delete("old"), s.insert("new", softDelete)),
+		fmt.Sprintf("CREATE TRIGGER %[1]s_search_ad AFTER DELETE ON %[1]s BEGIN %[2]s; END",
+			s.table, s.delete("old")),
+	}
+}
+
+// supportsFTS5 indica si la base es SQLite compilado con FTS5.
+func supportsFTS5(db *gorm.DB) bool {
+	if db.Dialector.Name() != "sqlite" {
+		return false
+	}
+	var enabled bool
+	if err := db.Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled).Error; err != nil {
+		return false
+	}
+	return enabled
+}
+
+func createSearchIndex(tx *gorm.DB) error {
+	if !supportsFTS5(tx) {
+		log.Printf("Índice de búsqueda no disponible en %s sin FTS5: se usará LIKE", tx.Dialector.Name())
+		return nil
+	}
+
+	statements := []string{
+		"CREATE VIRTUAL TABLE " + searchIndexTable + " USING fts5(" +
+			"entity_type UNINDEXED, entity_id UNINDEXED, workplace_id UNINDEXED, title, content, " +
+			"tokenize = 'unicode61 remove_diacritics 2')",
+	}
+	for _, source := range searchSources {
+		softDelete := tx.Migrator().HasColumn(source.table, "deleted_at")
+		statements = append(statements, source.triggers(softDelete)...)
+		// indexa lo que ya existe
+		statements = append(statements, source.insert(source.table, softDelete))
+	}
+
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropSearchIndex(tx *gorm.DB) error {
+	if tx.Dialector.Name() != "sqlite" {
+		return nil
+	}
+	for _, source := range searchSources {
+		for _, suffix := range []string{"ai", "au", "ad"} {
+			if err := tx.Exec(fmt.Sprintf("DROP TRIGGER IF EXISTS %s_search_%s", source.table, suffix)).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Exec("DROP TABLE IF EXISTS " + searchIndexTable).Error
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/config"
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestSeedPermissionsKeepsChanges(t *testing.T) {
+	db, err := Connect("sqlite://" + filepath.Join(t.TempDir(), "seed.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer CloseDB(db)
+
+	if err := Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := Seed(db, config.Admin{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var employee models.Role
+	if err := db.Where("name = ?", "employee_laundry").First(&employee).Error; err != nil {
+		t.Fatal(err)
+	}
+	countEmployee := func() int64 {
+		t.Helper()
+		var count int64
+		if err := db.Model(&models.RolePermission{}).Where("role_id = ?", employee.ID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+	if countEmployee() != int64(len(employeePermissions)) {
+		t.Fatalf("employee_laundry tiene %d permisos, se esperaban %d", countEmployee(), len(employeePermissions))
+	}
+
+	// lo que se quitó a mano no vuelve en el próximo arranque
+	if err := db.Where("role_id = ?", employee.ID).Delete(&models.RolePermission{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := Seed(db, config.Admin{}); err != nil {
+		t.Fatal(err)
+	}
+	if count := countEmployee(); count != 0 {
+		t.Errorf("employee_laundry recuperó %d permisos", count)
+	}
+
+	// un permiso nuevo del catálogo se asigna con sus roles por defecto
+	if err := db.Where("permission = ?", models.PermIncomeCreate).Delete(&models.RolePermission{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(&models.Permission{Name: models.PermIncomeCreate}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := SeedPermissions(db); err != nil {
+		t.Fatal(err)
+	}
+	if count := countEmployee(); count != 1 {
+		t.Errorf("employee_laundry tiene %d permisos, se esperaba solo %s", count, models.PermIncomeCreate)
+	}
+}
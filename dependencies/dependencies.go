@@ -1,21 +1,123 @@
 package dependencies
 
 import (
+	"github.com/DanielChachagua/GestionCar/config"
+	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/mailer"
 	"github.com/DanielChachagua/GestionCar/repositories"
+	"github.com/DanielChachagua/GestionCar/services"
 	"gorm.io/gorm"
 )
 
+// Dependency arma el grafo de repositorios, servicios y controladores.
+// Todos los repositorios de dominio los implementa *repositories.Repository.
 type Dependency struct {
 	Repository *repositories.Repository
+
+	AttendanceService      *services.AttendanceService
+	AuditService           *services.AuditService
+	AuthService            *services.AuthService
+	ClientService          *services.ClientService
+	EmployeeService        *services.EmployeeService
+	ExpenseService         *services.ExpenseService
+	IdempotencyService     *services.IdempotencyService
+	IncomeService          *services.IncomeService
+	MovementTypeService    *services.MovementTypeService
+	PasswordService        *services.PasswordService
+	PermissionService      *services.PermissionService
+	ProductService         *services.ProductService
+	PurchaseOrderService   *services.PurchaseOrderService
+	PurchaseProductService *services.PurchaseProductService
+	RoleService            *services.RoleService
+	SearchService          *services.SearchService
+	ServiceService         *services.ServiceService
+	SupplierService        *services.SupplierService
+	TrashService           *services.TrashService
+	UserService            *services.UserService
+	VehicleService         *services.VehicleService
+	WorkOrderService       *services.WorkOrderService
+	WorkplaceService       *services.WorkplaceService
+
+	AttendanceController      *controllers.AttendanceController
+	AuditController           *controllers.AuditController
+	AuthController            *controllers.AuthController
+	ClientController          *controllers.ClientController
+	EmployeeController        *controllers.EmployeeController
+	ExpenseController         *controllers.ExpenseController
+	IncomeController          *controllers.IncomeController
+	MovementTypeController    *controllers.MovementTypeController
+	PasswordController        *controllers.PasswordController
+	PermissionController      *controllers.PermissionController
+	ProductController         *controllers.ProductController
+	PurchaseOrderController   *controllers.PurchaseOrderController
+	PurchaseProductController *controllers.PurchaseProductController
+	RoleController            *controllers.RoleController
+	SearchController          *controllers.SearchController
+	ServiceController         *controllers.ServiceController
+	SupplierController        *controllers.SupplierController
+	TrashController           *controllers.TrashController
+	UserController            *controllers.UserController
+	VehicleController         *controllers.VehicleController
+	WorkOrderController       *controllers.WorkOrderController
+	WorkplaceController       *controllers.WorkplaceController
 }
 
-func NewDependency(db *gorm.DB) *Dependency {
+func NewDependency(db *gorm.DB, cfg *config.Config) *Dependency {
 
 	repo := &repositories.Repository{
 		DB: db,
 	}
 
-	return &Dependency{
+	dep := &Dependency{
 		Repository: repo,
 	}
-}
\ No newline at end of file
+
+	dep.AttendanceService = services.NewAttendanceService(repo)
+	dep.AuditService = services.NewAuditService(repo)
+	dep.AuthService = services.NewAuthService(repo, repo, repo, repo, repo, cfg.Auth, cfg.Login)
+	dep.ClientService = services.NewClientService(repo)
+	dep.EmployeeService = services.NewEmployeeService(repo)
+	dep.ExpenseService = services.NewExpenseService(repo)
+	dep.IdempotencyService = services.NewIdempotencyService(repo)
+	dep.IncomeService = services.NewIncomeService(repo)
+	dep.MovementTypeService = services.NewMovementTypeService(repo)
+	dep.PasswordService = services.NewPasswordService(repo, mailer.New(cfg.Mail), cfg.Auth, cfg.Mail)
+	dep.PermissionService = services.NewPermissionService(repo, repo)
+	dep.ProductService = services.NewProductService(repo)
+	dep.PurchaseOrderService = services.NewPurchaseOrderService(repo)
+	dep.PurchaseProductService = services.NewPurchaseProductService(repo)
+	dep.RoleService = services.NewRoleService(repo)
+	dep.SearchService = services.NewSearchService(repo)
+	dep.ServiceService = services.NewServiceService(repo)
+	dep.SupplierService = services.NewSupplierService(repo)
+	dep.TrashService = services.NewTrashService(repo)
+	dep.UserService = services.NewUserService(repo, repo)
+	dep.VehicleService = services.NewVehicleService(repo)
+	dep.WorkOrderService = services.NewWorkOrderService(repo)
+	dep.WorkplaceService = services.NewWorkplaceService(repo)
+
+	dep.AttendanceController = controllers.NewAttendanceController(dep.AttendanceService)
+	dep.AuditController = controllers.NewAuditController(dep.AuditService)
+	dep.AuthController = controllers.NewAuthController(dep.AuthService)
+	dep.ClientController = controllers.NewClientController(dep.ClientService)
+	dep.EmployeeController = controllers.NewEmployeeController(dep.EmployeeService)
+	dep.ExpenseController = controllers.NewExpenseController(dep.ExpenseService)
+	dep.IncomeController = controllers.NewIncomeController(dep.IncomeService)
+	dep.MovementTypeController = controllers.NewMovementTypeController(dep.MovementTypeService)
+	dep.PasswordController = controllers.NewPasswordController(dep.PasswordService)
+	dep.PermissionController = controllers.NewPermissionController(dep.PermissionService)
+	dep.ProductController = controllers.NewProductController(dep.ProductService)
+	dep.PurchaseOrderController = controllers.NewPurchaseOrderController(dep.PurchaseOrderService)
+	dep.PurchaseProductController = controllers.NewPurchaseProductController(dep.PurchaseProductService)
+	dep.RoleController = controllers.NewRoleController(dep.RoleService)
+	dep.SearchController = controllers.NewSearchController(dep.SearchService)
+	dep.ServiceController = controllers.NewServiceController(dep.ServiceService)
+	dep.SupplierController = controllers.NewSupplierController(dep.SupplierService)
+	dep.TrashController = controllers.NewTrashController(dep.TrashService)
+	dep.UserController = controllers.NewUserController(dep.UserService)
+	dep.VehicleController = controllers.NewVehicleController(dep.VehicleService)
+	dep.WorkOrderController = controllers.NewWorkOrderController(dep.WorkOrderService)
+	dep.WorkplaceController = controllers.NewWorkplaceController(dep.WorkplaceService)
+
+	return dep
+}
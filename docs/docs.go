@@ -50,6 +50,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.AttendanceCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -83,6 +89,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -203,6 +215,24 @@ const docTemplate = `{
                         "name": "X-Workplace-Token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -295,7 +325,7 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.AttendanceLaundry"
+                                                "$ref": "#/definitions/models.Attendance"
                                             }
                                         }
                                     }
@@ -444,6 +474,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.AttendanceUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -477,12 +513,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Version conflict, body has the current state",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Attendance"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -538,11 +598,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.AttendanceLaundry"
+                                            "$ref": "#/definitions/models.Attendance"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -572,9 +638,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Login user required identifier and password",
+        "/audit/get_all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the recorded changes, newest first. Filters: entity_type, entity_id, user_id, workplace_id, action (create, update, delete, restore, purge), created_at_from / created_at_to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -582,25 +653,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Audit"
                 ],
-                "summary": "Login user",
+                "summary": "List audit trail",
                 "parameters": [
                     {
-                        "description": "Credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AuthLogin"
-                        }
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AuditLog"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -610,19 +708,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                        "description": "Auth is required",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "403": {
+                        "description": "Not Authorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -636,14 +728,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/workplace_login/{workplace_id}": {
+        "/audit/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Login workplace required workplace_id",
+                "description": "Get one recorded change with its before and after snapshots",
                 "consumes": [
                     "application/json"
                 ],
@@ -651,14 +743,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Audit"
                 ],
-                "summary": "Login Workplace",
+                "summary": "Get audit entry by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "workplace_id",
-                        "name": "workplace_id",
+                        "description": "Id del registro de auditoría",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -667,7 +759,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.AuditLog"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -682,14 +786,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -703,14 +807,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/client/create": {
+        "/auth/change_password": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create client",
+                "description": "Changes the password of the logged user. The refresh tokens of all its sessions are revoked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -718,17 +822,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
                 ],
-                "summary": "Create client",
+                "summary": "Change password",
                 "parameters": [
                     {
-                        "description": "Información del cliente",
-                        "name": "clientCreate",
+                        "description": "Current and new password",
+                        "name": "password",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ClientCreate"
+                            "$ref": "#/definitions/models.ChangePasswordRequest"
                         }
                     }
                 ],
@@ -740,25 +844,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Wrong current password",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                        "description": "Auth is required",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "422": {
-                        "description": "Unprocessable Entity",
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -772,14 +870,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/client/delete/{id}": {
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete client by ID",
+        "/auth/forgot_password": {
+            "post": {
+                "description": "Sends a single-use password reset token to the email, if it belongs to an active user. The response is the same either way.",
                 "consumes": [
                     "application/json"
                 ],
@@ -787,35 +880,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
                 ],
-                "summary": "Delete client by ID",
+                "summary": "Forgot password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Id del cliente",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Email of the account",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ForgotPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "$ref": "#/definitions/models.Client"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
@@ -824,26 +907,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "422": {
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -851,14 +916,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/client/get_all": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get All Clients",
+        "/auth/login": {
+            "post": {
+                "description": "Login user required identifier and password. Unknown user and wrong password give the same 401; too many failures for the username or the IP lock them temporarily (429 with Retry-After).",
                 "consumes": [
                     "application/json"
                 ],
@@ -866,9 +926,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AuthLogin"
+                        }
+                    }
                 ],
-                "summary": "Get All Clients",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -881,10 +952,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Client"
-                                            }
+                                            "$ref": "#/definitions/models.AuthTokens"
                                         }
                                     }
                                 }
@@ -898,19 +966,19 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid credentials",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "429": {
+                        "description": "Too many failed attempts",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -924,14 +992,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/client/get_by_name": {
+        "/auth/login_history": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get Client By Name",
+                "description": "Lists login attempts, newest first. Filters: user_id, username, ip, user_agent, success, result (success, invalid_credentials, inactive, locked), created_at_from / created_at_to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -939,16 +1007,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
                 ],
-                "summary": "Get Client By Name",
+                "summary": "Login history",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Name",
-                        "name": "name",
-                        "in": "query",
-                        "required": true
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -965,8 +1044,11 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Client"
+                                                "$ref": "#/definitions/models.LoginAttempt"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
                                         }
                                     }
                                 }
@@ -980,19 +1062,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Auth is required",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                        "description": "Not Authorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1006,14 +1082,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/client/update": {
-            "put": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Actualizar un cliente",
+                "description": "Revoke the current access token and, if sent, the refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -1021,17 +1097,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
                 ],
-                "summary": "Actualizar un cliente",
+                "summary": "Logout",
                 "parameters": [
                     {
-                        "description": "Cliente a actualizar",
-                        "name": "ClientUpdate",
+                        "description": "Refresh token",
+                        "name": "logout",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ClientUpdate"
+                            "$ref": "#/definitions/models.LogoutRequest"
                         }
                     }
                 ],
@@ -1060,18 +1135,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1081,14 +1144,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/client/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get client by id",
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token; the refresh token is rotated",
                 "consumes": [
                     "application/json"
                 ],
@@ -1096,16 +1154,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Client"
+                    "Auth"
                 ],
-                "summary": "Get client by id",
+                "summary": "Refresh token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Id del cliente",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1120,7 +1180,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.Client"
+                                            "$ref": "#/definitions/models.AuthTokens"
                                         }
                                     }
                                 }
@@ -1139,14 +1199,60 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset_password": {
+            "post": {
+                "description": "Sets a new password with a reset token. The token can be used once and expires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1160,14 +1266,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/employee/create": {
-            "post": {
+        "/auth/workplace_login/{workplace_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates an employee for either laundry or workshop based on the provided information.",
+                "description": "Login workplace required workplace_id; the user's role must have access to the workplace",
                 "consumes": [
                     "application/json"
                 ],
@@ -1175,72 +1281,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employee"
+                    "Auth"
                 ],
-                "summary": "Create Employee",
+                "summary": "Login Workplace",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
+                        "description": "workplace_id",
+                        "name": "workplace_id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Employee information",
-                        "name": "employeeCreate",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.EmployeeCreate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Employee created",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "401": {
-                        "description": "Auth is required",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "403": {
-                        "description": "Not Authorized",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "422": {
-                        "description": "Model Invalid",
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1248,14 +1339,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/employee/delete/{id}": {
-            "delete": {
+        "/client/create": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Removes an employee from the database based on the provided ID and workplace context.",
+                "description": "Create client",
                 "consumes": [
                     "application/json"
                 ],
@@ -1263,28 +1354,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employee"
+                    "Client"
                 ],
-                "summary": "Delete Employee",
+                "summary": "Create client",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
-                        "required": true
+                        "description": "Información del cliente",
+                        "name": "clientCreate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ClientCreate"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "ID of the employee",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Empleado eliminado con éxito",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1296,25 +1388,31 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Auth is required",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "403": {
-                        "description": "Not Authorized",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress, or CUIL/DNI already taken, maybe by a client in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
-                        "description": "Error interno",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1322,14 +1420,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/employee/get_all": {
-            "get": {
+        "/client/delete/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches all employees from the specified workplace, either in laundry or workshop.",
+                "description": "Delete client by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1337,21 +1435,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employee"
+                    "Client"
                 ],
-                "summary": "Get all employees",
+                "summary": "Delete client by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
+                        "description": "Id del cliente",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of employees",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1361,10 +1459,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.EmployeeLaundry"
-                                            }
+                                            "$ref": "#/definitions/models.Client"
                                         }
                                     }
                                 }
@@ -1372,25 +1467,31 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "401": {
-                        "description": "Auth is required",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "403": {
-                        "description": "Not Authorized",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1398,14 +1499,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/employee/get_by_name": {
+        "/client/get_all": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches employees from either laundry or workshop based on the provided name and workplace.",
+                "description": "Get All Clients",
                 "consumes": [
                     "application/json"
                 ],
@@ -1413,28 +1514,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employee"
+                    "Client"
                 ],
-                "summary": "Get Employee By Name",
+                "summary": "Get All Clients",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
-                        "required": true
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Name of the Employee",
-                        "name": "name",
-                        "in": "query",
-                        "required": true
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of laundry employees",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1446,8 +1551,11 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.EmployeeLaundry"
+                                                "$ref": "#/definitions/models.Client"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
                                         }
                                     }
                                 }
@@ -1455,25 +1563,31 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "401": {
-                        "description": "Auth is required",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "403": {
-                        "description": "Not Authorized",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1481,14 +1595,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/employee/update": {
-            "put": {
-                "security": [
+        "/client/get_by_name": {
+            "get": {
+                "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the details of an employee based on the provided data.",
+                "description": "Get Client By Name",
                 "consumes": [
                     "application/json"
                 ],
@@ -1496,48 +1610,129 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employee"
+                    "Client"
                 ],
-                "summary": "Update Employee",
+                "summary": "Get Client By Name",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "query",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Client"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/client/update": {
+            "put": {
+                "security": [
                     {
-                        "description": "Employee data to update",
-                        "name": "employeeUpdate",
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Actualizar un cliente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Client"
+                ],
+                "summary": "Actualizar un cliente",
+                "parameters": [
+                    {
+                        "description": "Cliente a actualizar",
+                        "name": "ClientUpdate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.EmployeeUpdate"
+                            "$ref": "#/definitions/models.ClientUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Empleado editado con éxito",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or Workplace is required",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "401": {
-                        "description": "Auth is required",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "403": {
-                        "description": "Not Authorized",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1548,14 +1743,38 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Version conflict (body has the current state), or CUIL/DNI already taken, maybe by a client in the trash",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Client"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "422": {
-                        "description": "Model Invalid",
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
-                        "description": "Error interno",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1563,14 +1782,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/employee/{id}": {
+        "/client/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get Employee By ID",
+                "description": "Get client by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1578,20 +1797,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Employee"
+                    "Client"
                 ],
-                "summary": "Get Employee By ID",
+                "summary": "Get client by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "ID of Employee",
+                        "description": "Id del cliente",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1599,7 +1811,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Employee obtained successfully",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1609,11 +1821,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.EmployeeLaundry"
+                                            "$ref": "#/definitions/models.Client"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -1623,19 +1841,19 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Auth is required",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "403": {
-                        "description": "Not Authorized",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "404": {
-                        "description": "Employee not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1649,14 +1867,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/expense/create": {
+        "/employee/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Parses the request body to create a new expense entry for either laundry or workshop.",
+                "description": "Creates an employee for either laundry or workshop based on the provided information.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1664,9 +1882,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expense"
+                    "Employee"
                 ],
-                "summary": "Create Expense",
+                "summary": "Create Employee",
                 "parameters": [
                     {
                         "type": "string",
@@ -1676,18 +1894,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Expense information",
-                        "name": "expenseCreate",
+                        "description": "Employee information",
+                        "name": "employeeCreate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ExpenseCreate"
+                            "$ref": "#/definitions/models.EmployeeCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Expense created successfully",
+                        "description": "Employee created",
                         "schema": {
                             "allOf": [
                                 {
@@ -1705,7 +1929,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1722,6 +1946,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "422": {
                         "description": "Model Invalid",
                         "schema": {
@@ -1737,14 +1967,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/expense/delete/{id}": {
+        "/employee/delete/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes an expense based on the provided ID and workplace context.",
+                "description": "Removes an employee from the database based on the provided ID and workplace context.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1752,9 +1982,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expense"
+                    "Employee"
                 ],
-                "summary": "Delete Expense",
+                "summary": "Delete Employee",
                 "parameters": [
                     {
                         "type": "string",
@@ -1765,7 +1995,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the expense",
+                        "description": "ID of the employee",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1773,7 +2003,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Expense deleted successfully",
+                        "description": "Empleado eliminado con éxito",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1796,8 +2026,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Error interno",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1805,14 +2041,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/expense/get_all": {
+        "/employee/get_all": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches all expenses from the specified workplace, either in laundry or workshop.",
+                "description": "Fetches all employees from the specified workplace, either in laundry or workshop.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1820,9 +2056,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expense"
+                    "Employee"
                 ],
-                "summary": "Get all expenses",
+                "summary": "Get all employees",
                 "parameters": [
                     {
                         "type": "string",
@@ -1830,11 +2066,29 @@ const docTemplate = `{
                         "name": "X-Workplace-Token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of expenses",
+                        "description": "List of employees",
                         "schema": {
                             "allOf": [
                                 {
@@ -1846,8 +2100,11 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.ExpenseLaundry"
+                                                "$ref": "#/definitions/models.Employee"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
                                         }
                                     }
                                 }
@@ -1855,7 +2112,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1881,14 +2138,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/expense/get_today": {
+        "/employee/get_by_name": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches all expenses from the specified workplace, either in laundry or workshop, on the current day.",
+                "description": "Fetches employees from either laundry or workshop based on the provided name and workplace.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1896,9 +2153,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expense"
+                    "Employee"
                 ],
-                "summary": "Get expense today",
+                "summary": "Get Employee By Name",
                 "parameters": [
                     {
                         "type": "string",
@@ -1906,11 +2163,18 @@ const docTemplate = `{
                         "name": "X-Workplace-Token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the Employee",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of laundry expenses",
+                        "description": "List of laundry employees",
                         "schema": {
                             "allOf": [
                                 {
@@ -1922,7 +2186,7 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.ExpenseLaundry"
+                                                "$ref": "#/definitions/models.Employee"
                                             }
                                         }
                                     }
@@ -1931,7 +2195,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -1957,14 +2221,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/expense/update": {
+        "/employee/update": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the details of an expense based on the provided data.",
+                "description": "Updates the details of an employee based on the provided data.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1972,9 +2236,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expense"
+                    "Employee"
                 ],
-                "summary": "Update Expense",
+                "summary": "Update Employee",
                 "parameters": [
                     {
                         "type": "string",
@@ -1984,24 +2248,30 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Expense data to update",
-                        "name": "expenseUpdate",
+                        "description": "Employee data to update",
+                        "name": "employeeUpdate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ExpenseUpdate"
+                            "$ref": "#/definitions/models.EmployeeUpdate"
                         }
-                    }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Expense updated successfully",
+                        "description": "Empleado editado con éxito",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid request or Workplace is required",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2018,14 +2288,44 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Employee"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "422": {
                         "description": "Model Invalid",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Error interno",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2033,14 +2333,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/expense/{id}": {
+        "/employee/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get Expense By ID",
+                "description": "Get Employee By ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2048,9 +2348,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Expense"
+                    "Employee"
                 ],
-                "summary": "Get Expense By ID",
+                "summary": "Get Employee By ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -2061,7 +2361,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of Expense",
+                        "description": "ID of Employee",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2069,7 +2369,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Expense obtained successfully",
+                        "description": "Employee obtained successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2079,11 +2379,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.ExpenseLaundry"
+                                            "$ref": "#/definitions/models.Employee"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -2105,7 +2411,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Expense not found",
+                        "description": "Employee not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2119,14 +2425,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/income/create": {
+        "/expense/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Parses the request body to create a new income entry for either laundry or workshop.",
+                "description": "Parses the request body to create a new expense entry for either laundry or workshop.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2134,9 +2440,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Income"
+                    "Expense"
                 ],
-                "summary": "Create Income",
+                "summary": "Create Expense",
                 "parameters": [
                     {
                         "type": "string",
@@ -2146,18 +2452,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Income information",
-                        "name": "incomeCreate",
+                        "description": "Expense information",
+                        "name": "expenseCreate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.IncomeCreate"
+                            "$ref": "#/definitions/models.ExpenseCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Income created successfully",
+                        "description": "Expense created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2192,8 +2504,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Expense not found",
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2213,14 +2525,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/income/delete/{id}": {
+        "/expense/delete/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes an income entry based on the provided ID and workplace context.",
+                "description": "Deletes an expense based on the provided ID and workplace context.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2228,9 +2540,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Income"
+                    "Expense"
                 ],
-                "summary": "Delete Income",
+                "summary": "Delete Expense",
                 "parameters": [
                     {
                         "type": "string",
@@ -2241,7 +2553,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the income",
+                        "description": "ID of the expense",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2249,7 +2561,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Income deleted successfully",
+                        "description": "Expense deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2272,14 +2584,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Expense not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
-                        "description": "Error interno",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2287,14 +2593,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/income/get_all": {
+        "/expense/get_all": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches all incomes from the specified workplace, either in laundry or workshop.",
+                "description": "Fetches all expenses from the specified workplace, either in laundry or workshop.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2302,9 +2608,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Income"
+                    "Expense"
                 ],
-                "summary": "Get all incomes",
+                "summary": "Get all expenses",
                 "parameters": [
                     {
                         "type": "string",
@@ -2312,11 +2618,29 @@ const docTemplate = `{
                         "name": "X-Workplace-Token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of incomes",
+                        "description": "List of expenses",
                         "schema": {
                             "allOf": [
                                 {
@@ -2328,8 +2652,11 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.IncomeLaundry"
+                                                "$ref": "#/definitions/models.Expense"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
                                         }
                                     }
                                 }
@@ -2354,12 +2681,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Expense not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2369,14 +2690,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/income/get_today": {
+        "/expense/get_today": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches all incomes from the specified workplace, either in laundry or workshop, on the current day.",
+                "description": "Fetches all expenses from the specified workplace, either in laundry or workshop, on the current day.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2384,9 +2705,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Income"
+                    "Expense"
                 ],
-                "summary": "Get Income Today",
+                "summary": "Get expense today",
                 "parameters": [
                     {
                         "type": "string",
@@ -2398,7 +2719,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of laundry incomes",
+                        "description": "List of laundry expenses",
                         "schema": {
                             "allOf": [
                                 {
@@ -2410,7 +2731,7 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.IncomeLaundry"
+                                                "$ref": "#/definitions/models.Expense"
                                             }
                                         }
                                     }
@@ -2436,12 +2757,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Expense not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2451,14 +2766,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/income/update": {
+        "/expense/update": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the details of an income based on the provided data.",
+                "description": "Updates the details of an expense based on the provided data.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2466,9 +2781,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Income"
+                    "Expense"
                 ],
-                "summary": "Update Income",
+                "summary": "Update Expense",
                 "parameters": [
                     {
                         "type": "string",
@@ -2478,18 +2793,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Income data to update",
-                        "name": "incomeUpdate",
+                        "description": "Expense data to update",
+                        "name": "expenseUpdate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.IncomeUpdate"
+                            "$ref": "#/definitions/models.ExpenseUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Income updated successfully",
+                        "description": "Expense updated successfully",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2512,10 +2833,22 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Expense not found",
+                    "409": {
+                        "description": "Version conflict, body has the current state",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Expense"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "422": {
@@ -2524,6 +2857,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2533,14 +2872,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/income/{id}": {
+        "/expense/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches income details from either laundry or workshop based on the provided ID and workplace context.",
+                "description": "Get Expense By ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2548,9 +2887,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Income"
+                    "Expense"
                 ],
-                "summary": "Get Income By ID",
+                "summary": "Get Expense By ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -2561,7 +2900,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the income",
+                        "description": "ID of Expense",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2569,7 +2908,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Income details fetched successfully",
+                        "description": "Expense obtained successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2579,11 +2918,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.IncomeLaundry"
+                                            "$ref": "#/definitions/models.Expense"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -2611,7 +2956,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2619,14 +2964,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/movement/create": {
+        "/income/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint creates a new movement type based on the provided JSON payload.",
+                "description": "Creates an income with one line per service at its current price for the vehicle's category (or the general price). The amount is computed: the sum of the lines minus the discount, which requires a discount_reason. 400 if the vehicle does not exist, a service has no current price or the discount exceeds the subtotal.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2634,9 +2979,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movement"
+                    "Income"
                 ],
-                "summary": "Create Movement Type",
+                "summary": "Create Income",
                 "parameters": [
                     {
                         "type": "string",
@@ -2646,18 +2991,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Movement Type Details",
-                        "name": "movementType",
+                        "description": "Income information",
+                        "name": "incomeCreate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MovementTypeCreate"
+                            "$ref": "#/definitions/models.IncomeCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Movement created successfully",
+                        "description": "Income created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2698,8 +3049,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "422": {
-                        "description": "Model invalid",
+                        "description": "Model Invalid",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2713,14 +3070,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/movement/delete/{id}": {
+        "/income/delete/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a movement type based on its ID.",
+                "description": "Deletes an income entry based on the provided ID and workplace context.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2728,9 +3085,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movement"
+                    "Income"
                 ],
-                "summary": "Delete Movement Type",
+                "summary": "Delete Income",
                 "parameters": [
                     {
                         "type": "string",
@@ -2741,7 +3098,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the movement type",
+                        "description": "ID of the income",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2749,7 +3106,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Movement type deleted successfully",
+                        "description": "Income deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2778,8 +3135,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Income of a delivered work order",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Error interno",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2787,14 +3150,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/movement/get_all": {
+        "/income/get_all": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all movement types from either laundry or workshop based on the provided isIncome query parameter.",
+                "description": "Fetches all incomes from the specified workplace, either in laundry or workshop.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2802,9 +3165,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movement"
+                    "Income"
                 ],
-                "summary": "Get all movement types",
+                "summary": "Get all incomes",
                 "parameters": [
                     {
                         "type": "string",
@@ -2814,16 +3177,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "boolean",
-                        "description": "Is income movement type",
-                        "name": "isIncome",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of movement types",
+                        "description": "List of incomes",
                         "schema": {
                             "allOf": [
                                 {
@@ -2835,8 +3209,11 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.MovementTypeLaundry"
+                                                "$ref": "#/definitions/models.Income"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
                                         }
                                     }
                                 }
@@ -2876,14 +3253,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/movement/update": {
-            "put": {
+        "/income/get_today": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint updates a movement type based on the provided JSON payload.",
+                "description": "Fetches all incomes from the specified workplace, either in laundry or workshop, on the current day.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2891,9 +3268,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movement"
+                    "Income"
                 ],
-                "summary": "Update Movement Type",
+                "summary": "Get Income Today",
                 "parameters": [
                     {
                         "type": "string",
@@ -2901,20 +3278,108 @@ const docTemplate = `{
                         "name": "X-Workplace-Token",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Movement Type Details",
-                        "name": "movementType",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of laundry incomes",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Income"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Expense not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/income/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an income. Lines that stay keep the price they were charged at; new ones take the current price. The amount is recomputed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Income"
+                ],
+                "summary": "Update Income",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Income data to update",
+                        "name": "incomeUpdate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MovementTypeUpdate"
+                            "$ref": "#/definitions/models.IncomeUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Movement updated successfully",
+                        "description": "Income updated successfully",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2943,8 +3408,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Version conflict (body has the current state), or income of a delivered work order",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Income"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "422": {
-                        "description": "Model invalid",
+                        "description": "Model Invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -2958,14 +3447,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/movement/{id}": {
+        "/income/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get Movement Type By ID",
+                "description": "Fetches income details from either laundry or workshop based on the provided ID and workplace context.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2973,9 +3462,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movement"
+                    "Income"
                 ],
-                "summary": "Get Movement Type By ID",
+                "summary": "Get Income By ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -2986,7 +3475,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the movement type",
+                        "description": "ID of the income",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2994,7 +3483,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Movement type details",
+                        "description": "Income details fetched successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -3004,11 +3493,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.MovementTypeLaundry"
+                                            "$ref": "#/definitions/models.Income"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -3044,14 +3539,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/create": {
+        "/movement/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new product in the specified workplace.",
+                "description": "This endpoint creates a new movement type based on the provided JSON payload.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3059,9 +3554,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Movement"
                 ],
-                "summary": "Create Product",
+                "summary": "Create Movement Type",
                 "parameters": [
                     {
                         "type": "string",
@@ -3071,20 +3566,38 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Details of the product to create",
-                        "name": "product",
+                        "description": "Movement Type Details",
+                        "name": "movementType",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCreate"
+                            "$ref": "#/definitions/models.MovementTypeCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product created successfully",
+                        "description": "Movement created successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -3105,6 +3618,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "Expense not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "422": {
                         "description": "Model invalid",
                         "schema": {
@@ -3120,14 +3645,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/delete/{id}": {
+        "/movement/delete/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes the given product with the given id.",
+                "description": "Deletes a movement type based on its ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3135,9 +3660,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Movement"
                 ],
-                "summary": "Delete Product",
+                "summary": "Delete Movement Type",
                 "parameters": [
                     {
                         "type": "string",
@@ -3148,7 +3673,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the product",
+                        "description": "ID of the movement type",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3156,7 +3681,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deleted with success",
+                        "description": "Movement type deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -3180,7 +3705,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Expense not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -3194,14 +3719,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/get_all": {
+        "/movement/get_all": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get All Products",
+                "description": "Get all movement types from either laundry or workshop based on the provided isIncome query parameter.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3209,9 +3734,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Movement"
                 ],
-                "summary": "Get All Products",
+                "summary": "Get all movement types",
                 "parameters": [
                     {
                         "type": "string",
@@ -3219,11 +3744,36 @@ const docTemplate = `{
                         "name": "X-Workplace-Token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Is income movement type",
+                        "name": "isIncome",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Products obtained with success",
+                        "description": "List of movement types",
                         "schema": {
                             "allOf": [
                                 {
@@ -3235,8 +3785,11 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.ProductLaundry"
+                                                "$ref": "#/definitions/models.MovementType"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
                                         }
                                     }
                                 }
@@ -3261,6 +3814,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "Expense not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -3270,14 +3829,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/get_by_identifier": {
-            "get": {
+        "/movement/update": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get Products by identifier",
+                "description": "This endpoint updates a movement type based on the provided JSON payload.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3285,9 +3844,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Movement"
                 ],
-                "summary": "Get Products by identifier",
+                "summary": "Update Movement Type",
                 "parameters": [
                     {
                         "type": "string",
@@ -3296,34 +3855,27 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
+                    {
+                        "description": "Movement Type Details",
+                        "name": "movementType",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MovementTypeUpdate"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Identifier of product",
-                        "name": "identifier",
-                        "in": "query",
-                        "required": true
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Products obtained with success",
+                        "description": "Movement updated successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ProductLaundry"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
@@ -3344,8 +3896,44 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Expense not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.MovementType"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Model invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -3353,14 +3941,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/get_by_name": {
+        "/movement/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches products from either laundry or workshop based on the provided name and workplace.",
+                "description": "Get Movement Type By ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -3368,9 +3956,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Movement"
                 ],
-                "summary": "Get Product By Name",
+                "summary": "Get Movement Type By ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -3381,15 +3969,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Name of the Product",
-                        "name": "name",
-                        "in": "query",
+                        "description": "ID of the movement type",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of products",
+                        "description": "Movement type details",
                         "schema": {
                             "allOf": [
                                 {
@@ -3399,14 +3987,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ProductLaundry"
-                                            }
+                                            "$ref": "#/definitions/models.MovementType"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -3427,6 +4018,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "Expense not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -3436,14 +4033,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/update": {
-            "put": {
+        "/permission/get_all": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the given product and returns the updated product.",
+                "description": "Lists the permission catalog",
                 "consumes": [
                     "application/json"
                 ],
@@ -3451,38 +4048,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
-                ],
-                "summary": "Update Product",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Product update details",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ProductUpdate"
-                        }
-                    }
+                    "Permission"
                 ],
+                "summary": "List permissions",
                 "responses": {
                     "200": {
-                        "description": "Product updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "List of permissions",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Permission"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -3497,18 +4085,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "422": {
-                        "description": "Model invalid",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -3518,14 +4094,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/update_stock/{id}": {
-            "put": {
+        "/permission/role/{role}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the stock of a product based on the given method (add, subtract, update).",
+                "description": "Lists the permissions assigned to a role",
                 "consumes": [
                     "application/json"
                 ],
@@ -3533,52 +4109,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Permission"
                 ],
-                "summary": "Update Product Stock",
+                "summary": "Get role permissions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "ID of the product",
-                        "name": "id",
+                        "description": "Role name",
+                        "name": "role",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Method of stock update (add, subtract, update)",
-                        "name": "method",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "Stock update details",
-                        "name": "stock",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.StockUpdate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product stock updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Role permissions",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.RolePermissions"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -3594,13 +4153,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Product not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "422": {
-                        "description": "Model invalid",
+                        "description": "Role not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -3612,16 +4165,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/product/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a product or part by its ID within a specified workplace.",
+                "description": "Replaces the permissions of a role. Only roles below the caller's hierarchy can be changed, and only with permissions the caller's role has.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3629,28 +4180,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Permission"
                 ],
-                "summary": "Get Product By ID",
+                "summary": "Update role permissions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "ID of the product",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Permissions of the role",
+                        "name": "permissions",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RolePermissionsUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product obtained with success",
+                        "description": "Role permissions updated",
                         "schema": {
                             "allOf": [
                                 {
@@ -3660,7 +4213,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.ProductLaundry"
+                                            "$ref": "#/definitions/models.RolePermissions"
                                         }
                                     }
                                 }
@@ -3686,7 +4239,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Expense not found",
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -3700,17 +4259,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase_order/create": {
+        "/product/create": {
             "post": {
                 "security": [
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a purchase order, either for laundry or workshop.",
+                "description": "Creates a new product in the specified workplace.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3718,9 +4274,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchase Order"
+                    "Product"
                 ],
-                "summary": "Create Purchase Order",
+                "summary": "Create Product",
                 "parameters": [
                     {
                         "type": "string",
@@ -3730,32 +4286,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Purchase order creation data",
-                        "name": "purchaseOrderCreate",
+                        "description": "Details of the product to create",
+                        "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PurchaseOrderCreate"
+                            "$ref": "#/definitions/models.ProductCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Purchase order created successfully",
+                        "description": "Product created successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
@@ -3776,6 +4326,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress, or identifier already taken, maybe by a product in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "422": {
                         "description": "Model invalid",
                         "schema": {
@@ -3791,14 +4347,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase_order/delete/{id}": {
+        "/product/delete/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a specific purchase order by its ID.",
+                "description": "Deletes the given product with the given id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3806,9 +4362,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchase Order"
+                    "Product"
                 ],
-                "summary": "Delete Purchase Order",
+                "summary": "Delete Product",
                 "parameters": [
                     {
                         "type": "string",
@@ -3819,7 +4375,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of Purchase Order",
+                        "description": "ID of the product",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3827,7 +4383,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Purchase order deleted successfully",
+                        "description": "Product deleted with success",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -3851,7 +4407,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Purchase order not found",
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Product reserved in work orders",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -3865,17 +4427,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase_order/get_all": {
+        "/product/get_all": {
             "get": {
                 "security": [
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get All Purchase Orders",
+                "description": "Get All Products",
                 "consumes": [
                     "application/json"
                 ],
@@ -3883,9 +4442,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchase Order"
+                    "Product"
                 ],
-                "summary": "Get All Purchase Orders",
+                "summary": "Get All Products",
                 "parameters": [
                     {
                         "type": "string",
@@ -3893,11 +4452,29 @@ const docTemplate = `{
                         "name": "X-Workplace-Token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Purchase Orders obtained with success",
+                        "description": "Products obtained with success",
                         "schema": {
                             "allOf": [
                                 {
@@ -3909,8 +4486,11 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.PurchaseOrderLaundry"
+                                                "$ref": "#/definitions/models.Product"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
                                         }
                                     }
                                 }
@@ -3944,14 +4524,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase_order/update": {
-            "put": {
+        "/product/get_by_identifier": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing purchase order with new details.",
+                "description": "Get Products by identifier",
                 "consumes": [
                     "application/json"
                 ],
@@ -3959,9 +4539,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchase Order"
+                    "Product"
                 ],
-                "summary": "Update Purchase Order",
+                "summary": "Get Products by identifier",
                 "parameters": [
                     {
                         "type": "string",
@@ -3971,20 +4551,33 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Purchase order update data",
-                        "name": "purchaseOrderUpdate",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.PurchaseOrderUpdate"
-                        }
+                        "type": "string",
+                        "description": "Identifier of product",
+                        "name": "identifier",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Purchase order updated successfully",
+                        "description": "Products obtained with success",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Product"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -4005,12 +4598,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "422": {
-                        "description": "Model invalid",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -4020,14 +4607,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase_order/{id}": {
+        "/product/get_by_name": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a specific purchase order by its ID.",
+                "description": "Fetches products from either laundry or workshop based on the provided name and workplace.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4035,9 +4622,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchase Order"
+                    "Product"
                 ],
-                "summary": "Get Purchase Order By ID",
+                "summary": "Get Product By Name",
                 "parameters": [
                     {
                         "type": "string",
@@ -4048,15 +4635,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of Purchase Order",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Name of the Product",
+                        "name": "name",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Laundry order obtained successfully",
+                        "description": "List of products",
                         "schema": {
                             "allOf": [
                                 {
@@ -4066,7 +4653,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.PurchaseOrderLaundry"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Product"
+                                            }
                                         }
                                     }
                                 }
@@ -4091,12 +4681,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Purchase Order not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -4106,14 +4690,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase_product/create": {
-            "post": {
+        "/product/update": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a purchase product, either for laundry or workshop.",
+                "description": "Updates the given product and returns the updated product.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4121,9 +4705,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchase Product"
+                    "Product"
                 ],
-                "summary": "Create Purchase Product",
+                "summary": "Update Product",
                 "parameters": [
                     {
                         "type": "string",
@@ -4133,32 +4717,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Purchase product creation data",
-                        "name": "purchaseProductCreate",
+                        "description": "Product update details",
+                        "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PurchaseProductCreate"
+                            "$ref": "#/definitions/models.ProductUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Purchase product created successfully",
+                        "description": "Product updated successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "body": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.Response"
                         }
                     },
                     "400": {
@@ -4179,58 +4757,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "422": {
-                        "description": "Model is invalid",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Product not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/purchase_product/get_purchase/{purchase_id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get All Products From Purchase Order",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Purchase Product"
-                ],
-                "summary": "Get All Products From Purchase Order",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
-                        "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "ID of Purchase Order",
-                        "name": "purchase_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Products obtained with success",
+                    "409": {
+                        "description": "Version conflict (body has the current state), or identifier already taken, maybe by a product in the trash",
                         "schema": {
                             "allOf": [
                                 {
@@ -4240,36 +4774,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.PurchaseProductLaundry"
-                                            }
+                                            "$ref": "#/definitions/models.Product"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Auth is required",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "Not Authorized",
+                    "422": {
+                        "description": "Model invalid",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Purchase Order not found",
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4283,14 +4802,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase_product/update/{id}": {
+        "/product/update_stock/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the given purchase product and returns a success message.",
+                "description": "Updates the stock of a product based on the given method (add, subtract, update). The stock cannot go below what is reserved by work orders.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4298,9 +4817,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchase Product"
+                    "Product"
                 ],
-                "summary": "Update Purchase Product",
+                "summary": "Update Product Stock",
                 "parameters": [
                     {
                         "type": "string",
@@ -4311,24 +4830,31 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the purchase product",
+                        "description": "ID of the product",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Purchase product update details",
-                        "name": "product",
+                        "type": "string",
+                        "description": "Method of stock update (add, subtract, update)",
+                        "name": "method",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock update details",
+                        "name": "stock",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PurchaseProductUpdate"
+                            "$ref": "#/definitions/models.StockUpdate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Purchase product updated successfully",
+                        "description": "Product stock updated successfully",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4352,13 +4878,19 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Purchase Product not found",
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock below reserved in work orders",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "422": {
-                        "description": "Model is invalid",
+                        "description": "Model invalid",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4372,14 +4904,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase_product/{id}": {
+        "/product/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a specific purchase product by its ID for a given workplace.",
+                "description": "Get a product or part by its ID within a specified workplace.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4387,9 +4919,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Purchase Product"
+                    "Product"
                 ],
-                "summary": "Get Purchase Product By ID",
+                "summary": "Get Product By ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -4400,7 +4932,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the purchase product",
+                        "description": "ID of the product",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4408,7 +4940,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product obtained successfully",
+                        "description": "Product obtained with success",
                         "schema": {
                             "allOf": [
                                 {
@@ -4418,11 +4950,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.PurchaseProductLaundry"
+                                            "$ref": "#/definitions/models.Product"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -4444,7 +4982,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Purchase Product not found",
+                        "description": "Expense not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4456,88 +4994,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/purchase_order/create": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
-                    }
-                ],
-                "description": "Deletes a specific purchase product.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Purchase Product"
-                ],
-                "summary": "Delete Purchase Product",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Workplace Token",
-                        "name": "X-Workplace-Token",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "ID of Purchase Product",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Purchase product deleted successfully",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Auth is required",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "Not Authorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Purchase Product not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/service/create": {
-            "post": {
-                "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a service and returns its ID.",
+                "description": "Creates a purchase order, either for laundry or workshop.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4545,9 +5014,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Service"
+                    "Purchase Order"
                 ],
-                "summary": "Create Service",
+                "summary": "Create Purchase Order",
                 "parameters": [
                     {
                         "type": "string",
@@ -4557,18 +5026,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Service creation data",
-                        "name": "serviceCreate",
+                        "description": "Purchase order creation data",
+                        "name": "purchaseOrderCreate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceCreate"
+                            "$ref": "#/definitions/models.PurchaseOrderCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Service created successfully",
+                        "description": "Purchase order created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4603,8 +5078,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "422": {
-                        "description": "Model is invalid",
+                        "description": "Model invalid",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4618,14 +5099,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/service/delete/{id}": {
+        "/purchase_order/delete/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a service based on the provided ID and workplace context.",
+                "description": "Deletes a specific purchase order by its ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4633,9 +5114,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Service"
+                    "Purchase Order"
                 ],
-                "summary": "Delete Service",
+                "summary": "Delete Purchase Order",
                 "parameters": [
                     {
                         "type": "string",
@@ -4646,7 +5127,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the service",
+                        "description": "ID of Purchase Order",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4654,7 +5135,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Servicio eliminado con éxito",
+                        "description": "Purchase order deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4678,13 +5159,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Service not found",
+                        "description": "Purchase order not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
-                        "description": "Error interno",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4692,14 +5173,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/service/get_all": {
+        "/purchase_order/get_all": {
             "get": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all services from workplace",
+                "description": "Get All Purchase Orders",
                 "consumes": [
                     "application/json"
                 ],
@@ -4707,9 +5191,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Service"
+                    "Purchase Order"
                 ],
-                "summary": "Get all services from workplace",
+                "summary": "Get All Purchase Orders",
                 "parameters": [
                     {
                         "type": "string",
@@ -4717,11 +5201,29 @@ const docTemplate = `{
                         "name": "X-Workplace-Token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Purchase Orders obtained with success",
                         "schema": {
                             "allOf": [
                                 {
@@ -4733,8 +5235,11 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.ServiceLaundry"
+                                                "$ref": "#/definitions/models.PurchaseOrder"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
                                         }
                                     }
                                 }
@@ -4760,7 +5265,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4768,17 +5273,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/service/update": {
+        "/purchase_order/update": {
             "put": {
                 "security": [
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the details of a service based on the provided data.",
+                "description": "Updates an existing purchase order with new details.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4786,9 +5288,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Service"
+                    "Purchase Order"
                 ],
-                "summary": "Update Service",
+                "summary": "Update Purchase Order",
                 "parameters": [
                     {
                         "type": "string",
@@ -4798,18 +5300,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Service data to update",
-                        "name": "serviceUpdate",
+                        "description": "Purchase order update data",
+                        "name": "purchaseOrderUpdate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ServiceUpdate"
+                            "$ref": "#/definitions/models.PurchaseOrderUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Servicio editado con éxito",
+                        "description": "Purchase order updated successfully",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4832,20 +5340,38 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Expense not found",
+                    "409": {
+                        "description": "Version conflict, body has the current state",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.PurchaseOrder"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "422": {
-                        "description": "Model is invalid",
+                        "description": "Model invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
-                        "description": "Error interno",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4853,14 +5379,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/service/{id}": {
+        "/purchase_order/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a service by id",
+                "description": "Retrieves a specific purchase order by its ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4868,9 +5394,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Service"
+                    "Purchase Order"
                 ],
-                "summary": "Get a service by id",
+                "summary": "Get Purchase Order By ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -4881,7 +5407,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the income to get",
+                        "description": "ID of Purchase Order",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4889,7 +5415,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Purchase order obtained successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -4899,11 +5425,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.ServiceLaundry"
+                                            "$ref": "#/definitions/models.PurchaseOrder"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -4925,13 +5457,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Service not found",
+                        "description": "Purchase Order not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -4939,14 +5471,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/supplier/create": {
+        "/purchase_product/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new supplier within the specified workplace.",
+                "description": "Creates a purchase product, either for laundry or workshop.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4954,9 +5486,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Purchase Product"
                 ],
-                "summary": "Create Supplier",
+                "summary": "Create Purchase Product",
                 "parameters": [
                     {
                         "type": "string",
@@ -4966,18 +5498,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Details of the supplier to create",
-                        "name": "supplier",
+                        "description": "Purchase product creation data",
+                        "name": "purchaseProductCreate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.SupplierCreate"
+                            "$ref": "#/definitions/models.PurchaseProductCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Supplier created successfully",
+                        "description": "Purchase product created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -5012,6 +5550,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "422": {
                         "description": "Model is invalid",
                         "schema": {
@@ -5027,14 +5571,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/supplier/delete/{id}": {
-            "delete": {
+        "/purchase_product/get_purchase/{purchase_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a supplier based on the provided ID and workplace context.",
+                "description": "Get All Products From Purchase Order",
                 "consumes": [
                     "application/json"
                 ],
@@ -5042,9 +5586,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Purchase Product"
                 ],
-                "summary": "Delete Supplier",
+                "summary": "Get All Products From Purchase Order",
                 "parameters": [
                     {
                         "type": "string",
@@ -5055,17 +5599,32 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the supplier",
-                        "name": "id",
+                        "description": "ID of Purchase Order",
+                        "name": "purchase_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Supplier deleted with success",
+                        "description": "Products obtained with success",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.PurchaseProduct"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -5087,7 +5646,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Supplier not found",
+                        "description": "Purchase Order not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5101,14 +5660,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/supplier/get_all": {
-            "get": {
+        "/purchase_product/update/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get All Suppliers",
+                "description": "Updates the given purchase product and returns a success message.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5116,9 +5675,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Purchase Product"
                 ],
-                "summary": "Get All Suppliers",
+                "summary": "Update Purchase Product",
                 "parameters": [
                     {
                         "type": "string",
@@ -5126,11 +5685,63 @@ const docTemplate = `{
                         "name": "X-Workplace-Token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the purchase product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Purchase product update details",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseProductUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Suppliers obtained with success",
+                        "description": "Purchase product updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state",
                         "schema": {
                             "allOf": [
                                 {
@@ -5140,30 +5751,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.SupplierLaundry"
-                                            }
+                                            "$ref": "#/definitions/models.PurchaseProduct"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Auth is required",
+                    "422": {
+                        "description": "Model is invalid",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "403": {
-                        "description": "Not Authorized",
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5177,17 +5779,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/supplier/get_by_name": {
+        "/purchase_product/{id}": {
             "get": {
                 "security": [
-                    {
-                        "BearerAuth": []
-                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches suppliers from either laundry or workshop based on the provided name and workplace.",
+                "description": "Retrieves a specific purchase product by its ID for a given workplace.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5195,9 +5794,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Purchase Product"
                 ],
-                "summary": "Get Supplier By Name",
+                "summary": "Get Purchase Product By ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -5208,15 +5807,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Name of the Supplier",
-                        "name": "name",
-                        "in": "query",
+                        "description": "ID of the purchase product",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of suppliers",
+                        "description": "Product obtained successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -5226,14 +5825,17 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.SupplierLaundry"
-                                            }
+                                            "$ref": "#/definitions/models.PurchaseProduct"
                                         }
                                     }
                                 }
                             ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -5254,6 +5856,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "Purchase Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -5261,16 +5869,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/supplier/update": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a supplier's information from the specified workplace.",
+                "description": "Deletes a specific purchase product.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5278,9 +5884,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Purchase Product"
                 ],
-                "summary": "Update Supplier",
+                "summary": "Delete Purchase Product",
                 "parameters": [
                     {
                         "type": "string",
@@ -5290,18 +5896,16 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Supplier information",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.SupplierUpdate"
-                        }
+                        "type": "string",
+                        "description": "ID of Purchase Product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Supplier updated with success",
+                        "description": "Purchase product deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5325,13 +5929,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Supplier not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "422": {
-                        "description": "Model is invalid",
+                        "description": "Purchase Product not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5345,14 +5943,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/supplier/{id}": {
+        "/search": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a supplier by its ID within a specified workplace.",
+                "description": "Searches clients (name, CUIL, DNI, email), vehicles (domain, brand, model) and the workplace's products and suppliers. Accents and case are ignored; hits are ranked by relevance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5360,9 +5958,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Supplier"
+                    "Search"
                 ],
-                "summary": "Get Supplier By ID",
+                "summary": "Global search",
                 "parameters": [
                     {
                         "type": "string",
@@ -5373,15 +5971,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ID of the supplier",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Texto a buscar (mínimo 2 caracteres)",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Máximo de resultados (máximo 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Supplier obtained with success",
+                        "description": "Resultados ordenados por relevancia",
                         "schema": {
                             "allOf": [
                                 {
@@ -5391,7 +5995,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "body": {
-                                            "$ref": "#/definitions/models.SupplierLaundry"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SearchHit"
+                                            }
                                         }
                                     }
                                 }
@@ -5410,14 +6017,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "403": {
-                        "description": "Not Authorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "Supplier not found",
+                    "422": {
+                        "description": "Query too short",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5431,14 +6032,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/create": {
+        "/service/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new user.",
+                "description": "Creates a service and returns its ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5446,25 +6047,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Service"
                 ],
-                "summary": "Create User",
+                "summary": "Create Service",
                 "parameters": [
                     {
-                        "description": "User information",
-                        "name": "userCreate",
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Service creation data",
+                        "name": "serviceCreate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserCreate"
+                            "$ref": "#/definitions/models.ServiceCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Service created successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -5485,8 +6111,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress, or name already taken, maybe by a service in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Model is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5494,14 +6132,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/create": {
-            "post": {
+        "/service/delete/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new Vehicle",
+                "description": "Deletes a service based on the provided ID and workplace context.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5509,23 +6147,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Vehicle"
+                    "Service"
                 ],
-                "summary": "Create Vehicle",
+                "summary": "Delete Service",
                 "parameters": [
                     {
-                        "description": "Vehicle information",
-                        "name": "vehicleCreate",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.VehicleCreate"
-                        }
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the service",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Servicio eliminado con éxito",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5548,14 +6191,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "422": {
-                        "description": "Model is invalid",
+                    "404": {
+                        "description": "Service not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Error interno",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5563,14 +6206,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/delete/{id}": {
-            "delete": {
+        "/service/get_all": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete Vehicle with the given ID.",
+                "description": "Get all services from workplace",
                 "consumes": [
                     "application/json"
                 ],
@@ -5578,23 +6221,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Vehicle"
+                    "Service"
                 ],
-                "summary": "Delete Vehicle",
+                "summary": "Get all services from workplace",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID of Vehicle",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Vehicle deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Service"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -5615,12 +6294,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "404": {
-                        "description": "Vehicle not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5630,29 +6303,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/get_all": {
+        "/service/prices/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches all vehicles stored in the system.",
+                "description": "Lists the prices of a service by vehicle category (empty is the general price), latest effective date first. The current price is the latest one already in effect; later ones are scheduled.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Vehicle"
+                    "Service"
+                ],
+                "summary": "Service price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the service",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all vehicles",
                 "responses": {
                     "200": {
-                        "description": "List of vehicles retrieved successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Vehicle"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ServicePrice"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -5673,8 +6377,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5682,42 +6392,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/get_by_client/{client_id}": {
-            "get": {
+        "/service/set_price/{id}": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Fetches all vehicles that belong to the given client.",
+                "description": "Adds a price to the service history, effective now or from effective_from (which cannot be in the past). With vehicle_category the price applies only to vehicles of that category, and only in workplaces with category_prices; without it, it is the general price. Existing incomes keep the price they were charged.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Vehicle"
+                    "Service"
                 ],
-                "summary": "Get Vehicles By Client ID",
+                "summary": "Set service price",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Client ID",
-                        "name": "client_id",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the service",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New price",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ServicePriceCreate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of vehicles retrieved successfully",
+                        "description": "Price created, body has its ID",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Vehicle"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad Request, or vehicle_category in a workplace without category prices",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5734,63 +6472,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/vehicle/get_by_domain": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Fetches all vehicles that contain the given domain.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Vehicle"
-                ],
-                "summary": "Get Vehicles By Domain",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Domain string",
-                        "name": "domain",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "List of vehicles retrieved successfully",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Vehicle"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Service not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "401": {
-                        "description": "Auth is required",
+                    "409": {
+                        "description": "The service already has a price from that date",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
-                    "403": {
-                        "description": "Not Authorized",
+                    "422": {
+                        "description": "Model is invalid",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5804,14 +6499,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/update": {
+        "/service/update": {
             "put": {
                 "security": [
+                    {
+                        "BearerAuth": []
+                    },
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update Vehicle with the given ID.",
+                "description": "Updates the details of a service based on the provided data.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5819,23 +6517,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Vehicle"
+                    "Service"
                 ],
-                "summary": "Update Vehicle",
+                "summary": "Update Service",
                 "parameters": [
                     {
-                        "description": "VehicleUpdate",
-                        "name": "vehicleUpdate",
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Service data to update",
+                        "name": "serviceUpdate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.VehicleUpdate"
+                            "$ref": "#/definitions/models.ServiceUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Vehicle updated successfully",
+                        "description": "Servicio editado con éxito",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5859,19 +6570,43 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Vehicle not found",
+                        "description": "Expense not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "409": {
+                        "description": "Version conflict (body has the current state), or name already taken, maybe by a service in the trash",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Service"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "422": {
                         "description": "Model is invalid",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Error interno",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5879,14 +6614,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/{id}": {
+        "/service/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get Vehicle By ID",
+                "description": "Get a service by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -5894,13 +6629,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Vehicle"
+                    "Service"
                 ],
-                "summary": "Get Vehicle By ID",
+                "summary": "Get a service by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID of Vehicle",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the income to get",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5908,13 +6650,31 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Vehicle retrieved successfully",
-                        "schema": {
-                            "$ref": "#/definitions/models.Vehicle"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Service"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5932,7 +6692,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Vehicle not found",
+                        "description": "Service not found",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
@@ -5946,14 +6706,188 @@ const docTemplate = `{
                 }
             }
         },
-        "/workplace/get_all": {
+        "/supplier/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new supplier within the specified workplace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Create Supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Details of the supplier to create",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Model is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a supplier based on the provided ID and workplace context.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Delete Supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the supplier",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier deleted with success",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/get_all": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Workplace GetAll required auth token",
+                "description": "Get All Suppliers",
                 "consumes": [
                     "application/json"
                 ],
@@ -5961,12 +6895,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workplace"
+                    "Supplier"
+                ],
+                "summary": "Get All Suppliers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Workplace GetAll",
                 "responses": {
                     "200": {
-                        "description": "Workplaces obtenidos con éxito",
+                        "description": "Suppliers obtained with success",
                         "schema": {
                             "allOf": [
                                 {
@@ -5978,8 +6939,11 @@ const docTemplate = `{
                                         "body": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Workplace"
+                                                "$ref": "#/definitions/models.Supplier"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
                                         }
                                     }
                                 }
@@ -6005,57 +6969,4148 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AttendanceCreate": {
-            "type": "object",
-            "required": [
-                "amount",
-                "date",
-                "employee_id"
-            ],
-            "properties": {
-                "amount": {
-                    "type": "number",
-                    "example": 1234.56
-                },
-                "date": {
-                    "type": "string",
-                    "example": "2022-01-01"
-                },
-                "employee_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
-                },
-                "hours": {
-                    "type": "integer",
-                    "maximum": 24
-                },
-                "is_holiday": {
-                    "type": "boolean",
-                    "default": false,
-                    "example": false
-                },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "presente",
-                        "tarde",
-                        "parcial",
+        },
+        "/supplier/get_by_name": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches suppliers from either laundry or workshop based on the provided name and workplace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Get Supplier By Name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the Supplier",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of suppliers",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Supplier"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a supplier's information from the specified workplace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Update Supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier information",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier updated with success",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Model is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a supplier by its ID within a specified workplace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Supplier"
+                ],
+                "summary": "Get Supplier By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the supplier",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Supplier obtained with success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{entity}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the soft-deleted records of an entity type visible from the workplace. Clients and vehicles are global. Accepts the same filters as the entity's get_all plus deleted_at_from / deleted_at_to.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "attendance",
+                            "client",
+                            "employee",
+                            "expense",
+                            "income",
+                            "movement_type",
+                            "product",
+                            "purchase_order",
+                            "purchase_product",
+                            "service",
+                            "supplier",
+                            "vehicle"
+                        ],
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "entity",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted records, most recent first",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{entity}/purge/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently deletes a record that is in the trash, together with its child rows. Records still referenced by others, even from the trash, cannot be purged. This cannot be undone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Purge from trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "entity",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the record",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Record permanently deleted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not in trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Product reserved in work orders, or record still referenced",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{entity}/restore/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores a soft-deleted record. A client's vehicles and a purchase order's lines deleted with it are restored too. Records whose parent is still in the trash cannot be restored until the parent is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore from trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "entity",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the record",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Record restored",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not in trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "A parent record is still in trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/activate/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reactivates a deactivated user whose role is below the caller's",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reactivate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.UserDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new user. The role must exist in the roles table and be below the caller's role hierarchy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create User",
+                "parameters": [
+                    {
+                        "description": "User information",
+                        "name": "userCreate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, or role does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/deactivate/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deactivates a user whose role is below the caller's: it can no longer log in and its tokens stop working",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Deactivate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.UserDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a user whose role is below the caller's",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/get_all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists users. Filters: username, first_name, last_name, email, role, active, created_at_from / created_at_to.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.UserDTO"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the profile of the logged user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.UserDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the profile of the logged user; the id of the body is ignored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update current user",
+                "parameters": [
+                    {
+                        "description": "Profile data",
+                        "name": "userUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.UserDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email in use",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the profile of a user whose role is below the caller's",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "description": "Profile data",
+                        "name": "userUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.UserDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email in use",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update_role/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the role of a user. Both the current and the new role must be below the caller's role hierarchy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change user role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "roleUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserRoleUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.UserDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the user",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.UserDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/categories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the vehicle categories. Services can have a price per category; a vehicle without category pays the general price.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle"
+                ],
+                "summary": "List vehicle categories",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new Vehicle",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle"
+                ],
+                "summary": "Create Vehicle",
+                "parameters": [
+                    {
+                        "description": "Vehicle information",
+                        "name": "vehicleCreate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VehicleCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress, or domain already taken, maybe by a vehicle in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Model is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete Vehicle with the given ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle"
+                ],
+                "summary": "Delete Vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of Vehicle",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/get_all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches all vehicles stored in the system.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle"
+                ],
+                "summary": "Get all vehicles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of vehicles retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Vehicle"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/get_by_client/{client_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches all vehicles that belong to the given client.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle"
+                ],
+                "summary": "Get Vehicles By Client ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "client_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of vehicles retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Vehicle"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/get_by_domain": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches all vehicles that contain the given domain.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle"
+                ],
+                "summary": "Get Vehicles By Domain",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Domain string",
+                        "name": "domain",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of vehicles retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Vehicle"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update Vehicle with the given ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle"
+                ],
+                "summary": "Update Vehicle",
+                "parameters": [
+                    {
+                        "description": "VehicleUpdate",
+                        "name": "vehicleUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VehicleUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict (body has the current state), or domain already taken, maybe by a vehicle in the trash",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.Vehicle"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Model is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Vehicle By ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicle"
+                ],
+                "summary": "Get Vehicle By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of Vehicle",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Vehicle"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/add_part/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a part line (product, quantity, sale price per unit) to a work order and reserves the quantity from the product's stock. The stock is consumed when the order is delivered and the lines are added to the income. 400 if the product has not enough stock available (stock minus reserved); 409 if the product is already on the order or the order was delivered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Add Work Order Part",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the work order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Part line",
+                        "name": "workOrderPartCreate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkOrderPartCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET de la orden; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Part added, body is the line ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Work order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state; part already on the order; or order delivered",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.WorkOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Model Invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Opens a work order in status recibido. 400 if the vehicle does not belong to the client or the employee is not from the workplace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Create Work Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Work order information",
+                        "name": "workOrderCreate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkOrderCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Work order created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Model Invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a work order to the trash. 409 if it was already delivered or still has part lines.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Delete Work Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the work order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Work order deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Work order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Order already delivered",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Error interno",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/deliver/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delivers a work order in status listo: consumes the reserved parts from stock, creates the income for the order's client, vehicle and employee with the given services (priced as in /income/create) plus the part lines, and moves the order to entregado, all in one transaction. Returns the income ID. 409 if the order is not listo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Deliver Work Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the work order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Income data",
+                        "name": "workOrderDeliver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkOrderDeliver"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Work order delivered, body is the income ID",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Work order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state; or order not ready",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.WorkOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Model Invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/get_all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches the work orders of the workplace, newest first. Filters: status, client_id, vehicle_id, employee_id, description, status_changed_at, created_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Get all work orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Página, desde 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Elementos por página (máximo 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos de orden separados por coma, con - para descendente",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of work orders",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.WorkOrder"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.Pagination"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/remove_part/{id}/{part_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a part line from a work order and releases its reservation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Remove Work Order Part",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the work order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the part line",
+                        "name": "part_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET de la orden. * quita la línea sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Part removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Work order or part not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state; or order delivered",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.WorkOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/status/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a work order to another status and records the transition. Allowed: recibido → diagnostico; diagnostico → esperando_repuestos, en_reparacion; esperando_repuestos → en_reparacion; en_reparacion → esperando_repuestos, listo; listo → en_reparacion. entregado is reached only through /work_order/deliver. 409 for any other change.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Change Work Order Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the work order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "workOrderStatusUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkOrderStatusUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Work order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state; or transition not allowed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.WorkOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Model Invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the employee, description and diagnosis of a work order. Client and vehicle cannot change. 409 if the order was already delivered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Update Work Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Work order data to update",
+                        "name": "workOrderUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkOrderUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Work order updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Work order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state; or order already delivered",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.WorkOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Model Invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/update_part/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the quantity or sale price of a part line. The reservation is adjusted by the difference; 400 if the product has not enough stock available for the increase.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Update Work Order Part",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the work order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Part line",
+                        "name": "workOrderPartUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkOrderPartUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag del GET de la orden; alternativa al campo version del body, uno de los dos es obligatorio. * actualiza sin controlar la versión",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Part updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Work order or part not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Version conflict, body has the current state; or order delivered",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.WorkOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Model Invalid",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "428": {
+                        "description": "Version missing: send version or If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/work_order/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches a work order of the workplace with its status history, oldest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "WorkOrder"
+                ],
+                "summary": "Get Work Order By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workplace Token",
+                        "name": "X-Workplace-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the work order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Work order fetched successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "$ref": "#/definitions/models.WorkOrder"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versión del registro, para enviar en If-Match"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized, or work orders are disabled in the workplace",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Work order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/workplace/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new workplace (business line or branch).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workplace"
+                ],
+                "summary": "Create Workplace",
+                "parameters": [
+                    {
+                        "description": "Workplace information",
+                        "name": "workplaceCreate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkplaceCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Clave única del intento: si se repite con el mismo body se devuelve la respuesta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Workplace creado con éxito",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Idempotency-Key in use by a request still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/workplace/get_all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Workplace GetAll required auth token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workplace"
+                ],
+                "summary": "Workplace GetAll",
+                "responses": {
+                    "200": {
+                        "description": "Workplaces obtenidos con éxito",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "body": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Workplace"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Auth is required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not Authorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Attendance": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "employee": {
+                    "$ref": "#/definitions/models.Employee"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "integer",
+                    "maximum": 24
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_holiday": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "presente",
+                        "tarde",
+                        "parcial",
+                        "ausente"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workplace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AttendanceCreate": {
+            "type": "object",
+            "required": [
+                "amount",
+                "date",
+                "employee_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1234.56
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2022-01-01"
+                },
+                "employee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "hours": {
+                    "type": "integer",
+                    "maximum": 24
+                },
+                "is_holiday": {
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "presente",
+                        "tarde",
+                        "parcial",
+                        "ausente"
+                    ]
+                }
+            }
+        },
+        "models.AttendanceUpdate": {
+            "type": "object",
+            "required": [
+                "amount",
+                "date",
+                "employee_id",
+                "id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1234.56
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2022-01-01"
+                },
+                "employee_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "hours": {
+                    "type": "integer",
+                    "maximum": 24
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "is_holiday": {
+                    "type": "boolean",
+                    "default": false,
+                    "example": false
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "presente",
+                        "tarde",
+                        "parcial",
                         "ausente"
                     ]
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "client"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "workplace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuthLogin": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuthTokens": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer",
+                    "example": 900
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "models.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "models.Client": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "cuil": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "dni": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vehicles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Vehicle"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ClientCreate": {
+            "type": "object",
+            "required": [
+                "cuil",
+                "email",
+                "first_name",
+                "last_name"
+            ],
+            "properties": {
+                "cuil": {
+                    "type": "string"
+                },
+                "dni": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ClientUpdate": {
+            "type": "object",
+            "required": [
+                "cuil",
+                "email",
+                "first_name",
+                "id",
+                "last_name"
+            ],
+            "properties": {
+                "cuil": {
+                    "type": "string"
+                },
+                "dni": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DateBetween": {
+            "type": "object",
+            "required": [
+                "date_from",
+                "date_to"
+            ],
+            "properties": {
+                "date_from": {
+                    "type": "string",
+                    "example": "2022-01-01"
+                },
+                "date_to": {
+                    "type": "string",
+                    "example": "2022-01-01"
+                }
+            }
+        },
+        "models.Employee": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workplace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EmployeeCreate": {
+            "type": "object",
+            "required": [
+                "address",
+                "email",
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EmployeeUpdate": {
+            "type": "object",
+            "required": [
+                "address",
+                "email",
+                "id",
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Expense": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movement_type": {
+                    "$ref": "#/definitions/models.MovementType"
+                },
+                "movement_type_id": {
+                    "type": "string"
+                },
+                "supplier": {
+                    "$ref": "#/definitions/models.Supplier"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workplace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExpenseCreate": {
+            "type": "object",
+            "required": [
+                "amount",
+                "details",
+                "movement_type_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "movement_type_id": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExpenseUpdate": {
+            "type": "object",
+            "required": [
+                "amount",
+                "details",
+                "movement_type_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movement_type_id": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Income": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "client": {
+                    "$ref": "#/definitions/models.Client"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "discount_reason": {
+                    "type": "string"
+                },
+                "employee": {
+                    "$ref": "#/definitions/models.Employee"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movement_type": {
+                    "$ref": "#/definitions/models.MovementType"
+                },
+                "movement_type_id": {
+                    "type": "string"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IncomeService"
+                    }
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "ticket": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vehicle": {
+                    "$ref": "#/definitions/models.Vehicle"
+                },
+                "vehicle_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workplace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IncomeCreate": {
+            "type": "object",
+            "required": [
+                "client_id",
+                "details",
+                "movement_type_id",
+                "services_id",
+                "ticket",
+                "vehicle_id"
+            ],
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "discount_reason": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "movement_type_id": {
+                    "type": "string"
+                },
+                "services_id": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ticket": {
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IncomeService": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "income": {
+                    "$ref": "#/definitions/models.Income"
+                },
+                "income_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "service": {
+                    "$ref": "#/definitions/models.Service"
+                },
+                "service_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IncomeUpdate": {
+            "type": "object",
+            "required": [
+                "client_id",
+                "movement_type_id",
+                "services_id",
+                "ticket",
+                "vehicle_id"
+            ],
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "discount_reason": {
+                    "type": "string"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "movement_type_id": {
+                    "type": "string"
+                },
+                "services_id": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ticket": {
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LoginAttempt": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string",
+                    "example": "invalid_credentials"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MovementType": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_income": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workplace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MovementTypeCreate": {
+            "type": "object",
+            "properties": {
+                "is_income": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MovementTypeUpdate": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "is_income": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Pagination": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Permission": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Product": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "identifier": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reserved": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workplace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductCreate": {
+            "type": "object",
+            "required": [
+                "identifier",
+                "name"
+            ],
+            "properties": {
+                "identifier": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductUpdate": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "identifier": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.AttendanceLaundry": {
+        "models.PurchaseOrder": {
             "type": "object",
             "properties": {
                 "amount": {
@@ -6064,241 +11119,320 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
-                "date": {
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
                     "type": "string"
                 },
-                "employee": {
-                    "$ref": "#/definitions/models.EmployeeLaundry"
+                "order_date": {
+                    "type": "string"
                 },
-                "employee_id": {
+                "order_number": {
                     "type": "string"
                 },
-                "hours": {
-                    "type": "integer",
-                    "maximum": 24
+                "purchase_products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseProduct"
+                    }
                 },
-                "id": {
+                "supplier": {
+                    "$ref": "#/definitions/models.Supplier"
+                },
+                "supplier_id": {
                     "type": "string"
                 },
-                "is_holiday": {
-                    "type": "boolean"
+                "updated_at": {
+                    "type": "string"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "presente",
-                        "tarde",
-                        "parcial",
-                        "ausente"
-                    ]
+                "version": {
+                    "type": "integer"
                 },
-                "updated_at": {
+                "workplace_id": {
                     "type": "string"
                 }
             }
         },
-        "models.AttendanceUpdate": {
+        "models.PurchaseOrderCreate": {
             "type": "object",
             "required": [
                 "amount",
-                "date",
-                "employee_id",
-                "id"
+                "order_date",
+                "order_number",
+                "purchase_products"
             ],
             "properties": {
                 "amount": {
-                    "type": "number",
-                    "example": 1234.56
-                },
-                "date": {
-                    "type": "string",
-                    "example": "2022-01-01"
-                },
-                "employee_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "type": "number"
                 },
-                "hours": {
-                    "type": "integer",
-                    "maximum": 24
+                "order_date": {
+                    "type": "string"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "order_number": {
+                    "type": "string"
                 },
-                "is_holiday": {
-                    "type": "boolean",
-                    "default": false,
-                    "example": false
+                "purchase_products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseProductCreate"
+                    }
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "presente",
-                        "tarde",
-                        "parcial",
-                        "ausente"
-                    ]
+                "supplier_id": {
+                    "type": "string"
                 }
             }
         },
-        "models.AuthLogin": {
+        "models.PurchaseOrderUpdate": {
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "amount",
+                "id",
+                "order_date",
+                "order_number",
+                "purchase_products"
             ],
             "properties": {
-                "password": {
+                "amount": {
+                    "type": "number"
+                },
+                "id": {
                     "type": "string"
                 },
-                "username": {
+                "order_date": {
+                    "type": "string"
+                },
+                "order_number": {
+                    "type": "string"
+                },
+                "purchase_products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseProductUpdate"
+                    }
+                },
+                "supplier_id": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Client": {
+        "models.PurchaseProduct": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "cuil": {
-                    "type": "string"
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
                 },
-                "dni": {
+                "expired_at": {
                     "type": "string"
                 },
-                "email": {
+                "id": {
                     "type": "string"
                 },
-                "first_name": {
-                    "type": "string"
+                "product": {
+                    "$ref": "#/definitions/models.Product"
                 },
-                "id": {
+                "product_id": {
                     "type": "string"
                 },
-                "last_name": {
+                "purchase_order": {
+                    "$ref": "#/definitions/models.PurchaseOrder"
+                },
+                "purchase_order_id": {
                     "type": "string"
                 },
+                "quantity": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "type": "number"
+                },
+                "unit_price": {
+                    "type": "number"
+                },
                 "updated_at": {
                     "type": "string"
                 },
-                "vehicles": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Vehicle"
-                    }
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.ClientCreate": {
+        "models.PurchaseProductCreate": {
             "type": "object",
             "required": [
-                "cuil",
-                "email",
-                "first_name",
-                "last_name"
+                "product_id",
+                "quantity",
+                "unit_price"
             ],
             "properties": {
-                "cuil": {
+                "expired_at": {
                     "type": "string"
                 },
-                "dni": {
+                "product_id": {
                     "type": "string"
                 },
-                "email": {
+                "purchase_order_id": {
                     "type": "string"
                 },
-                "first_name": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer"
                 },
-                "last_name": {
-                    "type": "string"
+                "unit_price": {
+                    "type": "number"
                 }
             }
         },
-        "models.ClientUpdate": {
+        "models.PurchaseProductUpdate": {
             "type": "object",
             "required": [
-                "cuil",
-                "email",
-                "first_name",
                 "id",
-                "last_name"
+                "product_id",
+                "quantity",
+                "unit_price"
             ],
             "properties": {
-                "cuil": {
+                "expired_at": {
                     "type": "string"
                 },
-                "dni": {
+                "id": {
                     "type": "string"
                 },
-                "email": {
+                "product_id": {
                     "type": "string"
                 },
-                "first_name": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "unit_price": {
+                    "type": "number"
                 },
-                "last_name": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
                     "type": "string"
                 }
             }
         },
-        "models.DateBetween": {
+        "models.ResetPasswordRequest": {
             "type": "object",
             "required": [
-                "date_from",
-                "date_to"
+                "new_password",
+                "token"
             ],
             "properties": {
-                "date_from": {
+                "new_password": {
                     "type": "string",
-                    "example": "2022-01-01"
+                    "minLength": 8
                 },
-                "date_to": {
-                    "type": "string",
-                    "example": "2022-01-01"
+                "token": {
+                    "type": "string"
                 }
             }
         },
-        "models.EmployeeCreate": {
+        "models.Response": {
+            "type": "object",
+            "properties": {
+                "body": {},
+                "code": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "status": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.RolePermissions": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RolePermissionsUpdate": {
             "type": "object",
             "required": [
-                "address",
-                "email",
-                "name",
-                "phone"
+                "permissions"
             ],
             "properties": {
-                "address": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.SearchHit": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 },
-                "email": {
+                "score": {
+                    "type": "number"
+                },
+                "subtitle": {
                     "type": "string"
                 },
-                "name": {
+                "title": {
                     "type": "string"
                 },
-                "phone": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "models.EmployeeLaundry": {
+        "models.Service": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
+                "category_prices": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
                 },
                 "id": {
                     "type": "string"
@@ -6306,821 +11440,717 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "phone": {
-                    "type": "string"
+                "price": {
+                    "description": "Price es el precio general vigente (ver ServicePrice); nil si nunca tuvo\nuno. CategoryPrices tiene los vigentes por categoría de vehículo.",
+                    "type": "number"
                 },
                 "updated_at": {
                     "type": "string"
-                }
-            }
-        },
-        "models.EmployeeUpdate": {
-            "type": "object",
-            "required": [
-                "address",
-                "email",
-                "id",
-                "name",
-                "phone"
-            ],
-            "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "version": {
+                    "type": "integer"
                 },
-                "phone": {
+                "workplace_id": {
                     "type": "string"
                 }
             }
         },
-        "models.ExpenseCreate": {
+        "models.ServiceCreate": {
             "type": "object",
             "required": [
-                "amount",
-                "details",
-                "movement_type_id"
+                "name"
             ],
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "details": {
-                    "type": "string"
-                },
-                "movement_type_id": {
+                "name": {
                     "type": "string"
                 },
-                "supplier_id": {
-                    "type": "string"
+                "price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1500
                 }
             }
         },
-        "models.ExpenseLaundry": {
+        "models.ServicePrice": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "details": {
+                "effective_from": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "movement_type_id": {
-                    "type": "string"
-                },
-                "movement_type_laundry": {
-                    "$ref": "#/definitions/models.MovementTypeLaundry"
-                },
-                "supplier": {
-                    "$ref": "#/definitions/models.SupplierLaundry"
+                "price": {
+                    "type": "number"
                 },
-                "supplier_id": {
+                "service_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "vehicle_category": {
                     "type": "string"
                 }
             }
         },
-        "models.ExpenseUpdate": {
+        "models.ServicePriceCreate": {
             "type": "object",
-            "required": [
-                "amount",
-                "details",
-                "movement_type_id"
-            ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "effective_from": {
+                    "description": "EffectiveFrom es desde cuándo rige; si se omite, desde ahora.",
+                    "type": "string",
+                    "example": "2026-11-01T00:00:00-03:00"
                 },
-                "details": {
-                    "type": "string"
+                "price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1800
                 },
+                "vehicle_category": {
+                    "description": "VehicleCategory es la categoría a la que aplica; vacío es el precio general.",
+                    "type": "string",
+                    "example": "camioneta"
+                }
+            }
+        },
+        "models.ServiceUpdate": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
                 "id": {
                     "type": "string"
                 },
-                "movement_type_id": {
+                "name": {
                     "type": "string"
                 },
-                "supplier_id": {
-                    "type": "string"
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.IncomeCreate": {
+        "models.StockUpdate": {
             "type": "object",
             "required": [
-                "amount",
-                "client_id",
-                "details",
-                "movement_type_id",
-                "services_id",
-                "ticket",
-                "vehicle_id"
+                "stock"
             ],
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "client_id": {
-                    "type": "string"
-                },
-                "details": {
-                    "type": "string"
-                },
-                "employee_id": {
-                    "type": "string"
-                },
-                "movement_type_id": {
-                    "type": "string"
-                },
-                "services_id": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "ticket": {
-                    "type": "string"
-                },
-                "vehicle_id": {
-                    "type": "string"
+                "stock": {
+                    "type": "integer"
                 }
             }
         },
-        "models.IncomeLaundry": {
+        "models.Supplier": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "client": {
-                    "$ref": "#/definitions/models.Client"
-                },
-                "client_id": {
+                "address": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "details": {
-                    "type": "string"
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
                 },
-                "employee_id": {
+                "email": {
                     "type": "string"
                 },
-                "employee_laundry": {
-                    "$ref": "#/definitions/models.EmployeeLaundry"
-                },
                 "id": {
                     "type": "string"
                 },
-                "movement_type_id": {
+                "name": {
                     "type": "string"
                 },
-                "movement_type_laundry": {
-                    "$ref": "#/definitions/models.MovementTypeLaundry"
-                },
-                "ticket": {
+                "phone": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "vehicle": {
-                    "$ref": "#/definitions/models.Vehicle"
+                "version": {
+                    "type": "integer"
                 },
-                "vehicle_id": {
+                "workplace_id": {
                     "type": "string"
                 }
             }
         },
-        "models.IncomeUpdate": {
+        "models.SupplierCreate": {
             "type": "object",
             "required": [
-                "amount",
-                "client_id",
-                "movement_type_id",
-                "services_id",
-                "ticket",
-                "vehicle_id"
+                "name"
             ],
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "client_id": {
-                    "type": "string"
-                },
-                "details": {
-                    "type": "string"
-                },
-                "employee_id": {
-                    "type": "string"
-                },
-                "id": {
+                "address": {
                     "type": "string"
                 },
-                "movement_type_id": {
+                "email": {
                     "type": "string"
                 },
-                "services_id": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "ticket": {
+                "name": {
                     "type": "string"
                 },
-                "vehicle_id": {
+                "phone": {
                     "type": "string"
                 }
             }
         },
-        "models.MovementTypeCreate": {
+        "models.SupplierUpdate": {
             "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
             "properties": {
-                "is_income": {
-                    "type": "boolean"
-                },
-                "name": {
+                "address": {
                     "type": "string"
-                }
-            }
-        },
-        "models.MovementTypeLaundry": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                },
+                "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_income": {
-                    "type": "boolean"
-                },
                 "name": {
                     "type": "string"
                 },
-                "updated_at": {
+                "phone": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.MovementTypeUpdate": {
+        "models.UserCreate": {
             "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "password",
+                "role",
+                "username"
+            ],
             "properties": {
-                "id": {
+                "email": {
                     "type": "string"
                 },
-                "is_income": {
-                    "type": "boolean"
+                "first_name": {
+                    "type": "string"
                 },
-                "name": {
+                "last_name": {
                     "type": "string"
-                }
-            }
-        },
-        "models.ProductCreate": {
-            "type": "object",
-            "required": [
-                "identifier",
-                "name"
-            ],
-            "properties": {
-                "identifier": {
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
                     "type": "string"
                 },
-                "name": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "models.ProductLaundry": {
+        "models.UserDTO": {
             "type": "object",
             "properties": {
+                "active": {
+                    "type": "boolean"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "email": {
                     "type": "string"
                 },
-                "identifier": {
+                "first_name": {
                     "type": "string"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "stock": {
-                    "type": "integer"
+                "last_name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "models.ProductUpdate": {
+        "models.UserRoleUpdate": {
             "type": "object",
             "required": [
-                "id",
-                "name"
+                "role"
             ],
             "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "identifier": {
-                    "type": "string"
-                },
-                "name": {
+                "role": {
                     "type": "string"
                 }
             }
         },
-        "models.PurchaseOrderCreate": {
+        "models.UserUpdate": {
             "type": "object",
             "required": [
-                "amount",
-                "order_date",
-                "order_number",
-                "purchase_products"
+                "email",
+                "first_name",
+                "id",
+                "last_name",
+                "username"
             ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "email": {
+                    "type": "string"
                 },
-                "order_date": {
+                "first_name": {
                     "type": "string"
                 },
-                "order_number": {
+                "id": {
                     "type": "string"
                 },
-                "purchase_products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.PurchaseProductCreate"
-                    }
+                "last_name": {
+                    "type": "string"
                 },
-                "supplier_id": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "models.PurchaseOrderLaundry": {
+        "models.Vehicle": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "created_at": {
+                "brand": {
                     "type": "string"
                 },
-                "id": {
+                "category": {
+                    "type": "string",
+                    "example": "auto"
+                },
+                "client": {
+                    "$ref": "#/definitions/models.Client"
+                },
+                "client_id": {
                     "type": "string"
                 },
-                "order_date": {
+                "color": {
                     "type": "string"
                 },
-                "order_number": {
+                "created_at": {
                     "type": "string"
                 },
-                "purchase_products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.PurchaseProductLaundry"
-                    }
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
                 },
-                "supplier": {
-                    "$ref": "#/definitions/models.SupplierLaundry"
+                "domain": {
+                    "type": "string"
                 },
-                "supplier_id": {
+                "id": {
+                    "type": "string"
+                },
+                "model": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "string"
                 }
             }
         },
-        "models.PurchaseOrderUpdate": {
+        "models.VehicleCreate": {
             "type": "object",
             "required": [
-                "amount",
-                "id",
-                "order_date",
-                "order_number",
-                "purchase_products"
+                "brand",
+                "client_id",
+                "color",
+                "domain"
             ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "brand": {
+                    "type": "string",
+                    "example": "Toyota"
                 },
-                "id": {
-                    "type": "string"
+                "category": {
+                    "type": "string",
+                    "example": "auto"
                 },
-                "order_date": {
-                    "type": "string"
+                "client_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "order_number": {
-                    "type": "string"
+                "color": {
+                    "type": "string",
+                    "example": "Red"
                 },
-                "purchase_products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.PurchaseProductUpdate"
-                    }
+                "domain": {
+                    "type": "string",
+                    "example": "ABC123"
                 },
-                "supplier_id": {
-                    "type": "string"
+                "model": {
+                    "type": "string",
+                    "example": "Corolla or null"
+                },
+                "year": {
+                    "type": "string",
+                    "example": "2020"
                 }
             }
         },
-        "models.PurchaseProductCreate": {
+        "models.VehicleUpdate": {
             "type": "object",
             "required": [
-                "product_id",
-                "quantity",
-                "unit_price"
+                "client_id",
+                "id"
             ],
             "properties": {
-                "expired_at": {
-                    "type": "string"
+                "brand": {
+                    "type": "string",
+                    "example": "Toyota"
                 },
-                "product_id": {
-                    "type": "string"
+                "category": {
+                    "type": "string",
+                    "example": "suv"
                 },
-                "quantity": {
+                "client_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "color": {
+                    "type": "string",
+                    "example": "Red"
+                },
+                "domain": {
+                    "type": "string",
+                    "example": "ABC123"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "model": {
+                    "type": "string",
+                    "example": "Corolla"
+                },
+                "version": {
                     "type": "integer"
                 },
-                "unit_price": {
-                    "type": "number"
+                "year": {
+                    "type": "string",
+                    "example": "2020"
                 }
             }
         },
-        "models.PurchaseProductLaundry": {
+        "models.WorkOrder": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "client": {
+                    "$ref": "#/definitions/models.Client"
                 },
-                "expired_at": {
+                "client_id": {
                     "type": "string"
                 },
-                "id": {
+                "created_at": {
                     "type": "string"
                 },
-                "product": {
-                    "$ref": "#/definitions/models.ProductLaundry"
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time"
                 },
-                "product_id": {
+                "description": {
                     "type": "string"
                 },
-                "purchase_order": {
-                    "$ref": "#/definitions/models.PurchaseOrderLaundry"
-                },
-                "purchase_order_id": {
+                "diagnosis": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
-                },
-                "total_price": {
-                    "type": "number"
-                },
-                "unit_price": {
-                    "type": "number"
+                "employee": {
+                    "$ref": "#/definitions/models.Employee"
                 },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.PurchaseProductUpdate": {
-            "type": "object",
-            "required": [
-                "id",
-                "product_id",
-                "quantity",
-                "unit_price"
-            ],
-            "properties": {
-                "expired_at": {
+                "employee_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "product_id": {
+                "income_id": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
-                },
-                "unit_price": {
-                    "type": "number"
-                }
-            }
-        },
-        "models.Response": {
-            "type": "object",
-            "properties": {
-                "body": {},
-                "message": {
-                    "type": "string"
+                "parts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WorkOrderPart"
+                    }
                 },
                 "status": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.ServiceCreate": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.ServiceLaundry": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                    "type": "string",
+                    "example": "recibido"
+                },
+                "status_changed_at": {
                     "type": "string"
                 },
-                "id": {
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WorkOrderTransition"
+                    }
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "name": {
+                "vehicle": {
+                    "$ref": "#/definitions/models.Vehicle"
+                },
+                "vehicle_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "version": {
+                    "type": "integer"
+                },
+                "workplace_id": {
                     "type": "string"
                 }
             }
         },
-        "models.ServiceUpdate": {
+        "models.WorkOrderCreate": {
             "type": "object",
             "required": [
-                "name"
+                "client_id",
+                "description",
+                "vehicle_id"
             ],
             "properties": {
-                "id": {
+                "client_id": {
                     "type": "string"
                 },
-                "name": {
+                "description": {
+                    "type": "string",
+                    "example": "Ruido en el tren delantero"
+                },
+                "employee_id": {
+                    "type": "string"
+                },
+                "vehicle_id": {
                     "type": "string"
                 }
             }
         },
-        "models.StockUpdate": {
-            "type": "object",
-            "required": [
-                "stock"
-            ],
-            "properties": {
-                "stock": {
-                    "type": "integer"
-                }
-            }
-        },
-        "models.SupplierCreate": {
+        "models.WorkOrderDeliver": {
             "type": "object",
             "required": [
-                "name"
+                "movement_type_id",
+                "services_id",
+                "ticket"
             ],
             "properties": {
-                "address": {
+                "details": {
                     "type": "string"
                 },
-                "email": {
+                "discount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "discount_reason": {
                     "type": "string"
                 },
-                "name": {
+                "movement_type_id": {
                     "type": "string"
                 },
-                "phone": {
+                "note": {
+                    "type": "string"
+                },
+                "services_id": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ticket": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.SupplierLaundry": {
+        "models.WorkOrderPart": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "price": {
+                    "type": "number"
                 },
-                "phone": {
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "product_id": {
                     "type": "string"
                 },
+                "quantity": {
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "work_order_id": {
+                    "type": "string"
                 }
             }
         },
-        "models.SupplierUpdate": {
+        "models.WorkOrderPartCreate": {
             "type": "object",
             "required": [
-                "id",
-                "name"
+                "product_id",
+                "quantity"
             ],
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
+                "price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 15000
                 },
-                "id": {
+                "product_id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "phone": {
-                    "type": "string"
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.UserCreate": {
+        "models.WorkOrderPartUpdate": {
             "type": "object",
             "required": [
-                "email",
-                "first_name",
-                "last_name",
-                "password",
-                "role",
-                "username"
+                "id",
+                "quantity"
             ],
             "properties": {
-                "email": {
+                "id": {
                     "type": "string"
                 },
-                "first_name": {
-                    "type": "string"
+                "price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 15000
                 },
-                "last_name": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "password": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WorkOrderStatusUpdate": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "note": {
                     "type": "string"
                 },
-                "role": {
+                "status": {
                     "type": "string",
                     "enum": [
-                        "admin",
-                        "admin_laundry",
-                        "admin_workshop",
-                        "employee_laundry",
-                        "employee_workshop"
-                    ]
-                },
-                "username": {
-                    "type": "string"
+                        "diagnostico",
+                        "esperando_repuestos",
+                        "en_reparacion",
+                        "listo"
+                    ],
+                    "example": "diagnostico"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Vehicle": {
+        "models.WorkOrderTransition": {
             "type": "object",
             "properties": {
-                "brand": {
-                    "type": "string"
-                },
-                "client": {
-                    "$ref": "#/definitions/models.Client"
-                },
-                "client_id": {
-                    "type": "string"
-                },
-                "color": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "domain": {
+                "from_status": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "model": {
+                "note": {
                     "type": "string"
                 },
-                "updated_at": {
+                "to_status": {
                     "type": "string"
                 },
-                "year": {
+                "user_id": {
                     "type": "string"
-                }
-            }
-        },
-        "models.VehicleCreate": {
-            "type": "object",
-            "required": [
-                "brand",
-                "client_id",
-                "color",
-                "domain"
-            ],
-            "properties": {
-                "brand": {
-                    "type": "string",
-                    "example": "Toyota"
-                },
-                "client_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
-                },
-                "color": {
-                    "type": "string",
-                    "example": "Red"
-                },
-                "domain": {
-                    "type": "string",
-                    "example": "ABC123"
-                },
-                "model": {
-                    "type": "string",
-                    "example": "Corolla or null"
                 },
-                "year": {
-                    "type": "string",
-                    "example": "2020"
+                "work_order_id": {
+                    "type": "string"
                 }
             }
         },
-        "models.VehicleUpdate": {
+        "models.WorkOrderUpdate": {
             "type": "object",
             "required": [
-                "client_id",
+                "description",
                 "id"
             ],
             "properties": {
-                "brand": {
-                    "type": "string",
-                    "example": "Toyota"
-                },
-                "client_id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "description": {
+                    "type": "string"
                 },
-                "color": {
-                    "type": "string",
-                    "example": "Red"
+                "diagnosis": {
+                    "type": "string"
                 },
-                "domain": {
-                    "type": "string",
-                    "example": "ABC123"
+                "employee_id": {
+                    "type": "string"
                 },
                 "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
-                },
-                "model": {
-                    "type": "string",
-                    "example": "Corolla"
+                    "type": "string"
                 },
-                "year": {
-                    "type": "string",
-                    "example": "2020"
+                "version": {
+                    "type": "integer"
                 }
             }
         },
         "models.Workplace": {
             "type": "object",
+            "required": [
+                "identifier"
+            ],
             "properties": {
                 "address": {
                     "type": "string"
                 },
+                "category_prices": {
+                    "description": "CategoryPrices habilita los precios de servicios por categoría de\nvehículo (lavaderos).",
+                    "type": "boolean"
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -7131,11 +12161,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "identifier": {
-                    "type": "string",
-                    "enum": [
-                        "laundry",
-                        "workshop"
-                    ]
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
@@ -7145,6 +12171,51 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "work_orders": {
+                    "description": "WorkOrders habilita las órdenes de trabajo (talleres).",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.WorkplaceCreate": {
+            "type": "object",
+            "required": [
+                "address",
+                "email",
+                "identifier",
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Av. Los Olivos"
+                },
+                "category_prices": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "email": {
+                    "type": "string",
+                    "example": "tire_shop@example.com"
+                },
+                "identifier": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "tire_shop"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Gomeria"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "123456789"
+                },
+                "work_orders": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         }
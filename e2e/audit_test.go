@@ -0,0 +1,176 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestAuditTrail(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	laundry := admin.Workplace("laundry")
+
+	var adminUser models.User
+	if err := h.DB.Where("username = ?", AdminUsername).First(&adminUser).Error; err != nil {
+		t.Fatal(err)
+	}
+	audits := func(query string) []models.AuditLog {
+		t.Helper()
+		var audits []models.AuditLog
+		admin.Get("/audit/get_all?sort=created_at&" + query).OK().Decode(&audits)
+		return audits
+	}
+
+	t.Run("client", func(t *testing.T) {
+		clientID := createClient(laundry, "audit")
+		laundry.Put("/client/update", models.ClientUpdate{
+			ID:        clientID,
+			FirstName: "Auditado",
+			LastName:  "audit",
+			CUIL:      "cuil-audit",
+			Email:     "audit@vehiculo.test",
+			Version:   1,
+		}).OK()
+		laundry.Delete("/client/delete/" + clientID).OK()
+		laundry.Put("/trash/client/restore/"+clientID, nil).OK()
+
+		trail := audits("entity_type=client&entity_id=" + clientID)
+		actions := []string{models.AuditCreate, models.AuditUpdate, models.AuditDelete, models.AuditRestore}
+		if len(trail) != len(actions) {
+			t.Fatalf("auditoría del cliente = %+v", trail)
+		}
+		for i, audit := range trail {
+			if audit.Action != actions[i] {
+				t.Errorf("acción %d = %s, se esperaba %s", i, audit.Action, actions[i])
+			}
+			if audit.UserID != adminUser.ID {
+				t.Errorf("user_id = %s, se esperaba %s", audit.UserID, adminUser.ID)
+			}
+		}
+		// /client no es una ruta de workplace; /trash sí
+		if trail[0].WorkplaceID != "" || trail[3].WorkplaceID != h.Workplaces["laundry"].ID {
+			t.Errorf("workplace_id = %q / %q", trail[0].WorkplaceID, trail[3].WorkplaceID)
+		}
+
+		create, update, del := trail[0], trail[1], trail[2]
+		if create.Before != nil || create.After["first_name"] != "Cliente" {
+			t.Errorf("alta: before = %v, after = %v", create.Before, create.After)
+		}
+		if update.Before["first_name"] != "Cliente" || update.After["first_name"] != "Auditado" {
+			t.Errorf("modificación: before = %v, after = %v", update.Before, update.After)
+		}
+		if del.Before["first_name"] != "Auditado" || del.After != nil {
+			t.Errorf("baja: before = %v, after = %v", del.Before, del.After)
+		}
+
+		var audit models.AuditLog
+		admin.Get("/audit/" + update.ID).OK().Decode(&audit)
+		if audit.EntityID != clientID || audit.After["first_name"] != "Auditado" {
+			t.Errorf("GET /audit/%s = %+v", update.ID, audit)
+		}
+		admin.Get("/audit/no-existe").ExpectError(http.StatusNotFound, models.CodeNotFound)
+	})
+
+	t.Run("income", func(t *testing.T) {
+		clientID := createClient(laundry, "audit-income")
+		vehicleID := createVehicle(laundry, clientID, "AU001AA")
+		movementTypeID := laundry.Post("/movement/create", models.MovementTypeCreate{Name: "Cobro", IsIncome: true}).ID()
+		wash := laundry.Post("/service/create", models.ServiceCreate{Name: "Lavado", Price: 1500}).ID()
+		employeeID := laundry.Post("/employee/create", models.EmployeeCreate{
+			Name:    "Empleado",
+			Phone:   "123",
+			Email:   "empleado@audit.test",
+			Address: "Calle 1",
+		}).ID()
+		income := models.IncomeCreate{
+			Ticket:         "A-1",
+			ServicesID:     []string{wash},
+			Details:        "Lavado",
+			ClientID:       clientID,
+			VehicleID:      vehicleID,
+			EmployeeID:     employeeID,
+			MovementTypeID: movementTypeID,
+		}
+		id := laundry.Post("/income/create", income).ID()
+		laundry.IfMatch("/income/"+id).Put("/income/update", models.IncomeUpdate{
+			ID:             id,
+			Ticket:         income.Ticket,
+			ServicesID:     income.ServicesID,
+			Details:        income.Details,
+			ClientID:       clientID,
+			VehicleID:      vehicleID,
+			EmployeeID:     employeeID,
+			MovementTypeID: movementTypeID,
+			Discount:       300,
+			DiscountReason: "Promoción",
+		}).OK()
+
+		trail := audits("entity_type=income&entity_id=" + id)
+		if len(trail) != 2 {
+			t.Fatalf("auditoría del ingreso = %+v", trail)
+		}
+		update := trail[1]
+		if update.Before["amount"] != 1500.0 || update.After["amount"] != 1200.0 || update.After["discount_reason"] != "Promoción" {
+			t.Errorf("amount: before = %v, after = %v", update.Before["amount"], update.After["amount"])
+		}
+		lines, ok := update.After["services"].([]interface{})
+		if !ok || len(lines) != 1 {
+			t.Fatalf("services = %v", update.After["services"])
+		}
+		if line := lines[0].(map[string]interface{}); line["service_id"] != wash || line["price"] != 1500.0 {
+			t.Errorf("línea = %v", line)
+		}
+
+		// un cambio rechazado no deja rastro
+		laundry.Put("/income/update", models.IncomeUpdate{
+			ID:             id,
+			Ticket:         income.Ticket,
+			ServicesID:     income.ServicesID,
+			Details:        income.Details,
+			ClientID:       clientID,
+			VehicleID:      vehicleID,
+			EmployeeID:     employeeID,
+			MovementTypeID: movementTypeID,
+			Version:        1,
+		}).ExpectError(http.StatusConflict, models.CodeVersionConflict)
+		if trail := audits("entity_type=income&entity_id=" + id); len(trail) != 2 {
+			t.Errorf("el conflicto quedó auditado: %+v", trail)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		for _, audit := range audits("action=delete") {
+			if audit.Action != models.AuditDelete {
+				t.Errorf("filtro action devolvió %s", audit.Action)
+			}
+		}
+		if trail := audits("workplace_id=" + h.Workplaces["workshop"].ID); len(trail) != 0 {
+			t.Errorf("el taller no tuvo cambios, se obtuvo %+v", trail)
+		}
+		for _, audit := range audits("workplace_id=" + h.Workplaces["laundry"].ID) {
+			if audit.WorkplaceID != h.Workplaces["laundry"].ID {
+				t.Errorf("filtro workplace_id devolvió %s", audit.WorkplaceID)
+			}
+		}
+		admin.Get("/audit/get_all?color=rojo").ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	})
+
+	t.Run("permission", func(t *testing.T) {
+		admin.Post("/user/create", models.UserCreate{
+			FirstName: "empleado",
+			LastName:  "E2E",
+			Username:  "empleado",
+			Email:     "empleado@gestioncar.test",
+			Password:  "Clave123!",
+			Role:      "employee_laundry",
+		}).OK()
+		h.Login("empleado", "Clave123!").Get("/audit/get_all").ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		// la alta del usuario también queda registrada
+		if trail := audits("entity_type=user&action=create"); len(trail) != 1 || trail[0].UserID != adminUser.ID {
+			t.Errorf("auditoría de usuarios = %+v", trail)
+		}
+	})
+}
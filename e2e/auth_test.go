@@ -0,0 +1,160 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/utils"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestAuthTokens(t *testing.T) {
+	h := New(t)
+
+	var admin models.User
+	if err := h.DB.Where("username = ?", AdminUsername).First(&admin).Error; err != nil {
+		t.Fatal(err)
+	}
+	// cualquier ruta detrás de AuthMiddleware sirve para probar el access token
+	protected := "/auth/workplace_login/" + h.Workplaces["laundry"].ID
+
+	t.Run("login", func(t *testing.T) {
+		var tokens models.AuthTokens
+		h.Anonymous().Post("/auth/login", models.AuthLogin{Username: AdminUsername, Password: AdminPassword}).OK().Decode(&tokens)
+		if tokens.RefreshToken == "" || tokens.TokenType != "Bearer" || tokens.ExpiresIn != int64((15*time.Minute).Seconds()) {
+			t.Fatalf("tokens = %+v", tokens)
+		}
+
+		claims, err := utils.VerifyToken(tokens.AccessToken, h.Config.Auth.SecretKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mapClaims := claims.(jwt.MapClaims)
+		for _, claim := range []string{"exp", "iat", "jti"} {
+			if _, ok := mapClaims[claim]; !ok {
+				t.Errorf("falta el claim %q", claim)
+			}
+		}
+	})
+
+	t.Run("refresh rotates", func(t *testing.T) {
+		session := h.LoginAdmin()
+
+		var rotated models.AuthTokens
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: session.RefreshToken}).OK().Decode(&rotated)
+		if rotated.RefreshToken == "" || rotated.RefreshToken == session.RefreshToken {
+			t.Fatal("el refresh token no rotó")
+		}
+		fresh := &Session{h: h, Token: rotated.AccessToken}
+		fresh.Get(protected).OK()
+
+		// reusar el token rotado revoca también el que lo reemplazó
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: session.RefreshToken}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: rotated.RefreshToken}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: "no-es-un-token"}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+	})
+
+	t.Run("expired refresh", func(t *testing.T) {
+		session := h.LoginAdmin()
+		if err := h.DB.Model(&models.RefreshToken{}).Where("token_hash = ?", utils.HashToken(session.RefreshToken)).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+			t.Fatal(err)
+		}
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: session.RefreshToken}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+	})
+
+	t.Run("logout", func(t *testing.T) {
+		session := h.LoginAdmin()
+		other := h.LoginAdmin()
+
+		session.Post("/auth/logout", models.LogoutRequest{RefreshToken: session.RefreshToken}).OK()
+		session.Get(protected).ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+
+		// las demás sesiones del usuario siguen vivas; sin refresh_token solo
+		// se revoca el access token
+		other.Get(protected).OK()
+		other.Post("/auth/logout", nil).OK()
+		other.Get(protected).ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		var rotated models.AuthTokens
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: other.RefreshToken}).OK().Decode(&rotated)
+
+		// el refresh token del logout ya no sirve y presentarlo cuenta como reuso
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: session.RefreshToken}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: rotated.RefreshToken}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+	})
+
+	t.Run("expired access token", func(t *testing.T) {
+		token, err := utils.GenerateUserToken(&admin, h.Config.Auth.SecretKey, -time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expired := &Session{h: h, Token: token}
+		expired.Get(protected).ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+	})
+
+	t.Run("token without exp", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"id":       admin.ID,
+			"username": admin.Username,
+			"role":     admin.Role,
+		}).SignedString([]byte(h.Config.Auth.SecretKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		legacy := &Session{h: h, Token: token}
+		legacy.Get(protected).ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+	})
+}
+
+func TestWorkplaceAccess(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	admin.Post("/user/create", models.UserCreate{
+		FirstName: "Empleado",
+		LastName:  "Lavadero",
+		Username:  "empleado",
+		Email:     "empleado@gestioncar.test",
+		Password:  "Empleado123!",
+		Role:      "employee_laundry",
+	}).OK()
+	employee := h.Login("empleado", "Empleado123!")
+
+	t.Run("role workplace", func(t *testing.T) {
+		employee.Workplace("laundry").Get("/income/get_all").OK()
+		employee.Get("/auth/workplace_login/"+h.Workplaces["workshop"].ID).
+			ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		// los roles con workplace "all" entran a todos
+		admin.Workplace("laundry").Get("/income/get_all").OK()
+		admin.Workplace("workshop").Get("/income/get_all").OK()
+	})
+
+	t.Run("token bound to user", func(t *testing.T) {
+		workshop := admin.Workplace("workshop")
+		stolen := &Session{h: h, Token: employee.Token, WorkplaceToken: workshop.WorkplaceToken}
+		stolen.Get("/income/get_all").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+
+		// un token de workplace sin user_id no sirve
+		workplace := h.Workplaces["laundry"]
+		legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"id":  workplace.ID,
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte(h.Config.Auth.SecretKeyWorkplace))
+		if err != nil {
+			t.Fatal(err)
+		}
+		unbound := &Session{h: h, Token: employee.Token, WorkplaceToken: legacy}
+		unbound.Get("/income/get_all").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+	})
+}
@@ -0,0 +1,86 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestOptimisticConcurrency(t *testing.T) {
+	h := New(t)
+	laundry := h.LoginAdmin().Workplace("laundry")
+	clientID := createClient(laundry, "concurrency")
+
+	get := func() (models.Client, string) {
+		t.Helper()
+		var client models.Client
+		resp := laundry.Get("/client/" + clientID).OK().Decode(&client)
+		return client, resp.Header.Get("ETag")
+	}
+	update := func(name string, version int64) models.ClientUpdate {
+		return models.ClientUpdate{
+			ID:        clientID,
+			FirstName: name,
+			LastName:  "Concurrencia",
+			CUIL:      "cuil-concurrency",
+			Email:     "concurrency@vehiculo.test",
+			Version:   version,
+		}
+	}
+
+	client, etag := get()
+	if client.Version != 1 || etag != `"1"` {
+		t.Fatalf("version = %d, ETag = %s, se esperaba 1", client.Version, etag)
+	}
+
+	// dos cajeros leyeron la versión 1; el primero gana
+	laundry.WithHeader("If-Match", etag).Put("/client/update", update("Primero", 0)).OK()
+	client, etag = get()
+	if client.FirstName != "Primero" || etag != `"2"` {
+		t.Fatalf("first_name = %s, ETag = %s", client.FirstName, etag)
+	}
+
+	env := laundry.WithHeader("If-Match", `"1"`).Put("/client/update", update("Segundo", 0)).
+		ExpectError(http.StatusConflict, models.CodeVersionConflict).Envelope()
+	var current models.Client
+	if err := json.Unmarshal(env.Body, &current); err != nil || current.FirstName != "Primero" || current.Version != 2 {
+		t.Errorf("el 409 debería traer el estado actual: %s", env.Body)
+	}
+
+	// la versión también puede ir en el body
+	laundry.Put("/client/update", update("Segundo", 1)).ExpectError(http.StatusConflict, models.CodeVersionConflict)
+	laundry.Put("/client/update", update("Segundo", 2)).OK()
+	if client, _ = get(); client.FirstName != "Segundo" || client.Version != 3 {
+		t.Errorf("first_name = %s, version = %d", client.FirstName, client.Version)
+	}
+
+	// sin versión se rechaza; If-Match: * actualiza sin control y la versión
+	// avanza igual
+	laundry.Put("/client/update", update("Tercero", 0)).ExpectError(http.StatusPreconditionRequired, models.CodeVersionRequired)
+	laundry.WithHeader("If-Match", "*").Put("/client/update", update("Tercero", 0)).OK()
+	if client, _ = get(); client.Version != 4 {
+		t.Errorf("version = %d, se esperaba 4", client.Version)
+	}
+
+	laundry.WithHeader("If-Match", `"4"`).Put("/client/update", update("Cuarto", 3)).
+		ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	laundry.WithHeader("If-Match", "abc").Put("/client/update", update("Cuarto", 0)).
+		ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+
+	t.Run("workplace entity", func(t *testing.T) {
+		serviceID := laundry.Post("/service/create", models.ServiceCreate{Name: "Lavado"}).ID()
+		var service models.Service
+		etag := laundry.Get("/service/" + serviceID).OK().Decode(&service).Header.Get("ETag")
+
+		laundry.WithHeader("If-Match", etag).Put("/service/update", models.ServiceUpdate{ID: serviceID, Name: "Lavado completo"}).OK()
+		laundry.WithHeader("If-Match", etag).Put("/service/update", models.ServiceUpdate{ID: serviceID, Name: "Lavado simple"}).
+			ExpectError(http.StatusConflict, models.CodeVersionConflict)
+
+		laundry.Get("/service/" + serviceID).OK().Decode(&service)
+		if service.Name != "Lavado completo" || service.Version != 2 {
+			t.Errorf("name = %s, version = %d", service.Name, service.Version)
+		}
+	})
+}
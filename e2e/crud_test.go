@@ -0,0 +1,300 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+var workplaceIdentifiers = []string{"laundry", "workshop"}
+
+func TestClientCRUD(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+
+	for _, identifier := range workplaceIdentifiers {
+		t.Run(identifier, func(t *testing.T) {
+			s := admin.Workplace(identifier)
+
+			id := s.Post("/client/create", models.ClientCreate{
+				FirstName: "Juan",
+				LastName:  "Pérez " + identifier,
+				CUIL:      "20-1234-" + identifier,
+				DNI:       "1234-" + identifier,
+				Email:     identifier + "@cliente.test",
+			}).ID()
+
+			var client models.Client
+			s.Get("/client/" + id).OK().Decode(&client)
+			if client.Email != identifier+"@cliente.test" {
+				t.Errorf("email = %s", client.Email)
+			}
+
+			var clients []models.Client
+			s.Get("/client/get_by_name?name=" + identifier).OK().Decode(&clients)
+			if len(clients) != 1 {
+				t.Errorf("get_by_name devolvió %d clientes", len(clients))
+			}
+
+			s.Put("/client/update", models.ClientUpdate{
+				ID:        id,
+				FirstName: "Juana",
+				LastName:  client.LastName,
+				CUIL:      client.CUIL,
+				DNI:       client.DNI,
+				Email:     client.Email,
+				Version:   client.Version,
+			}).OK()
+			s.Get("/client/" + id).OK().Decode(&client)
+			if client.FirstName != "Juana" {
+				t.Errorf("first_name = %s, se esperaba Juana", client.FirstName)
+			}
+
+			s.Delete("/client/delete/" + id).OK()
+			s.Get("/client/" + id).Expect(http.StatusNotFound)
+		})
+	}
+}
+
+func TestVehicleCRUD(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+
+	for _, identifier := range workplaceIdentifiers {
+		t.Run(identifier, func(t *testing.T) {
+			s := admin.Workplace(identifier)
+			clientID := createClient(s, identifier)
+			domain := "AB" + identifier[:3] + "12"
+
+			id := s.Post("/vehicle/create", models.VehicleCreate{
+				Brand:    "Toyota",
+				Model:    "Corolla",
+				Color:    "Rojo",
+				Year:     "2020",
+				Domain:   domain,
+				ClientID: clientID,
+			}).ID()
+
+			var vehicle models.Vehicle
+			s.Get("/vehicle/" + id).OK().Decode(&vehicle)
+			if vehicle.Domain != domain {
+				t.Errorf("domain = %s, se esperaba %s", vehicle.Domain, domain)
+			}
+
+			var vehicles []models.Vehicle
+			s.Get("/vehicle/get_by_client/" + clientID).OK().Decode(&vehicles)
+			if len(vehicles) != 1 {
+				t.Errorf("get_by_client devolvió %d vehículos", len(vehicles))
+			}
+			s.Get("/vehicle/get_by_domain?domain=" + domain).OK().Decode(&vehicles)
+			if len(vehicles) != 1 {
+				t.Errorf("get_by_domain devolvió %d vehículos", len(vehicles))
+			}
+
+			s.Put("/vehicle/update", models.VehicleUpdate{
+				ID:       id,
+				Brand:    "Toyota",
+				Model:    "Etios",
+				Color:    "Azul",
+				Year:     "2021",
+				Domain:   domain,
+				ClientID: clientID,
+				Version:  vehicle.Version,
+			}).OK()
+			s.Get("/vehicle/" + id).OK().Decode(&vehicle)
+			if vehicle.Color != "Azul" {
+				t.Errorf("color = %s, se esperaba Azul", vehicle.Color)
+			}
+
+			s.Delete("/vehicle/delete/" + id).OK()
+			s.Get("/vehicle/" + id).Expect(http.StatusNotFound)
+		})
+	}
+}
+
+func TestIncomeCRUD(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	incomeIDs := map[string]string{}
+
+	for _, identifier := range workplaceIdentifiers {
+		t.Run(identifier, func(t *testing.T) {
+			s := admin.Workplace(identifier)
+			clientID := createClient(s, identifier)
+			vehicleID := createVehicle(s, clientID, "IN"+identifier[:3]+"01")
+			movementTypeID := s.Post("/movement/create", models.MovementTypeCreate{Name: "Cobro", IsIncome: true}).ID()
+			wash := s.Post("/service/create", models.ServiceCreate{Name: "Lavado " + identifier, Price: 1500}).ID()
+			wax := s.Post("/service/create", models.ServiceCreate{Name: "Encerado " + identifier, Price: 1000}).ID()
+			employeeID := s.Post("/employee/create", models.EmployeeCreate{
+				Name:    "Empleado",
+				Phone:   "123",
+				Email:   "empleado@" + identifier + ".test",
+				Address: "Calle 1",
+			}).ID()
+
+			id := s.Post("/income/create", models.IncomeCreate{
+				Ticket:         "T-1",
+				ServicesID:     []string{wash},
+				Details:        "Ticket de prueba",
+				ClientID:       clientID,
+				VehicleID:      vehicleID,
+				EmployeeID:     employeeID,
+				MovementTypeID: movementTypeID,
+			}).ID()
+			incomeIDs[identifier] = id
+
+			var income models.Income
+			s.Get("/income/" + id).OK().Decode(&income)
+			if income.WorkplaceID != h.Workplaces[identifier].ID {
+				t.Errorf("workplace_id = %s, se esperaba %s", income.WorkplaceID, h.Workplaces[identifier].ID)
+			}
+
+			var today []models.Income
+			s.Get("/income/get_today").OK().Decode(&today)
+			if len(today) != 1 {
+				t.Errorf("get_today devolvió %d ingresos", len(today))
+			}
+
+			s.Put("/income/update", models.IncomeUpdate{
+				ID:             id,
+				Ticket:         "T-1",
+				ServicesID:     []string{wash, wax},
+				Details:        "Con encerado",
+				ClientID:       clientID,
+				VehicleID:      vehicleID,
+				EmployeeID:     employeeID,
+				MovementTypeID: movementTypeID,
+				Discount:       500,
+				DiscountReason: "Cliente frecuente",
+				Version:        income.Version,
+			}).OK()
+			s.Get("/income/" + id).OK().Decode(&income)
+			if income.Subtotal != 2500 || income.Amount != 2000 || len(income.Services) != 2 {
+				t.Errorf("subtotal = %v, amount = %v, líneas = %d, se esperaba 2500 - 500 en 2 líneas", income.Subtotal, income.Amount, len(income.Services))
+			}
+		})
+	}
+
+	t.Run("isolation", func(t *testing.T) {
+		// un ingreso de la lavandería no se ve desde el taller
+		workshop := admin.Workplace("workshop")
+		workshop.Get("/income/" + incomeIDs["laundry"]).Expect(http.StatusNotFound)
+		workshop.Delete("/income/delete/" + incomeIDs["laundry"]).Expect(http.StatusNotFound)
+	})
+
+	for _, identifier := range workplaceIdentifiers {
+		s := admin.Workplace(identifier)
+		s.Delete("/income/delete/" + incomeIDs[identifier]).OK()
+		s.Get("/income/" + incomeIDs[identifier]).Expect(http.StatusNotFound)
+	}
+}
+
+func TestPurchaseOrderCRUD(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+
+	for _, identifier := range workplaceIdentifiers {
+		t.Run(identifier, func(t *testing.T) {
+			s := admin.Workplace(identifier)
+			supplierID := s.Post("/supplier/create", models.SupplierCreate{Name: "Proveedor " + identifier}).ID()
+			productID := s.Post("/product/create", models.ProductCreate{Identifier: "P-1", Name: "Shampoo"}).ID()
+
+			id := s.Post("/purchase_order/create", models.PurchaseOrderCreate{
+				OrderNumber: "OC-1",
+				OrderDate:   "2024-05-01",
+				Amount:      1000,
+				SupplierID:  supplierID,
+				PurchaseProductCreates: []models.PurchaseProductCreate{
+					{ProductID: productID, UnitPrice: 100, Quantity: 10},
+				},
+			}).ID()
+
+			var order models.PurchaseOrder
+			s.Get("/purchase_order/" + id).OK().Decode(&order)
+			if order.OrderNumber != "OC-1" {
+				t.Errorf("order_number = %s, se esperaba OC-1", order.OrderNumber)
+			}
+
+			var lines []models.PurchaseProduct
+			s.Get("/purchase_product/get_purchase/" + id).OK().Decode(&lines)
+			if len(lines) != 1 {
+				t.Fatalf("la orden tiene %d productos, se esperaba 1", len(lines))
+			}
+
+			var orders []models.PurchaseOrder
+			s.Get("/purchase_order/get_all").OK().Decode(&orders)
+			if len(orders) != 1 {
+				t.Errorf("get_all devolvió %d órdenes", len(orders))
+			}
+
+			line := lines[0]
+			s.Put("/purchase_order/update", models.PurchaseOrderUpdate{
+				ID:          id,
+				OrderNumber: "OC-1",
+				OrderDate:   "2024-05-02",
+				Amount:      1200,
+				SupplierID:  supplierID,
+				PurchaseProductUpdates: []models.PurchaseProductUpdate{
+					{ID: line.ID, ProductID: productID, UnitPrice: 120, Quantity: 10},
+				},
+				Version: order.Version,
+			}).OK()
+			s.Get("/purchase_order/" + id).OK().Decode(&order)
+			s.Get("/purchase_product/get_purchase/" + id).OK().Decode(&lines)
+			if order.Amount != 1200 || len(lines) != 1 || lines[0].TotalPrice != 1200 {
+				t.Errorf("amount = %v, líneas = %+v, se esperaba 1200", order.Amount, lines)
+			}
+
+			s.Delete("/purchase_order/delete/" + id).OK()
+			s.Get("/purchase_order/" + id).Expect(http.StatusNotFound)
+		})
+	}
+}
+
+func createClient(s *Session, suffix string) string {
+	return s.Post("/client/create", models.ClientCreate{
+		FirstName: "Cliente",
+		LastName:  suffix,
+		CUIL:      fmt.Sprintf("cuil-%s", suffix),
+		DNI:       fmt.Sprintf("dni-%s", suffix),
+		Email:     suffix + "@vehiculo.test",
+	}).ID()
+}
+
+func createVehicle(s *Session, clientID string, domain string) string {
+	return s.Post("/vehicle/create", models.VehicleCreate{
+		Brand:    "Ford",
+		Color:    "Blanco",
+		Domain:   domain,
+		ClientID: clientID,
+	}).ID()
+}
+
+func TestListPagination(t *testing.T) {
+	h := New(t)
+	s := h.LoginAdmin().Workplace("laundry")
+
+	for _, name := range []string{"Aspirado", "Lavado", "Encerado"} {
+		s.Post("/service/create", models.ServiceCreate{Name: name}).ID()
+	}
+
+	var services []models.Service
+	resp := s.Get("/service/get_all?page=2&page_size=2&sort=-name").OK().Decode(&services)
+	meta := resp.Envelope().Meta
+	if meta == nil || meta.Total != 3 || meta.TotalPages != 2 || meta.Page != 2 {
+		t.Errorf("meta = %+v", meta)
+	}
+	if len(services) != 1 || services[0].Name != "Aspirado" {
+		t.Errorf("página 2 = %+v", services)
+	}
+
+	s.Get("/service/get_all?name=lav").OK().Decode(&services)
+	if len(services) != 1 || services[0].Name != "Lavado" {
+		t.Errorf("filtro por nombre = %+v", services)
+	}
+
+	s.Get("/service/get_all?sort=password").ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	s.Get("/service/get_all?page_size=1000").ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+}
@@ -0,0 +1,52 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestErrorEnvelope(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	laundry := admin.Workplace("laundry")
+
+	t.Run("auth", func(t *testing.T) {
+		h.Anonymous().Get("/client/get_all").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		bad := &Session{h: h, Token: "no-es-un-token"}
+		bad.Get("/client/get_all").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		h.Anonymous().Post("/auth/login", models.AuthLogin{Username: AdminUsername, Password: "incorrecta"}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+	})
+
+	t.Run("workplace", func(t *testing.T) {
+		admin.Get("/income/get_all").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		bad := &Session{h: h, Token: admin.Token, WorkplaceToken: "no-es-un-token"}
+		bad.Get("/income/get_all").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		laundry.Get("/client/no-existe").ExpectError(http.StatusNotFound, models.CodeNotFound)
+		laundry.Get("/ruta/inexistente").ExpectError(http.StatusNotFound, models.CodeNotFound)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		env := laundry.Post("/service/create", models.ServiceCreate{}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation).Envelope()
+		if len(env.Errors) != 1 || env.Errors[0].Field != "name" || env.Errors[0].Rule != "required" {
+			t.Errorf("errors = %+v", env.Errors)
+		}
+		productID := laundry.Post("/product/create", models.ProductCreate{Identifier: "P-1", Name: "Cera"}).ID()
+		laundry.Put("/product/update_stock/"+productID+"?method=subtract", models.StockUpdate{Stock: 0}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		laundry.Post("/service/create", models.ServiceCreate{Name: "Lavado"}).ID()
+		laundry.Post("/service/create", models.ServiceCreate{Name: "Lavado"}).ExpectError(http.StatusConflict, models.CodeConflict)
+		productID := laundry.Post("/product/create", models.ProductCreate{Identifier: "P-2", Name: "Shampoo"}).ID()
+		laundry.Put("/product/update_stock/"+productID+"?method=subtract", models.StockUpdate{Stock: 5}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+	})
+}
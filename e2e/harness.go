@@ -0,0 +1,321 @@
+// Package e2e levanta la API completa sobre SQLite en memoria y ofrece helpers
+// para llamarla como lo haría el frontend: login, token de workplace y JSON.
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/config"
+	"github.com/DanielChachagua/GestionCar/database"
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/server"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	AdminUsername = "admin"
+	AdminPassword = "Admin123!"
+)
+
+// Harness contiene la app armada igual que en main.go y su base en memoria.
+type Harness struct {
+	t          testing.TB
+	App        *fiber.App
+	DB         *gorm.DB
+	Config     *config.Config
+	Workplaces map[string]models.Workplace
+}
+
+// New crea una base SQLite en memoria nueva, aplica migraciones y datos
+// iniciales (admin, lavandería, taller y roles) y arma la app.
+func New(t testing.TB) *Harness {
+	t.Helper()
+	return NewUpgraded(t, nil)
+}
+
+// NewUpgraded es como New, pero antes de migrar ejecuta prepare sobre la base
+// vacía para dejarla como la tendría una versión anterior de la aplicación.
+func NewUpgraded(t testing.TB, prepare func(db *gorm.DB) error) *Harness {
+	t.Helper()
+
+	cfg := &config.Config{
+		DatabaseURI: fmt.Sprintf("sqlite://file:%s?mode=memory&cache=shared", uuid.NewString()),
+		ListenAddr:  ":0",
+		// las pruebas usan el header para simular clientes con distintas IP;
+		// app.Test conecta desde 0.0.0.0
+		ProxyHeader:    fiber.HeaderXForwardedFor,
+		TrustedProxies: []string{"0.0.0.0"},
+		Auth: config.Auth{
+			SecretKey:          "e2e-secret-key-0123456789abcdefghij",
+			SecretKeyWorkplace: "e2e-workplace-key-0123456789abcdefgh",
+			AccessTokenTTL:     15 * time.Minute,
+			RefreshTokenTTL:    24 * time.Hour,
+			WorkplaceTokenTTL:  12 * time.Hour,
+			PasswordResetTTL:   time.Hour,
+		},
+		Mail: config.Mail{
+			Driver:    "file",
+			From:      "GestionCar <no-reply@gestioncar.test>",
+			OutboxDir: t.TempDir(),
+			ResetURL:  "https://gestioncar.test/reset",
+		},
+		Login: config.Login{
+			MaxAttempts:      3,
+			MaxAttemptsPerIP: 5,
+			Window:           15 * time.Minute,
+			Lockout:          15 * time.Minute,
+		},
+		Admin: config.Admin{
+			Email:     "admin@gestioncar.test",
+			Password:  AdminPassword,
+			Username:  AdminUsername,
+			FirstName: "Admin",
+			LastName:  "E2E",
+			Role:      "super_admin",
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := database.Connect(cfg.DatabaseURI)
+	if err != nil {
+		t.Fatalf("no se pudo abrir la base: %v", err)
+	}
+	t.Cleanup(func() { database.CloseDB(db) })
+
+	if prepare != nil {
+		if err := prepare(db); err != nil {
+			t.Fatalf("no se pudo preparar la base: %v", err)
+		}
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("no se pudieron aplicar las migraciones: %v", err)
+	}
+	if err := database.Seed(db, cfg.Admin); err != nil {
+		t.Fatalf("no se pudieron cargar los datos iniciales: %v", err)
+	}
+
+	var workplaces []models.Workplace
+	if err := db.Find(&workplaces).Error; err != nil {
+		t.Fatal(err)
+	}
+	byIdentifier := make(map[string]models.Workplace, len(workplaces))
+	for _, workplace := range workplaces {
+		byIdentifier[workplace.Identifier] = workplace
+	}
+
+	return &Harness{
+		t:          t,
+		App:        server.New(db, cfg),
+		DB:         db,
+		Config:     cfg,
+		Workplaces: byIdentifier,
+	}
+}
+
+// Session representa un usuario logueado, opcionalmente dentro de un workplace.
+type Session struct {
+	h              *Harness
+	Token          string
+	RefreshToken   string
+	WorkplaceToken string
+	Headers        map[string]string
+}
+
+// Anonymous devuelve una sesión sin tokens.
+func (h *Harness) Anonymous() *Session {
+	return &Session{h: h}
+}
+
+// Login se autentica por /auth/login y falla el test si no lo consigue.
+func (h *Harness) Login(username, password string) *Session {
+	h.t.Helper()
+	var tokens models.AuthTokens
+	h.Anonymous().Post("/auth/login", models.AuthLogin{Username: username, Password: password}).OK().Decode(&tokens)
+	return &Session{h: h, Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken}
+}
+
+// LoginAdmin se autentica con el super_admin creado por Seed.
+func (h *Harness) LoginAdmin() *Session {
+	h.t.Helper()
+	return h.Login(AdminUsername, AdminPassword)
+}
+
+// Workplace devuelve una copia de la sesión con el X-Workplace-Token del
+// workplace indicado por su identifier (laundry, workshop, ...).
+func (s *Session) Workplace(identifier string) *Session {
+	s.h.t.Helper()
+	workplace, ok := s.h.Workplaces[identifier]
+	if !ok {
+		s.h.t.Fatalf("no existe el workplace %q", identifier)
+	}
+	var token string
+	s.Get("/auth/workplace_login/" + workplace.ID).OK().Decode(&token)
+	return &Session{h: s.h, Token: s.Token, RefreshToken: s.RefreshToken, WorkplaceToken: token}
+}
+
+// WithHeader devuelve una copia de la sesión que además envía el header dado.
+func (s *Session) WithHeader(key, value string) *Session {
+	headers := map[string]string{key: value}
+	for k, v := range s.Headers {
+		if _, ok := headers[k]; !ok {
+			headers[k] = v
+		}
+	}
+	return &Session{h: s.h, Token: s.Token, RefreshToken: s.RefreshToken, WorkplaceToken: s.WorkplaceToken, Headers: headers}
+}
+
+// IfMatch devuelve una copia de la sesión que envía en If-Match el ETag
+// actual del registro de path, como pide toda actualización.
+func (s *Session) IfMatch(path string) *Session {
+	s.h.t.Helper()
+	etag := s.Get(path).OK().Header.Get(fiber.HeaderETag)
+	if etag == "" {
+		s.h.t.Fatalf("GET %s no devolvió ETag", path)
+	}
+	return s.WithHeader(fiber.HeaderIfMatch, etag)
+}
+
+func (s *Session) Get(path string) *Response {
+	return s.Do(fiber.MethodGet, path, nil)
+}
+
+func (s *Session) Post(path string, body interface{}) *Response {
+	return s.Do(fiber.MethodPost, path, body)
+}
+
+func (s *Session) Put(path string, body interface{}) *Response {
+	return s.Do(fiber.MethodPut, path, body)
+}
+
+func (s *Session) Delete(path string) *Response {
+	return s.Do(fiber.MethodDelete, path, nil)
+}
+
+// Do envía la request a la app con los headers de la sesión.
+func (s *Session) Do(method, path string, body interface{}) *Response {
+	s.h.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			s.h.t.Fatal(err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	if s.Token != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+s.Token)
+	}
+	if s.WorkplaceToken != "" {
+		req.Header.Set("X-Workplace-Token", s.WorkplaceToken)
+	}
+	for key, value := range s.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.h.App.Test(req, -1)
+	if err != nil {
+		s.h.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.h.t.Fatal(err)
+	}
+
+	return &Response{t: s.h.t, Method: method, Path: path, StatusCode: resp.StatusCode, Header: resp.Header, Raw: raw}
+}
+
+// Response es la respuesta cruda más helpers para el sobre models.Response.
+type Response struct {
+	t          testing.TB
+	Method     string
+	Path       string
+	StatusCode int
+	Header     http.Header
+	Raw        []byte
+}
+
+type envelope struct {
+	Status  bool                `json:"status"`
+	Body    json.RawMessage     `json:"body"`
+	Message string              `json:"message"`
+	Meta    *models.Pagination  `json:"meta"`
+	Code    string              `json:"code"`
+	Errors  []models.FieldError `json:"errors"`
+}
+
+// Expect falla el test si el status no es el esperado.
+func (r *Response) Expect(status int) *Response {
+	r.t.Helper()
+	if r.StatusCode != status {
+		r.t.Fatalf("%s %s: status %d, se esperaba %d: %s", r.Method, r.Path, r.StatusCode, status, r.Raw)
+	}
+	return r
+}
+
+// OK falla el test si el status no es 2xx.
+func (r *Response) OK() *Response {
+	r.t.Helper()
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		r.t.Fatalf("%s %s: status %d: %s", r.Method, r.Path, r.StatusCode, r.Raw)
+	}
+	return r
+}
+
+// ExpectError falla el test si no es un error con ese status y código.
+func (r *Response) ExpectError(status int, code string) *Response {
+	r.t.Helper()
+	r.Expect(status)
+	if env := r.Envelope(); env.Status || env.Code != code {
+		r.t.Fatalf("%s %s: code %q, se esperaba %q: %s", r.Method, r.Path, env.Code, code, r.Raw)
+	}
+	return r
+}
+
+// Envelope decodifica el sobre {status, body, message, code}.
+func (r *Response) Envelope() envelope {
+	r.t.Helper()
+	var env envelope
+	if err := json.Unmarshal(r.Raw, &env); err != nil {
+		r.t.Fatalf("%s %s: respuesta no es JSON: %s", r.Method, r.Path, r.Raw)
+	}
+	return env
+}
+
+// Decode decodifica el campo body del sobre en v.
+func (r *Response) Decode(v interface{}) *Response {
+	r.t.Helper()
+	if err := json.Unmarshal(r.Envelope().Body, v); err != nil {
+		r.t.Fatalf("%s %s: no se pudo decodificar body: %v: %s", r.Method, r.Path, err, r.Raw)
+	}
+	return r
+}
+
+// ID decodifica el body como el id devuelto por los endpoints de creación.
+func (r *Response) ID() string {
+	r.t.Helper()
+	var id string
+	r.OK().Decode(&id)
+	if id == "" {
+		r.t.Fatalf("%s %s: no devolvió id: %s", r.Method, r.Path, r.Raw)
+	}
+	return id
+}
@@ -0,0 +1,95 @@
+package e2e
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"gorm.io/gorm"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	laundry := admin.Workplace("laundry")
+	movementTypeID := laundry.Post("/movement/create", models.MovementTypeCreate{Name: "Pago"}).ID()
+	expense := models.ExpenseCreate{Details: "Luz", MovementTypeID: movementTypeID, Amount: 100}
+
+	countExpenses := func() int64 {
+		t.Helper()
+		var count int64
+		if err := h.DB.Model(&models.Expense{}).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	retry := laundry.WithHeader("Idempotency-Key", "gasto-1")
+	first := retry.Post("/expense/create", expense)
+	id := first.ID()
+	if first.Header.Get("Idempotent-Replayed") != "" {
+		t.Error("la primera request no es un replay")
+	}
+
+	second := retry.Post("/expense/create", expense)
+	if second.ID() != id || second.Header.Get("Idempotent-Replayed") != "true" {
+		t.Errorf("el reintento devolvió %s, se esperaba el replay de %s", second.Raw, id)
+	}
+	if n := countExpenses(); n != 1 {
+		t.Errorf("hay %d egresos, se esperaba 1", n)
+	}
+
+	// la misma key con otro body es un error del cliente
+	other := expense
+	other.Amount = 200
+	retry.Post("/expense/create", other).ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+
+	// la key es por usuario y sin key no hay deduplicación
+	laundry.WithHeader("Idempotency-Key", "gasto-2").Post("/expense/create", expense).ID()
+	laundry.Post("/expense/create", expense).ID()
+	if n := countExpenses(); n != 3 {
+		t.Errorf("hay %d egresos, se esperaba 3", n)
+	}
+
+	t.Run("failed request releases the key", func(t *testing.T) {
+		retry := laundry.WithHeader("Idempotency-Key", "gasto-invalido")
+		retry.Post("/expense/create", models.ExpenseCreate{Details: "Sin monto", MovementTypeID: movementTypeID}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+		retry.Post("/expense/create", models.ExpenseCreate{Details: "Con monto", MovementTypeID: movementTypeID, Amount: 50}).ID()
+	})
+
+	t.Run("unsaved response releases the key", func(t *testing.T) {
+		// falla el guardado de la respuesta, no el handler
+		failComplete := func(db *gorm.DB) {
+			if db.Statement.Table == "idempotency_keys" {
+				db.AddError(errors.New("falla simulada"))
+			}
+		}
+		if err := h.DB.Callback().Update().Before("gorm:update").Register("e2e:fail_idempotency_complete", failComplete); err != nil {
+			t.Fatal(err)
+		}
+		retry := laundry.WithHeader("Idempotency-Key", "sin-respuesta")
+		retry.Post("/expense/create", expense).ID()
+		if err := h.DB.Callback().Update().Remove("e2e:fail_idempotency_complete"); err != nil {
+			t.Fatal(err)
+		}
+
+		before := countExpenses()
+		second := retry.Post("/expense/create", expense)
+		second.ID()
+		if second.Header.Get("Idempotent-Replayed") != "" || countExpenses() != before+1 {
+			t.Error("sin respuesta guardada el reintento debería ejecutarse de nuevo")
+		}
+	})
+
+	t.Run("in progress", func(t *testing.T) {
+		retry := laundry.WithHeader("Idempotency-Key", "en-curso")
+		retry.Post("/expense/create", expense).ID()
+		// simula que la primera request todavía no terminó
+		if err := h.DB.Model(&models.IdempotencyKey{}).Where("idempotency_key = ?", "en-curso").Update("completed_at", nil).Error; err != nil {
+			t.Fatal(err)
+		}
+		retry.Post("/expense/create", expense).ExpectError(http.StatusConflict, models.CodeConflict)
+	})
+}
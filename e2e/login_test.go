@@ -0,0 +1,113 @@
+package e2e
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/server"
+)
+
+func TestLoginThrottling(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	for _, username := range []string{"empleado", "encargado"} {
+		admin.Post("/user/create", models.UserCreate{
+			FirstName: username,
+			LastName:  "E2E",
+			Username:  username,
+			Email:     username + "@gestioncar.test",
+			Password:  "Clave123!",
+			Role:      "employee_laundry",
+		}).OK()
+	}
+	from := func(ip string) *Session {
+		return h.Anonymous().WithHeader("X-Forwarded-For", ip).WithHeader("User-Agent", "e2e/"+ip)
+	}
+	login := func(s *Session, username, password string) *Response {
+		return s.Post("/auth/login", models.AuthLogin{Username: username, Password: password})
+	}
+
+	t.Run("uniform errors", func(t *testing.T) {
+		unknown := login(from("10.0.0.1"), "no-existe", "Clave123!").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized).Envelope()
+		wrong := login(from("10.0.0.1"), "encargado", "incorrecta").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized).Envelope()
+		if unknown.Message != wrong.Message {
+			t.Errorf("los mensajes delatan al usuario: %q / %q", unknown.Message, wrong.Message)
+		}
+		// un login exitoso reinicia la cuenta del username
+		login(from("10.0.0.1"), "encargado", "incorrecta").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		login(from("10.0.0.1"), "encargado", "Clave123!").OK()
+		login(from("10.0.0.1"), "encargado", "incorrecta").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		login(from("10.0.0.1"), "encargado", "Clave123!").OK()
+	})
+
+	t.Run("username lockout", func(t *testing.T) {
+		for range h.Config.Login.MaxAttempts {
+			login(from("10.0.0.2"), "empleado", "incorrecta").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		}
+		// bloqueado aunque la contraseña sea correcta y venga de otra IP
+		res := login(from("10.0.0.3"), "empleado", "Clave123!").ExpectError(http.StatusTooManyRequests, models.CodeTooManyRequests)
+		retryAfter, err := strconv.Atoi(res.Header.Get("Retry-After"))
+		if err != nil || retryAfter <= 0 || retryAfter > int(h.Config.Login.Lockout.Seconds()) {
+			t.Errorf("Retry-After = %q", res.Header.Get("Retry-After"))
+		}
+		// los demás usuarios de esa IP siguen entrando
+		login(from("10.0.0.2"), "encargado", "Clave123!").OK()
+
+		// pasado el bloqueo vuelve a entrar
+		if err := h.DB.Model(&models.LoginAttempt{}).Where("username = ?", "empleado").
+			Update("created_at", time.Now().Add(-h.Config.Login.Lockout-time.Minute)).Error; err != nil {
+			t.Fatal(err)
+		}
+		login(from("10.0.0.3"), "empleado", "Clave123!").OK()
+	})
+
+	t.Run("ip lockout", func(t *testing.T) {
+		for i := range h.Config.Login.MaxAttemptsPerIP {
+			login(from("10.0.0.4"), "usuario"+strconv.Itoa(i), "incorrecta").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		}
+		login(from("10.0.0.4"), AdminUsername, AdminPassword).ExpectError(http.StatusTooManyRequests, models.CodeTooManyRequests)
+		login(from("10.0.0.5"), AdminUsername, AdminPassword).OK()
+	})
+
+	t.Run("history", func(t *testing.T) {
+		var attempts []models.LoginAttempt
+		env := admin.Get("/auth/login_history?username=empleado").OK().Decode(&attempts).Envelope()
+		if env.Meta == nil || env.Meta.Total != int64(h.Config.Login.MaxAttempts)+2 {
+			t.Fatalf("historial de empleado = %+v", attempts)
+		}
+		// el más reciente primero
+		if latest := attempts[0]; !latest.Success || latest.IP != "10.0.0.3" || latest.UserAgent != "e2e/10.0.0.3" || latest.UserID == nil {
+			t.Errorf("último intento = %+v", latest)
+		}
+
+		admin.Get("/auth/login_history?result=locked").OK().Decode(&attempts)
+		if len(attempts) != 2 {
+			t.Errorf("intentos bloqueados = %+v", attempts)
+		}
+		admin.Get("/auth/login_history?ip=10.0.0.4&success=false").OK().Decode(&attempts)
+		if len(attempts) != h.Config.Login.MaxAttemptsPerIP+1 || attempts[0].Result != models.LoginLocked {
+			t.Errorf("intentos desde 10.0.0.4 = %+v", attempts)
+		}
+		admin.Get("/auth/login_history?password=x").ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+
+		employee := h.Login("empleado", "Clave123!")
+		employee.Get("/auth/login_history").ExpectError(http.StatusForbidden, models.CodeForbidden)
+	})
+	t.Run("untrusted proxy", func(t *testing.T) {
+		// si la conexión no viene de un proxy de confianza el header se ignora:
+		// rotarlo no saltea el límite por IP
+		cfg := *h.Config
+		cfg.TrustedProxies = []string{"10.9.9.9"}
+		app := h.App
+		h.App = server.New(h.DB, &cfg)
+		defer func() { h.App = app }()
+
+		for i := range h.Config.Login.MaxAttemptsPerIP {
+			login(from("10.1.0."+strconv.Itoa(i)), "usuario"+strconv.Itoa(i), "incorrecta").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		}
+		login(from("10.1.1.1"), AdminUsername, AdminPassword).ExpectError(http.StatusTooManyRequests, models.CodeTooManyRequests)
+	})
+}
@@ -0,0 +1,166 @@
+package e2e
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/server"
+)
+
+var resetLink = regexp.MustCompile(`token=(\S+)`)
+
+// outbox devuelve los correos que dejó el mailer de archivos, en orden.
+func outbox(t *testing.T, h *Harness) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(h.Config.Mail.OutboxDir, "*.eml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mails := make([]string, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mails = append(mails, string(data))
+	}
+	return mails
+}
+
+// resetToken pide la recuperación para email y devuelve el token del correo.
+func resetToken(t *testing.T, h *Harness, email string) string {
+	t.Helper()
+	before := len(outbox(t, h))
+	h.Anonymous().Post("/auth/forgot_password", models.ForgotPasswordRequest{Email: email}).OK()
+	// el correo se envía en segundo plano
+	mails := outbox(t, h)
+	for deadline := time.Now().Add(5 * time.Second); len(mails) == before && time.Now().Before(deadline); mails = outbox(t, h) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(mails) != before+1 {
+		t.Fatalf("se esperaba un correo nuevo, hay %d", len(mails)-before)
+	}
+	mail := mails[len(mails)-1]
+	if !strings.Contains(mail, "To: "+email) {
+		t.Fatalf("correo a otro destinatario:\n%s", mail)
+	}
+	match := resetLink.FindStringSubmatch(mail)
+	if match == nil {
+		t.Fatalf("el correo no tiene el link de recuperación:\n%s", mail)
+	}
+	token, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestPasswordChange(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	admin.Post("/user/create", models.UserCreate{
+		FirstName: "Empleado",
+		LastName:  "E2E",
+		Username:  "empleado",
+		Email:     "empleado@gestioncar.test",
+		Password:  "Clave123!",
+		Role:      "employee_laundry",
+	}).OK()
+	employee := h.Login("empleado", "Clave123!")
+	other := h.Login("empleado", "Clave123!")
+
+	employee.Post("/auth/change_password", models.ChangePasswordRequest{CurrentPassword: "incorrecta", NewPassword: "Nueva123!"}).
+		ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	employee.Post("/auth/change_password", models.ChangePasswordRequest{CurrentPassword: "Clave123!", NewPassword: "corta"}).
+		ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+	employee.Post("/auth/change_password", models.ChangePasswordRequest{CurrentPassword: "Clave123!", NewPassword: "Clave123!"}).
+		ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	h.Anonymous().Post("/auth/change_password", models.ChangePasswordRequest{CurrentPassword: "Clave123!", NewPassword: "Nueva123!"}).
+		ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+
+	employee.Post("/auth/change_password", models.ChangePasswordRequest{CurrentPassword: "Clave123!", NewPassword: "Nueva123!"}).OK()
+
+	h.Anonymous().Post("/auth/login", models.AuthLogin{Username: "empleado", Password: "Clave123!"}).
+		ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+	h.Login("empleado", "Nueva123!")
+	// las otras sesiones no pueden renovarse
+	h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: other.RefreshToken}).
+		ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+
+	var audit []models.AuditLog
+	admin.Get("/audit/get_all?entity_type=user&action=" + models.AuditUpdate).OK().Decode(&audit)
+	if len(audit) != 1 || audit[0].UserID != audit[0].EntityID || audit[0].After["password"] != nil {
+		t.Errorf("auditoría del cambio de contraseña = %+v", audit)
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	email := "admin@gestioncar.test"
+
+	t.Run("unknown email", func(t *testing.T) {
+		unknown := h.Anonymous().Post("/auth/forgot_password", models.ForgotPasswordRequest{Email: "nadie@gestioncar.test"}).OK().Envelope()
+		time.Sleep(50 * time.Millisecond)
+		if mails := outbox(t, h); len(mails) != 0 {
+			t.Errorf("no debería enviarse correo: %v", mails)
+		}
+
+		// aunque el correo no pueda enviarse la respuesta es la misma
+		cfg := *h.Config
+		// bajo un archivo no se puede crear el directorio, y no queda nada
+		// que limpiar si el envío termina después del test
+		cfg.Mail.OutboxDir = filepath.Join(os.DevNull, "outbox")
+		app := h.App
+		h.App = server.New(h.DB, &cfg)
+		defer func() { h.App = app }()
+		known := h.Anonymous().Post("/auth/forgot_password", models.ForgotPasswordRequest{Email: email}).OK().Envelope()
+		if known.Message != unknown.Message {
+			t.Errorf("las respuestas delatan el email: %q / %q", known.Message, unknown.Message)
+		}
+		h.Anonymous().Post("/auth/forgot_password", models.ForgotPasswordRequest{Email: "no-es-email"}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		token := resetToken(t, h, email)
+		h.Anonymous().Post("/auth/reset_password", models.ResetPasswordRequest{Token: "invalido", NewPassword: "Nueva123!"}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+
+		h.Anonymous().Post("/auth/reset_password", models.ResetPasswordRequest{Token: token, NewPassword: "Nueva123!"}).OK()
+		h.Anonymous().Post("/auth/reset_password", models.ResetPasswordRequest{Token: token, NewPassword: "Otra123!"}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+
+		h.Anonymous().Post("/auth/login", models.AuthLogin{Username: AdminUsername, Password: AdminPassword}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		h.Login(AdminUsername, "Nueva123!")
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: admin.RefreshToken}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+	})
+
+	t.Run("single pending token", func(t *testing.T) {
+		first := resetToken(t, h, email)
+		second := resetToken(t, h, email)
+		h.Anonymous().Post("/auth/reset_password", models.ResetPasswordRequest{Token: second, NewPassword: "Clave123!"}).OK()
+		h.Anonymous().Post("/auth/reset_password", models.ResetPasswordRequest{Token: first, NewPassword: "Otra123!"}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		token := resetToken(t, h, email)
+		if err := h.DB.Model(&models.PasswordResetToken{}).Where("used_at IS NULL").
+			Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+			t.Fatal(err)
+		}
+		h.Anonymous().Post("/auth/reset_password", models.ResetPasswordRequest{Token: token, NewPassword: "Otra123!"}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+		h.Login(AdminUsername, "Clave123!")
+	})
+}
@@ -0,0 +1,102 @@
+package e2e
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestPermissions(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	createUser := func(username, role string) *Session {
+		t.Helper()
+		admin.Post("/user/create", models.UserCreate{
+			FirstName: username,
+			LastName:  "E2E",
+			Username:  username,
+			Email:     username + "@gestioncar.test",
+			Password:  "Clave123!",
+			Role:      role,
+		}).OK()
+		return h.Login(username, "Clave123!")
+	}
+	employee := createUser("empleado", "employee_laundry")
+	manager := createUser("encargado", "admin_laundry")
+
+	laundry := admin.Workplace("laundry")
+	movementTypeID := laundry.Post("/movement/create", models.MovementTypeCreate{Name: "Pago"}).ID()
+	employeeLaundry := employee.Workplace("laundry")
+
+	rolePermissions := func(role string) []string {
+		t.Helper()
+		var body models.RolePermissions
+		admin.Get("/permission/role/" + role).OK().Decode(&body)
+		return body.Permissions
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		expenseID := employeeLaundry.Post("/expense/create", models.ExpenseCreate{Details: "Luz", MovementTypeID: movementTypeID, Amount: 100}).ID()
+		employeeLaundry.Delete("/expense/delete/"+expenseID).ExpectError(http.StatusForbidden, models.CodeForbidden)
+		employeeLaundry.Post("/movement/create", models.MovementTypeCreate{Name: "Otro"}).ExpectError(http.StatusForbidden, models.CodeForbidden)
+		employee.Post("/user/create", models.UserCreate{}).ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		manager.Workplace("laundry").Delete("/expense/delete/" + expenseID).OK()
+		manager.Workplace("laundry").Get("/trash/expense").ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		var catalog []models.Permission
+		admin.Get("/permission/get_all").OK().Decode(&catalog)
+		if len(catalog) != len(models.Permissions) {
+			t.Errorf("catálogo con %d permisos, se esperaban %d", len(catalog), len(models.Permissions))
+		}
+		employee.Get("/permission/get_all").ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		permissions := rolePermissions("employee_laundry")
+		if !slices.Contains(permissions, models.PermExpenseCreate) || slices.Contains(permissions, models.PermExpenseDelete) {
+			t.Errorf("permisos de employee_laundry = %v", permissions)
+		}
+		admin.Get("/permission/role/no-existe").ExpectError(http.StatusNotFound, models.CodeNotFound)
+	})
+
+	t.Run("grant", func(t *testing.T) {
+		permissions := append(rolePermissions("employee_laundry"), models.PermExpenseDelete)
+		admin.Put("/permission/role/employee_laundry", models.RolePermissionsUpdate{Permissions: permissions}).OK()
+
+		expenseID := employeeLaundry.Post("/expense/create", models.ExpenseCreate{Details: "Agua", MovementTypeID: movementTypeID, Amount: 50}).ID()
+		employeeLaundry.Delete("/expense/delete/" + expenseID).OK()
+
+		// al quitarlo vuelve a estar prohibido
+		admin.Put("/permission/role/employee_laundry", models.RolePermissionsUpdate{Permissions: []string{models.PermExpenseCreate}}).OK()
+		expenseID = employeeLaundry.Post("/expense/create", models.ExpenseCreate{Details: "Gas", MovementTypeID: movementTypeID, Amount: 75}).ID()
+		employeeLaundry.Delete("/expense/delete/"+expenseID).ExpectError(http.StatusForbidden, models.CodeForbidden)
+		if got := rolePermissions("employee_laundry"); !slices.Equal(got, []string{models.PermExpenseCreate}) {
+			t.Errorf("permisos de employee_laundry = %v", got)
+		}
+	})
+
+	t.Run("hierarchy", func(t *testing.T) {
+		// el admin sembrado es super_admin: nadie puede tocar su rol
+		admin.Put("/permission/role/super_admin", models.RolePermissionsUpdate{Permissions: []string{}}).
+			ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		// un admin_laundry con permission.manage solo reparte lo que tiene
+		managerPermissions := append(rolePermissions("admin_laundry"), models.PermPermissionManage)
+		admin.Put("/permission/role/admin_laundry", models.RolePermissionsUpdate{Permissions: managerPermissions}).OK()
+		manager.Put("/permission/role/employee_laundry", models.RolePermissionsUpdate{Permissions: []string{models.PermTrashManage}}).
+			ExpectError(http.StatusForbidden, models.CodeForbidden)
+		manager.Put("/permission/role/employee_laundry", models.RolePermissionsUpdate{Permissions: []string{models.PermExpenseCreate, models.PermExpenseDelete}}).OK()
+		manager.Put("/permission/role/admin_workshop", models.RolePermissionsUpdate{Permissions: []string{}}).
+			ExpectError(http.StatusForbidden, models.CodeForbidden)
+		manager.Put("/permission/role/admin", models.RolePermissionsUpdate{Permissions: []string{}}).
+			ExpectError(http.StatusForbidden, models.CodeForbidden)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		admin.Put("/permission/role/employee_laundry", models.RolePermissionsUpdate{Permissions: []string{"no.existe"}}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+		admin.Put("/permission/role/employee_laundry", map[string]interface{}{}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+	})
+}
@@ -0,0 +1,265 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestServicePrices(t *testing.T) {
+	h := New(t)
+	laundry := h.LoginAdmin().Workplace("laundry")
+
+	clientID := createClient(laundry, "precios")
+	vehicleID := createVehicle(laundry, clientID, "PR001AA")
+	movementTypeID := laundry.Post("/movement/create", models.MovementTypeCreate{Name: "Cobro", IsIncome: true}).ID()
+	wash := laundry.Post("/service/create", models.ServiceCreate{Name: "Lavado", Price: 1000}).ID()
+	wax := laundry.Post("/service/create", models.ServiceCreate{Name: "Encerado", Price: 800}).ID()
+
+	price := func(serviceID string) float32 {
+		t.Helper()
+		var service models.Service
+		laundry.Get("/service/" + serviceID).OK().Decode(&service)
+		if service.Price == nil {
+			t.Fatalf("el servicio %s no tiene precio", serviceID)
+		}
+		return *service.Price
+	}
+	newIncome := func(servicesID ...string) models.IncomeCreate {
+		return models.IncomeCreate{
+			Ticket:         "P-1",
+			ServicesID:     servicesID,
+			Details:        "Precios",
+			ClientID:       clientID,
+			VehicleID:      vehicleID,
+			MovementTypeID: movementTypeID,
+		}
+	}
+
+	t.Run("history", func(t *testing.T) {
+		// un aumento programado no cambia el precio vigente
+		laundry.Post("/service/set_price/"+wash, models.ServicePriceCreate{Price: 1200, EffectiveFrom: ptr(time.Now().Add(time.Hour))}).OK()
+		if p := price(wash); p != 1000 {
+			t.Errorf("precio vigente = %v, se esperaba 1000", p)
+		}
+		var prices []models.ServicePrice
+		laundry.Get("/service/prices/" + wash).OK().Decode(&prices)
+		if len(prices) != 2 || prices[0].Price != 1200 || prices[1].Price != 1000 {
+			t.Errorf("historial = %+v", prices)
+		}
+
+		laundry.Post("/service/set_price/"+wash, models.ServicePriceCreate{Price: 900, EffectiveFrom: ptr(time.Now().Add(-time.Hour))}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+		laundry.Post("/service/set_price/"+wash, models.ServicePriceCreate{Price: -1}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+		laundry.Post("/service/set_price/no-existe", models.ServicePriceCreate{Price: 10}).
+			ExpectError(http.StatusNotFound, models.CodeNotFound)
+		h.LoginAdmin().Workplace("workshop").Get("/service/prices/"+wash).ExpectError(http.StatusNotFound, models.CodeNotFound)
+	})
+
+	t.Run("income snapshot", func(t *testing.T) {
+		id := laundry.Post("/income/create", newIncome(wash)).ID()
+
+		// el aumento entra en vigencia
+		if err := h.DB.Model(&models.ServicePrice{}).Where("service_id = ? AND price = ?", wash, 1200).
+			Update("effective_from", time.Now()).Error; err != nil {
+			t.Fatal(err)
+		}
+		if p := price(wash); p != 1200 {
+			t.Fatalf("precio vigente = %v, se esperaba 1200", p)
+		}
+
+		// la línea que ya estaba conserva su precio; la nueva toma el vigente
+		update := newIncome(wash, wax)
+		laundry.IfMatch("/income/"+id).Put("/income/update", models.IncomeUpdate{
+			ID:             id,
+			Ticket:         update.Ticket,
+			ServicesID:     update.ServicesID,
+			Details:        update.Details,
+			ClientID:       clientID,
+			VehicleID:      vehicleID,
+			MovementTypeID: movementTypeID,
+		}).OK()
+		var income models.Income
+		laundry.Get("/income/" + id).OK().Decode(&income)
+		charged := map[string]float32{}
+		for _, line := range income.Services {
+			charged[line.ServiceID] = line.Price
+		}
+		if charged[wash] != 1000 || charged[wax] != 800 || income.Subtotal != 1800 || income.Amount != 1800 {
+			t.Errorf("líneas = %v, subtotal = %v, amount = %v", charged, income.Subtotal, income.Amount)
+		}
+
+		laundry.Post("/income/create", newIncome(wash)).ID()
+		var incomes []models.Income
+		laundry.Get("/income/get_all?sort=-amount").OK().Decode(&incomes)
+		if len(incomes) != 2 || incomes[1].Amount != 1200 {
+			t.Errorf("ingresos = %+v", incomes)
+		}
+	})
+
+	t.Run("discount", func(t *testing.T) {
+		income := newIncome(wash, wax)
+		income.Discount = 200
+		env := laundry.Post("/income/create", income).ExpectError(http.StatusUnprocessableEntity, models.CodeValidation).Envelope()
+		if len(env.Errors) != 1 || env.Errors[0].Field != "discount_reason" || env.Errors[0].Message != "discount_reason es un campo requerido cuando se indica discount" {
+			t.Errorf("errores = %+v", env.Errors)
+		}
+
+		income.DiscountReason = "Cliente frecuente"
+		id := laundry.Post("/income/create", income).ID()
+		var created models.Income
+		laundry.Get("/income/" + id).OK().Decode(&created)
+		if created.Subtotal != 2000 || created.Discount != 200 || created.Amount != 1800 || created.DiscountReason != "Cliente frecuente" {
+			t.Errorf("ingreso = %+v", created)
+		}
+
+		income.Discount = 2500
+		laundry.Post("/income/create", income).ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	})
+
+	t.Run("vehicle category", func(t *testing.T) {
+		var categories []string
+		laundry.Get("/vehicle/categories").OK().Decode(&categories)
+		if len(categories) != 5 || categories[2] != models.VehiclePickup {
+			t.Errorf("categorías = %v", categories)
+		}
+
+		pickupID := laundry.Post("/vehicle/create", models.VehicleCreate{
+			Brand: "Toyota", Color: "Gris", Domain: "PR002AA", Category: models.VehiclePickup, ClientID: clientID,
+		}).ID()
+		motoID := laundry.Post("/vehicle/create", models.VehicleCreate{
+			Brand: "Honda", Color: "Rojo", Domain: "PR003AA", Category: models.VehicleMoto, ClientID: clientID,
+		}).ID()
+		laundry.Post("/vehicle/create", models.VehicleCreate{Brand: "Ford", Color: "Azul", Domain: "PR004AA", Category: "camion", ClientID: clientID}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+
+		laundry.Post("/service/set_price/"+wash, models.ServicePriceCreate{Price: 1500, VehicleCategory: models.VehiclePickup}).OK()
+		laundry.Post("/service/set_price/"+wash, models.ServicePriceCreate{Price: 1, VehicleCategory: "camion"}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+		var service models.Service
+		laundry.Get("/service/" + wash).OK().Decode(&service)
+		if *service.Price != 1200 || service.CategoryPrices[models.VehiclePickup] != 1500 || len(service.CategoryPrices) != 1 {
+			t.Errorf("precios = %v / %v", *service.Price, service.CategoryPrices)
+		}
+
+		// la camioneta paga su precio; la moto, sin precio propio, el general
+		charged := func(vehicleID string) float32 {
+			t.Helper()
+			income := newIncome(wash)
+			income.VehicleID = vehicleID
+			var created models.Income
+			laundry.Get("/income/" + laundry.Post("/income/create", income).ID()).OK().Decode(&created)
+			return created.Amount
+		}
+		if amount := charged(pickupID); amount != 1500 {
+			t.Errorf("camioneta = %v, se esperaba 1500", amount)
+		}
+		if amount := charged(motoID); amount != 1200 {
+			t.Errorf("moto = %v, se esperaba 1200", amount)
+		}
+
+		// cambiar el ingreso a un vehículo de otra categoría recalcula sus
+		// líneas con los precios de esa categoría a la fecha del ingreso, no
+		// con los de hoy
+		incomeID := laundry.Post("/income/create", func() models.IncomeCreate {
+			income := newIncome(wash)
+			income.VehicleID = pickupID
+			return income
+		}()).ID()
+		if err := h.DB.Create(&models.ServicePrice{ID: "aumento-general", ServiceID: wash, Price: 1300, EffectiveFrom: time.Now()}).Error; err != nil {
+			t.Fatal(err)
+		}
+		moveTo := func(vehicleID string) models.Income {
+			t.Helper()
+			var income models.Income
+			laundry.Get("/income/" + incomeID).OK().Decode(&income)
+			laundry.Put("/income/update", models.IncomeUpdate{
+				ID:             incomeID,
+				Ticket:         income.Ticket,
+				ServicesID:     []string{wash},
+				Details:        income.Details,
+				ClientID:       clientID,
+				VehicleID:      vehicleID,
+				MovementTypeID: movementTypeID,
+				Version:        income.Version,
+			}).OK()
+			laundry.Get("/income/" + incomeID).OK().Decode(&income)
+			return income
+		}
+		if income := moveTo(motoID); len(income.Services) != 1 || income.Services[0].Price != 1200 || income.Amount != 1200 {
+			t.Errorf("ingreso pasado a la moto: líneas = %+v, amount = %v", income.Services, income.Amount)
+		}
+		if income := moveTo(pickupID); len(income.Services) != 1 || income.Services[0].Price != 1500 || income.Amount != 1500 {
+			t.Errorf("ingreso devuelto a la camioneta: líneas = %+v, amount = %v", income.Services, income.Amount)
+		}
+
+		// al cambiar la categoría del vehículo cambia el precio de los ingresos nuevos
+		laundry.IfMatch("/vehicle/"+motoID).Put("/vehicle/update", models.VehicleUpdate{ID: motoID, Category: models.VehiclePickup, ClientID: clientID}).OK()
+		if amount := charged(motoID); amount != 1500 {
+			t.Errorf("moto como camioneta = %v, se esperaba 1500", amount)
+		}
+
+		income := newIncome(wash)
+		income.VehicleID = "no-existe"
+		laundry.Post("/income/create", income).ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	})
+
+	t.Run("workplace without category prices", func(t *testing.T) {
+		workshop := h.LoginAdmin().Workplace("workshop")
+		alignment := workshop.Post("/service/create", models.ServiceCreate{Name: "Alineación camioneta", Price: 500}).ID()
+		workshop.Post("/service/set_price/"+alignment, models.ServicePriceCreate{Price: 900, VehicleCategory: models.VehiclePickup}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+
+		// un precio por categoría cargado antes de la restricción no se usa
+		if err := h.DB.Create(&models.ServicePrice{
+			ID:              "precio-categoria-taller",
+			ServiceID:       alignment,
+			VehicleCategory: models.VehiclePickup,
+			Price:           900,
+			EffectiveFrom:   time.Now().Add(-time.Minute),
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+		pickupID := laundry.Post("/vehicle/create", models.VehicleCreate{
+			Brand: "Ford", Color: "Negro", Domain: "PR009AA", Category: models.VehiclePickup, ClientID: clientID,
+		}).ID()
+		workshopMovement := workshop.Post("/movement/create", models.MovementTypeCreate{Name: "Cobro taller", IsIncome: true}).ID()
+		id := workshop.Post("/income/create", models.IncomeCreate{
+			Ticket:         "P-2",
+			ServicesID:     []string{alignment},
+			Details:        "Precio general en el taller",
+			ClientID:       clientID,
+			VehicleID:      pickupID,
+			MovementTypeID: workshopMovement,
+		}).ID()
+		var income models.Income
+		workshop.Get("/income/" + id).OK().Decode(&income)
+		if income.Amount != 500 {
+			t.Errorf("amount = %v, se esperaba el precio general 500", income.Amount)
+		}
+	})
+
+	t.Run("unpriced service", func(t *testing.T) {
+		// un servicio de antes de los precios, o de otro workplace
+		legacy := laundry.Post("/service/create", models.ServiceCreate{Name: "Tapizado"}).ID()
+		if err := h.DB.Where("service_id = ?", legacy).Delete(&models.ServicePrice{}).Error; err != nil {
+			t.Fatal(err)
+		}
+		var service models.Service
+		laundry.Get("/service/" + legacy).OK().Decode(&service)
+		if service.Price != nil {
+			t.Errorf("precio = %v, se esperaba null", *service.Price)
+		}
+		laundry.Post("/income/create", newIncome(wash, legacy)).ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+
+		other := h.LoginAdmin().Workplace("workshop").Post("/service/create", models.ServiceCreate{Name: "Alineación", Price: 500}).ID()
+		laundry.Post("/income/create", newIncome(other)).ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,117 @@
+package e2e
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestSearch(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	laundry := admin.Workplace("laundry")
+	workshop := admin.Workplace("workshop")
+
+	clientID := laundry.Post("/client/create", models.ClientCreate{
+		FirstName: "Juan",
+		LastName:  "Pérez",
+		CUIL:      "20-30111222-3",
+		DNI:       "30111222",
+		Email:     "juan@cliente.test",
+	}).ID()
+	// Perez solo en el email: tiene que quedar después del que lo tiene en el nombre
+	emailClientID := laundry.Post("/client/create", models.ClientCreate{
+		FirstName: "Ana",
+		LastName:  "Gómez",
+		CUIL:      "27-30999888-4",
+		DNI:       "30999888",
+		Email:     "perez.ana@cliente.test",
+	}).ID()
+	vehicleID := createVehicle(laundry, clientID, "AB123CD")
+	laundry.Post("/product/create", models.ProductCreate{Identifier: "SH-1", Name: "Shampoo neutro"}).ID()
+	workshop.Post("/supplier/create", models.SupplierCreate{Name: "Químicos del Norte", Email: "ventas@quimicos.test"}).ID()
+
+	search := func(s *Session, q string) []models.SearchHit {
+		t.Helper()
+		var hits []models.SearchHit
+		s.Get("/search?q=" + url.QueryEscape(q)).OK().Decode(&hits)
+		return hits
+	}
+	hasHit := func(hits []models.SearchHit, hitType, id string) bool {
+		for _, hit := range hits {
+			if hit.Type == hitType && (id == "" || hit.ID == id) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if hits := search(laundry, "Pérez"); !hasHit(hits, models.SearchTypeClient, clientID) {
+		t.Errorf("Pérez: %+v", hits)
+	}
+	if hits := search(laundry, "30111222"); !hasHit(hits, models.SearchTypeClient, clientID) {
+		t.Errorf("DNI: %+v", hits)
+	}
+	if hits := search(laundry, "ab123"); !hasHit(hits, models.SearchTypeVehicle, vehicleID) {
+		t.Errorf("dominio: %+v", hits)
+	}
+
+	// productos y proveedores son del workplace
+	if hits := search(laundry, "shampoo"); !hasHit(hits, models.SearchTypeProduct, "") {
+		t.Errorf("shampoo en lavandería: %+v", hits)
+	}
+	if hits := search(workshop, "shampoo"); len(hits) != 0 {
+		t.Errorf("shampoo en taller: %+v", hits)
+	}
+	if hits := search(laundry, "Químicos"); len(hits) != 0 {
+		t.Errorf("proveedor del taller visible en lavandería: %+v", hits)
+	}
+
+	t.Run("relevance", func(t *testing.T) {
+		if !h.DB.Migrator().HasTable("search_index") {
+			t.Skip("sin FTS5 la búsqueda usa LIKE: correr con -tags sqlite_fts5 (make test)")
+		}
+		// ignora acentos y ordena por relevancia: el título pesa más que el resto
+		for _, q := range []string{"perez", "PEREZ", "Pérez"} {
+			if hits := search(laundry, q); len(hits) != 2 || hits[0].ID != clientID || hits[1].ID != emailClientID ||
+				hits[0].Score <= hits[1].Score {
+				t.Errorf("%s: %+v", q, hits)
+			}
+		}
+		if hits := search(workshop, "quimicos"); !hasHit(hits, models.SearchTypeSupplier, "") {
+			t.Errorf("quimicos: %+v", hits)
+		}
+		if hits := search(laundry, "gomez"); len(hits) != 1 || hits[0].ID != emailClientID {
+			t.Errorf("gomez: %+v", hits)
+		}
+	})
+
+	// % y _ no son comodines
+	if hits := search(laundry, "30_11"); len(hits) != 0 {
+		t.Errorf("30_11: %+v", hits)
+	}
+	if hits := search(laundry, "_%"); len(hits) != 0 {
+		t.Errorf("_%%: %+v", hits)
+	}
+
+	// el índice sigue a las escrituras
+	laundry.IfMatch("/client/"+clientID).Put("/client/update", models.ClientUpdate{
+		ID:        clientID,
+		FirstName: "Juan",
+		LastName:  "Gómez",
+		CUIL:      "20-30111222-3",
+		DNI:       "30111222",
+		Email:     "juan@cliente.test",
+	}).OK()
+	if hits := search(laundry, "Pérez"); hasHit(hits, models.SearchTypeClient, clientID) {
+		t.Errorf("el cliente renombrado sigue apareciendo como Pérez: %+v", hits)
+	}
+	laundry.Delete("/vehicle/delete/" + vehicleID).OK()
+	if hits := search(laundry, "ab123"); hasHit(hits, models.SearchTypeVehicle, vehicleID) {
+		t.Errorf("el vehículo borrado sigue apareciendo: %+v", hits)
+	}
+
+	laundry.Get("/search?q=a").ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+}
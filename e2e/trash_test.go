@@ -0,0 +1,187 @@
+package e2e
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestTrash(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	laundry := admin.Workplace("laundry")
+
+	trashIDs := func(s *Session, entity string) map[string]bool {
+		t.Helper()
+		var items []struct {
+			ID        string `json:"id"`
+			DeletedAt string `json:"deleted_at"`
+		}
+		s.Get("/trash/" + entity).OK().Decode(&items)
+		ids := map[string]bool{}
+		for _, item := range items {
+			if item.DeletedAt == "" {
+				t.Errorf("%s %s en la papelera sin deleted_at", entity, item.ID)
+			}
+			ids[item.ID] = true
+		}
+		return ids
+	}
+
+	t.Run("client with vehicles", func(t *testing.T) {
+		clientID := createClient(laundry, "trash")
+		vehicleID := createVehicle(laundry, clientID, "TR001AA")
+
+		laundry.Delete("/client/delete/" + clientID).OK()
+		laundry.Get("/client/" + clientID).Expect(http.StatusNotFound)
+		laundry.Get("/vehicle/" + vehicleID).Expect(http.StatusNotFound)
+		if !trashIDs(laundry, "client")[clientID] || !trashIDs(laundry, "vehicle")[vehicleID] {
+			t.Fatal("el cliente y su vehículo deberían estar en la papelera")
+		}
+
+		var hits []models.SearchHit
+		laundry.Get("/search?q=" + url.QueryEscape("TR001")).OK().Decode(&hits)
+		if len(hits) != 0 {
+			t.Errorf("la búsqueda devolvió registros borrados: %+v", hits)
+		}
+
+		// el dominio sigue ocupado mientras el vehículo esté en la papelera
+		laundry.Post("/vehicle/create", models.VehicleCreate{Brand: "Ford", Color: "Rojo", Domain: "TR001AA", ClientID: clientID}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+
+		laundry.Put("/trash/client/restore/"+clientID, nil).OK()
+		laundry.Get("/client/" + clientID).OK()
+		laundry.Get("/vehicle/" + vehicleID).OK()
+		if trashIDs(laundry, "vehicle")[vehicleID] {
+			t.Error("el vehículo debería haberse restaurado con el cliente")
+		}
+		laundry.Put("/trash/client/restore/"+clientID, nil).ExpectError(http.StatusNotFound, models.CodeNotFound)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		productID := laundry.Post("/product/create", models.ProductCreate{Identifier: "TR-1", Name: "Cera"}).ID()
+
+		// solo se purga lo que está en la papelera
+		laundry.Delete("/trash/product/purge/"+productID).ExpectError(http.StatusNotFound, models.CodeNotFound)
+
+		laundry.Delete("/product/delete/" + productID).OK()
+		laundry.Delete("/trash/product/purge/" + productID).OK()
+		if trashIDs(laundry, "product")[productID] {
+			t.Error("el producto purgado sigue en la papelera")
+		}
+		laundry.Put("/trash/product/restore/"+productID, nil).ExpectError(http.StatusNotFound, models.CodeNotFound)
+
+		// el identifier queda libre
+		laundry.Post("/product/create", models.ProductCreate{Identifier: "TR-1", Name: "Cera"}).ID()
+	})
+
+	t.Run("references", func(t *testing.T) {
+		clientID := createClient(laundry, "trash-ref")
+		vehicleID := createVehicle(laundry, clientID, "TR002AA")
+		serviceID := laundry.Post("/service/create", models.ServiceCreate{Name: "Lavado papelera", Price: 1500}).ID()
+		movementTypeID := laundry.Post("/movement/create", models.MovementTypeCreate{Name: "Cobro papelera"}).ID()
+		incomeID := laundry.Post("/income/create", models.IncomeCreate{
+			Ticket:         "TR-2",
+			ServicesID:     []string{serviceID},
+			Details:        "Ticket con cliente en la papelera",
+			ClientID:       clientID,
+			VehicleID:      vehicleID,
+			MovementTypeID: movementTypeID,
+		}).ID()
+
+		laundry.Delete("/client/delete/" + clientID).OK()
+
+		// el ingreso sigue apuntando al cliente y a su vehículo
+		laundry.Delete("/trash/client/purge/"+clientID).ExpectError(http.StatusConflict, models.CodeConflict)
+		laundry.Delete("/trash/vehicle/purge/"+vehicleID).ExpectError(http.StatusConflict, models.CodeConflict)
+
+		// el vehículo no vuelve sin su cliente
+		laundry.Put("/trash/vehicle/restore/"+vehicleID, nil).ExpectError(http.StatusConflict, models.CodeConflict)
+		laundry.Get("/vehicle/" + vehicleID).Expect(http.StatusNotFound)
+
+		// un ingreso en la papelera también cuenta como referencia
+		laundry.Put("/trash/client/restore/"+clientID, nil).OK()
+		laundry.Delete("/income/delete/" + incomeID).OK()
+		laundry.Delete("/client/delete/" + clientID).OK()
+		laundry.Delete("/trash/client/purge/"+clientID).ExpectError(http.StatusConflict, models.CodeConflict)
+		laundry.Delete("/service/delete/" + serviceID).OK()
+		laundry.Delete("/trash/service/purge/"+serviceID).ExpectError(http.StatusConflict, models.CodeConflict)
+
+		laundry.Delete("/trash/income/purge/" + incomeID).OK()
+		laundry.Delete("/trash/client/purge/" + clientID).OK()
+		laundry.Delete("/trash/service/purge/" + serviceID).OK()
+		if trashIDs(laundry, "vehicle")[vehicleID] {
+			t.Error("el vehículo debería haberse purgado con el cliente")
+		}
+	})
+
+	t.Run("unique keys", func(t *testing.T) {
+		clientID := createClient(laundry, "trash-key")
+		laundry.Delete("/client/delete/" + clientID).OK()
+		laundry.Post("/client/create", models.ClientCreate{FirstName: "Otro", LastName: "Cliente", CUIL: "cuil-trash-key", DNI: "dni-nuevo", Email: "otro@vehiculo.test"}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+		otherID := createClient(laundry, "trash-key-2")
+		laundry.Put("/client/update", models.ClientUpdate{ID: otherID, FirstName: "Otro", LastName: "Cliente", CUIL: "cuil-trash-key-2", DNI: "dni-trash-key", Email: "otro@vehiculo.test", Version: 1}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+		// conservar los propios valores no choca consigo mismo
+		laundry.Put("/client/update", models.ClientUpdate{ID: otherID, FirstName: "Otro", LastName: "Cliente", CUIL: "cuil-trash-key-2", DNI: "dni-trash-key-2", Email: "otro@vehiculo.test", Version: 1}).OK()
+
+		productID := laundry.Post("/product/create", models.ProductCreate{Identifier: "TR-3", Name: "Shampoo"}).ID()
+		laundry.Delete("/product/delete/" + productID).OK()
+		laundry.Post("/product/create", models.ProductCreate{Identifier: "TR-3", Name: "Shampoo"}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+		otherProductID := laundry.Post("/product/create", models.ProductCreate{Identifier: "TR-4", Name: "Shampoo"}).ID()
+		laundry.Put("/product/update", models.ProductUpdate{ID: otherProductID, Identifier: "TR-3", Name: "Shampoo", Version: 1}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+		// el identifier es por workplace
+		admin.Workplace("workshop").Post("/product/create", models.ProductCreate{Identifier: "TR-3", Name: "Shampoo"}).ID()
+
+		serviceID := laundry.Post("/service/create", models.ServiceCreate{Name: "Encerado papelera"}).ID()
+		laundry.Delete("/service/delete/" + serviceID).OK()
+		otherServiceID := laundry.Post("/service/create", models.ServiceCreate{Name: "Pulido papelera"}).ID()
+		laundry.Put("/service/update", models.ServiceUpdate{ID: otherServiceID, Name: "Encerado papelera", Version: 1}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+		laundry.Put("/service/update", models.ServiceUpdate{ID: otherServiceID, Name: "Pulido papelera", Version: 1}).OK()
+
+		vehicleOwner := createClient(laundry, "trash-key-3")
+		vehicleID := createVehicle(laundry, vehicleOwner, "TR005AA")
+		laundry.Delete("/vehicle/delete/" + vehicleID).OK()
+		otherVehicleID := createVehicle(laundry, vehicleOwner, "TR006AA")
+		laundry.Put("/vehicle/update", models.VehicleUpdate{ID: otherVehicleID, Domain: "TR005AA", ClientID: vehicleOwner, Version: 1}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+	})
+
+	t.Run("workplace", func(t *testing.T) {
+		movementTypeID := laundry.Post("/movement/create", models.MovementTypeCreate{Name: "Pago"}).ID()
+		expenseID := laundry.Post("/expense/create", models.ExpenseCreate{Details: "Luz", MovementTypeID: movementTypeID, Amount: 100}).ID()
+		laundry.Delete("/expense/delete/" + expenseID).OK()
+
+		workshop := admin.Workplace("workshop")
+		if trashIDs(workshop, "expense")[expenseID] {
+			t.Error("el egreso de la lavandería se ve en la papelera del taller")
+		}
+		workshop.Put("/trash/expense/restore/"+expenseID, nil).ExpectError(http.StatusNotFound, models.CodeNotFound)
+		laundry.Put("/trash/expense/restore/"+expenseID, nil).OK()
+		laundry.Get("/expense/" + expenseID).OK()
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		laundry.Get("/trash/user").ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+		laundry.Get("/trash/client?sort=nope").ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		admin.Post("/user/create", models.UserCreate{
+			FirstName: "Empleado",
+			LastName:  "Lavado",
+			Username:  "empleado",
+			Email:     "empleado@gestioncar.test",
+			Password:  "Empleado123!",
+			Role:      "employee_laundry",
+		}).OK()
+		employee := h.Login("empleado", "Empleado123!").Workplace("laundry")
+		employee.Get("/trash/client").ExpectError(http.StatusForbidden, models.CodeForbidden)
+	})
+}
@@ -0,0 +1,104 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/utils"
+	"gorm.io/gorm"
+)
+
+// baselineSchema son las tablas tal como las dejaba el AutoMigrate de la
+// primera versión publicada, con tablas separadas para lavadero y taller.
+var baselineSchema = []string{
+	"CREATE TABLE `users` (`id` text,`first_name` text NOT NULL,`last_name` text NOT NULL,`username` text NOT NULL,`email` text NOT NULL,`password` text NOT NULL,`role` text NOT NULL,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `uni_users_username` UNIQUE (`username`),CONSTRAINT `uni_users_email` UNIQUE (`email`))",
+	"CREATE TABLE `clients` (`id` text,`first_name` text NOT NULL,`last_name` text NOT NULL,`c_ui_l` text,`dni` text,`email` text,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `uni_clients_c_ui_l` UNIQUE (`c_ui_l`),CONSTRAINT `uni_clients_dni` UNIQUE (`dni`),CONSTRAINT `uni_clients_email` UNIQUE (`email`))",
+	"CREATE TABLE `vehicles` (`id` text,`brand` text NOT NULL,`model` text,`color` text NOT NULL,`year` text,`domain` text NOT NULL,`client_id` text NOT NULL,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_clients_vehicles` FOREIGN KEY (`client_id`) REFERENCES `clients`(`id`),CONSTRAINT `uni_vehicles_domain` UNIQUE (`domain`))",
+	"CREATE TABLE `workplaces` (`id` text,`name` text NOT NULL,`address` text NOT NULL,`phone` text NOT NULL,`email` text NOT NULL,`identifier` text NOT NULL,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `uni_workplaces_identifier` UNIQUE (`identifier`))",
+	"CREATE TABLE `roles` (`id` text,`name` text NOT NULL,`hierarchy` integer NOT NULL,`workplace` text NOT NULL,PRIMARY KEY (`id`))",
+	"CREATE TABLE `audit_logs` (`id` text,`user_id` text NOT NULL,`method` text NOT NULL,`path` text NOT NULL,`created_at` text,`updated_at` text,PRIMARY KEY (`id`))",
+	"CREATE TABLE `employee_laundries` (`id` text,`name` text NOT NULL,`phone` text NOT NULL,`email` text NOT NULL,`address` text NOT NULL,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`))",
+	"CREATE TABLE `movement_type_laundries` (`id` text,`name` text NOT NULL,`is_income` numeric NOT NULL,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`))",
+	"CREATE TABLE `income_laundries` (`id` text,`ticket` text,`details` text,`client_id` text NOT NULL,`vehicle_id` text NOT NULL,`employee_id` text,`amount` real NOT NULL,`movement_type_id` text NOT NULL,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `fk_income_laundries_client` FOREIGN KEY (`client_id`) REFERENCES `clients`(`id`),CONSTRAINT `fk_income_laundries_vehicle` FOREIGN KEY (`vehicle_id`) REFERENCES `vehicles`(`id`),CONSTRAINT `fk_income_laundries_employee_laundry` FOREIGN KEY (`employee_id`) REFERENCES `employee_laundries`(`id`),CONSTRAINT `fk_income_laundries_movement_type_laundry` FOREIGN KEY (`movement_type_id`) REFERENCES `movement_type_laundries`(`id`))",
+	"CREATE TABLE `service_laundries` (`id` text,`name` text NOT NULL,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `uni_service_laundries_name` UNIQUE (`name`))",
+	"CREATE TABLE `income_service_laundries` (`id` text,`income_laundry_id` text NOT NULL,`service_id` text NOT NULL,PRIMARY KEY (`id`),CONSTRAINT `fk_income_service_laundries_income_laundry` FOREIGN KEY (`income_laundry_id`) REFERENCES `income_laundries`(`id`),CONSTRAINT `fk_income_service_laundries_service` FOREIGN KEY (`service_id`) REFERENCES `service_laundries`(`id`))",
+	"CREATE TABLE `part_workshops` (`id` text,`identifier` text NOT NULL,`name` text NOT NULL,`stock` integer NOT NULL DEFAULT 0,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`),CONSTRAINT `uni_part_workshops_identifier` UNIQUE (`identifier`))",
+}
+
+// baselineData carga lo que tendría una instalación en uso: el admin, los
+// workplaces y roles que creaba Connect, un registro de la auditoría vieja y
+// un ingreso del lavadero.
+func baselineData(password string) [][]interface{} {
+	const now = "2025-03-01 10:00:00"
+	return [][]interface{}{
+		{"INSERT INTO users VALUES ('u1', 'Admin', 'E2E', ?, 'admin@gestioncar.test', ?, 'super_admin', ?, ?)", AdminUsername, password, now, now},
+		{"INSERT INTO workplaces VALUES ('w1', 'Lavanderia', 'Av. Los Olivos', '123456789', 'laundry@example.com', 'laundry', ?, ?)", now, now},
+		{"INSERT INTO workplaces VALUES ('w2', 'Taller', 'Av. Los Olivos', '123456789', 'workshop@example.com', 'workshop', ?, ?)", now, now},
+		{"INSERT INTO roles VALUES ('r1', 'super_admin', 1, 'all')"},
+		{"INSERT INTO audit_logs VALUES ('a1', 'u1', 'POST', '/client/create', ?, ?)", now, now},
+		{"INSERT INTO clients VALUES ('c1', 'Juana', 'Pérez', NULL, '30111222', NULL, ?, ?)", now, now},
+		{"INSERT INTO vehicles VALUES ('v1', 'Fiat', 'Uno', 'Rojo', '2010', 'AB123CD', 'c1', ?, ?)", now, now},
+		{"INSERT INTO movement_type_laundries VALUES ('m1', 'Lavado', 1, ?, ?)", now, now},
+		{"INSERT INTO service_laundries VALUES ('s1', 'Lavado completo', ?, ?)", now, now},
+		{"INSERT INTO income_laundries VALUES ('i1', 'T-1', 'Lavado', 'c1', 'v1', NULL, 15000, 'm1', ?, ?)", now, now},
+		{"INSERT INTO income_service_laundries VALUES ('is1', 'i1', 's1')"},
+		{"INSERT INTO part_workshops VALUES ('p1', 'F-01', 'Filtro de aceite', 4, ?, ?)", now, now},
+	}
+}
+
+func TestUpgradeFromBaseline(t *testing.T) {
+	h := NewUpgraded(t, func(db *gorm.DB) error {
+		for _, statement := range baselineSchema {
+			if err := db.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		password, err := utils.HashPassword(AdminPassword)
+		if err != nil {
+			return err
+		}
+		for _, row := range baselineData(password) {
+			if err := db.Exec(row[0].(string), row[1:]...).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	for _, table := range []string{"income_laundries", "service_laundries", "part_workshops"} {
+		if h.DB.Migrator().HasTable(table) {
+			t.Errorf("la tabla %s sigue existiendo", table)
+		}
+	}
+	if !h.DB.Migrator().HasColumn("audit_logs", "entity_type") || h.DB.Migrator().HasColumn("audit_logs", "method") {
+		t.Error("audit_logs no tiene el formato nuevo")
+	}
+
+	admin := h.LoginAdmin()
+	laundry := admin.Workplace("laundry")
+	var income models.Income
+	laundry.Get("/income/i1").OK().Decode(&income)
+	if income.ClientID != "c1" || income.VehicleID != "v1" || income.Amount != 15000 || income.Subtotal != 15000 ||
+		len(income.Services) != 1 || income.Services[0].ServiceID != "s1" {
+		t.Errorf("ingreso migrado = %+v", income)
+	}
+
+	workshop := admin.Workplace("workshop")
+	var product models.Product
+	workshop.Get("/product/p1").OK().Decode(&product)
+	if product.Stock != 4 || product.Reserved != 0 || product.Version != 1 {
+		t.Errorf("producto migrado = %+v", product)
+	}
+	laundry.Get("/product/p1").Expect(404)
+	workshop.Get("/work_order/get_all").OK()
+	laundry.Get("/work_order/get_all").Expect(403)
+
+	// la base migrada se usa igual que una nueva
+	clientID := createClient(laundry, "migrada")
+	createVehicle(laundry, clientID, "MG001AA")
+	var audit []models.AuditLog
+	admin.Get("/audit/get_all?entity_type=client&entity_id=" + clientID).OK().Decode(&audit)
+	if len(audit) != 1 {
+		t.Errorf("auditoría = %+v", audit)
+	}
+}
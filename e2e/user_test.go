@@ -0,0 +1,145 @@
+package e2e
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestUserManagement(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	createUser := func(s *Session, username, role string) string {
+		t.Helper()
+		return s.Post("/user/create", models.UserCreate{
+			FirstName: username,
+			LastName:  "E2E",
+			Username:  username,
+			Email:     username + "@gestioncar.test",
+			Password:  "Clave123!",
+			Role:      role,
+		}).ID()
+	}
+	managerID := createUser(admin, "encargado", "admin")
+	employeeID := createUser(admin, "empleado", "employee_laundry")
+	manager := h.Login("encargado", "Clave123!")
+
+	t.Run("no password", func(t *testing.T) {
+		for _, path := range []string{"/user/me", "/user/get_all", "/user/" + employeeID} {
+			res := admin.Get(path).OK()
+			if strings.Contains(string(res.Raw), "password") {
+				t.Errorf("%s expone el password: %s", path, res.Raw)
+			}
+		}
+	})
+
+	t.Run("me", func(t *testing.T) {
+		var me models.UserDTO
+		manager.Get("/user/me").OK().Decode(&me)
+		if me.ID != managerID || me.Role != "admin" || !me.Active {
+			t.Fatalf("me = %+v", me)
+		}
+
+		manager.Put("/user/me", models.UserUpdate{FirstName: "Encargado", LastName: "General", Username: "encargado", Email: "encargado@gestioncar.test"}).OK().Decode(&me)
+		if me.FirstName != "Encargado" || me.LastName != "General" {
+			t.Errorf("me = %+v", me)
+		}
+		manager.Put("/user/me", models.UserUpdate{FirstName: "Encargado", LastName: "General", Username: AdminUsername, Email: "encargado@gestioncar.test"}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var users []models.UserDTO
+		env := admin.Get("/user/get_all?role=employee_laundry").OK().Decode(&users).Envelope()
+		if len(users) != 1 || users[0].ID != employeeID || env.Meta == nil || env.Meta.Total != 1 {
+			t.Errorf("users = %+v", users)
+		}
+		admin.Get("/user/get_all?sort=password").ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+		h.Login("empleado", "Clave123!").Get("/user/get_all").ExpectError(http.StatusForbidden, models.CodeForbidden)
+	})
+
+	t.Run("hierarchy", func(t *testing.T) {
+		// admin no puede crear ni administrar usuarios de su mismo nivel o superior
+		manager.Post("/user/create", models.UserCreate{FirstName: "Otro", LastName: "Admin", Username: "otro", Email: "otro@gestioncar.test", Password: "Clave123!", Role: "admin"}).
+			ExpectError(http.StatusForbidden, models.CodeForbidden)
+		manager.Put("/user/update_role/"+employeeID, models.UserRoleUpdate{Role: "admin"}).
+			ExpectError(http.StatusForbidden, models.CodeForbidden)
+		manager.Put("/user/deactivate/"+managerID, nil).ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		var adminUser models.UserDTO
+		admin.Get("/user/me").OK().Decode(&adminUser)
+		manager.Put("/user/update", models.UserUpdate{ID: adminUser.ID, FirstName: "X", LastName: "Y", Username: AdminUsername, Email: "admin@gestioncar.test"}).
+			ExpectError(http.StatusForbidden, models.CodeForbidden)
+		manager.Delete("/user/delete/"+adminUser.ID).ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		var updated models.UserDTO
+		manager.Put("/user/update_role/"+employeeID, models.UserRoleUpdate{Role: "admin_laundry"}).OK().Decode(&updated)
+		if updated.Role != "admin_laundry" {
+			t.Errorf("role = %s", updated.Role)
+		}
+		manager.Put("/user/update_role/"+employeeID, models.UserRoleUpdate{Role: "no_existe"}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	})
+
+	t.Run("roles table", func(t *testing.T) {
+		// los roles válidos son los de la tabla, no una lista fija
+		if err := h.DB.Create(&models.Role{ID: "rol-cajero", Name: "cajero_laundry", Hierarchy: 4, Workplace: "laundry"}).Error; err != nil {
+			t.Fatal(err)
+		}
+		cashierID := createUser(admin, "cajero", "cajero_laundry")
+		var cashier models.UserDTO
+		admin.Get("/user/" + cashierID).OK().Decode(&cashier)
+		if cashier.Role != "cajero_laundry" {
+			t.Errorf("role = %s", cashier.Role)
+		}
+		admin.Post("/user/create", models.UserCreate{FirstName: "X", LastName: "Y", Username: "sinrol", Email: "sinrol@gestioncar.test", Password: "Clave123!", Role: "no_existe"}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+	})
+
+	t.Run("deactivate", func(t *testing.T) {
+		employee := h.Login("empleado", "Clave123!")
+		employee.Get("/user/me").OK()
+
+		manager.Put("/user/deactivate/"+employeeID, nil).OK()
+		employee.Get("/user/me").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		h.Anonymous().Post("/auth/refresh", models.RefreshRequest{RefreshToken: employee.RefreshToken}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		// la contraseña correcta no se distingue de una incorrecta
+		inactive := h.Anonymous().Post("/auth/login", models.AuthLogin{Username: "empleado", Password: "Clave123!"}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized).Envelope()
+		wrong := h.Anonymous().Post("/auth/login", models.AuthLogin{Username: "empleado", Password: "Otra123!"}).
+			ExpectError(http.StatusUnauthorized, models.CodeUnauthorized).Envelope()
+		if inactive.Message != wrong.Message {
+			t.Errorf("el login delata al usuario desactivado: %q / %q", inactive.Message, wrong.Message)
+		}
+
+		var updated models.UserDTO
+		manager.Put("/user/activate/"+employeeID, nil).OK().Decode(&updated)
+		if !updated.Active {
+			t.Error("el usuario debería estar activo")
+		}
+		h.Login("empleado", "Clave123!").Get("/user/me").OK()
+	})
+
+	t.Run("workplace token", func(t *testing.T) {
+		// el token de workplace vale mientras el rol siga teniendo acceso
+		laundry := h.Login("empleado", "Clave123!").Workplace("laundry")
+		laundry.Get("/service/get_all").OK()
+
+		manager.Put("/user/update_role/"+employeeID, models.UserRoleUpdate{Role: "employee_workshop"}).OK()
+		laundry.Get("/service/get_all").ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		manager.Put("/user/update_role/"+employeeID, models.UserRoleUpdate{Role: "employee_laundry"}).OK()
+		laundry.Get("/service/get_all").OK()
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		employee := h.Login("empleado", "Clave123!")
+		manager.Delete("/user/delete/" + employeeID).OK()
+		admin.Get("/user/"+employeeID).ExpectError(http.StatusNotFound, models.CodeNotFound)
+		employee.Get("/user/me").ExpectError(http.StatusUnauthorized, models.CodeUnauthorized)
+		manager.Delete("/user/delete/"+employeeID).ExpectError(http.StatusNotFound, models.CodeNotFound)
+	})
+}
@@ -0,0 +1,244 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func TestWorkOrders(t *testing.T) {
+	h := New(t)
+	admin := h.LoginAdmin()
+	workshop := admin.Workplace("workshop")
+
+	clientID := createClient(workshop, "taller")
+	vehicleID := createVehicle(workshop, clientID, "OT001AA")
+	otherClientID := createClient(workshop, "otro")
+	movementTypeID := workshop.Post("/movement/create", models.MovementTypeCreate{Name: "Cobro taller", IsIncome: true}).ID()
+	repair := workshop.Post("/service/create", models.ServiceCreate{Name: "Cambio de amortiguadores", Price: 50000}).ID()
+	alignment := workshop.Post("/service/create", models.ServiceCreate{Name: "Alineación", Price: 8000}).ID()
+
+	open := func(s *Session) string {
+		t.Helper()
+		return s.Post("/work_order/create", models.WorkOrderCreate{
+			ClientID:    clientID,
+			VehicleID:   vehicleID,
+			Description: "Ruido en el tren delantero",
+		}).ID()
+	}
+	get := func(id string) models.WorkOrder {
+		t.Helper()
+		var workOrder models.WorkOrder
+		workshop.Get("/work_order/" + id).OK().Decode(&workOrder)
+		return workOrder
+	}
+	move := func(id, status string) *Response {
+		return workshop.IfMatch("/work_order/"+id).Put("/work_order/status/"+id, models.WorkOrderStatusUpdate{Status: status})
+	}
+	deliver := models.WorkOrderDeliver{
+		Ticket:         "OT-1",
+		ServicesID:     []string{repair, alignment},
+		MovementTypeID: movementTypeID,
+		Discount:       3000,
+		DiscountReason: "Cliente frecuente",
+		Note:           "Retira el titular",
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		id := open(workshop)
+
+		workshop.IfMatch("/work_order/"+id).Post("/work_order/deliver/"+id, deliver).ExpectError(http.StatusConflict, models.CodeConflict)
+		move(id, models.WorkOrderReady).ExpectError(http.StatusConflict, models.CodeConflict)
+		move(id, models.WorkOrderDelivered).ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+
+		for _, status := range []string{models.WorkOrderDiagnosis, models.WorkOrderWaitingParts, models.WorkOrderInRepair, models.WorkOrderReady} {
+			move(id, status).OK()
+		}
+		workOrder := get(id)
+		workshop.Put("/work_order/status/"+id, models.WorkOrderStatusUpdate{Status: models.WorkOrderInRepair, Version: workOrder.Version - 1}).
+			ExpectError(http.StatusConflict, models.CodeVersionConflict)
+
+		incomeID := workshop.IfMatch("/work_order/"+id).Post("/work_order/deliver/"+id, deliver).ID()
+		workOrder = get(id)
+		if workOrder.Status != models.WorkOrderDelivered || workOrder.IncomeID != incomeID {
+			t.Errorf("orden = %+v", workOrder)
+		}
+		expected := []string{"", models.WorkOrderReceived, models.WorkOrderDiagnosis, models.WorkOrderWaitingParts, models.WorkOrderInRepair, models.WorkOrderReady, models.WorkOrderDelivered}
+		if len(workOrder.Transitions) != len(expected)-1 {
+			t.Fatalf("transiciones = %+v", workOrder.Transitions)
+		}
+		for i, transition := range workOrder.Transitions {
+			if transition.FromStatus != expected[i] || transition.ToStatus != expected[i+1] || transition.UserID == "" || transition.CreatedAt.IsZero() {
+				t.Errorf("transición %d = %+v", i, transition)
+			}
+		}
+		last := workOrder.Transitions[len(workOrder.Transitions)-1]
+		if last.Note != deliver.Note || !workOrder.StatusChangedAt.Equal(last.CreatedAt) {
+			t.Errorf("última transición = %+v, status_changed_at = %v", last, workOrder.StatusChangedAt)
+		}
+
+		var income models.Income
+		workshop.Get("/income/" + incomeID).OK().Decode(&income)
+		if income.ClientID != clientID || income.VehicleID != vehicleID || income.Details != "Ruido en el tren delantero" ||
+			income.Subtotal != 58000 || income.Amount != 55000 || len(income.Services) != 2 {
+			t.Errorf("ingreso = %+v", income)
+		}
+
+		// entregada no se toca más
+		workshop.IfMatch("/work_order/"+id).Post("/work_order/deliver/"+id, deliver).ExpectError(http.StatusConflict, models.CodeConflict)
+		move(id, models.WorkOrderInRepair).ExpectError(http.StatusConflict, models.CodeConflict)
+		workshop.IfMatch("/work_order/"+id).Put("/work_order/update", models.WorkOrderUpdate{ID: id, Description: "Otra"}).ExpectError(http.StatusConflict, models.CodeConflict)
+		workshop.Delete("/work_order/delete/"+id).ExpectError(http.StatusConflict, models.CodeConflict)
+
+		var workOrders []models.WorkOrder
+		workshop.Get("/work_order/get_all?status=" + models.WorkOrderDelivered).OK().Decode(&workOrders)
+		if len(workOrders) != 1 || workOrders[0].ID != id {
+			t.Errorf("órdenes entregadas = %+v", workOrders)
+		}
+	})
+
+	t.Run("failed delivery", func(t *testing.T) {
+		id := open(workshop)
+		for _, status := range []string{models.WorkOrderDiagnosis, models.WorkOrderInRepair, models.WorkOrderReady} {
+			move(id, status).OK()
+		}
+		failed := deliver
+		failed.Discount = 100000
+		workshop.IfMatch("/work_order/"+id).Post("/work_order/deliver/"+id, failed).ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+
+		// el ingreso y el cambio de estado se hacen juntos o no se hacen
+		if workOrder := get(id); workOrder.Status != models.WorkOrderReady || workOrder.IncomeID != "" {
+			t.Errorf("orden = %+v", workOrder)
+		}
+	})
+
+	t.Run("parts", func(t *testing.T) {
+		pads := workshop.Post("/product/create", models.ProductCreate{Identifier: "PF-01", Name: "Pastillas de freno"}).ID()
+		workshop.Put("/product/update_stock/"+pads+"?method=add", models.StockUpdate{Stock: 5}).OK()
+		stock := func() (int32, int32) {
+			t.Helper()
+			var product models.Product
+			workshop.Get("/product/" + pads).OK().Decode(&product)
+			return product.Stock, product.Reserved
+		}
+		addPart := func(id string, quantity int32) *Response {
+			return workshop.IfMatch("/work_order/"+id).Post("/work_order/add_part/"+id, models.WorkOrderPartCreate{ProductID: pads, Quantity: quantity, Price: 12000})
+		}
+
+		first := open(workshop)
+		second := open(workshop)
+		partID := addPart(first, 3).ID()
+		addPart(first, 1).ExpectError(http.StatusConflict, models.CodeConflict)
+		// quedan 2 disponibles
+		addPart(second, 3).ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+		secondPart := addPart(second, 2).ID()
+		if s, r := stock(); s != 5 || r != 5 {
+			t.Errorf("stock = %d, reservado = %d", s, r)
+		}
+		workshop.Put("/product/update_stock/"+pads+"?method=subtract", models.StockUpdate{Stock: 1}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+		workshop.Put("/product/update_stock/"+pads+"?method=update", models.StockUpdate{Stock: 4}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+		workshop.Delete("/product/delete/"+pads).ExpectError(http.StatusConflict, models.CodeConflict)
+		workshop.IfMatch("/work_order/"+first).Post("/work_order/add_part/"+first, models.WorkOrderPartCreate{ProductID: "no-existe", Quantity: 1}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+
+		workshop.IfMatch("/work_order/"+first).Put("/work_order/update_part/"+first, models.WorkOrderPartUpdate{ID: partID, Quantity: 4, Price: 12000}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+		workshop.Delete("/work_order/delete/"+second).ExpectError(http.StatusConflict, models.CodeConflict)
+		workshop.IfMatch("/work_order/" + second).Delete("/work_order/remove_part/" + second + "/" + secondPart).OK()
+		workshop.IfMatch("/work_order/"+first).Put("/work_order/update_part/"+first, models.WorkOrderPartUpdate{ID: partID, Quantity: 4, Price: 11000}).OK()
+		workshop.Delete("/work_order/delete/" + second).OK()
+		if s, r := stock(); s != 5 || r != 4 {
+			t.Errorf("stock = %d, reservado = %d", s, r)
+		}
+
+		for _, status := range []string{models.WorkOrderDiagnosis, models.WorkOrderInRepair, models.WorkOrderReady} {
+			move(first, status).OK()
+		}
+		workOrder := get(first)
+		if len(workOrder.Parts) != 1 || workOrder.Parts[0].Quantity != 4 || workOrder.Parts[0].Product.Name != "Pastillas de freno" {
+			t.Errorf("repuestos = %+v", workOrder.Parts)
+		}
+		incomeID := workshop.IfMatch("/work_order/"+first).Post("/work_order/deliver/"+first, deliver).ID()
+		if s, r := stock(); s != 1 || r != 0 {
+			t.Errorf("stock = %d, reservado = %d tras entregar", s, r)
+		}
+		var income models.Income
+		workshop.Get("/income/" + incomeID).OK().Decode(&income)
+		if income.Subtotal != 58000+44000 || income.Amount != 58000+44000-3000 {
+			t.Errorf("ingreso = %+v", income)
+		}
+		workshop.IfMatch("/work_order/"+first).Put("/work_order/update_part/"+first, models.WorkOrderPartUpdate{ID: partID, Quantity: 1}).
+			ExpectError(http.StatusConflict, models.CodeConflict)
+
+		// el ingreso sale de la orden: no se edita ni se borra por separado
+		workshop.IfMatch("/income/"+incomeID).Put("/income/update", models.IncomeUpdate{
+			ID:             incomeID,
+			Ticket:         "OT-1",
+			ServicesID:     []string{repair},
+			Details:        income.Details,
+			ClientID:       clientID,
+			VehicleID:      vehicleID,
+			MovementTypeID: movementTypeID,
+		}).ExpectError(http.StatusConflict, models.CodeConflict)
+		workshop.Delete("/income/delete/"+incomeID).ExpectError(http.StatusConflict, models.CodeConflict)
+		workshop.Get("/income/" + incomeID).OK().Decode(&income)
+		if income.Subtotal != 58000+44000 || income.Version != 1 {
+			t.Errorf("ingreso tras intentar editarlo = %+v", income)
+		}
+	})
+
+	t.Run("references", func(t *testing.T) {
+		workshop.Post("/work_order/create", models.WorkOrderCreate{ClientID: otherClientID, VehicleID: vehicleID, Description: "Service"}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+		workshop.Post("/work_order/create", models.WorkOrderCreate{ClientID: clientID, VehicleID: vehicleID, EmployeeID: "no-existe", Description: "Service"}).
+			ExpectError(http.StatusBadRequest, models.CodeBadRequest)
+		workshop.Post("/work_order/create", models.WorkOrderCreate{ClientID: clientID}).
+			ExpectError(http.StatusUnprocessableEntity, models.CodeValidation)
+
+		id := open(workshop)
+		// las órdenes de trabajo son de los workplaces que las tienen habilitadas
+		laundry := admin.Workplace("laundry")
+		laundry.Get("/work_order/"+id).ExpectError(http.StatusForbidden, models.CodeForbidden)
+		laundry.Get("/work_order/get_all").ExpectError(http.StatusForbidden, models.CodeForbidden)
+		laundry.Post("/work_order/create", models.WorkOrderCreate{ClientID: clientID, VehicleID: vehicleID, Description: "Lavado"}).
+			ExpectError(http.StatusForbidden, models.CodeForbidden)
+		branchID := admin.Post("/workplace/create", models.WorkplaceCreate{
+			Name: "Taller Sur", Address: "Ruta 9", Phone: "123", Email: "sur@gestioncar.test", Identifier: "taller_sur", WorkOrders: true,
+		}).ID()
+		branch := &Session{h: h, Token: admin.Token}
+		admin.Get("/auth/workplace_login/" + branchID).OK().Decode(&branch.WorkplaceToken)
+		branch.Post("/work_order/create", models.WorkOrderCreate{ClientID: clientID, VehicleID: vehicleID, Description: "Service"}).ID()
+		branch.Get("/work_order/"+id).ExpectError(http.StatusNotFound, models.CodeNotFound)
+
+		workshop.IfMatch("/work_order/"+id).Put("/work_order/update", models.WorkOrderUpdate{ID: id, Description: "Ruido y vibración", Diagnosis: "Amortiguadores gastados"}).OK()
+		if workOrder := get(id); workOrder.Diagnosis != "Amortiguadores gastados" || workOrder.Status != models.WorkOrderReceived {
+			t.Errorf("orden = %+v", workOrder)
+		}
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		admin.Post("/user/create", models.UserCreate{
+			FirstName: "mecanico",
+			LastName:  "E2E",
+			Username:  "mecanico",
+			Email:     "mecanico@gestioncar.test",
+			Password:  "Clave123!",
+			Role:      "employee_workshop",
+		}).OK()
+		mechanic := h.Login("mecanico", "Clave123!").Workplace("workshop")
+
+		id := open(mechanic)
+		mechanic.IfMatch("/work_order/"+id).Put("/work_order/status/"+id, models.WorkOrderStatusUpdate{Status: models.WorkOrderDiagnosis}).OK()
+		mechanic.Delete("/work_order/delete/"+id).ExpectError(http.StatusForbidden, models.CodeForbidden)
+
+		workshop.Delete("/work_order/delete/" + id).OK()
+		workshop.Get("/work_order/"+id).ExpectError(http.StatusNotFound, models.CodeNotFound)
+		workshop.Put("/trash/work_order/restore/"+id, nil).OK()
+		if workOrder := get(id); len(workOrder.Transitions) != 2 {
+			t.Errorf("transiciones tras restaurar = %+v", workOrder.Transitions)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// Package mailer envía los correos de la API. La implementación se elige con
+// config.Mail.Driver: SMTP en producción, y un outbox en archivos o el log
+// para desarrollo y tests.
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/config"
+	"github.com/google/uuid"
+)
+
+// Message es un correo de texto plano.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New arma el Mailer del driver configurado. La configuración ya debe estar
+// validada: un driver desconocido cae en el log.
+func New(cfg config.Mail) Mailer {
+	switch cfg.Driver {
+	case "smtp":
+		return &SMTPMailer{
+			Addr:     net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+			Host:     cfg.SMTPHost,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.From,
+		}
+	case "file":
+		return &FileMailer{Dir: cfg.OutboxDir, From: cfg.From}
+	default:
+		return &LogMailer{From: cfg.From}
+	}
+}
+
+// SMTPMailer envía por SMTP, con STARTTLS si el servidor lo ofrece y
+// autenticación PLAIN si hay usuario.
+type SMTPMailer struct {
+	Addr     string
+	Host     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(msg Message) error {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return fmt.Errorf("remitente inválido: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("destinatario inválido: %w", err)
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+	return smtp.SendMail(m.Addr, auth, from.Address, []string{to.Address}, Format(m.From, msg, time.Now()))
+}
+
+// FileMailer deja cada correo como un .eml en Dir.
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func (m *FileMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+	now := time.Now()
+	name := now.Format("20060102T150405.000000000") + "-" + uuid.NewString() + ".eml"
+	return os.WriteFile(filepath.Join(m.Dir, name), Format(m.From, msg, now), 0o600)
+}
+
+// LogMailer escribe los correos en el log en lugar de enviarlos.
+type LogMailer struct {
+	From string
+}
+
+func (m *LogMailer) Send(msg Message) error {
+	log.Printf("Correo para %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// Format arma el correo en formato RFC 5322, en UTF-8.
+func Format(from string, msg Message, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.Body)
+	return buf.Bytes()
+}
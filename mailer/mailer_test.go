@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/config"
+)
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	m := New(config.Mail{Driver: "file", From: "GestionCar <no-reply@gestioncar.test>", OutboxDir: dir})
+
+	msg := Message{To: "ana@gestioncar.test", Subject: "Recuperación de contraseña", Body: "Código: 1234"}
+	if err := m.Send(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("archivos en el outbox: %v (%v)", files, err)
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	parsed, err := mail.ReadMessage(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil || subject != msg.Subject {
+		t.Errorf("subject = %q (%v)", subject, err)
+	}
+	if parsed.Header.Get("To") != msg.To {
+		t.Errorf("to = %q", parsed.Header.Get("To"))
+	}
+	if _, err := parsed.Header.Date(); err != nil {
+		t.Errorf("date: %v", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	raw := string(Format("no-reply@gestioncar.test", Message{To: "a@b.com", Subject: "Hola", Body: "línea"}, time.Now()))
+	if !strings.Contains(raw, "Content-Type: text/plain; charset=UTF-8\r\n") || !strings.HasSuffix(raw, "\r\n\r\nlínea") {
+		t.Errorf("correo mal formado:\n%s", raw)
+	}
+}
+
+func TestNewDefaultsToLog(t *testing.T) {
+	if _, ok := New(config.Mail{Driver: "log"}).(*LogMailer); !ok {
+		t.Error("el driver log debe usar LogMailer")
+	}
+	if _, ok := New(config.Mail{Driver: "smtp", SMTPHost: "localhost", SMTPPort: 25}).(*SMTPMailer); !ok {
+		t.Error("el driver smtp debe usar SMTPMailer")
+	}
+}
@@ -3,18 +3,11 @@ package main
 import (
 	"fmt"
 	"log"
-	"os"
 
+	"github.com/DanielChachagua/GestionCar/config"
 	"github.com/DanielChachagua/GestionCar/database"
-	"github.com/DanielChachagua/GestionCar/dependencies"
 	_ "github.com/DanielChachagua/GestionCar/docs"
-	"github.com/DanielChachagua/GestionCar/middleware"
-	"github.com/DanielChachagua/GestionCar/repositories"
-	"github.com/DanielChachagua/GestionCar/routes"
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/swagger"
-	"github.com/joho/godotenv"
+	"github.com/DanielChachagua/GestionCar/server"
 )
 
 //	@title						APP GESTIONCAR
@@ -28,39 +21,32 @@ import (
 
 func main() {
 	fmt.Println("Inicio app")
-	err := godotenv.Load()
 
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		log.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
 	}
 
-	db, err := database.Connect(os.Getenv("URI_DB"))
+	db, err := database.Connect(cfg.DatabaseURI)
 	if err != nil {
 		log.Fatalf("Error al conectar con la base de datos: %v", err)
 	}
 	defer database.CloseDB(db)
 
-	app := fiber.New()
-
-	app.Use(cors.New(cors.Config{
-    AllowOrigins:  "*",
-    AllowMethods:  "*",
-    AllowHeaders:  "*",
-    AllowCredentials: false,
-    }))
-
-	dep := dependencies.NewDependency(db)
-
-	app.Use(middleware.LoggingMiddleware)
-	// app.Use(middleware.AuditMiddleware())
-
-	routes.SetupRoutes(app)
+	if err := database.Migrate(db); err != nil {
+		log.Fatalf("Error al aplicar migraciones: %v", err)
+	}
 
-	repositories.Repo = dep.Repository
+	if err := database.Seed(db, cfg.Admin); err != nil {
+		log.Fatalf("Error al cargar datos iniciales: %v", err)
+	}
 
-	app.Get("/swagger/*", swagger.HandlerDefault)
+	app := server.New(db, cfg)
 
-	log.Fatal(app.Listen(":3000"))
+	log.Fatal(app.Listen(cfg.ListenAddr))
 
 
 }
\ No newline at end of file
@@ -3,50 +3,40 @@ package middleware
 import (
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/DanielChachagua/GestionCar/services"
-	"github.com/DanielChachagua/GestionCar/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func AuthMiddleware() fiber.Handler {
+func AuthMiddleware(authService *services.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		token := c.Get("Authorization")
 
 		if token == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Token no proporcionado",
-			})
+			return models.Unauthorized("Token no proporcionado", nil)
 		}
 
-		claims, err := utils.VerifyToken(token)
+		claims, err := authService.VerifyUserToken(token)
 
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Token inválido",
-			})
+			return models.Unauthorized("Token inválido", err)
 		}
 
-		userId := claims.(jwt.MapClaims)["id"].(string)
+		mapClaims := claims.(jwt.MapClaims)
+		if err := authService.CheckTokenRevoked(mapClaims); err != nil {
+			return err
+		}
+
+		userId, _ := mapClaims["id"].(string)
 
-		user, err := services.CurrentUser(userId)
+		user, err := authService.CurrentUser(userId)
 
 		if err != nil {
-			if errResp, ok := err.(*models.ErrorStruc); ok {
-				return c.Status(errResp.StatusCode).JSON(models.Response{
-					Status:  false,
-					Body:    nil,
-					Message: errResp.Message,
-				})
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: "Error interno",
-			})
+			return err
 		}
 		
 		c.Locals("user", user)
+		c.Locals("claims", mapClaims)
 
 		return c.Next()
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"math"
+	"strconv"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler es el fiber.Config.ErrorHandler de la app. Convierte cualquier
+// error devuelto por handlers y middlewares en el sobre models.Response con
+// su código legible por máquina. Los errores 5xx se loguean y se responden
+// sin exponer el error original. Un conflicto de versión lleva en el body el
+// estado actual del registro y un 429, el header Retry-After.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	var errResp *models.ErrorStruc
+	var fiberErr *fiber.Error
+
+	switch {
+	case errors.As(err, &errResp):
+	case errors.As(err, &fiberErr):
+		errResp = models.ErrorResponse(fiberErr.Code, fiberErr.Message, err)
+	default:
+		errResp = models.Internal("Error interno", err)
+	}
+
+	if errResp.StatusCode >= fiber.StatusInternalServerError {
+		log.Printf("Error: %s %s: %s: %v", c.Method(), c.Path(), errResp.Message, errResp.Err)
+	}
+
+	if errResp.RetryAfter > 0 {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(errResp.RetryAfter.Seconds()))))
+	}
+
+	return c.Status(errResp.StatusCode).JSON(models.Response{
+		Status:  false,
+		Body:    errResp.Current,
+		Message: errResp.Message,
+		Code:    errResp.Code,
+		Errors:  errResp.Fields,
+	})
+}
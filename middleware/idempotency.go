@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdempotencyMiddleware permite reintentar un POST de creación sin duplicar
+// registros: la primera request con un Idempotency-Key se ejecuta y, si sale
+// bien, su respuesta se guarda; los reintentos con la misma key y el mismo
+// body reciben esa respuesta sin volver a ejecutar el handler. Si la request
+// falla, o si su respuesta no se pudo guardar, la key se libera. Va después de
+// AuthMiddleware (la key es por usuario).
+func IdempotencyMiddleware(idempotencyService *services.IdempotencyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(models.IdempotencyKeyHeader)
+		if key == "" {
+			return c.Next()
+		}
+
+		user, ok := c.Locals("user").(*models.User)
+		if !ok || user == nil {
+			return models.Unauthorized("Usuario no autenticado", nil)
+		}
+		workplaceID := ""
+		if workplace, ok := c.Locals("workplace").(*models.Workplace); ok && workplace != nil {
+			workplaceID = workplace.ID
+		}
+
+		record := &models.IdempotencyKey{
+			UserID:      user.ID,
+			Key:         key,
+			WorkplaceID: workplaceID,
+			Method:      c.Method(),
+			Path:        c.Path(),
+			RequestHash: requestHash(c.Method(), c.Path(), workplaceID, c.Body()),
+		}
+		replay, err := idempotencyService.Begin(record)
+		if err != nil {
+			return err
+		}
+		if replay != nil {
+			c.Set("Idempotent-Replayed", "true")
+			c.Set(fiber.HeaderContentType, replay.ContentType)
+			return c.Status(replay.StatusCode).Send(replay.ResponseBody)
+		}
+
+		err = c.Next()
+		status := c.Response().StatusCode()
+		if err != nil || status < fiber.StatusOK || status >= fiber.StatusMultipleChoices {
+			if abortErr := idempotencyService.Abort(record.ID); abortErr != nil {
+				log.Printf("Error: Idempotency-Key %s: %v", key, errors.Unwrap(abortErr))
+			}
+			return err
+		}
+
+		// el registro ya se creó: si no se puede guardar la respuesta se
+		// responde igual y se libera la key, que si no quedaría en curso hasta
+		// vencer. Un reintento vuelve a ejecutar la request.
+		body := append([]byte(nil), c.Response().Body()...)
+		contentType := string(c.Response().Header.ContentType())
+		if completeErr := idempotencyService.Complete(record.ID, status, contentType, body); completeErr != nil {
+			log.Printf("Error: Idempotency-Key %s: %v", key, errors.Unwrap(completeErr))
+			if abortErr := idempotencyService.Abort(record.ID); abortErr != nil {
+				log.Printf("Error: Idempotency-Key %s: %v", key, errors.Unwrap(abortErr))
+			}
+		}
+		return nil
+	}
+}
+
+func requestHash(method, path, workplaceID string, body []byte) string {
+	hash := sha256.New()
+	for _, part := range [][]byte{[]byte(method), []byte(path), []byte(workplaceID), body} {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
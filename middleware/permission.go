@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PermissionMiddleware exige que el rol del usuario tenga todos los permisos
+// dados. Va después de AuthMiddleware.
+func PermissionMiddleware(permissionService *services.PermissionService, permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals("user").(*models.User)
+		if !ok {
+			return models.Unauthorized("Unauthorized", nil)
+		}
+
+		if err := permissionService.Authorize(user, permissions...); err != nil {
+			return err
+		}
+
+		return c.Next()
+	}
+}
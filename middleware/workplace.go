@@ -3,50 +3,60 @@ package middleware
 import (
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/DanielChachagua/GestionCar/services"
-	"github.com/DanielChachagua/GestionCar/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func WorkplaceMiddleware() fiber.Handler {
+func WorkplaceMiddleware(authService *services.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		token := c.Get("X-Workplace-Token")
 
 		if token == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Token no proporcionado",
-			})
+			return models.Unauthorized("Token no proporcionado", nil)
 		}
 
-		claims, err := utils.VerifyWorkplaceToken(token)
+		claims, err := authService.VerifyWorkplaceToken(token)
 
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Token inválido",
-			})
+			return models.Unauthorized("Token inválido", err)
 		}
 
-		workplaceId := claims.(jwt.MapClaims)["id"].(string)
+		mapClaims := claims.(jwt.MapClaims)
+		user, ok := c.Locals("user").(*models.User)
+		if !ok {
+			return models.Unauthorized("Unauthorized", nil)
+		}
+		if userId, _ := mapClaims["user_id"].(string); userId != user.ID {
+			return models.Unauthorized("El token de workplace no pertenece al usuario", nil)
+		}
+
+		workplaceId, _ := mapClaims["id"].(string)
 
-		workplace, err := services.CurrentWorkplace(workplaceId)
+		workplace, err := authService.CurrentWorkplace(user, workplaceId)
 
 		if err != nil {
-			if errResp, ok := err.(*models.ErrorStruc); ok {
-				return c.Status(errResp.StatusCode).JSON(models.Response{
-					Status:  false,
-					Body:    nil,
-					Message: errResp.Message,
-				})
-			}
-			return c.Status(fiber.StatusInternalServerError).JSON(models.Response{
-				Status:  false,
-				Body:    nil,
-				Message: "Error interno",
-			})
+			return err
 		}
 		
 		c.Locals("workplace", workplace)
 
 		return c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// WorkOrdersMiddleware limita las rutas a los workplaces con órdenes de
+// trabajo habilitadas. Va después de WorkplaceMiddleware.
+func WorkOrdersMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		workplace, ok := c.Locals("workplace").(*models.Workplace)
+		if !ok {
+			return models.Unauthorized("Unauthorized", nil)
+		}
+
+		if !workplace.WorkOrders {
+			return models.Forbidden("El workplace "+workplace.Identifier+" no tiene órdenes de trabajo habilitadas", nil)
+		}
+
+		return c.Next()
+	}
+}
@@ -6,26 +6,39 @@ import (
 	"fmt"
 )
 
+// JSONMap es un objeto JSON guardado como texto en una columna.
 type JSONMap map[string]interface{}
 
-func (j *JSONMap) Value() (driver.Value, error) {
-    return json.Marshal(j)
+func (j JSONMap) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(j)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
 }
 
 func (j *JSONMap) Scan(value interface{}) error {
-    bytes, ok := value.([]byte)
-    if !ok {
-        return fmt.Errorf("Scan source is not []byte")
-    }
-    return json.Unmarshal(bytes, j)
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, j)
+	case string:
+		return json.Unmarshal([]byte(v), j)
+	}
+	return fmt.Errorf("Scan source is not []byte")
 }
 
 func StructToJSONMap(s interface{}) (JSONMap, error) {
-    b, err := json.Marshal(s)
-    if err != nil {
-        return nil, err
-    }
-    var m JSONMap
-    err = json.Unmarshal(b, &m)
-    return m, err
-}
\ No newline at end of file
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var m JSONMap
+	err = json.Unmarshal(b, &m)
+	return m, err
+}
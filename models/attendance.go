@@ -3,34 +3,24 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
 // Asistencia empleados
-type AttendanceLaundry struct {
-	ID         string          `gorm:"primaryKey" json:"id"`
-	EmployeeID string          `gorm:"not null" json:"employee_id"`
-	Attendance string          `gorm:"not null" json:"role" validate:"oneof=presente tarde parcial ausente"`
-	Hours      int             `gorm:"not null;" json:"hours" validate:"max=24"`
-	Date       string          `gorm:"not null" json:"date"`
-	Amount     float32          `gorm:"not null" json:"amount"`
-	IsHoliday  bool            `gorm:"not null;default:false" json:"is_holiday"`
-	CreatedAt  time.Time          `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt  time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
-	Employee   EmployeeLaundry `gorm:"foreignKey:EmployeeID;references:ID" json:"employee"`
-}
-
-type AttendanceWorkshop struct {
-	ID         string          `gorm:"primaryKey" json:"id"`
-	EmployeeID string          `gorm:"not null" json:"employee_id"`
-	Attendance string          `gorm:"not null" json:"role" validate:"oneof=presente tarde parcial ausente"`
-	Hours      int             `gorm:"not null;" json:"hours" validate:"max=24"`
-	Date       string          `gorm:"not null" json:"date"`
-	Amount     float32          `gorm:"not null" json:"amount"`
-	IsHoliday  bool            `gorm:"not null;default:false" json:"is_holiday"`
-	CreatedAt  time.Time          `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt  time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
-	Employee   EmployeeWorkshop `gorm:"foreignKey:EmployeeID;references:ID" json:"employee"`
+type Attendance struct {
+	ID          string         `gorm:"primaryKey" json:"id"`
+	WorkplaceID string         `gorm:"not null;index" json:"workplace_id"`
+	EmployeeID  string         `gorm:"not null" json:"employee_id"`
+	Attendance  string         `gorm:"not null" json:"role" validate:"oneof=presente tarde parcial ausente"`
+	Hours       int            `gorm:"not null;" json:"hours" validate:"max=24"`
+	Date        string         `gorm:"not null" json:"date"`
+	Amount      float32        `gorm:"not null" json:"amount"`
+	IsHoliday   bool           `gorm:"not null;default:false" json:"is_holiday"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version     int64          `gorm:"not null;default:1" json:"version"`
+	Employee    Employee       `gorm:"foreignKey:EmployeeID;references:ID" json:"employee"`
 }
 
 type AttendanceCreate struct {
@@ -43,8 +33,7 @@ type AttendanceCreate struct {
 }
 
 func (e *AttendanceCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(e)
+	return ValidateStruct(e)
 }
 
 type AttendanceUpdate struct {
@@ -55,11 +44,11 @@ type AttendanceUpdate struct {
 	Date       string          `json:"date" validate:"required" example:"2022-01-01"`
 	Amount     float32          `json:"amount" validate:"required" example:"1234.56"`
 	IsHoliday  bool            `json:"is_holiday" default:"false" example:"false"`
+	Version    int64           `json:"version"`
 }
 
 func (e *AttendanceUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(e)
+	return ValidateStruct(e)
 }
 
 type DateBetween struct {
@@ -68,6 +57,5 @@ type DateBetween struct {
 }
 
 func (e *DateBetween) Validate() error {
-	validate := validator.New()
-	return validate.Struct(e)
+	return ValidateStruct(e)
 }
\ No newline at end of file
@@ -1,10 +1,35 @@
 package models
 
+import "time"
+
+// Acciones que registra un AuditLog.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+)
+
+// Actor es quien hace un cambio: el usuario logueado y, en las rutas de un
+// workplace, ese workplace. Los repositorios lo guardan en el AuditLog. Los
+// cambios hechos desde la CLI van con el Actor vacío.
+type Actor struct {
+	UserID      string
+	WorkplaceID string
+}
+
+// AuditLog registra un alta, modificación o baja de una entidad con su
+// estado antes y después (Before es nil al crear y After al borrar). Se
+// escribe en la misma transacción que el cambio.
 type AuditLog struct {
-	ID        string `gorm:"primaryKey" json:"id"`
-	UserID 	string `gorm:"not null" json:"user_id"`
-	Method    string `gorm:"not null" json:"method"`
-	Path      string `gorm:"not null" json:"path"`
-	CreatedAt string `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"`
-}
\ No newline at end of file
+	ID          string    `gorm:"primaryKey" json:"id"`
+	UserID      string    `gorm:"index" json:"user_id"`
+	WorkplaceID string    `gorm:"index" json:"workplace_id"`
+	EntityType  string    `gorm:"not null;index:idx_audit_logs_entity" json:"entity_type" example:"client"`
+	EntityID    string    `gorm:"not null;index:idx_audit_logs_entity" json:"entity_id"`
+	Action      string    `gorm:"not null" json:"action" example:"update"`
+	Before      JSONMap   `gorm:"type:text" json:"before" swaggertype:"object"`
+	After       JSONMap   `gorm:"type:text" json:"after" swaggertype:"object"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
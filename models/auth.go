@@ -1,13 +1,58 @@
 package models
 
-import "github.com/go-playground/validator/v10"
-
 type AuthLogin struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
 }
 
 func (a *AuthLogin) Validate() error {
-	validate := validator.New()
-	return validate.Struct(a)
-}
\ No newline at end of file
+	return ValidateStruct(a)
+}
+
+// AuthTokens es la respuesta del login y del refresh.
+type AuthTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func (r *RefreshRequest) Validate() error {
+	return ValidateStruct(r)
+}
+
+// LogoutRequest lleva el refresh token a revocar junto con el access token;
+// es opcional.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
+}
+
+func (r *ChangePasswordRequest) Validate() error {
+	return ValidateStruct(r)
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+func (r *ForgotPasswordRequest) Validate() error {
+	return ValidateStruct(r)
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8"`
+}
+
+func (r *ResetPasswordRequest) Validate() error {
+	return ValidateStruct(r)
+}
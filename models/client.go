@@ -3,19 +3,21 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
 type Client struct {
-	ID        string    `gorm:"primaryKey" json:"id"`
-	FirstName string    `gorm:"not null;size:30" json:"first_name"`
-	LastName  string    `gorm:"not null;size:30" json:"last_name"`
-	CUIL      string    `gorm:"unique;size:30" json:"cuil"`
-	DNI       string    `gorm:"unique;size:30" json:"dni"`
-	Email     string    `gorm:"unique" json:"email" validate:"email"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	Vehicles  []Vehicle  `gorm:"foreignKey:ClientID" json:"vehicles"`
+	ID        string         `gorm:"primaryKey" json:"id"`
+	FirstName string         `gorm:"not null;size:30" json:"first_name"`
+	LastName  string         `gorm:"not null;size:30" json:"last_name"`
+	CUIL      string         `gorm:"unique;size:30" json:"cuil"`
+	DNI       string         `gorm:"unique;size:30" json:"dni"`
+	Email     string         `gorm:"unique" json:"email" validate:"email"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version   int64          `gorm:"not null;default:1" json:"version"`
+	Vehicles  []Vehicle      `gorm:"foreignKey:ClientID" json:"vehicles"`
 }
 
 type ClientCreate struct {
@@ -27,8 +29,7 @@ type ClientCreate struct {
 }
 
 func (c *ClientCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(c)
+	return ValidateStruct(c)
 }
 
 type ClientUpdate struct {
@@ -38,9 +39,9 @@ type ClientUpdate struct {
 	CUIL      string `json:"cuil" validate:"required"`		
 	DNI       string `json:"dni" validate:""`
 	Email     string `json:"email" validate:"required,email"`
+	Version   int64  `json:"version"`
 }
 
 func (c *ClientUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(c)
+	return ValidateStruct(c)
 }
\ No newline at end of file
@@ -3,27 +3,20 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
-type EmployeeLaundry struct {
-	ID   string `gorm:"primaryKey" json:"id"`
-	Name string `gorm:"not null" json:"name"`
-	Phone string `gorm:"not null" json:"phone"`
-	Email string `gorm:"not null" json:"email"`
-	Address string `gorm:"not null" json:"address"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-}
-
-type EmployeeWorkshop struct {
-	ID   string `gorm:"primaryKey" json:"id"`
-	Name string `gorm:"not null" json:"name"`
-	Phone string `gorm:"not null" json:"phone"`
-	Email string `gorm:"not null" json:"email"`
-	Address string `gorm:"not null" json:"address"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+type Employee struct {
+	ID          string         `gorm:"primaryKey" json:"id"`
+	WorkplaceID string         `gorm:"not null;index" json:"workplace_id"`
+	Name        string         `gorm:"not null" json:"name"`
+	Phone       string         `gorm:"not null" json:"phone"`
+	Email       string         `gorm:"not null" json:"email"`
+	Address     string         `gorm:"not null" json:"address"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version     int64          `gorm:"not null;default:1" json:"version"`
 }
 
 type EmployeeCreate struct {
@@ -34,8 +27,7 @@ type EmployeeCreate struct {
 } 
 
 func (e *EmployeeCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(e)
+	return ValidateStruct(e)
 }
 
 type EmployeeUpdate struct {
@@ -44,9 +36,9 @@ type EmployeeUpdate struct {
 	Phone string `json:"phone" validate:"required"`
 	Email string `json:"email" validate:"required,email"`
 	Address string `json:"address" validate:"required"`
+	Version int64 `json:"version"`
 }
 
 func (e *EmployeeUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(e)
+	return ValidateStruct(e)
 }
@@ -1,8 +1,32 @@
 package models
 
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Códigos de error legibles por máquina que viajan en models.Response.Code.
+const (
+	CodeBadRequest      = "bad_request"
+	CodeValidation      = "validation_error"
+	CodeUnauthorized    = "unauthorized"
+	CodeForbidden       = "forbidden"
+	CodeNotFound        = "not_found"
+	CodeConflict        = "conflict"
+	CodeVersionConflict = "version_conflict"
+	CodeVersionRequired = "version_required"
+	CodeTooManyRequests = "too_many_requests"
+	CodeInternal        = "internal_error"
+)
+
 type ErrorStruc struct {
 	StatusCode int
+	Code       string
 	Message    string
+	Fields     ValidationErrors
+	Current    interface{}
+	RetryAfter time.Duration
 	Err        error
 }
 
@@ -10,10 +34,100 @@ func (e *ErrorStruc) Error() string {
 	return e.Message
 }
 
+func (e *ErrorStruc) Unwrap() error {
+	return e.Err
+}
+
+// ErrorResponse arma un error con el código derivado del status HTTP.
+// Para los casos habituales conviene usar NotFound, Conflict, Validation, etc.
 func ErrorResponse(code int, message string, err error) *ErrorStruc {
 	return &ErrorStruc{
 		StatusCode: code,
+		Code:       CodeForStatus(code),
 		Message:    message,
 		Err:        err,
 	}
 }
+
+// BadRequest indica una request mal formada (body ilegible, parámetros faltantes).
+func BadRequest(message string, err error) *ErrorStruc {
+	return &ErrorStruc{StatusCode: http.StatusBadRequest, Code: CodeBadRequest, Message: message, Err: err}
+}
+
+// Validation indica datos bien formados que no cumplen las reglas de negocio.
+// Si err es un ValidationErrors, el detalle por campo viaja en la respuesta.
+func Validation(message string, err error) *ErrorStruc {
+	errResp := &ErrorStruc{StatusCode: http.StatusUnprocessableEntity, Code: CodeValidation, Message: message, Err: err}
+	errors.As(err, &errResp.Fields)
+	return errResp
+}
+
+// Unauthorized indica que falta autenticación o que no es válida.
+func Unauthorized(message string, err error) *ErrorStruc {
+	return &ErrorStruc{StatusCode: http.StatusUnauthorized, Code: CodeUnauthorized, Message: message, Err: err}
+}
+
+// Forbidden indica que el usuario está autenticado pero no tiene permiso.
+func Forbidden(message string, err error) *ErrorStruc {
+	return &ErrorStruc{StatusCode: http.StatusForbidden, Code: CodeForbidden, Message: message, Err: err}
+}
+
+// NotFound indica que la entidad pedida no existe (o no es visible en el workplace).
+func NotFound(message string, err error) *ErrorStruc {
+	return &ErrorStruc{StatusCode: http.StatusNotFound, Code: CodeNotFound, Message: message, Err: err}
+}
+
+// Conflict indica que la operación choca con el estado actual (duplicados, etc.).
+func Conflict(message string, err error) *ErrorStruc {
+	return &ErrorStruc{StatusCode: http.StatusConflict, Code: CodeConflict, Message: message, Err: err}
+}
+
+// VersionConflict indica que el registro cambió desde que el cliente lo leyó
+// (la versión de If-Match o del body no coincide). current es el estado
+// actual, que viaja en el body para que el cliente pueda reintentar.
+func VersionConflict(message string, current interface{}, err error) *ErrorStruc {
+	return &ErrorStruc{StatusCode: http.StatusConflict, Code: CodeVersionConflict, Message: message, Current: current, Err: err}
+}
+
+// VersionRequired indica una actualización sin la versión que se leyó (ni en
+// el body ni en If-Match), que podría pisar cambios ajenos sin saberlo.
+func VersionRequired(message string, err error) *ErrorStruc {
+	return &ErrorStruc{StatusCode: http.StatusPreconditionRequired, Code: CodeVersionRequired, Message: message, Err: err}
+}
+
+// TooManyRequests indica que se superó un límite de intentos. retryAfter es
+// cuánto falta para poder reintentar y viaja en el header Retry-After.
+func TooManyRequests(message string, retryAfter time.Duration, err error) *ErrorStruc {
+	return &ErrorStruc{StatusCode: http.StatusTooManyRequests, Code: CodeTooManyRequests, Message: message, RetryAfter: retryAfter, Err: err}
+}
+
+// Internal envuelve un error inesperado; Err se loguea pero no se expone.
+func Internal(message string, err error) *ErrorStruc {
+	return &ErrorStruc{StatusCode: http.StatusInternalServerError, Code: CodeInternal, Message: message, Err: err}
+}
+
+// CodeForStatus devuelve el código de error correspondiente a un status HTTP.
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusPreconditionRequired:
+		return CodeVersionRequired
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
@@ -3,31 +3,22 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
-type ExpenseLaundry struct {
-	ID                  string              `gorm:"primaryKey" json:"id"`
-	Details             string              `json:"details"`
-	SupplierID          string              `json:"supplier_id"`
-	MovementTypeID      string              `gorm:"not null" json:"movement_type_id"`
-	Amount              float32             `gorm:"not null" json:"amount"`
-	CreatedAt           time.Time           `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt           time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
-	Supplier            SupplierLaundry     `gorm:"foreignKey:SupplierID" json:"supplier"`
-	MovementTypeLaundry MovementTypeLaundry `gorm:"foreignKey:MovementTypeID;references:ID" json:"movement_type_laundry"`
-}
-
-type ExpenseWorkshop struct {
-	ID                   string               `gorm:"primaryKey" json:"id"`
-	Details              string               `json:"details"`
-	SupplierID           string               `json:"supplier_id"`
-	MovementTypeID       string               `gorm:"not null" json:"movement_type_id"`
-	Amount               float32              `gorm:"not null" json:"amount"`
-	CreatedAt            time.Time            `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt            time.Time            `gorm:"autoUpdateTime" json:"updated_at"`
-	Supplier             SupplierWorkshop     `gorm:"foreignKey:SupplierID" json:"supplier"`
-	MovementTypeWorkshop MovementTypeWorkshop `gorm:"foreignKey:MovementTypeID;references:ID" json:"movement_type_workshop"`
+type Expense struct {
+	ID             string         `gorm:"primaryKey" json:"id"`
+	WorkplaceID    string         `gorm:"not null;index" json:"workplace_id"`
+	Details        string         `json:"details"`
+	SupplierID     string         `json:"supplier_id"`
+	MovementTypeID string         `gorm:"not null" json:"movement_type_id"`
+	Amount         float32        `gorm:"not null" json:"amount"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version        int64          `gorm:"not null;default:1" json:"version"`
+	Supplier       Supplier       `gorm:"foreignKey:SupplierID" json:"supplier"`
+	MovementType   MovementType   `gorm:"foreignKey:MovementTypeID;references:ID" json:"movement_type"`
 }
 
 type ExpenseCreate struct {
@@ -38,8 +29,7 @@ type ExpenseCreate struct {
 }
 
 func (e *ExpenseCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(e)
+	return ValidateStruct(e)
 }
 
 type ExpenseUpdate struct {
@@ -48,9 +38,9 @@ type ExpenseUpdate struct {
 	SupplierID     string  `json:"supplier_id"`
 	MovementTypeID string  `json:"movement_type_id" validate:"required"`
 	Amount         float32 `json:"amount" validate:"required"`
+	Version        int64   `json:"version"`
 }
 
 func (e *ExpenseUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(e)
+	return ValidateStruct(e)
 }
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// IdempotencyKeyHeader es el header con el que los clientes marcan un POST
+// para poder reintentarlo sin duplicar lo que crea.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyKey guarda la primera request hecha con una key y, cuando termina
+// bien, su respuesta para repetirla. CompletedAt nil es una request en curso.
+type IdempotencyKey struct {
+	ID           string     `gorm:"primaryKey" json:"id"`
+	UserID       string     `gorm:"not null;uniqueIndex:idx_idempotency_user_key" json:"user_id"`
+	Key          string     `gorm:"column:idempotency_key;not null;size:255;uniqueIndex:idx_idempotency_user_key" json:"key"`
+	WorkplaceID  string     `json:"workplace_id"`
+	Method       string     `gorm:"not null" json:"method"`
+	Path         string     `gorm:"not null" json:"path"`
+	RequestHash  string     `gorm:"not null" json:"request_hash"`
+	StatusCode   int        `json:"status_code"`
+	ContentType  string     `json:"content_type"`
+	ResponseBody []byte     `json:"-"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at"`
+}
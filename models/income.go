@@ -3,72 +3,66 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
-type IncomeLaundry struct {
-	ID                  string              `gorm:"primaryKey" json:"id"`
-	Ticket              string              `json:"ticket"`
-	Details             string              `json:"details"`
-	ClientID            string              `gorm:"not null" json:"client_id"`
-	VehicleID           string              `gorm:"not null" json:"vehicle_id"`
-	EmployeeID          string              `json:"employee_id"`
-	Amount              float32             `gorm:"not null" json:"amount"`
-	MovementTypeID      string              `gorm:"not null" json:"movement_type_id"`
-	CreatedAt           time.Time           `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt           time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
-	Client              Client              `gorm:"foreignKey:ClientID" json:"client"`
-	Vehicle             Vehicle             `gorm:"foreignKey:VehicleID" json:"vehicle"`
-	EmployeeLaundry     EmployeeLaundry     `gorm:"foreignKey:EmployeeID" json:"employee_laundry"`
-	MovementTypeLaundry MovementTypeLaundry `gorm:"foreignKey:MovementTypeID;references:ID" json:"movement_type_laundry"`
-}
-
-type IncomeWorkshop struct {
-	ID                   string               `gorm:"primaryKey" json:"id"`
-	Ticket               string               `json:"ticket"`
-	Details              string               `json:"details"`
-	ClientID             string               `gorm:"not null" json:"client_id"`
-	VehicleID            string               `json:"vehicle_id"`
-	EmployeeID           string               `json:"employee_id"`
-	Amount               float32              `json:"amount"`
-	MovementTypeID       string               `json:"movement_type_id"`
-	CreatedAt            time.Time            `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt            time.Time            `gorm:"autoUpdateTime" json:"updated_at"`
-	Client               Client               `gorm:"foreignKey:ClientID" json:"client"`
-	Vehicle              Vehicle              `gorm:"foreignKey:VehicleID" json:"vehicle"`
-	EmployeeWorkshop     EmployeeWorkshop     `gorm:"foreignKey:EmployeeID" json:"employee_workshop"`
-	MovementTypeWorkshop MovementTypeWorkshop `gorm:"foreignKey:MovementTypeID;references:ID" json:"movement_type_workshop"`
+// Income es un ingreso por servicios. Amount no se carga a mano: es Subtotal
+// (la suma de los precios de las líneas más, si es la entrega de una orden de
+// trabajo, sus repuestos) menos Discount.
+type Income struct {
+	ID             string          `gorm:"primaryKey" json:"id"`
+	WorkplaceID    string          `gorm:"not null;index" json:"workplace_id"`
+	Ticket         string          `json:"ticket"`
+	Details        string          `json:"details"`
+	ClientID       string          `gorm:"not null" json:"client_id"`
+	VehicleID      string          `json:"vehicle_id"`
+	EmployeeID     string          `json:"employee_id"`
+	Subtotal       float32         `gorm:"not null;default:0" json:"subtotal"`
+	Discount       float32         `gorm:"not null;default:0" json:"discount"`
+	DiscountReason string          `json:"discount_reason"`
+	Amount         float32         `gorm:"not null" json:"amount"`
+	MovementTypeID string          `gorm:"not null" json:"movement_type_id"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt  `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version        int64           `gorm:"not null;default:1" json:"version"`
+	Client         Client          `gorm:"foreignKey:ClientID" json:"client"`
+	Vehicle        Vehicle         `gorm:"foreignKey:VehicleID" json:"vehicle"`
+	Employee       Employee        `gorm:"foreignKey:EmployeeID" json:"employee"`
+	MovementType   MovementType    `gorm:"foreignKey:MovementTypeID;references:ID" json:"movement_type"`
+	Services       []IncomeService `gorm:"foreignKey:IncomeID;references:ID" json:"services"`
 }
 
 type IncomeCreate struct {
 	Ticket         string   `json:"ticket" validate:"required"`
-	ServicesID     []string `json:"services_id" validate:"required,gt=0"`
+	ServicesID     []string `json:"services_id" validate:"required,gt=0,unique,dive,required"`
 	Details        string   `json:"details" validate:"required"`
 	ClientID       string   `json:"client_id" validate:"required"`
 	VehicleID      string   `json:"vehicle_id" validate:"required"`
 	EmployeeID     string   `json:"employee_id"`
 	MovementTypeID string   `json:"movement_type_id" validate:"required"`
-	Amount         float32  `json:"amount" validate:"required"`
+	Discount       float32  `json:"discount" validate:"gte=0"`
+	DiscountReason string   `json:"discount_reason" validate:"required_with=Discount"`
 }
 
 func (i *IncomeCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(i)
+	return ValidateStruct(i)
 }
 
 type IncomeUpdate struct {
 	ID             string   `json:"id"`
 	Ticket         string   `json:"ticket" validate:"required"`
-	ServicesID     []string `json:"services_id" validate:"required,gt=0"`
+	ServicesID     []string `json:"services_id" validate:"required,gt=0,unique,dive,required"`
 	Details        string   `json:"details"`
 	ClientID       string   `json:"client_id" validate:"required"`
 	VehicleID      string   `json:"vehicle_id" validate:"required"`
 	EmployeeID     string   `json:"employee_id"`
 	MovementTypeID string   `json:"movement_type_id" validate:"required"`
-	Amount         float32  `json:"amount" validate:"required"`
+	Discount       float32  `json:"discount" validate:"gte=0"`
+	DiscountReason string   `json:"discount_reason" validate:"required_with=Discount"`
+	Version        int64    `json:"version"`
 }
 
 func (i *IncomeUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(i)
+	return ValidateStruct(i)
 }
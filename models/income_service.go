@@ -1,17 +1,12 @@
 package models
 
-type IncomeServiceLaundry struct {
-	ID string `gorm:"primaryKey" json:"id"`
-	IncomeLaundryID string `gorm:"not null" json:"income_laundry_id"`
-	ServiceID string `gorm:"not null" json:"service_id"`
-	IncomeLaundry IncomeLaundry `gorm:"foreignKey:IncomeLaundryID;references:ID" json:"income_laundry"`
-	Service ServiceLaundry `gorm:"foreignKey:ServiceID;references:ID" json:"service"`
+// IncomeService es una línea de un ingreso: el servicio y el precio que se
+// cobró, copiado del ServicePrice vigente al agregar la línea.
+type IncomeService struct {
+	ID        string  `gorm:"primaryKey" json:"id"`
+	IncomeID  string  `gorm:"not null;index" json:"income_id"`
+	ServiceID string  `gorm:"not null" json:"service_id"`
+	Price     float32 `gorm:"not null;default:0" json:"price"`
+	Income    Income  `gorm:"foreignKey:IncomeID;references:ID" json:"income"`
+	Service   Service `gorm:"foreignKey:ServiceID;references:ID" json:"service"`
 }
-
-type IncomeServiceWorkshop struct {
-	ID string `gorm:"primaryKey" json:"id"`
-	IncomeWorkshopID string `gorm:"not null" json:"income_workshop_id"`
-	ServiceID string `gorm:"not null" json:"service_id"`
-	IncomeWorkshop IncomeWorkshop `gorm:"foreignKey:IncomeWorkshopID;references:ID" json:"income_workshop"`
-	Service ServiceWorkshop `gorm:"foreignKey:ServiceID;references:ID" json:"service"`
-}
\ No newline at end of file
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Resultados posibles de un LoginAttempt.
+const (
+	LoginSucceeded          = "success"
+	LoginInvalidCredentials = "invalid_credentials"
+	LoginInactive           = "inactive"
+	LoginLocked             = "locked"
+)
+
+// LoginAttempt es una entrada del historial de login. Los intentos con
+// LoginInvalidCredentials recientes bloquean el username y la IP; los
+// rechazados por LoginLocked no cuentan, así insistir no alarga el bloqueo.
+type LoginAttempt struct {
+	ID        string    `gorm:"primaryKey" json:"id"`
+	UserID    *string   `gorm:"index" json:"user_id"`
+	Username  string    `gorm:"not null;index" json:"username"`
+	IP        string    `gorm:"not null;index" json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Success   bool      `gorm:"not null" json:"success"`
+	Result    string    `gorm:"not null" json:"result" example:"invalid_credentials"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
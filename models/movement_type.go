@@ -3,23 +3,18 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
-type MovementTypeLaundry struct {
-	ID   string `gorm:"primaryKey" json:"id"`
-	Name string `gorm:"not null" json:"name"`
-	IsIncome bool   `gorm:"not null" json:"is_income"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`	
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-}
-
-type MovementTypeWorkshop struct {
-	ID   string `gorm:"primaryKey" json:"id"`
-	Name string `gorm:"not null" json:"name"`
-	IsIncome bool   `gorm:"not null" json:"is_income"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+type MovementType struct {
+	ID          string         `gorm:"primaryKey" json:"id"`
+	WorkplaceID string         `gorm:"not null;index" json:"workplace_id"`
+	Name        string         `gorm:"not null" json:"name"`
+	IsIncome    bool           `gorm:"not null" json:"is_income"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version     int64          `gorm:"not null;default:1" json:"version"`
 }
 
 type MovementTypeCreate struct {
@@ -28,17 +23,16 @@ type MovementTypeCreate struct {
 }
 
 func (m *MovementTypeCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(m)
+	return ValidateStruct(m)
 }
 
 type MovementTypeUpdate struct {
 	ID string `gojson:"id"`
 	Name string `json:"name"`
 	IsIncome bool   `json:"is_income"`
+	Version int64 `json:"version"`
 }
 
 func (m *MovementTypeUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(m)
+	return ValidateStruct(m)
 }
\ No newline at end of file
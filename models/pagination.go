@@ -0,0 +1,39 @@
+package models
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ListParams agrupa los parámetros de los endpoints get_all. Sort y Filters
+// usan los nombres json de los campos; Sort admite varios separados por coma
+// y el prefijo "-" para orden descendente (p. ej. "-created_at,amount").
+type ListParams struct {
+	Page     int
+	PageSize int
+	Sort     string
+	Filters  map[string]string
+}
+
+// Offset devuelve cuántas filas saltear para llegar a la página pedida.
+func (p *ListParams) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// Pagination viaja en Response.Meta de los listados.
+type Pagination struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+func NewPagination(params *ListParams, total int64) *Pagination {
+	pages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
+	return &Pagination{
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		Total:      total,
+		TotalPages: pages,
+	}
+}
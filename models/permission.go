@@ -0,0 +1,130 @@
+package models
+
+// Permission es una acción protegida, con nombre <entidad>.<acción>. El
+// catálogo vive en el código (Permissions) porque las rutas lo referencian;
+// la base guarda qué roles tienen cada permiso.
+type Permission struct {
+	Name        string `gorm:"primaryKey" json:"name"`
+	Description string `gorm:"not null" json:"description"`
+}
+
+// RolePermission asigna un permiso a un rol.
+type RolePermission struct {
+	RoleID     string `gorm:"primaryKey" json:"role_id"`
+	Permission string `gorm:"primaryKey" json:"permission"`
+}
+
+type RolePermissionsUpdate struct {
+	Permissions []string `json:"permissions" validate:"required,dive,required"`
+}
+
+func (r *RolePermissionsUpdate) Validate() error {
+	return ValidateStruct(r)
+}
+
+// RolePermissions es la respuesta de /permission/role/{role}.
+type RolePermissions struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+const (
+	PermAttendanceCreate    = "attendance.create"
+	PermAttendanceUpdate    = "attendance.update"
+	PermAttendanceDelete    = "attendance.delete"
+	PermClientCreate        = "client.create"
+	PermClientUpdate        = "client.update"
+	PermClientDelete        = "client.delete"
+	PermEmployeeCreate      = "employee.create"
+	PermEmployeeUpdate      = "employee.update"
+	PermEmployeeDelete      = "employee.delete"
+	PermExpenseCreate       = "expense.create"
+	PermExpenseUpdate       = "expense.update"
+	PermExpenseDelete       = "expense.delete"
+	PermIncomeCreate        = "income.create"
+	PermIncomeUpdate        = "income.update"
+	PermIncomeDelete        = "income.delete"
+	PermMovementTypeCreate  = "movement_type.create"
+	PermMovementTypeUpdate  = "movement_type.update"
+	PermMovementTypeDelete  = "movement_type.delete"
+	PermProductCreate       = "product.create"
+	PermProductUpdate       = "product.update"
+	PermProductDelete       = "product.delete"
+	PermProductStockAdjust  = "product.stock.adjust"
+	PermPurchaseOrderCreate = "purchase_order.create"
+	PermPurchaseOrderUpdate = "purchase_order.update"
+	PermPurchaseOrderDelete = "purchase_order.delete"
+	PermServiceCreate       = "service.create"
+	PermServiceUpdate       = "service.update"
+	PermServiceDelete       = "service.delete"
+	PermSupplierCreate      = "supplier.create"
+	PermSupplierUpdate      = "supplier.update"
+	PermSupplierDelete      = "supplier.delete"
+	PermVehicleCreate       = "vehicle.create"
+	PermVehicleUpdate       = "vehicle.update"
+	PermVehicleDelete       = "vehicle.delete"
+	PermWorkOrderCreate     = "work_order.create"
+	PermWorkOrderUpdate     = "work_order.update"
+	PermWorkOrderDeliver    = "work_order.deliver"
+	PermWorkOrderDelete     = "work_order.delete"
+	PermTrashManage         = "trash.manage"
+	PermUserRead            = "user.read"
+	PermUserCreate          = "user.create"
+	PermUserUpdate          = "user.update"
+	PermUserDelete          = "user.delete"
+	PermLoginHistoryRead    = "login_history.read"
+	PermAuditRead           = "audit.read"
+	PermWorkplaceCreate     = "workplace.create"
+	PermPermissionManage    = "permission.manage"
+)
+
+// Permissions es el catálogo de permisos.
+var Permissions = []Permission{
+	{Name: PermAttendanceCreate, Description: "Registrar asistencias"},
+	{Name: PermAttendanceUpdate, Description: "Modificar asistencias"},
+	{Name: PermAttendanceDelete, Description: "Eliminar asistencias"},
+	{Name: PermClientCreate, Description: "Crear clientes"},
+	{Name: PermClientUpdate, Description: "Modificar clientes"},
+	{Name: PermClientDelete, Description: "Eliminar clientes"},
+	{Name: PermEmployeeCreate, Description: "Crear empleados"},
+	{Name: PermEmployeeUpdate, Description: "Modificar empleados"},
+	{Name: PermEmployeeDelete, Description: "Eliminar empleados"},
+	{Name: PermExpenseCreate, Description: "Registrar egresos"},
+	{Name: PermExpenseUpdate, Description: "Modificar egresos"},
+	{Name: PermExpenseDelete, Description: "Eliminar egresos"},
+	{Name: PermIncomeCreate, Description: "Registrar ingresos"},
+	{Name: PermIncomeUpdate, Description: "Modificar ingresos"},
+	{Name: PermIncomeDelete, Description: "Eliminar ingresos"},
+	{Name: PermMovementTypeCreate, Description: "Crear tipos de movimiento"},
+	{Name: PermMovementTypeUpdate, Description: "Modificar tipos de movimiento"},
+	{Name: PermMovementTypeDelete, Description: "Eliminar tipos de movimiento"},
+	{Name: PermProductCreate, Description: "Crear productos"},
+	{Name: PermProductUpdate, Description: "Modificar productos"},
+	{Name: PermProductDelete, Description: "Eliminar productos"},
+	{Name: PermProductStockAdjust, Description: "Ajustar el stock de productos"},
+	{Name: PermPurchaseOrderCreate, Description: "Crear órdenes de compra"},
+	{Name: PermPurchaseOrderUpdate, Description: "Modificar órdenes de compra y sus productos"},
+	{Name: PermPurchaseOrderDelete, Description: "Eliminar órdenes de compra"},
+	{Name: PermServiceCreate, Description: "Crear servicios"},
+	{Name: PermServiceUpdate, Description: "Modificar servicios"},
+	{Name: PermServiceDelete, Description: "Eliminar servicios"},
+	{Name: PermSupplierCreate, Description: "Crear proveedores"},
+	{Name: PermSupplierUpdate, Description: "Modificar proveedores"},
+	{Name: PermSupplierDelete, Description: "Eliminar proveedores"},
+	{Name: PermVehicleCreate, Description: "Crear vehículos"},
+	{Name: PermVehicleUpdate, Description: "Modificar vehículos"},
+	{Name: PermVehicleDelete, Description: "Eliminar vehículos"},
+	{Name: PermWorkOrderCreate, Description: "Abrir órdenes de trabajo"},
+	{Name: PermWorkOrderUpdate, Description: "Modificar órdenes de trabajo y cambiar su estado"},
+	{Name: PermWorkOrderDeliver, Description: "Entregar órdenes de trabajo, generando el ingreso"},
+	{Name: PermWorkOrderDelete, Description: "Eliminar órdenes de trabajo"},
+	{Name: PermTrashManage, Description: "Ver, restaurar y purgar la papelera"},
+	{Name: PermUserRead, Description: "Ver usuarios"},
+	{Name: PermUserCreate, Description: "Crear usuarios"},
+	{Name: PermUserUpdate, Description: "Modificar usuarios, su rol y activarlos o desactivarlos"},
+	{Name: PermUserDelete, Description: "Eliminar usuarios"},
+	{Name: PermAuditRead, Description: "Ver la auditoría de cambios"},
+	{Name: PermLoginHistoryRead, Description: "Ver el historial de login"},
+	{Name: PermWorkplaceCreate, Description: "Crear lugares de trabajo"},
+	{Name: PermPermissionManage, Description: "Asignar permisos a los roles"},
+}
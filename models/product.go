@@ -3,25 +3,23 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
-type ProductLaundry struct {
-	ID         string    `gorm:"primaryKey" json:"id"`
-	Identifier string    `gorm:"not null;unique" json:"identifier"`
-	Name       string    `gorm:"not null" json:"name"`
-	Stock      int32     `gorm:"not null;min:0;default:0" json:"stock"`
-	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-}
-
-type PartWorkshop struct {
-	ID         string    `gorm:"primaryKey" json:"id"`
-	Identifier string    `gorm:"not null;unique" json:"identifier"`
-	Name       string    `gorm:"not null" json:"name"`
-	Stock      int32     `gorm:"not null;min:0;default:0" json:"stock"`
-	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+// Product es un repuesto o insumo del workplace. Reserved es la parte del
+// stock comprometida en órdenes de trabajo sin entregar: se descuenta de Stock
+// al entregarlas.
+type Product struct {
+	ID          string         `gorm:"primaryKey" json:"id"`
+	WorkplaceID string         `gorm:"not null;uniqueIndex:idx_product_workplace_identifier" json:"workplace_id"`
+	Identifier  string         `gorm:"not null;uniqueIndex:idx_product_workplace_identifier" json:"identifier"`
+	Name        string         `gorm:"not null" json:"name"`
+	Stock       int32          `gorm:"not null;min:0;default:0" json:"stock"`
+	Reserved    int32          `gorm:"not null;default:0" json:"reserved"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version     int64          `gorm:"not null;default:1" json:"version"`
 }
 
 type ProductCreate struct {
@@ -30,19 +28,18 @@ type ProductCreate struct {
 }
 
 func (p *ProductCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return ValidateStruct(p)
 }
 
 type ProductUpdate struct {
 	ID         string  `json:"id" validate:"required"`
 	Identifier string  `json:"identifier"`
 	Name       string  `json:"name" validate:"required"`
+	Version    int64   `json:"version"`
 }
 
 func (p *ProductUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return ValidateStruct(p)
 }
 
 type StockUpdate struct {
@@ -50,6 +47,5 @@ type StockUpdate struct {
 }
 
 func (p *StockUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return ValidateStruct(p)
 }
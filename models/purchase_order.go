@@ -3,31 +3,22 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
-type PurchaseOrderLaundry struct {
-	ID            string `gorm:"not null;primaryKey" json:"id"`
-	OrderNumber   string `gorm:"not null" json:"order_number"`
-	OrderDate     string `gorm:"not null" json:"order_date"`
-	Amount        float32 `gorm:"not null" json:"amount"`
-	SupplierID string  `gorm:"not null" json:"supplier_id"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	Supplier      SupplierLaundry `gorm:"foreignKey:SupplierID;references:ID" json:"supplier"`
-	PurchaseProductLaundrys []PurchaseProductLaundry `gorm:"foreignKey:PurchaseOrderID;references:ID" json:"purchase_products"`
-}
-
-type PurchaseOrderWorkshop struct {
-	ID            string `gorm:"not null;primaryKey" json:"id"`
-	OrderNumber   string `gorm:"not null" json:"order_number"`
-	OrderDate     string `gorm:"not null" json:"order_date"`
-	Amount        float32 `gorm:"not null" json:"amount"`
-	SupplierID string  `gorm:"not null" json:"supplier_id"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	Supplier      SupplierWorkshop `gorm:"foreignKey:SupplierID;references:ID" json:"supplier"`
-	PurchasePartWorkshops []PurchasePartWorkshop `gorm:"foreignKey:PurchaseOrderID;references:ID" json:"purchase_parts"`
+type PurchaseOrder struct {
+	ID               string            `gorm:"not null;primaryKey" json:"id"`
+	WorkplaceID      string            `gorm:"not null;index" json:"workplace_id"`
+	OrderNumber      string            `gorm:"not null" json:"order_number"`
+	OrderDate        string            `gorm:"not null" json:"order_date"`
+	Amount           float32           `gorm:"not null" json:"amount"`
+	SupplierID       string            `gorm:"not null" json:"supplier_id"`
+	CreatedAt        time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt        gorm.DeletedAt    `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version          int64             `gorm:"not null;default:1" json:"version"`
+	Supplier         Supplier          `gorm:"foreignKey:SupplierID;references:ID" json:"supplier"`
+	PurchaseProducts []PurchaseProduct `gorm:"foreignKey:PurchaseOrderID;references:ID" json:"purchase_products"`
 }
 
 type PurchaseOrderCreate struct {
@@ -35,12 +26,11 @@ type PurchaseOrderCreate struct {
 	OrderDate     string `json:"order_date" validate:"required"`
 	Amount        float32 `json:"amount" validate:"required"`
 	SupplierID string  `json:"supplier_id"`
-	PurchaseProductCreates []PurchaseProductCreate `json:"purchase_products" validate:"required,gt=0"`
+	PurchaseProductCreates []PurchaseProductCreate `json:"purchase_products" validate:"required,gt=0,dive"`
 }
 
 func (p *PurchaseOrderCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return ValidateStruct(p)
 }
 
 type PurchaseOrderUpdate struct {
@@ -49,10 +39,10 @@ type PurchaseOrderUpdate struct {
 	OrderDate     string `json:"order_date" validate:"required"`
 	Amount        float32 `json:"amount" validate:"required"`
 	SupplierID string  `json:"supplier_id"`
-	PurchaseProductUpdates []PurchaseProductUpdate `json:"purchase_products" validate:"required,gt=0"`
+	PurchaseProductUpdates []PurchaseProductUpdate `json:"purchase_products" validate:"required,gt=0,dive"`
+	Version       int64  `json:"version"`
 }
 
 func (p *PurchaseOrderUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return ValidateStruct(p)
 }
\ No newline at end of file
@@ -3,47 +3,35 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
-type PurchaseProductLaundry struct {
-	ID        string  `gorm:"primaryKey" json:"id"`
-	ProductID string  `gorm:"not null" json:"product_id"`
-	PurchaseOrderID string `gorm:"not null" json:"purchase_order_id"`
-	ExpiredAt string  `gorm:"not null" json:"expired_at"`
-	UnitPrice  float32 `gorm:"not null" json:"unit_price"`
-	Quantity   int     `gorm:"not null" json:"quantity"`
-	TotalPrice float32 `gorm:"not null" json:"total_price"`
-	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
-	Product   ProductLaundry `gorm:"foreignKey:ProductID;references:ID" json:"product"`
-	PurchaseOrder PurchaseOrderLaundry `gorm:"foreignKey:PurchaseOrderID;references:ID" json:"purchase_order"`
-}
-
-type PurchasePartWorkshop struct {
-	ID        string  `gorm:"primaryKey" json:"id"`
-	PartID string  `gorm:"not null" json:"part_id"`
-	PurchaseOrderID string `gorm:"not null" json:"purchase_order_id"`
-	ExpiredAt string  `gorm:"not null" json:"expired_at"`
-	UnitPrice  float32 `gorm:"not null" json:"unit_price"`
-	Quantity   int     `gorm:"not null" json:"quantity"`
-	TotalPrice float32 `gorm:"not null" json:"total_price"`
-	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
-	PartWorkshop PartWorkshop `gorm:"foreignKey:PartID;references:ID" json:"part"`
-	PurchaseOrderWorkshop PurchaseOrderWorkshop `gorm:"foreignKey:PurchaseOrderID;references:ID" json:"purchase_order"`
+type PurchaseProduct struct {
+	ID              string         `gorm:"primaryKey" json:"id"`
+	ProductID       string         `gorm:"not null" json:"product_id"`
+	PurchaseOrderID string         `gorm:"not null;index" json:"purchase_order_id"`
+	ExpiredAt       string         `gorm:"not null" json:"expired_at"`
+	UnitPrice       float32        `gorm:"not null" json:"unit_price"`
+	Quantity        int            `gorm:"not null" json:"quantity"`
+	TotalPrice      float32        `gorm:"not null" json:"total_price"`
+	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version         int64          `gorm:"not null;default:1" json:"version"`
+	Product         Product        `gorm:"foreignKey:ProductID;references:ID" json:"product"`
+	PurchaseOrder   PurchaseOrder  `gorm:"foreignKey:PurchaseOrderID;references:ID" json:"purchase_order"`
 }
 
 type PurchaseProductCreate struct {
 	ProductID string  `json:"product_id" validate:"required"`
+	PurchaseOrderID string `json:"purchase_order_id"`
 	ExpiredAt string  `json:"expired_at"`
 	UnitPrice  float32 `json:"unit_price" validate:"required"`
 	Quantity   int     `json:"quantity" validate:"required"`
 }
 
 func (p *PurchaseProductCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return ValidateStruct(p)
 }
 
 type PurchaseProductUpdate struct {
@@ -52,9 +40,9 @@ type PurchaseProductUpdate struct {
 	ExpiredAt string  `json:"expired_at"`
 	UnitPrice  float32 `json:"unit_price" validate:"required"`
 	Quantity   int     `json:"quantity" validate:"required"`
+	Version   int64   `json:"version"`
 }
 
 func (p *PurchaseProductUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(p)
+	return ValidateStruct(p)
 }
\ No newline at end of file
@@ -1,7 +1,10 @@
 package models
 
 type Response struct {
-	Status bool `json:"status"`
-	Body interface{} `json:"body"`
-	Message string `json:"message"`
-}
\ No newline at end of file
+	Status  bool         `json:"status"`
+	Body    interface{}  `json:"body"`
+	Message string       `json:"message"`
+	Meta    *Pagination  `json:"meta,omitempty"`
+	Code    string       `json:"code,omitempty"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
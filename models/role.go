@@ -1,8 +1,10 @@
 package models
 
+// Workplace contiene el identifier del lugar de trabajo del rol, o "all" si el rol
+// tiene acceso a todos los lugares de trabajo.
 type Role struct {
 	ID   string `gorm:"primaryKey" json:"id"`
 	Name string    `gorm:"not null" json:"name"`
 	Hierarchy int    `gorm:"not null" json:"hierarchy"`
-	Workplace string `gorm:"not null" json:"workplace" validate:"oneof=all laundry workshop"`
-}
\ No newline at end of file
+	Workplace string `gorm:"not null" json:"workplace" validate:"required"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// Tipos de resultado de /search.
+const (
+	SearchTypeClient   = "client"
+	SearchTypeVehicle  = "vehicle"
+	SearchTypeProduct  = "product"
+	SearchTypeSupplier = "supplier"
+)
+
+// SearchHit es un resultado de /search. Score es mayor cuanto más relevante.
+type SearchHit struct {
+	Type     string  `json:"type"`
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle"`
+	Score    float64 `json:"score"`
+}
@@ -3,43 +3,66 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
-type ServiceWorkshop struct {
-	ID        string    `gorm:"primaryKey" json:"id"`
-	Name      string    `gorm:"not null;unique" json:"name"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-}
-
-type ServiceLaundry struct {
-	ID        string    `gorm:"primaryKey" json:"id"`
-	Name      string    `gorm:"not null;unique" json:"name"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-}
-
 type Service struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
+	ID          string         `gorm:"primaryKey" json:"id"`
+	WorkplaceID string         `gorm:"not null;uniqueIndex:idx_service_workplace_name" json:"workplace_id"`
+	Name        string         `gorm:"not null;uniqueIndex:idx_service_workplace_name" json:"name"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version     int64          `gorm:"not null;default:1" json:"version"`
+	// Price es el precio general vigente (ver ServicePrice); nil si nunca tuvo
+	// uno. CategoryPrices tiene los vigentes por categoría de vehículo.
+	Price          *float32           `gorm:"-" json:"price"`
+	CategoryPrices map[string]float32 `gorm:"-" json:"category_prices"`
 }
 
 type ServiceCreate struct {
-	Name string `json:"name" validate:"required"`
+	Name  string  `json:"name" validate:"required"`
+	Price float32 `json:"price" validate:"gte=0" example:"1500"`
 }
 
 func (s *ServiceCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(s)
+	return ValidateStruct(s)
 }
 
 type ServiceUpdate struct {
-	ID   string `json:"id"`
-	Name string `json:"name" validate:"required"`
+	ID      string `json:"id"`
+	Name    string `json:"name" validate:"required"`
+	Version int64  `json:"version"`
 }
 
 func (s *ServiceUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(s)
-}
\ No newline at end of file
+	return ValidateStruct(s)
+}
+
+// ServicePrice es el precio de un servicio a partir de EffectiveFrom. Rige el
+// de EffectiveFrom más reciente que ya pasó, así se pueden cargar aumentos por
+// adelantado. Los ingresos copian el precio en sus líneas: cambiarlo no altera
+// lo ya cobrado.
+//
+// VehicleCategory vacío es el precio general. Un vehículo con categoría paga
+// el precio vigente de su categoría y, si no hay, el general.
+type ServicePrice struct {
+	ID              string    `gorm:"primaryKey" json:"id"`
+	ServiceID       string    `gorm:"not null;uniqueIndex:idx_service_price_category_effective" json:"service_id"`
+	VehicleCategory string    `gorm:"not null;default:'';uniqueIndex:idx_service_price_category_effective" json:"vehicle_category"`
+	Price           float32   `gorm:"not null" json:"price"`
+	EffectiveFrom   time.Time `gorm:"not null;uniqueIndex:idx_service_price_category_effective" json:"effective_from"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type ServicePriceCreate struct {
+	Price float32 `json:"price" validate:"gte=0" example:"1800"`
+	// VehicleCategory es la categoría a la que aplica; vacío es el precio general.
+	VehicleCategory string `json:"vehicle_category" validate:"omitempty,vehicle_category" example:"camioneta"`
+	// EffectiveFrom es desde cuándo rige; si se omite, desde ahora.
+	EffectiveFrom *time.Time `json:"effective_from" example:"2026-11-01T00:00:00-03:00"`
+}
+
+func (s *ServicePriceCreate) Validate() error {
+	return ValidateStruct(s)
+}
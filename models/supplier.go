@@ -3,29 +3,21 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
 // Proveedor
-type SupplierLaundry struct {
-	ID        string `gorm:"primaryKey" json:"id"`
-	Name      string `gorm:"not null" json:"name"`
-	Address   string `gorm:"not null" json:"address"`
-	Phone     string `gorm:"not null" json:"phone"`
-	Email     string `gorm:"not null" json:"email"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-}
-
-
-type SupplierWorkshop struct {
-	ID        string `gorm:"primaryKey" json:"id"`
-	Name      string `gorm:"not null" json:"name"`
-	Address   string `gorm:"not null" json:"address"`
-	Phone     string `gorm:"not null" json:"phone"`
-	Email     string `gorm:"not null" json:"email"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+type Supplier struct {
+	ID          string         `gorm:"primaryKey" json:"id"`
+	WorkplaceID string         `gorm:"not null;index" json:"workplace_id"`
+	Name        string         `gorm:"not null" json:"name"`
+	Address     string         `gorm:"not null" json:"address"`
+	Phone       string         `gorm:"not null" json:"phone"`
+	Email       string         `gorm:"not null" json:"email"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version     int64          `gorm:"not null;default:1" json:"version"`
 }
 
 type SupplierCreate struct {
@@ -36,8 +28,7 @@ type SupplierCreate struct {
 }
 
 func (s *SupplierCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(s)
+	return ValidateStruct(s)
 }
 
 type SupplierUpdate struct {
@@ -46,9 +37,9 @@ type SupplierUpdate struct {
 	Address string `json:"address"`
 	Phone   string `json:"phone"`
 	Email   string `json:"email"`
+	Version int64  `json:"version"`
 }
 
 func (s *SupplierUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(s)
+	return ValidateStruct(s)
 }
\ No newline at end of file
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// RefreshToken es un refresh token emitido en el login o en una rotación. Se
+// guarda solo su hash. Al rotar, el anterior queda revocado y apunta al nuevo
+// con ReplacedBy; si alguien vuelve a presentar uno revocado se revocan todos
+// los del usuario, porque el token fue robado o reutilizado.
+type RefreshToken struct {
+	ID         string     `gorm:"primaryKey" json:"id"`
+	UserID     string     `gorm:"not null;index" json:"user_id"`
+	TokenHash  string     `gorm:"not null;uniqueIndex" json:"-"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	ReplacedBy string     `json:"replaced_by"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// RevokedToken es un access token revocado por logout antes de vencer. Se
+// guarda hasta su exp: después el JWT ya no es válido de todas formas.
+type RevokedToken struct {
+	JTI       string    `gorm:"primaryKey" json:"jti"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+// PasswordResetToken es un token de recuperación de contraseña enviado por
+// correo. Se guarda solo su hash, vence a las PasswordResetTTL y sirve una
+// sola vez: al usarlo se marca UsedAt.
+type PasswordResetToken struct {
+	ID        string     `gorm:"primaryKey" json:"id"`
+	UserID    string     `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -2,8 +2,6 @@ package models
 
 import (
 	"time"
-
-	"github.com/go-playground/validator/v10"
 )
 
 type User struct {
@@ -12,12 +10,28 @@ type User struct {
 	LastName  string    `gorm:"not null;size:30" json:"last_name"`
 	Username  string    `gorm:"unique;size:30;not null" json:"username"`
 	Email     string    `gorm:"unique;not null" json:"email" validate:"email"`
-	Password  string    `gorm:"not null" json:"password"`
-	Role      string    `gorm:"not null" json:"role" validate:"oneof=super_admin admin admin_laundry admin_workshop employee_laundry employee_workshop"`
+	Password  string    `gorm:"not null" json:"-"`
+	Role      string    `gorm:"not null" json:"role"`
+	Active    bool      `gorm:"not null;default:true" json:"active"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
+// DTO es la forma en que la API devuelve un usuario: sin el hash de la
+// contraseña.
+func (u *User) DTO() *UserDTO {
+	return &UserDTO{
+		ID:        u.ID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Username:  u.Username,
+		Email:     u.Email,
+		Role:      u.Role,
+		Active:    u.Active,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
 
 type UserDTO struct {
 	ID        string    `json:"id"`
@@ -26,20 +40,42 @@ type UserDTO struct {
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
 	Role      string    `json:"role"`
+	Active    bool      `json:"active"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// UserCreate son los datos de un usuario nuevo. Role no se limita acá a una
+// lista fija: el servicio lo controla contra la tabla de roles.
 type UserCreate struct {
 	FirstName string `json:"first_name" validate:"required"`
 	LastName  string `json:"last_name" validate:"required"`
 	Username  string `json:"username" validate:"required"`
 	Email     string `json:"email" validate:"required,email"`
 	Password  string `json:"password" validate:"required"`
-	Role      string `json:"role" validate:"required,oneof= admin admin_laundry admin_workshop employee_laundry employee_workshop"`
+	Role      string `json:"role" validate:"required"`
 }
 
 func (u *UserCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(u)
-}
\ No newline at end of file
+	return ValidateStruct(u)
+}
+// UserUpdate son los datos del perfil. En /user/me el id se toma del token.
+type UserUpdate struct {
+	ID        string `json:"id" validate:"required"`
+	FirstName string `json:"first_name" validate:"required"`
+	LastName  string `json:"last_name" validate:"required"`
+	Username  string `json:"username" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+}
+
+func (u *UserUpdate) Validate() error {
+	return ValidateStruct(u)
+}
+
+type UserRoleUpdate struct {
+	Role string `json:"role" validate:"required"`
+}
+
+func (u *UserRoleUpdate) Validate() error {
+	return ValidateStruct(u)
+}
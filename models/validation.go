@@ -0,0 +1,128 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/locales/es"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	es_translations "github.com/go-playground/validator/v10/translations/es"
+)
+
+// validate es la única instancia del validador: cachea los structs que ya
+// analizó, usa los nombres de los tags json y traduce los mensajes al español.
+var (
+	validate   = validator.New()
+	translator ut.Translator
+)
+
+func init() {
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	spanish := es.New()
+	translator, _ = ut.New(spanish, spanish).GetTranslator("es")
+	if err := es_translations.RegisterDefaultTranslations(validate, translator); err != nil {
+		panic(err)
+	}
+
+	// required_with y datetime no tienen traducción en el paquete es
+	validate.RegisterTranslation("required_with", translator, func(ut ut.Translator) error {
+		return ut.Add("required_with", "{0} es un campo requerido cuando se indica {1}", false)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		msg, _ := ut.T("required_with", fe.Field(), snakeCase(fe.Param()))
+		return msg
+	})
+	// vehicle_category valida contra VehicleCategories para no repetir la
+	// lista en cada tag oneof
+	validate.RegisterValidation("vehicle_category", func(fl validator.FieldLevel) bool {
+		return slices.Contains(VehicleCategories, fl.Field().String())
+	})
+	validate.RegisterTranslation("vehicle_category", translator, func(ut ut.Translator) error {
+		return ut.Add("vehicle_category", "{0} debe ser uno de [{1}]", false)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		msg, _ := ut.T("vehicle_category", fe.Field(), strings.Join(VehicleCategories, " "))
+		return msg
+	})
+	validate.RegisterTranslation("datetime", translator, func(ut ut.Translator) error {
+		return ut.Add("datetime", "{0} debe tener el formato {1}", false)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		msg, _ := ut.T("datetime", fe.Field(), fe.Param())
+		return msg
+	})
+}
+
+// snakeCase pasa el nombre Go de un campo (el parámetro de reglas como
+// required_with) al estilo de los tags json: DiscountReason -> discount_reason.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// FieldError describe un campo que no pasó la validación.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors es el error que devuelven los Validate() de los DTOs.
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, fieldError := range v {
+		messages[i] = fieldError.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateStruct valida s con la instancia compartida y traduce los errores
+// a ValidationErrors. Los campos anidados se nombran con su ruta json
+// (p. ej. purchase_products[0].product_id).
+func ValidateStruct(s interface{}) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	fields := make(ValidationErrors, len(validationErrors))
+	for i, fe := range validationErrors {
+		field := fe.Namespace()
+		if _, rest, ok := strings.Cut(field, "."); ok {
+			field = rest
+		}
+		fields[i] = FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Message: fe.Translate(translator),
+		}
+	}
+	return fields
+}
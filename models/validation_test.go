@@ -0,0 +1,66 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateStructFieldErrors(t *testing.T) {
+	err := (&PurchaseOrderCreate{
+		OrderNumber: "OC-1",
+		PurchaseProductCreates: []PurchaseProductCreate{
+			{UnitPrice: 10, Quantity: 1},
+		},
+	}).Validate()
+
+	var fields ValidationErrors
+	if !errors.As(err, &fields) {
+		t.Fatalf("se esperaba ValidationErrors, se obtuvo %T: %v", err, err)
+	}
+
+	byField := map[string]FieldError{}
+	for _, field := range fields {
+		byField[field.Field] = field
+	}
+
+	orderDate, ok := byField["order_date"]
+	if !ok {
+		t.Fatalf("falta el error de order_date: %+v", fields)
+	}
+	if orderDate.Rule != "required" || orderDate.Message != "order_date es un campo requerido" {
+		t.Errorf("order_date = %+v", orderDate)
+	}
+	if _, ok := byField["purchase_products[0].product_id"]; !ok {
+		t.Errorf("falta el error anidado de product_id: %+v", fields)
+	}
+}
+
+func TestValidateStructValid(t *testing.T) {
+	if err := (&AuthLogin{Username: "admin", Password: "secreta"}).Validate(); err != nil {
+		t.Errorf("no se esperaba error: %v", err)
+	}
+}
+
+func TestValidationCarriesFields(t *testing.T) {
+	errResp := Validation("Datos inválidos", (&AuthLogin{}).Validate())
+	if errResp.StatusCode != 422 || errResp.Code != CodeValidation || len(errResp.Fields) != 2 {
+		t.Errorf("error = %+v", errResp)
+	}
+}
+
+func TestValidateVehicleCategory(t *testing.T) {
+	for _, category := range append([]string{""}, VehicleCategories...) {
+		if err := (&VehicleCreate{Brand: "Ford", Color: "Rojo", Domain: "AB123CD", ClientID: "c", Category: category}).Validate(); err != nil {
+			t.Errorf("categoría %q: no se esperaba error: %v", category, err)
+		}
+	}
+
+	err := (&ServicePriceCreate{VehicleCategory: "camion"}).Validate()
+	var fields ValidationErrors
+	if !errors.As(err, &fields) || len(fields) != 1 {
+		t.Fatalf("se esperaba un error de vehicle_category, se obtuvo %v", err)
+	}
+	if fields[0].Rule != "vehicle_category" || fields[0].Message != "vehicle_category debe ser uno de [auto suv camioneta utilitario moto]" {
+		t.Errorf("error = %+v", fields[0])
+	}
+}
@@ -3,20 +3,37 @@ package models
 import (
 	"time"
 
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 )
 
+// Categorías de vehículo. En los workplaces con CategoryPrices los servicios
+// pueden tener un precio por categoría (ver ServicePrice); un vehículo sin
+// categoría paga el precio general.
+const (
+	VehicleAuto       = "auto"
+	VehicleSUV        = "suv"
+	VehiclePickup     = "camioneta"
+	VehicleUtilitario = "utilitario"
+	VehicleMoto       = "moto"
+)
+
+// VehicleCategories son las categorías válidas, en el orden en que se listan.
+var VehicleCategories = []string{VehicleAuto, VehicleSUV, VehiclePickup, VehicleUtilitario, VehicleMoto}
+
 type Vehicle struct {
-	ID   string `gorm:"primaryKey" json:"id"`
-	Brand string `gorm:"not null" json:"brand"`
-	Model string ` json:"model"`
-	Color string `gorm:"not null" json:"color"`
-	Year  string `json:"year"`
-	Domain string `gorm:"not null;unique" json:"domain"`
-	ClientID string `gorm:"not null" json:"client_id"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	Client Client `gorm:"foreignKey:ClientID" json:"client"`
+	ID        string         `gorm:"primaryKey" json:"id"`
+	Brand     string         `gorm:"not null" json:"brand"`
+	Model     string         ` json:"model"`
+	Color     string         `gorm:"not null" json:"color"`
+	Year      string         `json:"year"`
+	Domain    string         `gorm:"not null;unique" json:"domain"`
+	Category  string         `gorm:"index" json:"category" example:"auto"`
+	ClientID  string         `gorm:"not null" json:"client_id"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version   int64          `gorm:"not null;default:1" json:"version"`
+	Client    Client         `gorm:"foreignKey:ClientID" json:"client"`
 }
 
 type VehicleCreate struct {
@@ -25,12 +42,12 @@ type VehicleCreate struct {
 	Color string `json:"color" validate:"required" example:"Red"`
 	Year  string `json:"year" example:"2020"`
 	Domain string `json:"domain" validate:"required" example:"ABC123"`
+	Category string `json:"category" validate:"omitempty,vehicle_category" example:"auto"`
 	ClientID string `json:"client_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
 }
 
 func (v *VehicleCreate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(v)
+	return ValidateStruct(v)
 }
 
 type VehicleUpdate struct {
@@ -40,12 +57,13 @@ type VehicleUpdate struct {
 	Color string `json:"color" example:"Red"`
 	Year  string `json:"year" example:"2020"`
 	Domain string `json:"domain" example:"ABC123"`
+	Category string `json:"category" validate:"omitempty,vehicle_category" example:"suv"`
 	ClientID string `json:"client_id" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Version int64 `json:"version"`
 }
 
 func (v *VehicleUpdate) Validate() error {
-	validate := validator.New()
-	return validate.Struct(v)
+	return ValidateStruct(v)
 }
 
 type VehicleDTO struct {
@@ -55,6 +73,7 @@ type VehicleDTO struct {
 	Color string `json:"color"`
 	Year  string `json:"year"`
 	Domain string `json:"domain"`
+	Category string `json:"category"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
\ No newline at end of file
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Estados de una orden de trabajo del taller.
+const (
+	WorkOrderReceived     = "recibido"
+	WorkOrderDiagnosis    = "diagnostico"
+	WorkOrderWaitingParts = "esperando_repuestos"
+	WorkOrderInRepair     = "en_reparacion"
+	WorkOrderReady        = "listo"
+	WorkOrderDelivered    = "entregado"
+)
+
+// WorkOrderTransitions son los cambios de estado permitidos. A entregado se
+// llega solo con /work_order/deliver, que además genera el ingreso.
+var WorkOrderTransitions = map[string][]string{
+	WorkOrderReceived:     {WorkOrderDiagnosis},
+	WorkOrderDiagnosis:    {WorkOrderWaitingParts, WorkOrderInRepair},
+	WorkOrderWaitingParts: {WorkOrderInRepair},
+	WorkOrderInRepair:     {WorkOrderWaitingParts, WorkOrderReady},
+	WorkOrderReady:        {WorkOrderInRepair, WorkOrderDelivered},
+	WorkOrderDelivered:    {},
+}
+
+// CanTransition indica si una orden en el estado from puede pasar a to.
+func CanTransition(from, to string) bool {
+	return slices.Contains(WorkOrderTransitions[from], to)
+}
+
+// WorkOrder es una reparación del taller desde que se recibe el vehículo hasta
+// que se entrega. Al entregarla se crea el Income y queda en IncomeID.
+type WorkOrder struct {
+	ID              string                `gorm:"primaryKey" json:"id"`
+	WorkplaceID     string                `gorm:"not null;index" json:"workplace_id"`
+	ClientID        string                `gorm:"not null;index" json:"client_id"`
+	VehicleID       string                `gorm:"not null;index" json:"vehicle_id"`
+	EmployeeID      string                `gorm:"index" json:"employee_id"`
+	Description     string                `gorm:"not null" json:"description"`
+	Diagnosis       string                `json:"diagnosis"`
+	Status          string                `gorm:"not null;index" json:"status" example:"recibido"`
+	StatusChangedAt time.Time             `gorm:"not null" json:"status_changed_at"`
+	IncomeID        string                `json:"income_id"`
+	CreatedAt       time.Time             `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time             `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt        `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
+	Version         int64                 `gorm:"not null;default:1" json:"version"`
+	Client          Client                `gorm:"foreignKey:ClientID" json:"client"`
+	Vehicle         Vehicle               `gorm:"foreignKey:VehicleID" json:"vehicle"`
+	Employee        Employee              `gorm:"foreignKey:EmployeeID" json:"employee"`
+	Transitions     []WorkOrderTransition `gorm:"foreignKey:WorkOrderID;references:ID" json:"transitions"`
+	Parts           []WorkOrderPart       `gorm:"foreignKey:WorkOrderID;references:ID" json:"parts"`
+}
+
+// WorkOrderTransition registra cada cambio de estado de una orden, con quién
+// lo hizo y cuándo. La creación es la transición de "" a recibido.
+type WorkOrderTransition struct {
+	ID          string    `gorm:"primaryKey" json:"id"`
+	WorkOrderID string    `gorm:"not null;index" json:"work_order_id"`
+	FromStatus  string    `json:"from_status"`
+	ToStatus    string    `gorm:"not null" json:"to_status"`
+	Note        string    `json:"note"`
+	UserID      string    `json:"user_id"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// WorkOrderPart es un repuesto usado en la orden. Mientras la orden no se
+// entrega la cantidad queda reservada en el producto; al entregarla se
+// descuenta del stock y Quantity*Price se suma al ingreso.
+type WorkOrderPart struct {
+	ID          string    `gorm:"primaryKey" json:"id"`
+	WorkOrderID string    `gorm:"not null;uniqueIndex:idx_work_order_part" json:"work_order_id"`
+	ProductID   string    `gorm:"not null;uniqueIndex:idx_work_order_part" json:"product_id"`
+	Quantity    int32     `gorm:"not null" json:"quantity"`
+	Price       float32   `gorm:"not null" json:"price"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	Product     Product   `gorm:"foreignKey:ProductID" json:"product"`
+}
+
+type WorkOrderCreate struct {
+	ClientID    string `json:"client_id" validate:"required"`
+	VehicleID   string `json:"vehicle_id" validate:"required"`
+	EmployeeID  string `json:"employee_id"`
+	Description string `json:"description" validate:"required" example:"Ruido en el tren delantero"`
+}
+
+func (w *WorkOrderCreate) Validate() error {
+	return ValidateStruct(w)
+}
+
+type WorkOrderUpdate struct {
+	ID          string `json:"id" validate:"required"`
+	EmployeeID  string `json:"employee_id"`
+	Description string `json:"description" validate:"required"`
+	Diagnosis   string `json:"diagnosis"`
+	Version     int64  `json:"version"`
+}
+
+func (w *WorkOrderUpdate) Validate() error {
+	return ValidateStruct(w)
+}
+
+type WorkOrderStatusUpdate struct {
+	Status  string `json:"status" validate:"required,oneof=diagnostico esperando_repuestos en_reparacion listo" example:"diagnostico"`
+	Note    string `json:"note"`
+	Version int64  `json:"version"`
+}
+
+func (w *WorkOrderStatusUpdate) Validate() error {
+	return ValidateStruct(w)
+}
+
+// WorkOrderDeliver son los datos del ingreso que se genera al entregar una
+// orden lista. Cliente, vehículo y empleado salen de la orden; el detalle, de
+// la descripción si no se indica.
+type WorkOrderDeliver struct {
+	Ticket         string   `json:"ticket" validate:"required"`
+	ServicesID     []string `json:"services_id" validate:"required,gt=0,unique,dive,required"`
+	Details        string   `json:"details"`
+	MovementTypeID string   `json:"movement_type_id" validate:"required"`
+	Discount       float32  `json:"discount" validate:"gte=0"`
+	DiscountReason string   `json:"discount_reason" validate:"required_with=Discount"`
+	Note           string   `json:"note"`
+	Version        int64    `json:"version"`
+}
+
+func (w *WorkOrderDeliver) Validate() error {
+	return ValidateStruct(w)
+}
+
+// WorkOrderPartCreate agrega un repuesto a la orden. Price es el precio de
+// venta por unidad. Version es la de la orden.
+type WorkOrderPartCreate struct {
+	ProductID string  `json:"product_id" validate:"required"`
+	Quantity  int32   `json:"quantity" validate:"required,gt=0" example:"2"`
+	Price     float32 `json:"price" validate:"gte=0" example:"15000"`
+	Version   int64   `json:"version"`
+}
+
+func (w *WorkOrderPartCreate) Validate() error {
+	return ValidateStruct(w)
+}
+
+type WorkOrderPartUpdate struct {
+	ID       string  `json:"id" validate:"required"`
+	Quantity int32   `json:"quantity" validate:"required,gt=0" example:"1"`
+	Price    float32 `json:"price" validate:"gte=0" example:"15000"`
+	Version  int64   `json:"version"`
+}
+
+func (w *WorkOrderPartUpdate) Validate() error {
+	return ValidateStruct(w)
+}
@@ -1,6 +1,8 @@
 package models
 
-import "time"
+import (
+	"time"
+)
 
 type Workplace struct {
 	ID   string    `gorm:"primaryKey" json:"id"`
@@ -8,7 +10,26 @@ type Workplace struct {
 	Address string `gorm:"not null" json:"address"`
 	Phone string `gorm:"not null" json:"phone"`
 	Email string `gorm:"not null" json:"email"`
-	Identifier string `gorm:"not null;unique" validate:"oneof=laundry workshop" json:"identifier"`
+	Identifier string `gorm:"not null;unique" validate:"required" json:"identifier"`
+	// WorkOrders habilita las órdenes de trabajo (talleres).
+	WorkOrders bool `gorm:"not null;default:false" json:"work_orders"`
+	// CategoryPrices habilita los precios de servicios por categoría de
+	// vehículo (lavaderos).
+	CategoryPrices bool `gorm:"not null;default:false" json:"category_prices"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-}
\ No newline at end of file
+}
+
+type WorkplaceCreate struct {
+	Name           string `json:"name" validate:"required" example:"Gomeria"`
+	Address        string `json:"address" validate:"required" example:"Av. Los Olivos"`
+	Phone          string `json:"phone" validate:"required" example:"123456789"`
+	Email          string `json:"email" validate:"required,email" example:"tire_shop@example.com"`
+	Identifier     string `json:"identifier" validate:"required,max=30,excludesall= " example:"tire_shop"`
+	WorkOrders     bool   `json:"work_orders" example:"true"`
+	CategoryPrices bool   `json:"category_prices" example:"false"`
+}
+
+func (w *WorkplaceCreate) Validate() error {
+	return ValidateStruct(w)
+}
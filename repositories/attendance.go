@@ -1,158 +1,103 @@
 package repositories
 
 import (
-	"fmt"
-
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-func (r *Repository) GetAttendanceByID(id string, workplace string) (*models.AttendanceLaundry, *models.AttendanceWorkshop, error) {
-	if workplace == "laundry" {
-		var attendance models.AttendanceLaundry
-		if err := r.DB.Where("id = ?", id).First(&attendance).Error; err != nil {
-			return nil, nil, err
-		}
-		return &attendance, nil, nil
-	} else if workplace == "workshop" {
-		var attendance models.AttendanceWorkshop
-		if err := r.DB.Where("id = ?", id).First(&attendance).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &attendance, nil
+func (r *Repository) GetAttendanceByID(id string, workplaceID string) (*models.Attendance, error) {
+	var attendance models.Attendance
+	if err := r.DB.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&attendance).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, nil
+	return &attendance, nil
 }
 
-func (r *Repository) GetAllAttendances(workplace string) (*[]models.AttendanceLaundry, *[]models.AttendanceWorkshop, error) {
-	if workplace == "laundry" {
-		var attendances []models.AttendanceLaundry
-		if err := r.DB.Find(&attendances).Error; err != nil {
-			return nil, nil, err
-		}
-		return &attendances, nil, nil
-	} else if workplace == "workshop" {
-		var attendances []models.AttendanceWorkshop
-		if err := r.DB.Find(&attendances).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &attendances, nil
-	}
-	return nil, nil, nil
+var attendanceList = listSpec{
+	fields: map[string]listField{
+		"employee_id": {column: "employee_id", filter: filterEquals},
+		"role":        {column: "attendance", filter: filterEquals},
+		"is_holiday":  {column: "is_holiday", filter: filterBool},
+		"date":        {column: "date", filter: filterDate},
+		"hours":       {column: "hours"},
+		"amount":      {column: "amount"},
+		"created_at":  {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "-date",
 }
 
-func (r *Repository) CreateAttendance(attendance *models.AttendanceCreate, workplace string) (string, error) {
-	newId := uuid.NewString()
-	switch workplace {
-		case "laundry":
-			if err := r.DB.Create(&models.AttendanceLaundry{
-				ID: newId,
-				EmployeeID: attendance.EmployeeID,
-				Attendance: attendance.Attendance,
-				Hours: attendance.Hours,
-				Date: attendance.Date,
-				Amount: attendance.Amount,
-				IsHoliday: attendance.IsHoliday,
-			}).Error; err != nil {
-				return "", err
-			}
-			return newId, nil
-		case "workshop":
-			if err := r.DB.Create(&models.AttendanceWorkshop{
-				ID: newId,
-				EmployeeID: attendance.EmployeeID,
-				Attendance: attendance.Attendance,
-				Hours: attendance.Hours,
-				Date: attendance.Date,
-				Amount: attendance.Amount,
-				IsHoliday: attendance.IsHoliday,
-			}).Error; err != nil {
-				return "", err
-			}
-			return newId, nil
-		default:
-			return "", fmt.Errorf("tipo de asistencia no soportado: %T", attendance)
+func (r *Repository) GetAllAttendances(workplaceID string, params *models.ListParams) (*[]models.Attendance, int64, error) {
+	var attendances []models.Attendance
+	query := r.DB.Model(&models.Attendance{}).Where("workplace_id = ?", workplaceID)
+	total, err := paginate(query, attendanceList, params, &attendances)
+	if err != nil {
+		return nil, 0, err
 	}
+	return &attendances, total, nil
 }
 
-func (r *Repository) UpdateAttendance(attendance *models.AttendanceUpdate, workplace string) error {
-	switch workplace {
-		case "laundry":
-			if err := r.DB.Where("id = ?", attendance.ID).Updates(&models.AttendanceLaundry{
-				EmployeeID: attendance.EmployeeID,
-				Attendance: attendance.Attendance,
-				Hours: attendance.Hours,
-				Date: attendance.Date,
-				Amount: attendance.Amount,
-				IsHoliday: attendance.IsHoliday,
-			}).Error; err != nil {
-				return err
-			}
-			return nil
-		case "workshop":
-			if err := r.DB.Where("id = ?", attendance.ID).Updates(&models.AttendanceWorkshop{
-				EmployeeID: attendance.EmployeeID,
-				Attendance: attendance.Attendance,
-				Hours: attendance.Hours,
-				Date: attendance.Date,
-				Amount: attendance.Amount,
-				IsHoliday: attendance.IsHoliday,
-			}).Error; err != nil {
-				return err
-			}
-			return nil
-		default:
-			return fmt.Errorf("tipo de asistencia no soportado: %T", attendance)
+func (r *Repository) CreateAttendance(attendance *models.AttendanceCreate, workplaceID string, actor models.Actor) (string, error) {
+	newId := uuid.NewString()
+	err := audited[models.Attendance](r.DB, actor, models.AuditCreate, "attendance", newId, func(tx *gorm.DB) error {
+		return tx.Create(&models.Attendance{
+			ID: newId,
+			WorkplaceID: workplaceID,
+			EmployeeID: attendance.EmployeeID,
+			Attendance: attendance.Attendance,
+			Hours: attendance.Hours,
+			Date: attendance.Date,
+			Amount: attendance.Amount,
+			IsHoliday: attendance.IsHoliday,
+		}).Error
+	})
+	if err != nil {
+		return "", err
 	}
+	return newId, nil
 }
 
-func (r *Repository) DeleteAttendance(id string, workplace string) error {
-	if workplace == "laundry" {
-		var attendance models.AttendanceLaundry
-		if err := r.DB.Where("id = ?", id).Delete(&attendance).Error; err != nil {
+func (r *Repository) UpdateAttendance(attendance *models.AttendanceUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.Attendance](r.DB, actor, models.AuditUpdate, "attendance", attendance.ID, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND workplace_id = ?", attendance.ID, workplaceID).First(&models.Attendance{}).Error; err != nil {
 			return err
 		}
-	} else if workplace == "workshop" {
-		var attendance models.AttendanceWorkshop
-		if err := r.DB.Where("id = ?", id).Delete(&attendance).Error; err != nil {
+		if err := bumpVersion(tx, &models.Attendance{}, attendance.ID, attendance.Version); err != nil {
 			return err
 		}
-	} else {
-		return fmt.Errorf("tipo de asistencia no soportado: %s", workplace)
-	}
-	return nil
+		return tx.Where("id = ? AND workplace_id = ?", attendance.ID, workplaceID).Updates(&models.Attendance{
+			EmployeeID: attendance.EmployeeID,
+			Attendance: attendance.Attendance,
+			Hours: attendance.Hours,
+			Date: attendance.Date,
+			Amount: attendance.Amount,
+			IsHoliday: attendance.IsHoliday,
+		}).Error
+	})
 }
 
-func (r *Repository) GetAttendancesByDate(date_start string, date_end string, workplace string) (*[]models.AttendanceLaundry, *[]models.AttendanceWorkshop, error) {
-	if workplace == "laundry" {
-		var attendances []models.AttendanceLaundry
-		if err := r.DB.Where("DATE(date) >= ? AND DATE(date) <= ?", date_start, date_end).Find(&attendances).Error; err != nil {
-			return nil, nil, err
-		}
-		return &attendances, nil, nil
-	} else if workplace == "workshop" {
-		var attendances []models.AttendanceWorkshop
-		if err := r.DB.Where("DATE(created_at) >= ? AND DATE(created_at) <= ?", date_start, date_end).Find(&attendances).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &attendances, nil
+func (r *Repository) DeleteAttendance(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.Attendance](r.DB, actor, models.AuditDelete, "attendance", id, func(tx *gorm.DB) error {
+		return deleteScoped(tx, &models.Attendance{}, id, workplaceID)
+	})
+}
+
+func (r *Repository) GetAttendancesByDate(date_start string, date_end string, workplaceID string) (*[]models.Attendance, error) {
+	date_limit, err := nextDay(date_end)
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil, nil
+	var attendances []models.Attendance
+	if err := r.DB.Where("workplace_id = ? AND date >= ? AND date < ?", workplaceID, date_start, date_limit).Find(&attendances).Error; err != nil {
+		return nil, err
+	}
+	return &attendances, nil
 }
 
-func (r *Repository) GetAttendanceByEmployeeID(userID string, workplace string) (*[]models.AttendanceLaundry, *[]models.AttendanceWorkshop, error) {
-	if workplace == "laundry" {
-		var attendances []models.AttendanceLaundry
-		if err := r.DB.Where("employee_id = ?", userID).Find(&attendances).Error; err != nil {
-			return nil, nil, err
-		}
-		return &attendances, nil, nil
-	} else if workplace == "workshop" {
-		var attendances []models.AttendanceWorkshop
-		if err := r.DB.Where("employee_id = ?", userID).Find(&attendances).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &attendances, nil
+func (r *Repository) GetAttendanceByEmployeeID(userID string, workplaceID string) (*[]models.Attendance, error) {
+	var attendances []models.Attendance
+	if err := r.DB.Where("employee_id = ? AND workplace_id = ?", userID, workplaceID).Find(&attendances).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, nil
-}
\ No newline at end of file
+	return &attendances, nil
+}
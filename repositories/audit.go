@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"strings"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// auditChange guarda en tx el AuditLog de un cambio hecho por actor. before
+// y after son el estado de la entidad antes y después, nil al crear y al
+// borrar respectivamente.
+func auditChange(tx *gorm.DB, actor models.Actor, action, entityType, entityID string, before, after models.JSONMap) error {
+	return tx.Create(&models.AuditLog{
+		ID:          uuid.NewString(),
+		UserID:      actor.UserID,
+		WorkplaceID: actor.WorkplaceID,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Action:      action,
+		Before:      before,
+		After:       after,
+	}).Error
+}
+
+// auditChildren agrega al snapshot de una entidad las líneas que se editan
+// junto con ella.
+var auditChildren = map[string]func(tx *gorm.DB, id string, m models.JSONMap) error{
+	"income": func(tx *gorm.DB, id string, m models.JSONMap) error {
+		var services []models.IncomeService
+		if err := tx.Where("income_id = ?", id).Order("service_id").Find(&services).Error; err != nil {
+			return err
+		}
+		lines := make([]models.JSONMap, 0, len(services))
+		for i := range services {
+			line, err := auditMap(tx, &services[i])
+			if err != nil {
+				return err
+			}
+			lines = append(lines, line)
+		}
+		m["services"] = lines
+		return nil
+	},
+	"service": func(tx *gorm.DB, id string, m models.JSONMap) error {
+		services := []models.Service{{ID: id}}
+		if err := setCurrentPrices(tx, services); err != nil {
+			return err
+		}
+		m["price"] = services[0].Price
+		m["category_prices"] = services[0].CategoryPrices
+		return nil
+	},
+	"work_order": func(tx *gorm.DB, id string, m models.JSONMap) error {
+		var parts []models.WorkOrderPart
+		if err := tx.Where("work_order_id = ?", id).Order("product_id").Find(&parts).Error; err != nil {
+			return err
+		}
+		lines := make([]models.JSONMap, 0, len(parts))
+		for i := range parts {
+			line, err := auditMap(tx, &parts[i])
+			if err != nil {
+				return err
+			}
+			lines = append(lines, line)
+		}
+		m["parts"] = lines
+		return nil
+	},
+	"purchase_order": func(tx *gorm.DB, id string, m models.JSONMap) error {
+		var products []models.PurchaseProduct
+		if err := tx.Unscoped().Where("purchase_order_id = ?", id).Order("id").Find(&products).Error; err != nil {
+			return err
+		}
+		lines := make([]models.JSONMap, 0, len(products))
+		for i := range products {
+			line, err := auditMap(tx, &products[i])
+			if err != nil {
+				return err
+			}
+			lines = append(lines, line)
+		}
+		m["purchase_products"] = lines
+		return nil
+	},
+}
+
+// snapshot lee la fila id de T dentro de tx, aunque esté en la papelera, y la
+// devuelve como JSONMap para el AuditLog.
+func snapshot[T any](tx *gorm.DB, entityType, id string) (models.JSONMap, error) {
+	var row T
+	if err := tx.Unscoped().Where("id = ?", id).First(&row).Error; err != nil {
+		return nil, err
+	}
+	m, err := auditMap(tx, &row)
+	if err != nil {
+		return nil, err
+	}
+	if children, ok := auditChildren[entityType]; ok {
+		if err := children(tx, id, m); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// auditMap convierte una entidad en JSONMap solo con sus columnas. Quedan
+// afuera las relaciones, que vienen vacías salvo que se hayan precargado y
+// ya tienen su propio historial, y los campos calculados (gorm:"-").
+func auditMap(tx *gorm.DB, value interface{}) (models.JSONMap, error) {
+	m, err := models.StructToJSONMap(value)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(value); err != nil {
+		return nil, err
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" {
+			delete(m, jsonName(field))
+		}
+	}
+	return m, nil
+}
+
+func jsonName(field *schema.Field) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// audited ejecuta change en una transacción y registra el cambio de la fila
+// id de T: lee su estado antes y después dentro de la misma transacción. Para
+// altas change debe crear la fila; para bajas, borrarla.
+func audited[T any](db *gorm.DB, actor models.Actor, action, entityType, id string, change func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var before, after models.JSONMap
+		var err error
+		if action != models.AuditCreate {
+			if before, err = snapshot[T](tx, entityType, id); err != nil {
+				return err
+			}
+		}
+		if err := change(tx); err != nil {
+			return err
+		}
+		if action != models.AuditDelete && action != models.AuditPurge {
+			if after, err = snapshot[T](tx, entityType, id); err != nil {
+				return err
+			}
+		}
+		return auditChange(tx, actor, action, entityType, id, before, after)
+	})
+}
+
+// deleteScoped borra (a la papelera, si el modelo la tiene) la fila id de
+// model dentro del workplace. Falla con gorm.ErrRecordNotFound si no había
+// nada que borrar.
+func deleteScoped(tx *gorm.DB, model interface{}, id string, workplaceID string) error {
+	result := tx.Where("id = ? AND workplace_id = ?", id, workplaceID).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *Repository) GetAuditLogByID(id string) (*models.AuditLog, error) {
+	var audit models.AuditLog
+	if err := r.DB.Where("id = ?", id).First(&audit).Error; err != nil {
+		return nil, err
+	}
+	return &audit, nil
+}
+
+var auditLogList = listSpec{
+	fields: map[string]listField{
+		"user_id":      {column: "user_id", filter: filterEquals},
+		"workplace_id": {column: "workplace_id", filter: filterEquals},
+		"entity_type":  {column: "entity_type", filter: filterEquals},
+		"entity_id":    {column: "entity_id", filter: filterEquals},
+		"action":       {column: "action", filter: filterEquals},
+		"created_at":   {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "-created_at",
+}
+
+func (r *Repository) ListAuditLogs(params *models.ListParams) ([]models.AuditLog, int64, error) {
+	var audits []models.AuditLog
+	total, err := paginate(r.DB.Model(&models.AuditLog{}), auditLogList, params, &audits)
+	if err != nil {
+		return nil, 0, err
+	}
+	return audits, total, nil
+}
@@ -1,7 +1,10 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/DanielChachagua/GestionCar/models"
+	"gorm.io/gorm"
 )
 
 func (r *Repository) GetClientByID(id string) (*models.Client, error) {
@@ -14,45 +17,78 @@ func (r *Repository) GetClientByID(id string) (*models.Client, error) {
 
 func (r *Repository) GetClientByName(name string) (*[]models.Client, error) {
 	var client []models.Client
-	if err := r.DB.Where("last_name LIKE ? OR first_name LIKE ?", "%"+name+"%", "%"+name+"%").Find(&client).Error; err != nil {
+	if err := r.DB.Where(ilike("last_name")+" OR "+ilike("first_name"), likePattern(name), likePattern(name)).Find(&client).Error; err != nil {
     return nil, err
 	}
 	return &client, nil
 }
 
-func (r *Repository) GetAllClients() ([]models.Client, error) {
+var clientList = listSpec{
+	fields: map[string]listField{
+		"first_name": {column: "first_name", filter: filterContains},
+		"last_name":  {column: "last_name", filter: filterContains},
+		"cuil":       {column: "c_ui_l", filter: filterContains},
+		"dni":        {column: "dni", filter: filterContains},
+		"email":      {column: "email", filter: filterContains},
+		"created_at": {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "last_name,first_name",
+}
+
+func (r *Repository) GetAllClients(params *models.ListParams) ([]models.Client, int64, error) {
 	var clients []models.Client
-	if err := r.DB.Find(&clients).Error; err != nil {
-		return nil, err
+	total, err := paginate(r.DB.Model(&models.Client{}), clientList, params, &clients)
+	if err != nil {
+		return nil, 0, err
 	}
-	return clients, nil
+	return clients, total, nil
 }
 
-func (r *Repository) CreateClient(client *models.Client) (string, error) {
-	if err := r.DB.Create(client).Error; err != nil {
+func (r *Repository) GetClientByDocument(cuil string, dni string, excludeID string) (bool, error) {
+	return taken(r.DB, &models.Client{}, excludeID, "c_ui_l = ? OR dni = ?", cuil, dni)
+}
+
+func (r *Repository) CreateClient(client *models.Client, actor models.Actor) (string, error) {
+	err := audited[models.Client](r.DB, actor, models.AuditCreate, "client", client.ID, func(tx *gorm.DB) error {
+		return tx.Create(client).Error
+	})
+	if err != nil {
 		return "", err
 	}
 	return client.ID, nil
 }
 
-func (r *Repository) UpdateClient(client *models.Client) error {
-	var existing models.Client
-	if err := r.DB.First(&existing, "id = ?", client.ID).Error; err != nil {
-			return err 
-	}
-
-	if err := r.DB.Save(client).Error; err != nil {
+func (r *Repository) UpdateClient(client *models.Client, actor models.Actor) error {
+	return audited[models.Client](r.DB, actor, models.AuditUpdate, "client", client.ID, func(tx *gorm.DB) error {
+		var existing models.Client
+		if err := tx.First(&existing, "id = ?", client.ID).Error; err != nil {
 			return err
-	}
-	return nil
+		}
+		if err := bumpVersion(tx, &models.Client{}, client.ID, client.Version); err != nil {
+			return err
+		}
+		// Save pisaría la versión recién incrementada y created_at
+		return tx.Model(&models.Client{}).Where("id = ?", client.ID).
+			Select("first_name", "last_name", "c_ui_l", "dni", "email").
+			Updates(client).Error
+	})
 }
 
-func (r *Repository) DeleteClient(id string) error {
-	if err := r.DB.Where("client_id = ?", id).Delete(&models.Vehicle{}).Error; err != nil {
+// DeleteClient manda a la papelera al cliente y a sus vehículos con el mismo
+// deleted_at, así RestoreTrash sabe qué vehículos se borraron junto con él.
+func (r *Repository) DeleteClient(id string, actor models.Actor) error {
+	return audited[models.Client](r.DB, actor, models.AuditDelete, "client", id, func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&models.Vehicle{}).Where("client_id = ?", id).Update("deleted_at", now).Error; err != nil {
 			return err
-	}
-	if err := r.DB.Where("id = ?", id).Delete(&models.Client{}).Error; err != nil {
-			return err
-	}
-	return nil
+		}
+		result := tx.Model(&models.Client{}).Where("id = ? AND deleted_at IS NULL", id).Update("deleted_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
 }
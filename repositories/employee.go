@@ -1,139 +1,84 @@
 package repositories
 
 import (
-	"fmt"
-
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-func (r *Repository) GetEmployeeByID(id string, workplace string) (*models.EmployeeLaundry, *models.EmployeeWorkshop, error) {
-	if workplace == "laundry" {
-		var employee models.EmployeeLaundry
-		if err := r.DB.Where("id = ?", id).First(&employee).Error; err != nil {
-			return nil, nil, err
-		}
-		return &employee, nil, nil
-	} else if workplace == "workshop" {
-		var employee models.EmployeeWorkshop
-		if err := r.DB.Where("id = ?", id).First(&employee).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &employee, nil
+func (r *Repository) GetEmployeeByID(id string, workplaceID string) (*models.Employee, error) {
+	var employee models.Employee
+	if err := r.DB.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&employee).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de espacio no soportado")
+	return &employee, nil
 }
 
-func (r *Repository) GetAllEmployees(workplace string) (*[]models.EmployeeLaundry, *[]models.EmployeeWorkshop, error) {
-	if workplace == "laundry" {
-		var employees []models.EmployeeLaundry
-		if err := r.DB.Find(&employees).Error; err != nil {
-			return nil, nil, err
-		}
-		return &employees, nil, nil
-	} else	if workplace == "workshop" {
-		var employees []models.EmployeeWorkshop
-		if err := r.DB.Find(&employees).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &employees, nil
+var employeeList = listSpec{
+	fields: map[string]listField{
+		"name":       {column: "name", filter: filterContains},
+		"phone":      {column: "phone", filter: filterContains},
+		"email":      {column: "email", filter: filterContains},
+		"created_at": {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "name",
+}
+
+func (r *Repository) GetAllEmployees(workplaceID string, params *models.ListParams) (*[]models.Employee, int64, error) {
+	var employees []models.Employee
+	query := r.DB.Model(&models.Employee{}).Where("workplace_id = ?", workplaceID)
+	total, err := paginate(query, employeeList, params, &employees)
+	if err != nil {
+		return nil, 0, err
 	}
-	return nil, nil, fmt.Errorf("tipo de espacio no soportado")
+	return &employees, total, nil
 }
 
-func (r *Repository) CreateEmployee(employee *models.EmployeeCreate, workplace string) (string, error) {
+func (r *Repository) CreateEmployee(employee *models.EmployeeCreate, workplaceID string, actor models.Actor) (string, error) {
 	newID := uuid.NewString()
-	switch workplace{
-	case "laundry":
-		if err := r.DB.Create(&models.EmployeeLaundry{
-			ID: newID,
-			Name: employee.Name,
-			Phone: employee.Phone,
-			Email: employee.Email,
-			Address: employee.Address,
-		}).Error; err != nil {
-			return "", err
-		}
-		return newID, nil
-	case "workshop":
-		if err := r.DB.Create(&models.EmployeeWorkshop{
+	err := audited[models.Employee](r.DB, actor, models.AuditCreate, "employee", newID, func(tx *gorm.DB) error {
+		return tx.Create(&models.Employee{
 			ID: newID,
+			WorkplaceID: workplaceID,
 			Name: employee.Name,
 			Phone: employee.Phone,
 			Email: employee.Email,
 			Address: employee.Address,
-		}).Error; err != nil {
-			return "", err
-		}
-		return newID, nil
-	default:
-		return "", fmt.Errorf("tipo de empleado no soportado")
+		}).Error
+	})
+	if err != nil {
+		return "", err
 	}
+	return newID, nil
 }
 
-func (r *Repository) UpdateEmployee(employeeUpdate *models.EmployeeUpdate, workplace string) error {
-	switch workplace{
-	case "laundry":
-		var employee models.EmployeeLaundry
-		if err := r.DB.Where("id = ?", employeeUpdate.ID).First(&employee).Error; err != nil {
+func (r *Repository) UpdateEmployee(employeeUpdate *models.EmployeeUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.Employee](r.DB, actor, models.AuditUpdate, "employee", employeeUpdate.ID, func(tx *gorm.DB) error {
+		var employee models.Employee
+		if err := tx.Where("id = ? AND workplace_id = ?", employeeUpdate.ID, workplaceID).First(&employee).Error; err != nil {
 			return err
 		}
-		employee.Name = employeeUpdate.Name
-		employee.Phone = employeeUpdate.Phone
-		employee.Email = employeeUpdate.Email
-		employee.Address = employeeUpdate.Address
-		if err := r.DB.Save(&employee).Error; err != nil {
-			return err
-		}
-		return nil
-	case "workshop":
-		var employee models.EmployeeWorkshop
-		if err := r.DB.Where("id = ?", employee.ID).First(&employee).Error; err != nil {
+		if err := bumpVersion(tx, &models.Employee{}, employeeUpdate.ID, employeeUpdate.Version); err != nil {
 			return err
 		}
 		employee.Name = employeeUpdate.Name
 		employee.Phone = employeeUpdate.Phone
 		employee.Email = employeeUpdate.Email
 		employee.Address = employeeUpdate.Address
-		if err := r.DB.Save(&employee).Error; err != nil {
-			return err
-		}
-		return nil
-	default:
-		return fmt.Errorf("tipo de empleado no soportado")
-	}
+		return tx.Omit("version").Save(&employee).Error
+	})
 }
 
-func (r *Repository) DeleteEmployee(id string, workplace string) error {
-	if workplace == "laundry" {
-		var employee models.EmployeeLaundry
-		if err := r.DB.Where("id = ?", id).Delete(&employee).Error; err != nil {
-			return err
-		}
-	} else if workplace == "workshop" {
-		var employee models.EmployeeWorkshop
-		if err := r.DB.Where("id = ?", id).Delete(&employee).Error; err != nil {
-			return err
-		}
-	} else {
-		return fmt.Errorf("tipo de espacio no soportado")
-	}
-	return nil
+func (r *Repository) DeleteEmployee(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.Employee](r.DB, actor, models.AuditDelete, "employee", id, func(tx *gorm.DB) error {
+		return deleteScoped(tx, &models.Employee{}, id, workplaceID)
+	})
 }
 
-func (r *Repository) GetEmployeeByName(name string, workplace string) (*[]models.EmployeeLaundry, *[]models.EmployeeWorkshop, error) {
-	if workplace == "laundry" {
-		var employees []models.EmployeeLaundry
-		if err := r.DB.Where("name LIKE ?", "%"+name+"%").Find(&employees).Error; err != nil {
-			return nil, nil, err
-		}
-		return &employees, nil, nil
-	} else	if workplace == "workshop" {
-		var employees []models.EmployeeWorkshop
-		if err := r.DB.Where("name LIKE ?", "%"+name+"%").Find(&employees).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &employees, nil
+func (r *Repository) GetEmployeeByName(name string, workplaceID string) (*[]models.Employee, error) {
+	var employees []models.Employee
+	if err := r.DB.Where("workplace_id = ? AND "+ilike("name"), workplaceID, likePattern(name)).Find(&employees).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de espacio no soportado")
-}
\ No newline at end of file
+	return &employees, nil
+}
@@ -1,7 +1,6 @@
 package repositories
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/DanielChachagua/GestionCar/models"
@@ -9,130 +8,78 @@ import (
 	"gorm.io/gorm"
 )
 
-func (r *Repository) GetExpenseByID(id string, workplace string) (*models.ExpenseLaundry, *models.ExpenseWorkshop, error) {
-	switch workplace {
-	case "laundry":
-		var expense models.ExpenseLaundry
-		if err := r.DB.Where("id = ?", id).First(&expense).Error; err != nil {
-			return nil, nil, err
-		}
-		return &expense, nil, nil
-	case "workshop":
-		var expense models.ExpenseWorkshop
-		if err := r.DB.Where("id = ?", id).First(&expense).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &expense, nil
-	default:
-		return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+func (r *Repository) GetExpenseByID(id string, workplaceID string) (*models.Expense, error) {
+	var expense models.Expense
+	if err := r.DB.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&expense).Error; err != nil {
+		return nil, err
 	}
+	return &expense, nil
 }
 
-func (r *Repository) GetAllExpenses(workplace string) (*[]models.ExpenseLaundry, *[]models.ExpenseWorkshop, error) {
-	if workplace == "laundry" {
-		var expenses []models.ExpenseLaundry
-		if err := r.DB.Limit(100).Order("created_at desc").Find(&expenses).Error; err != nil {
-			return nil, nil, err
-		}
-		return &expenses, nil, nil
-	} else if workplace == "workshop" {
-		var expenses []models.ExpenseWorkshop
-		if err := r.DB.Limit(100).Order("created_at desc").Find(&expenses).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &expenses, nil
+var expenseList = listSpec{
+	fields: map[string]listField{
+		"supplier_id":      {column: "supplier_id", filter: filterEquals},
+		"movement_type_id": {column: "movement_type_id", filter: filterEquals},
+		"details":          {column: "details", filter: filterContains},
+		"amount":           {column: "amount"},
+		"created_at":       {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "-created_at",
+}
+
+func (r *Repository) GetAllExpenses(workplaceID string, params *models.ListParams) (*[]models.Expense, int64, error) {
+	var expenses []models.Expense
+	query := r.DB.Model(&models.Expense{}).Where("workplace_id = ?", workplaceID)
+	total, err := paginate(query, expenseList, params, &expenses)
+	if err != nil {
+		return nil, 0, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return &expenses, total, nil
 }
 
-func (r *Repository) GetExpenseToday(workplace string) (*[]models.ExpenseLaundry, *[]models.ExpenseWorkshop, error) {
-    today := time.Now().Format("2006-01-02")
-    switch workplace {
-    case "laundry":
-        var expenses []models.ExpenseLaundry
-        if err := r.DB.Where("DATE(created_at) = ?", today).Order("created_at desc").Find(&expenses).Error; err != nil {
-            return nil, nil, err
-        }
-        return &expenses, nil, nil
-    case "workshop":
-        var expenses []models.ExpenseWorkshop
-        if err := r.DB.Where("DATE(created_at) = ?", today).Order("created_at desc").Find(&expenses).Error; err != nil {
-            return nil, nil, err
-        }
-        return nil, &expenses, nil
-    default:
-        return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
-    }
+func (r *Repository) GetExpenseToday(workplaceID string) (*[]models.Expense, error) {
+	start, end := dayBounds(time.Now())
+	var expenses []models.Expense
+	if err := r.DB.Where("workplace_id = ? AND created_at >= ? AND created_at < ?", workplaceID, start, end).Order("created_at desc").Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+	return &expenses, nil
 }
 
-func (r *Repository) CreateExpense(expense *models.ExpenseCreate, workplace string) (string, error) {
+func (r *Repository) CreateExpense(expense *models.ExpenseCreate, workplaceID string, actor models.Actor) (string, error) {
 	newID := uuid.NewString()
-	switch workplace {
-	case "laundry":
-		if err := r.DB.Create(&models.ExpenseLaundry{
-			ID:             newID,
-			Details:        expense.Details,
-			SupplierID:     expense.SupplierID,
-			MovementTypeID: expense.MovementTypeID,
-			Amount:         expense.Amount,
-		}).Error; err != nil {
-			return "", err
-		}
-		return newID, nil
-	case "workshop":
-		if err := r.DB.Create(&models.ExpenseWorkshop{
+	err := audited[models.Expense](r.DB, actor, models.AuditCreate, "expense", newID, func(tx *gorm.DB) error {
+		return tx.Create(&models.Expense{
 			ID:             newID,
+			WorkplaceID:    workplaceID,
 			Details:        expense.Details,
 			SupplierID:     expense.SupplierID,
 			MovementTypeID: expense.MovementTypeID,
 			Amount:         expense.Amount,
-		}).Error; err != nil {
-			return "", err
-		}
-		return newID, nil
-	default:
-		return "", fmt.Errorf("tipo de movimiento no soportado")
-	}
-}
-
-func (r *Repository) UpdateExpense(expense *models.ExpenseUpdate, workplace string) error {
-	return r.DB.Transaction(func(tx *gorm.DB) error {
-		switch workplace {
-		case "laundry":
-			if err := tx.Where("id = ?", expense.ID).
-				Updates(&models.ExpenseLaundry{
-					Details: expense.Details, 
-					SupplierID: expense.SupplierID, 
-					MovementTypeID: expense.MovementTypeID, 
-					Amount: expense.Amount,
-					}).Error; err != nil {
-				return err
-			}
-			return nil
-		case "workshop":
-			if err := r.DB.Model(&models.ExpenseWorkshop{}).
-				Where("id = ?", expense.ID).
-				Updates(map[string]interface{}{"details": expense.Details, "supplier_id": expense.SupplierID, "movement_type_id": expense.MovementTypeID, "amount": expense.Amount}).Error; err != nil {
-				return err
-			}
-			return nil
-		default:
-			return fmt.Errorf("tipo de movimiento no soportado")
-		}
+		}).Error
 	})
+	if err != nil {
+		return "", err
+	}
+	return newID, nil
 }
 
-func (r *Repository) DeleteExpenseByID(id string, workplace string) error {
-	if workplace == "laundry" {
-		if err := r.DB.Where("id = ?", id).Delete(&models.ExpenseLaundry{}).Error; err != nil {
+func (r *Repository) UpdateExpense(expense *models.ExpenseUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.Expense](r.DB, actor, models.AuditUpdate, "expense", expense.ID, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND workplace_id = ?", expense.ID, workplaceID).First(&models.Expense{}).Error; err != nil {
 			return err
 		}
-	} else if workplace == "workshop" {
-		if err := r.DB.Where("id = ?", id).Delete(&models.ExpenseWorkshop{}).Error; err != nil {
+		if err := bumpVersion(tx, &models.Expense{}, expense.ID, expense.Version); err != nil {
 			return err
 		}
-	} else {
-		return fmt.Errorf("tipo de movimiento no soportado")
-	}
-	return nil
-}
\ No newline at end of file
+		return tx.Model(&models.Expense{}).
+			Where("id = ? AND workplace_id = ?", expense.ID, workplaceID).
+			Updates(map[string]interface{}{"details": expense.Details, "supplier_id": expense.SupplierID, "movement_type_id": expense.MovementTypeID, "amount": expense.Amount}).Error
+	})
+}
+
+func (r *Repository) DeleteExpenseByID(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.Expense](r.DB, actor, models.AuditDelete, "expense", id, func(tx *gorm.DB) error {
+		return deleteScoped(tx, &models.Expense{}, id, workplaceID)
+	})
+}
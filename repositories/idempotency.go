@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+func (r *Repository) GetIdempotencyKey(userID string, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	if err := r.DB.Where("user_id = ? AND idempotency_key = ?", userID, key).First(&record).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// CreateIdempotencyKey falla si la key ya existe para el usuario: el índice
+// único es lo que impide que dos reintentos simultáneos creen dos registros.
+func (r *Repository) CreateIdempotencyKey(record *models.IdempotencyKey) error {
+	return r.DB.Create(record).Error
+}
+
+func (r *Repository) CompleteIdempotencyKey(id string, statusCode int, contentType string, body []byte) error {
+	return r.DB.Model(&models.IdempotencyKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status_code":   statusCode,
+		"content_type":  contentType,
+		"response_body": body,
+		"completed_at":  time.Now(),
+	}).Error
+}
+
+func (r *Repository) DeleteIdempotencyKey(id string) error {
+	return r.DB.Where("id = ?", id).Delete(&models.IdempotencyKey{}).Error
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,117 +10,128 @@ import (
 	"gorm.io/gorm"
 )
 
-func (r *Repository) GetIncomeByID(id string, workplace string) (*models.IncomeLaundry, *models.IncomeWorkshop, error) {
-	switch workplace {
-	case "laundry":
-		var income models.IncomeLaundry
-		if err := r.DB.Where("id = ?", id).First(&income).Error; err != nil {
-			return nil, nil, err
-		}
-		return &income, nil, nil
-	case "workshop":
-		var income models.IncomeWorkshop
-		if err := r.DB.Where("id = ?", id).First(&income).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &income, nil
-	default:
-		return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+func (r *Repository) GetIncomeByID(id string, workplaceID string) (*models.Income, error) {
+	var income models.Income
+	if err := r.DB.Preload("Services").Where("id = ? AND workplace_id = ?", id, workplaceID).First(&income).Error; err != nil {
+		return nil, err
 	}
+	return &income, nil
 }
 
-func (r *Repository) GetAllIncomes(workplace string) (*[]models.IncomeLaundry, *[]models.IncomeWorkshop, error) {
-	if workplace == "laundry" {
-		var incomes []models.IncomeLaundry
-		if err := r.DB.Limit(100).Order("created_at desc").Find(&incomes).Error; err != nil {
-			return nil, nil, err
-		}
-		return &incomes, nil, nil
-	} else if workplace == "workshop" {
-		var incomes []models.IncomeWorkshop
-		if err := r.DB.Limit(100).Order("created_at desc").Find(&incomes).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &incomes, nil
+// ErrDiscountExceedsSubtotal indica un descuento mayor que la suma de las
+// líneas del ingreso.
+var ErrDiscountExceedsSubtotal = errors.New("el descuento supera el subtotal del ingreso")
+
+// incomeTotals calcula el subtotal (la suma de los precios de las líneas y de
+// los repuestos de la orden de trabajo entregada con el ingreso, si hay) y el
+// total del ingreso id con el descuento dado.
+func incomeTotals(tx *gorm.DB, id string, discount float32) (map[string]interface{}, error) {
+	var services, parts float32
+	if err := tx.Model(&models.IncomeService{}).Where("income_id = ?", id).Select("COALESCE(SUM(price), 0)").Scan(&services).Error; err != nil {
+		return nil, err
+	}
+	workOrders := tx.Session(&gorm.Session{NewDB: true}).Model(&models.WorkOrder{}).Select("id").Where("income_id = ?", id)
+	if err := tx.Model(&models.WorkOrderPart{}).Where("work_order_id IN (?)", workOrders).Select("COALESCE(SUM(quantity * price), 0)").Scan(&parts).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	subtotal := services + parts
+	if discount > subtotal {
+		return nil, fmt.Errorf("%w (%.2f)", ErrDiscountExceedsSubtotal, subtotal)
+	}
+	return map[string]interface{}{
+		"subtotal": subtotal,
+		"amount":   subtotal - discount,
+	}, nil
 }
 
-func (r *Repository) GetIncomeToday(workplace string) (*[]models.IncomeLaundry, *[]models.IncomeWorkshop, error) {
-	today := time.Now().Format("2006-01-02")
-	switch workplace {
-	case "laundry":
-		var incomes []models.IncomeLaundry
-		if err := r.DB.Where("DATE(created_at) = ?", today).Order("created_at desc").Find(&incomes).Error; err != nil {
-			return nil, nil, err
-		}
-		return &incomes, nil, nil
-	case "workshop":
-		var incomes []models.IncomeWorkshop
-		if err := r.DB.Where("DATE(created_at) = ?", today).Order("created_at desc").Find(&incomes).Error; err != nil {
-			return nil, nil, err
+// ErrIncomeFromWorkOrder indica un ingreso generado al entregar una orden de
+// trabajo: sus líneas y repuestos salen de la orden, así que no se edita ni se
+// borra por separado.
+var ErrIncomeFromWorkOrder = errors.New("el ingreso es de una orden de trabajo entregada y no se puede modificar")
+
+// checkNotFromWorkOrder falla con ErrIncomeFromWorkOrder si el ingreso id se
+// generó al entregar una orden de trabajo.
+func checkNotFromWorkOrder(tx *gorm.DB, id string) error {
+	var count int64
+	if err := tx.Unscoped().Model(&models.WorkOrder{}).Where("income_id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrIncomeFromWorkOrder
+	}
+	return nil
+}
+
+// ErrIncomeVehicle indica que el vehículo del ingreso no existe.
+var ErrIncomeVehicle = errors.New("el vehículo del ingreso no existe")
+
+// vehicleCategory devuelve la categoría del vehículo, que define el precio de
+// las líneas del ingreso.
+func vehicleCategory(tx *gorm.DB, vehicleID string) (string, error) {
+	var vehicle models.Vehicle
+	if err := tx.Select("category").Where("id = ?", vehicleID).First(&vehicle).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("%w: %s", ErrIncomeVehicle, vehicleID)
 		}
-		return nil, &incomes, nil
-	default:
-		return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+		return "", err
 	}
+	return vehicle.Category, nil
 }
 
-func (r *Repository) CreateIncome(income *models.IncomeCreate, workplace string) (string, error) {
-	newID := uuid.NewString()
-	err := r.DB.Transaction(func(tx *gorm.DB) error {
-		switch workplace {
-		case "laundry":
-			if err := r.DB.Create(&models.IncomeLaundry{
-				ID:             newID,
-				Ticket:         income.Ticket,
-				Details:        income.Details,
-				ClientID:       income.ClientID,
-				VehicleID:      income.VehicleID,
-				EmployeeID:     income.EmployeeID,
-				Amount:         income.Amount,
-				MovementTypeID: income.MovementTypeID,
-			}).Error; err != nil {
-				return err
-			}
+// addIncomeService agrega al ingreso una línea con el precio vigente del
+// servicio para la categoría del vehículo.
+func addIncomeService(tx *gorm.DB, incomeID string, serviceID string, workplaceID string, category string) error {
+	price, err := servicePriceAt(tx, serviceID, workplaceID, category, time.Now())
+	if err != nil {
+		return err
+	}
+	return tx.Create(&models.IncomeService{
+		ID:        uuid.NewString(),
+		IncomeID:  incomeID,
+		ServiceID: serviceID,
+		Price:     price,
+	}).Error
+}
 
-			for _, item := range income.ServicesID {
-				if err := r.DB.Create(&models.IncomeServiceLaundry{
-					ID:              newID,
-					IncomeLaundryID: newID,
-					ServiceID:       item,
-				}).Error; err != nil {
-					return err
-				}
-			}
-			return nil
-		case "workshop":
-			if err := r.DB.Create(&models.IncomeWorkshop{
-				ID:             newID,
-				Ticket:         income.Ticket,
-				Details:        income.Details,
-				ClientID:       income.ClientID,
-				VehicleID:      income.VehicleID,
-				EmployeeID:     income.EmployeeID,
-				Amount:         income.Amount,
-				MovementTypeID: income.MovementTypeID,
-			}).Error; err != nil {
-				return err
-			}
+var incomeList = listSpec{
+	fields: map[string]listField{
+		"ticket":           {column: "ticket", filter: filterContains},
+		"details":          {column: "details", filter: filterContains},
+		"client_id":        {column: "client_id", filter: filterEquals},
+		"vehicle_id":       {column: "vehicle_id", filter: filterEquals},
+		"employee_id":      {column: "employee_id", filter: filterEquals},
+		"movement_type_id": {column: "movement_type_id", filter: filterEquals},
+		"amount":           {column: "amount"},
+		"created_at":       {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "-created_at",
+}
 
-			for _, item := range income.ServicesID {
-				if err := r.DB.Create(&models.IncomeServiceWorkshop{
-					ID:               newID,
-					IncomeWorkshopID: newID,
-					ServiceID:        item,
-				}).Error; err != nil {
-					return err
-				}
-			}
-			return nil
-		default:
-			return fmt.Errorf("tipo de movimiento no soportado")
-		}
+func (r *Repository) GetAllIncomes(workplaceID string, params *models.ListParams) (*[]models.Income, int64, error) {
+	var incomes []models.Income
+	query := r.DB.Model(&models.Income{}).Where("workplace_id = ?", workplaceID)
+	total, err := paginate(query, incomeList, params, &incomes)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &incomes, total, nil
+}
+
+func (r *Repository) GetIncomeToday(workplaceID string) (*[]models.Income, error) {
+	start, end := dayBounds(time.Now())
+	var incomes []models.Income
+	if err := r.DB.Where("workplace_id = ? AND created_at >= ? AND created_at < ?", workplaceID, start, end).Order("created_at desc").Find(&incomes).Error; err != nil {
+		return nil, err
+	}
+	return &incomes, nil
+}
+
+// CreateIncome guarda el ingreso con una línea por servicio, al precio
+// vigente para la categoría del vehículo, y calcula el total.
+func (r *Repository) CreateIncome(income *models.IncomeCreate, workplaceID string, actor models.Actor) (string, error) {
+	newID := uuid.NewString()
+	err := audited[models.Income](r.DB, actor, models.AuditCreate, "income", newID, func(tx *gorm.DB) error {
+		return createIncome(tx, newID, income, workplaceID)
 	})
 	if err != nil {
 		return "", err
@@ -127,147 +139,151 @@ func (r *Repository) CreateIncome(income *models.IncomeCreate, workplace string)
 	return newID, nil
 }
 
-func (r *Repository) UpdateIncome(income *models.IncomeUpdate, workplace string) error {
-	return r.DB.Transaction(func(tx *gorm.DB) error {
-		switch workplace {
-		case "laundry":
-			if err := tx.Where("id = ?", income.ID).
-				Updates(&models.IncomeLaundry{
-					Ticket:         income.Ticket,
-					Details:        income.Details,
-					ClientID:       income.ClientID,
-					VehicleID:      income.VehicleID,
-					EmployeeID:     income.EmployeeID,
-					Amount:         income.Amount,
-					MovementTypeID: income.MovementTypeID,
-				}).Error; err != nil {
-				return err
-			}
+// createIncome carga el ingreso con sus líneas y totales dentro de tx; lo usa
+// también la entrega de órdenes de trabajo.
+func createIncome(tx *gorm.DB, id string, income *models.IncomeCreate, workplaceID string) error {
+	if err := tx.Create(&models.Income{
+		ID:             id,
+		WorkplaceID:    workplaceID,
+		Ticket:         income.Ticket,
+		Details:        income.Details,
+		ClientID:       income.ClientID,
+		VehicleID:      income.VehicleID,
+		EmployeeID:     income.EmployeeID,
+		Discount:       income.Discount,
+		DiscountReason: income.DiscountReason,
+		MovementTypeID: income.MovementTypeID,
+	}).Error; err != nil {
+		return err
+	}
 
-			var existingProducts []models.IncomeServiceLaundry
-			if err := tx.Where("income_laundry_id = ?", income.ID).Find(&existingProducts).Error; err != nil {
-				return err
-			}
-			existingIDs := map[string]bool{}
-			for _, p := range existingProducts {
-				existingIDs[p.ID] = true
-			}
+	category, err := vehicleCategory(tx, income.VehicleID)
+	if err != nil {
+		return err
+	}
+	for _, serviceID := range income.ServicesID {
+		if err := addIncomeService(tx, id, serviceID, workplaceID, category); err != nil {
+			return err
+		}
+	}
 
-			receivedIDs := map[string]bool{}
-			for _, prod := range income.ServicesID {
-				receivedIDs[prod] = true
-			}
+	totals, err := incomeTotals(tx, id, income.Discount)
+	if err != nil {
+		return err
+	}
+	return tx.Model(&models.Income{}).Where("id = ?", id).UpdateColumns(totals).Error
+}
 
-			for _, p := range existingProducts {
-				if !receivedIDs[p.ID] {
-					if err := tx.Delete(&models.IncomeServiceLaundry{}, "id = ?", p.ID).Error; err != nil {
-						return err
-					}
-				}
-			}
+// repriceIncomeServices vuelve a calcular el precio de las líneas para la
+// categoría dada, con los precios vigentes a la fecha del ingreso.
+func repriceIncomeServices(tx *gorm.DB, lines []models.IncomeService, workplaceID string, category string, at time.Time) error {
+	for _, line := range lines {
+		price, err := servicePriceAt(tx, line.ServiceID, workplaceID, category, at)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&models.IncomeService{}).Where("id = ?", line.ID).Update("price", price).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			for _, prod := range income.ServicesID {
-				if prod == "" || !existingIDs[prod] {
-					newProd := models.IncomeServiceLaundry{
-						ID:              uuid.NewString(),
-						IncomeLaundryID: income.ID,
-						ServiceID:       prod,
-					}
-					if err := tx.Create(&newProd).Error; err != nil {
-						return err
-					}
-				} else {
-					if err := tx.Model(&models.IncomeServiceLaundry{}).
-						Where("id = ?", prod).
-						Updates(map[string]interface{}{
-							"service_id": prod,
-						}).Error; err != nil {
-						return err
-					}
+// UpdateIncome actualiza el ingreso y sus líneas. Las líneas que siguen
+// conservan el precio con que se cargaron, salvo que el vehículo nuevo sea de
+// otra categoría: ahí se recalculan con los precios de esa categoría a la
+// fecha del ingreso. Las nuevas toman el vigente.
+func (r *Repository) UpdateIncome(income *models.IncomeUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.Income](r.DB, actor, models.AuditUpdate, "income", income.ID, func(tx *gorm.DB) error {
+		var current models.Income
+		if err := tx.Where("id = ? AND workplace_id = ?", income.ID, workplaceID).First(&current).Error; err != nil {
+			return err
+		}
+		if err := checkNotFromWorkOrder(tx, income.ID); err != nil {
+			return err
+		}
+		if err := bumpVersion(tx, &models.Income{}, income.ID, income.Version); err != nil {
+			return err
+		}
+
+		var existingServices []models.IncomeService
+		if err := tx.Where("income_id = ?", income.ID).Find(&existingServices).Error; err != nil {
+			return err
+		}
+		existingIDs := map[string]bool{}
+		for _, s := range existingServices {
+			existingIDs[s.ServiceID] = true
+		}
+
+		receivedIDs := map[string]bool{}
+		for _, serviceID := range income.ServicesID {
+			receivedIDs[serviceID] = true
+		}
+
+		var keptServices []models.IncomeService
+		for _, s := range existingServices {
+			if !receivedIDs[s.ServiceID] {
+				if err := tx.Delete(&models.IncomeService{}, "id = ?", s.ID).Error; err != nil {
+					return err
 				}
+				continue
 			}
-			return nil
-		case "workshop":
-			if err := tx.Where("id = ?", income.ID).
-				Updates(&models.IncomeWorkshop{
-					Ticket:         income.Ticket,
-					Details:        income.Details,
-					ClientID:       income.ClientID,
-					VehicleID:      income.VehicleID,
-					EmployeeID:     income.EmployeeID,
-					Amount:         income.Amount,
-					MovementTypeID: income.MovementTypeID,
-				}).Error; err != nil {
-				return err
-			}
+			keptServices = append(keptServices, s)
+		}
 
-			var existingProducts []models.IncomeServiceWorkshop
-			if err := tx.Where("income_workshop_id = ?", income.ID).Find(&existingProducts).Error; err != nil {
+		category, err := vehicleCategory(tx, income.VehicleID)
+		if err != nil {
+			return err
+		}
+		if income.VehicleID != current.VehicleID {
+			// el vehículo anterior puede estar en la papelera
+			var previous models.Vehicle
+			if err := tx.Unscoped().Select("category").Where("id = ?", current.VehicleID).Limit(1).Find(&previous).Error; err != nil {
 				return err
 			}
-			existingIDs := map[string]bool{}
-			for _, p := range existingProducts {
-				existingIDs[p.ID] = true
-			}
-
-			receivedIDs := map[string]bool{}
-			for _, prod := range income.ServicesID {
-				receivedIDs[prod] = true
-			}
-
-			for _, p := range existingProducts {
-				if !receivedIDs[p.ID] {
-					if err := tx.Delete(&models.IncomeServiceWorkshop{}, "id = ?", p.ID).Error; err != nil {
-						return err
-					}
+			if previous.Category != category {
+				if err := repriceIncomeServices(tx, keptServices, workplaceID, category, current.CreatedAt); err != nil {
+					return err
 				}
 			}
-
-			for _, prod := range income.ServicesID {
-				if prod == "" || !existingIDs[prod] {
-					newProd := models.IncomeServiceWorkshop{
-						ID:              uuid.NewString(),
-						IncomeWorkshopID: income.ID,
-						ServiceID:       prod,
-					}
-					if err := tx.Create(&newProd).Error; err != nil {
-						return err
-					}
-				} else {
-					if err := tx.Model(&models.IncomeServiceWorkshop{}).
-						Where("id = ?", prod).
-						Updates(map[string]interface{}{
-							"service_id": prod,
-						}).Error; err != nil {
-						return err
-					}
+		}
+		for _, serviceID := range income.ServicesID {
+			if !existingIDs[serviceID] {
+				if err := addIncomeService(tx, income.ID, serviceID, workplaceID, category); err != nil {
+					return err
 				}
+				existingIDs[serviceID] = true
 			}
-			return nil
-		default:
-			return fmt.Errorf("tipo de movimiento no soportado")
 		}
+
+		updates, err := incomeTotals(tx, income.ID, income.Discount)
+		if err != nil {
+			return err
+		}
+		updates["ticket"] = income.Ticket
+		updates["details"] = income.Details
+		updates["client_id"] = income.ClientID
+		updates["vehicle_id"] = income.VehicleID
+		updates["employee_id"] = income.EmployeeID
+		updates["discount"] = income.Discount
+		updates["discount_reason"] = income.DiscountReason
+		updates["movement_type_id"] = income.MovementTypeID
+		return tx.Model(&models.Income{}).Where("id = ? AND workplace_id = ?", income.ID, workplaceID).Updates(updates).Error
 	})
 }
 
-func (r *Repository) DeleteIncomeByID(id string, workplace string) error {
-	return r.DB.Transaction(func(tx *gorm.DB) error {
-		if workplace == "laundry" {
-			if err := tx.Where("income_laundry_id = ?", id).Delete(&models.IncomeServiceLaundry{}).Error; err != nil {
-				return err
-			}
-			if err := tx.Where("id = ?", id).Delete(&models.IncomeLaundry{}).Error; err != nil {
-				return err
-			}
-		} else if workplace == "workshop" {
-			if err := tx.Where("income_workshop_id = ?", id).Delete(&models.IncomeServiceWorkshop{}).Error; err != nil {
-				return err
-			}
-			if err := tx.Where("id = ?", id).Delete(&models.IncomeWorkshop{}).Error; err != nil {
-				return err
-			}
-		} else {
-			return fmt.Errorf("tipo de movimiento no soportado")
+// DeleteIncomeByID manda el ingreso a la papelera. Sus líneas de servicio se
+// conservan para poder restaurarlo; PurgeTrash las borra definitivamente.
+func (r *Repository) DeleteIncomeByID(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.Income](r.DB, actor, models.AuditDelete, "income", id, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&models.Income{}).Error; err != nil {
+			return err
+		}
+		if err := checkNotFromWorkOrder(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Where("id = ? AND workplace_id = ?", id, workplaceID).Delete(&models.Income{}).Error; err != nil {
+			return err
 		}
 		return nil
 	})
@@ -0,0 +1,245 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+type AttendanceRepository interface {
+	GetAttendanceByID(id string, workplaceID string) (*models.Attendance, error)
+	GetAllAttendances(workplaceID string, params *models.ListParams) (*[]models.Attendance, int64, error)
+	CreateAttendance(attendance *models.AttendanceCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateAttendance(attendance *models.AttendanceUpdate, workplaceID string, actor models.Actor) error
+	DeleteAttendance(id string, workplaceID string, actor models.Actor) error
+	GetAttendancesByDate(date_start string, date_end string, workplaceID string) (*[]models.Attendance, error)
+	GetAttendanceByEmployeeID(userID string, workplaceID string) (*[]models.Attendance, error)
+}
+
+type ClientRepository interface {
+	GetClientByID(id string) (*models.Client, error)
+	GetClientByName(name string) (*[]models.Client, error)
+	GetClientByDocument(cuil string, dni string, excludeID string) (bool, error)
+	GetAllClients(params *models.ListParams) ([]models.Client, int64, error)
+	CreateClient(client *models.Client, actor models.Actor) (string, error)
+	UpdateClient(client *models.Client, actor models.Actor) error
+	DeleteClient(id string, actor models.Actor) error
+}
+
+type EmployeeRepository interface {
+	GetEmployeeByID(id string, workplaceID string) (*models.Employee, error)
+	GetAllEmployees(workplaceID string, params *models.ListParams) (*[]models.Employee, int64, error)
+	CreateEmployee(employee *models.EmployeeCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateEmployee(employeeUpdate *models.EmployeeUpdate, workplaceID string, actor models.Actor) error
+	DeleteEmployee(id string, workplaceID string, actor models.Actor) error
+	GetEmployeeByName(name string, workplaceID string) (*[]models.Employee, error)
+}
+
+type ExpenseRepository interface {
+	GetExpenseByID(id string, workplaceID string) (*models.Expense, error)
+	GetAllExpenses(workplaceID string, params *models.ListParams) (*[]models.Expense, int64, error)
+	GetExpenseToday(workplaceID string) (*[]models.Expense, error)
+	CreateExpense(expense *models.ExpenseCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateExpense(expense *models.ExpenseUpdate, workplaceID string, actor models.Actor) error
+	DeleteExpenseByID(id string, workplaceID string, actor models.Actor) error
+}
+
+type IncomeRepository interface {
+	GetIncomeByID(id string, workplaceID string) (*models.Income, error)
+	GetAllIncomes(workplaceID string, params *models.ListParams) (*[]models.Income, int64, error)
+	GetIncomeToday(workplaceID string) (*[]models.Income, error)
+	CreateIncome(income *models.IncomeCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateIncome(income *models.IncomeUpdate, workplaceID string, actor models.Actor) error
+	DeleteIncomeByID(id string, workplaceID string, actor models.Actor) error
+}
+
+type MovementTypeRepository interface {
+	GetMovementTypeByID(id string, workplaceID string) (*models.MovementType, error)
+	GetAllMovementTypes(isIncome bool, workplaceID string, params *models.ListParams) (*[]models.MovementType, int64, error)
+	CreateMovementType(movementType *models.MovementTypeCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateMovementType(movementTypeUpdate *models.MovementTypeUpdate, workplaceID string, actor models.Actor) error
+	DeleteMovementType(id string, workplaceID string, actor models.Actor) error
+}
+
+type ProductRepository interface {
+	GetElementByID(id string, workplaceID string) (*models.Product, error)
+	GetElementsByIdentifier(identifier string, workplaceID string) (*[]models.Product, error)
+	GetElementByIdentifierEq(identifier string, workplaceID string, excludeID string) (bool, error)
+	GetAllElementsByName(name string, workplaceID string) (*[]models.Product, error)
+	GetAllElements(workplaceID string, params *models.ListParams) (*[]models.Product, int64, error)
+	CreateElement(element *models.ProductCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateElement(element *models.ProductUpdate, workplaceID string, actor models.Actor) error
+	UpdateStock(stock int32, id string, workplaceID string, actor models.Actor) error
+	AddToStock(id string, cantidad int32, workplaceID string, actor models.Actor) error
+	SubtractFromStockToStock(id string, cantidad int32, workplaceID string, actor models.Actor) error
+	DeleteElement(id string, workplaceID string, actor models.Actor) error
+}
+
+type PurchaseOrderRepository interface {
+	GetPurchaseOrderByID(id string, workplaceID string) (*models.PurchaseOrder, error)
+	GetAllPurchaseOrders(workplaceID string, params *models.ListParams) (*[]models.PurchaseOrder, int64, error)
+	CreatePurchaseOrder(purchaseOrder *models.PurchaseOrderCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdatePurchaseOrder(purchaseOrder *models.PurchaseOrderUpdate, workplaceID string, actor models.Actor) error
+	DeletePurchaseOrderByID(id string, workplaceID string, actor models.Actor) error
+}
+
+type PurchaseProductRepository interface {
+	GetPurchaseElementByID(id string, workplaceID string) (*models.PurchaseProduct, error)
+	GetPurchaseElementByPurchaseID(purchaseID string, workplaceID string) (*[]models.PurchaseProduct, error)
+	GetAllPurchaseElements(workplaceID string) ([]models.PurchaseProduct, error)
+	CreatePurchaseElement(element *models.PurchaseProductCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdatePurchaseElement(element *models.PurchaseProductUpdate, workplaceID string, actor models.Actor) error
+	DeletePurchaseElementByID(id string, workplaceID string, actor models.Actor) error
+}
+
+type RoleRepository interface {
+	GetAllRoles(roleName string, workplace string) (*[]models.Role, error)
+	GetRoleByName(name string) (*models.Role, error)
+}
+
+type ServiceRepository interface {
+	GetServiceByID(id string, workplaceID string) (*models.Service, error)
+	GetServiceByName(name string, workplaceID string, excludeID string) (bool, error)
+	GetAllServices(workplaceID string, params *models.ListParams) (*[]models.Service, int64, error)
+	CreateService(service *models.ServiceCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateService(service *models.ServiceUpdate, workplaceID string, actor models.Actor) error
+	DeleteServiceByID(id string, workplaceID string, actor models.Actor) error
+	GetServicePrices(serviceID string, workplaceID string) ([]models.ServicePrice, error)
+	CreateServicePrice(serviceID string, price *models.ServicePriceCreate, workplaceID string, actor models.Actor) (string, error)
+}
+
+type SupplierRepository interface {
+	GetSupplierByID(id string, workplaceID string) (*models.Supplier, error)
+	GetAllSuppliers(workplaceID string, params *models.ListParams) ([]models.Supplier, int64, error)
+	CreateSupplier(supplierCreate *models.SupplierCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateSupplier(supplierUpdate *models.SupplierUpdate, workplaceID string, actor models.Actor) error
+	DeleteSupplierByID(id string, workplaceID string, actor models.Actor) error
+	GetSupplierByName(name string, workplaceID string) (*[]models.Supplier, error)
+}
+
+type UserRepository interface {
+	GetUserByID(id string) (*models.User, error)
+	GetUserByUsername(username string) (*models.User, error)
+	GetUserByUsernameEmail(username string, email string) (bool, error)
+	CreateUser(user *models.User, actor models.Actor) error
+	GetAllUsers() (*[]models.User, error)
+	UpdateUserRole(id string, role string, actor models.Actor) error
+	ListUsers(params *models.ListParams) ([]models.User, int64, error)
+	ExistsOtherUser(id string, username string, email string) (bool, error)
+	UpdateUser(user *models.User, actor models.Actor) error
+	SetUserActive(id string, active bool, actor models.Actor) error
+	DeleteUser(id string, actor models.Actor) error
+}
+
+type VehicleRepository interface {
+	GetVehicleByID(id string) (*models.Vehicle, error)
+	GetVehicleByDomain(domain string) (*[]models.Vehicle, error)
+	GetVehicleByDomainEq(domain string, excludeID string) (bool, error)
+	CreateVehicle(vehicle *models.Vehicle, actor models.Actor) (string, error)
+	UpdateVehicle(vehicle *models.Vehicle, actor models.Actor) error
+	DeleteVehicle(id string, actor models.Actor) error
+	GetAllVehicles(params *models.ListParams) ([]models.Vehicle, int64, error)
+	GetVehicleByClientID(clientID string) (*[]models.Vehicle, error)
+}
+
+type WorkplaceRepository interface {
+	GetWorkplaceByID(id string) (*models.Workplace, error)
+	GetWorkplaceByIdentifier(identifier string) (*models.Workplace, error)
+	GetWorkplaceAll(role string) (*[]models.Workplace, error)
+	CreateWorkplace(workplace *models.Workplace, actor models.Actor) (string, error)
+}
+
+type AuditLogRepository interface {
+	GetAuditLogByID(id string) (*models.AuditLog, error)
+	ListAuditLogs(params *models.ListParams) ([]models.AuditLog, int64, error)
+}
+
+type SearchRepository interface {
+	Search(query string, workplaceID string, limit int) ([]models.SearchHit, error)
+}
+
+type IdempotencyRepository interface {
+	GetIdempotencyKey(userID string, key string) (*models.IdempotencyKey, error)
+	CreateIdempotencyKey(record *models.IdempotencyKey) error
+	CompleteIdempotencyKey(id string, statusCode int, contentType string, body []byte) error
+	DeleteIdempotencyKey(id string) error
+}
+
+type TokenRepository interface {
+	CreateRefreshToken(token *models.RefreshToken) error
+	GetRefreshTokenByHash(hash string) (*models.RefreshToken, error)
+	RotateRefreshToken(old *models.RefreshToken, next *models.RefreshToken) error
+	RevokeRefreshToken(id string) error
+	RevokeUserRefreshTokens(userID string) error
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(jti string) (bool, error)
+}
+
+type TrashRepository interface {
+	GetTrash(entityType string, workplaceID string, params *models.ListParams) (interface{}, int64, error)
+	RestoreTrash(entityType string, id string, workplaceID string, actor models.Actor) error
+	PurgeTrash(entityType string, id string, workplaceID string, actor models.Actor) error
+}
+
+type PermissionRepository interface {
+	GetAllPermissions() ([]models.Permission, error)
+	GetRolePermissions(roleID string) ([]string, error)
+	SetRolePermissions(roleID string, permissions []string, actor models.Actor) error
+	RoleHasPermissions(roleName string, permissions []string) (bool, error)
+}
+
+type PasswordResetRepository interface {
+	GetUserByEmail(email string) (*models.User, error)
+	CreatePasswordResetToken(token *models.PasswordResetToken) error
+	GetPasswordResetTokenByHash(hash string) (*models.PasswordResetToken, error)
+	ResetPassword(token *models.PasswordResetToken, passwordHash string) error
+	ChangePassword(userID string, passwordHash string, actor models.Actor) error
+}
+
+type LoginAttemptRepository interface {
+	CreateLoginAttempt(attempt *models.LoginAttempt) error
+	UsernameLoginFailures(username string, since time.Time) (int64, *time.Time, error)
+	IPLoginFailures(ip string, since time.Time) (int64, *time.Time, error)
+	ListLoginAttempts(params *models.ListParams) ([]models.LoginAttempt, int64, error)
+}
+
+type WorkOrderRepository interface {
+	GetWorkOrderByID(id string, workplaceID string) (*models.WorkOrder, error)
+	GetAllWorkOrders(workplaceID string, params *models.ListParams) (*[]models.WorkOrder, int64, error)
+	CreateWorkOrder(workOrder *models.WorkOrderCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateWorkOrder(workOrder *models.WorkOrderUpdate, workplaceID string, actor models.Actor) error
+	ChangeWorkOrderStatus(id string, status *models.WorkOrderStatusUpdate, workplaceID string, actor models.Actor) error
+	DeliverWorkOrder(id string, deliver *models.WorkOrderDeliver, workplaceID string, actor models.Actor) (string, error)
+	DeleteWorkOrderByID(id string, workplaceID string, actor models.Actor) error
+	AddWorkOrderPart(workOrderID string, part *models.WorkOrderPartCreate, workplaceID string, actor models.Actor) (string, error)
+	UpdateWorkOrderPart(workOrderID string, part *models.WorkOrderPartUpdate, workplaceID string, actor models.Actor) error
+	RemoveWorkOrderPart(workOrderID string, partID string, version int64, workplaceID string, actor models.Actor) error
+}
+
+// Repository implementa todas las interfaces.
+var (
+	_ AttendanceRepository      = (*Repository)(nil)
+	_ ClientRepository          = (*Repository)(nil)
+	_ EmployeeRepository        = (*Repository)(nil)
+	_ ExpenseRepository         = (*Repository)(nil)
+	_ IncomeRepository          = (*Repository)(nil)
+	_ MovementTypeRepository    = (*Repository)(nil)
+	_ ProductRepository         = (*Repository)(nil)
+	_ PurchaseOrderRepository   = (*Repository)(nil)
+	_ PurchaseProductRepository = (*Repository)(nil)
+	_ RoleRepository            = (*Repository)(nil)
+	_ ServiceRepository         = (*Repository)(nil)
+	_ SupplierRepository        = (*Repository)(nil)
+	_ UserRepository            = (*Repository)(nil)
+	_ VehicleRepository         = (*Repository)(nil)
+	_ WorkplaceRepository       = (*Repository)(nil)
+	_ AuditLogRepository        = (*Repository)(nil)
+	_ SearchRepository          = (*Repository)(nil)
+	_ TrashRepository           = (*Repository)(nil)
+	_ IdempotencyRepository     = (*Repository)(nil)
+	_ TokenRepository           = (*Repository)(nil)
+	_ PermissionRepository      = (*Repository)(nil)
+	_ PasswordResetRepository   = (*Repository)(nil)
+	_ LoginAttemptRepository    = (*Repository)(nil)
+	_ WorkOrderRepository       = (*Repository)(nil)
+)
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidListParams indica un orden o filtro que el listado no admite.
+var ErrInvalidListParams = errors.New("parámetro de listado inválido")
+
+type filterKind int
+
+const (
+	filterNone     filterKind = iota
+	filterEquals              // campo=valor
+	filterContains            // campo=texto, sin distinguir mayúsculas
+	filterBool                // campo=true|false
+	filterDate                // campo_from / campo_to sobre una columna YYYY-MM-DD
+	filterTime                // campo_from / campo_to (YYYY-MM-DD) sobre un timestamp
+)
+
+type listField struct {
+	column string
+	filter filterKind
+}
+
+// listSpec declara los campos de un listado que se pueden ordenar y filtrar,
+// con la columna que corresponde a cada nombre json. Todo lo que no esté acá
+// se rechaza, así sort y filtros nunca llegan crudos al SQL.
+type listSpec struct {
+	fields      map[string]listField
+	defaultSort string
+}
+
+// paginate aplica filtros, cuenta el total, ordena y trae la página pedida.
+// query ya debe traer el Model y las condiciones fijas (workplace, etc.).
+func paginate(query *gorm.DB, spec listSpec, params *models.ListParams, dest interface{}) (int64, error) {
+	query, err := spec.filter(query, params.Filters)
+	if err != nil {
+		return 0, err
+	}
+	order, err := spec.order(params.Sort)
+	if err != nil {
+		return 0, err
+	}
+
+	query = query.Session(&gorm.Session{})
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	if err := query.Order(order).Offset(params.Offset()).Limit(params.PageSize).Find(dest).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (spec listSpec) filter(query *gorm.DB, filters map[string]string) (*gorm.DB, error) {
+	for name, value := range filters {
+		field, bound := spec.lookupFilter(name)
+		switch field.filter {
+		case filterEquals:
+			query = query.Where(field.column+" = ?", value)
+		case filterContains:
+			query = query.Where(ilike(field.column), likePattern(value))
+		case filterBool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s debe ser true o false", ErrInvalidListParams, name)
+			}
+			query = query.Where(field.column+" = ?", b)
+		case filterDate, filterTime:
+			day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s debe tener el formato YYYY-MM-DD", ErrInvalidListParams, name)
+			}
+			if bound == "_to" {
+				day = day.AddDate(0, 0, 1)
+			}
+			var arg interface{} = day
+			if field.filter == filterDate {
+				arg = day.Format("2006-01-02")
+			}
+			if bound == "_from" {
+				query = query.Where(field.column+" >= ?", arg)
+			} else {
+				query = query.Where(field.column+" < ?", arg)
+			}
+		default:
+			return nil, fmt.Errorf("%w: no se puede filtrar por %s", ErrInvalidListParams, name)
+		}
+	}
+	return query, nil
+}
+
+// lookupFilter resuelve el nombre de un filtro; los de fecha llevan el sufijo
+// _from o _to, que se devuelve aparte.
+func (spec listSpec) lookupFilter(name string) (listField, string) {
+	for _, suffix := range []string{"_from", "_to"} {
+		if base, ok := strings.CutSuffix(name, suffix); ok {
+			if field, ok := spec.fields[base]; ok && (field.filter == filterDate || field.filter == filterTime) {
+				return field, suffix
+			}
+		}
+	}
+	field, ok := spec.fields[name]
+	if !ok || field.filter == filterDate || field.filter == filterTime {
+		return listField{}, ""
+	}
+	return field, ""
+}
+
+func (spec listSpec) order(sort string) (string, error) {
+	if sort == "" {
+		sort = spec.defaultSort
+	}
+	var clauses []string
+	for _, name := range strings.Split(sort, ",") {
+		name = strings.TrimSpace(name)
+		direction := "asc"
+		if strings.HasPrefix(name, "-") {
+			name, direction = name[1:], "desc"
+		}
+		field, ok := spec.fields[name]
+		if !ok {
+			return "", fmt.Errorf("%w: no se puede ordenar por %s", ErrInvalidListParams, name)
+		}
+		clauses = append(clauses, field.column+" "+direction)
+	}
+	// el id desempata para que las páginas no se solapen
+	return strings.Join(append(clauses, "id asc"), ", "), nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/google/uuid"
+)
+
+func TestGetAllExpensesPaginated(t *testing.T) {
+	for name, repo := range testRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			workplaceID := uuid.NewString()
+			start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+			for i := 0; i < 5; i++ {
+				expense := models.Expense{
+					ID:             uuid.NewString(),
+					WorkplaceID:    workplaceID,
+					Details:        fmt.Sprintf("Gasto %d", i),
+					MovementTypeID: "compra",
+					Amount:         float32(100 * (i + 1)),
+					CreatedAt:      start.AddDate(0, 0, i),
+				}
+				if err := repo.DB.Create(&expense).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			params := &models.ListParams{Page: 2, PageSize: 2, Filters: map[string]string{}}
+			expenses, total, err := repo.GetAllExpenses(workplaceID, params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// por defecto del más nuevo al más viejo: la página 2 son los días 3 y 2
+			if total != 5 || len(*expenses) != 2 || (*expenses)[0].Details != "Gasto 2" {
+				t.Errorf("total = %d, página = %+v", total, *expenses)
+			}
+
+			params = &models.ListParams{Page: 1, PageSize: 10, Sort: "amount", Filters: map[string]string{
+				"created_at_from": "2024-05-02",
+				"created_at_to":   "2024-05-04",
+				"details":         "GASTO",
+			}}
+			expenses, total, err = repo.GetAllExpenses(workplaceID, params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != 3 || len(*expenses) != 3 || (*expenses)[0].Amount != 200 {
+				t.Errorf("total = %d, filtrados = %+v", total, *expenses)
+			}
+
+			for _, params := range []*models.ListParams{
+				{Page: 1, PageSize: 10, Sort: "workplace_id"},
+				{Page: 1, PageSize: 10, Filters: map[string]string{"amount": "100"}},
+				{Page: 1, PageSize: 10, Filters: map[string]string{"created_at_from": "ayer"}},
+			} {
+				if _, _, err := repo.GetAllExpenses(workplaceID, params); !errors.Is(err, ErrInvalidListParams) {
+					t.Errorf("%+v: se esperaba ErrInvalidListParams, se obtuvo %v", params, err)
+				}
+			}
+		})
+	}
+}
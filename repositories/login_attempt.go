@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) CreateLoginAttempt(attempt *models.LoginAttempt) error {
+	return r.DB.Create(attempt).Error
+}
+
+// UsernameLoginFailures cuenta los intentos con credenciales incorrectas para
+// username desde since o desde su último login exitoso, lo que sea posterior,
+// y devuelve cuándo fue el último.
+func (r *Repository) UsernameLoginFailures(username string, since time.Time) (int64, *time.Time, error) {
+	var success []models.LoginAttempt
+	err := r.DB.Where("username = ? AND success = ?", username, true).Order("created_at desc").Limit(1).Find(&success).Error
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(success) > 0 && success[0].CreatedAt.After(since) {
+		since = success[0].CreatedAt
+	}
+	return r.loginFailures(r.DB.Where("username = ?", username), since)
+}
+
+// IPLoginFailures cuenta los intentos con credenciales incorrectas desde ip
+// a partir de since. Un login exitoso desde la IP no los descuenta: con una
+// cuenta propia se podría seguir probando contra las demás.
+func (r *Repository) IPLoginFailures(ip string, since time.Time) (int64, *time.Time, error) {
+	return r.loginFailures(r.DB.Where("ip = ?", ip), since)
+}
+
+func (r *Repository) loginFailures(query *gorm.DB, since time.Time) (int64, *time.Time, error) {
+	query = query.Model(&models.LoginAttempt{}).
+		Where("result = ? AND created_at > ?", models.LoginInvalidCredentials, since).
+		Session(&gorm.Session{})
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, nil, err
+	}
+	if count == 0 {
+		return 0, nil, nil
+	}
+	var last models.LoginAttempt
+	if err := query.Order("created_at desc").First(&last).Error; err != nil {
+		return 0, nil, err
+	}
+	return count, &last.CreatedAt, nil
+}
+
+var loginAttemptList = listSpec{
+	fields: map[string]listField{
+		"user_id":    {column: "user_id", filter: filterEquals},
+		"username":   {column: "username", filter: filterEquals},
+		"ip":         {column: "ip", filter: filterEquals},
+		"user_agent": {column: "user_agent", filter: filterContains},
+		"success":    {column: "success", filter: filterBool},
+		"result":     {column: "result", filter: filterEquals},
+		"created_at": {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "-created_at",
+}
+
+func (r *Repository) ListLoginAttempts(params *models.ListParams) ([]models.LoginAttempt, int64, error) {
+	var attempts []models.LoginAttempt
+	total, err := paginate(r.DB.Model(&models.LoginAttempt{}), loginAttemptList, params, &attempts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return attempts, total, nil
+}
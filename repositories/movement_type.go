@@ -1,108 +1,70 @@
 package repositories
 
 import (
-	"fmt"
-
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-func (r *Repository) GetMovementTypeByID(id string, workplace string) (*models.MovementTypeLaundry, *models.MovementTypeWorkshop, error) {
-	if workplace == "laundry" {
-		var movementType models.MovementTypeLaundry
-		if err := r.DB.Where("id = ?", id).First(&movementType).Error; err != nil {
-			return nil, nil, err
-		}
-		return &movementType, nil, nil
-	} else if workplace == "workshop" {
-		var movementType models.MovementTypeWorkshop
-		if err := r.DB.Where("id = ?", id).First(&movementType).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &movementType, nil
+func (r *Repository) GetMovementTypeByID(id string, workplaceID string) (*models.MovementType, error) {
+	var movementType models.MovementType
+	if err := r.DB.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&movementType).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de espacio no soportado")
+	return &movementType, nil
 }
 
-func (r *Repository) GetAllMovementTypes(isIncome bool,workplace string) (*[]models.MovementTypeLaundry, *[]models.MovementTypeWorkshop, error) {
-	if workplace == "laundry" {
-		var movementTypes []models.MovementTypeLaundry
-		if err := r.DB.Where("is_income = ?", isIncome).Find(&movementTypes).Error; err != nil {
-			return nil, nil, err
-		}
-		return &movementTypes, nil, nil
-	} else if workplace == "workshop" {
-		var movementTypes []models.MovementTypeWorkshop
-		if err := r.DB.Where("is_income = ?", isIncome).Find(&movementTypes).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &movementTypes, nil
-	}
-	return nil, nil, fmt.Errorf("tipo de espacio no soportado")
+var movementTypeList = listSpec{
+	fields: map[string]listField{
+		"name":       {column: "name", filter: filterContains},
+		"created_at": {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "name",
 }
 
-func (r *Repository) CreateMovementType(movementType *models.MovementTypeCreate, workplace string) (string, error) {
-	newID := uuid.NewString()
-	switch workplace{
-		case "laundry":
-			if err := r.DB.Create(&models.MovementTypeLaundry{
-				ID: newID,
-				Name: movementType.Name,
-				IsIncome: movementType.IsIncome,
-			}).Error; err != nil {
-				return "", err
-			}
-			return newID, nil
-		case "workshop":
-			if err := r.DB.Create(&models.MovementTypeWorkshop{
-				ID: newID,
-				Name: movementType.Name,
-				IsIncome: movementType.IsIncome,
-			}).Error; err != nil {
-				return "", err
-			}
-			return newID, nil
-		default:
-			return "", fmt.Errorf("tipo de movimiento no soportado")
+func (r *Repository) GetAllMovementTypes(isIncome bool, workplaceID string, params *models.ListParams) (*[]models.MovementType, int64, error) {
+	var movementTypes []models.MovementType
+	query := r.DB.Model(&models.MovementType{}).Where("workplace_id = ? AND is_income = ?", workplaceID, isIncome)
+	total, err := paginate(query, movementTypeList, params, &movementTypes)
+	if err != nil {
+		return nil, 0, err
 	}
+	return &movementTypes, total, nil
 }
 
-func (r *Repository) UpdateMovementType(movementTypeUpdate *models.MovementTypeUpdate, workplace string) error {
-	switch workplace{
-		case "laundry":
-			if err := r.DB.Model(&models.MovementTypeLaundry{}).Where("id = ?", movementTypeUpdate.ID).Updates(&models.MovementTypeLaundry{
-				Name: movementTypeUpdate.Name,
-				IsIncome: movementTypeUpdate.IsIncome,
-			}).Error; err != nil {
-				return err
-			}
-			return nil
-		case "workshop":
-			if err := r.DB.Model(&models.MovementTypeWorkshop{}).Where("id = ?", movementTypeUpdate.ID).Updates(&models.MovementTypeWorkshop{
-				Name: movementTypeUpdate.Name,
-				IsIncome: movementTypeUpdate.IsIncome,
-			}).Error; err != nil {
-				return err
-			}
-			return nil
-		default:
-			return fmt.Errorf("tipo de movimiento no soportado")
+func (r *Repository) CreateMovementType(movementType *models.MovementTypeCreate, workplaceID string, actor models.Actor) (string, error) {
+	newID := uuid.NewString()
+	err := audited[models.MovementType](r.DB, actor, models.AuditCreate, "movement_type", newID, func(tx *gorm.DB) error {
+		return tx.Create(&models.MovementType{
+			ID: newID,
+			WorkplaceID: workplaceID,
+			Name: movementType.Name,
+			IsIncome: movementType.IsIncome,
+		}).Error
+	})
+	if err != nil {
+		return "", err
 	}
+	return newID, nil
 }
 
-func (r *Repository) DeleteMovementType(id string, workplace string) error {
-	if workplace == "laundry" {
-		var movementType models.MovementTypeLaundry
-		if err := r.DB.Where("id = ?", id).Delete(&movementType).Error; err != nil {
+func (r *Repository) UpdateMovementType(movementTypeUpdate *models.MovementTypeUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.MovementType](r.DB, actor, models.AuditUpdate, "movement_type", movementTypeUpdate.ID, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND workplace_id = ?", movementTypeUpdate.ID, workplaceID).First(&models.MovementType{}).Error; err != nil {
 			return err
 		}
-		return nil
-	} else if workplace == "workshop" {
-		var movementType models.MovementTypeWorkshop
-		if err := r.DB.Where("id = ?", id).Delete(&movementType).Error; err != nil {
+		if err := bumpVersion(tx, &models.MovementType{}, movementTypeUpdate.ID, movementTypeUpdate.Version); err != nil {
 			return err
 		}
-		return nil
-	}
-	return fmt.Errorf("tipo de espacio no soportado")
-}
\ No newline at end of file
+		return tx.Model(&models.MovementType{}).Where("id = ? AND workplace_id = ?", movementTypeUpdate.ID, workplaceID).Updates(map[string]interface{}{
+			"name": movementTypeUpdate.Name,
+			"is_income": movementTypeUpdate.IsIncome,
+		}).Error
+	})
+}
+
+func (r *Repository) DeleteMovementType(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.MovementType](r.DB, actor, models.AuditDelete, "movement_type", id, func(tx *gorm.DB) error {
+		return deleteScoped(tx, &models.MovementType{}, id, workplaceID)
+	})
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) GetUserByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.DB.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *Repository) CreatePasswordResetToken(token *models.PasswordResetToken) error {
+	return r.DB.Create(token).Error
+}
+
+func (r *Repository) GetPasswordResetTokenByHash(hash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	if err := r.DB.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ResetPassword usa el token y cambia la contraseña en una transacción. Falla
+// con gorm.ErrRecordNotFound si el token ya se usó. Además invalida los demás
+// tokens de recuperación del usuario y revoca sus refresh tokens.
+func (r *Repository) ResetPassword(token *models.PasswordResetToken, passwordHash string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.User{}).Where("id = ?", token.UserID).Update("password", passwordHash).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", token.UserID).
+			Update("revoked_at", now).Error
+	})
+}
+
+// ChangePassword guarda la contraseña nueva y revoca los refresh tokens del
+// usuario, así las otras sesiones no pueden renovarse. El AuditLog registra el
+// cambio, sin el hash.
+func (r *Repository) ChangePassword(userID string, passwordHash string, actor models.Actor) error {
+	return audited[models.User](r.DB, actor, models.AuditUpdate, "user", userID, func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("password", passwordHash).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error
+	})
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"slices"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) GetAllPermissions() ([]models.Permission, error) {
+	var permissions []models.Permission
+	if err := r.DB.Order("name").Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *Repository) GetRolePermissions(roleID string) ([]string, error) {
+	permissions := []string{}
+	if err := r.DB.Model(&models.RolePermission{}).Where("role_id = ?", roleID).Order("permission").Pluck("permission", &permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// SetRolePermissions reemplaza los permisos del rol por permissions.
+func (r *Repository) SetRolePermissions(roleID string, permissions []string, actor models.Actor) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var before []string
+		if err := tx.Model(&models.RolePermission{}).Where("role_id = ?", roleID).Order("permission").Pluck("permission", &before).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", roleID).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(permissions) > 0 {
+			rows := make([]models.RolePermission, 0, len(permissions))
+			for _, permission := range permissions {
+				rows = append(rows, models.RolePermission{RoleID: roleID, Permission: permission})
+			}
+			if err := tx.Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		after := slices.Sorted(slices.Values(permissions))
+		return auditChange(tx, actor, models.AuditUpdate, "role", roleID,
+			models.JSONMap{"permissions": before}, models.JSONMap{"permissions": after})
+	})
+}
+
+// RoleHasPermissions indica si el rol con ese nombre tiene todos los permisos
+// dados; permissions no debe tener repetidos.
+func (r *Repository) RoleHasPermissions(roleName string, permissions []string) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.RolePermission{}).
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ? AND role_permissions.permission IN ?", roleName, permissions).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count == int64(len(permissions)), nil
+}
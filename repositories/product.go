@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/DanielChachagua/GestionCar/models"
@@ -8,187 +9,160 @@ import (
 	"gorm.io/gorm"
 )
 
-func (r *Repository) GetElementByID(id string, workplace string) (*models.ProductLaundry, *models.PartWorkshop, error) {
-	if workplace == "laundry" {
-		var product models.ProductLaundry
-		if err := r.DB.Where("id = ?", id).First(&product).Error; err != nil {
-			return nil, nil, err
-		}
-		return &product, nil, nil
-	} else if workplace == "workshop" {
-		var part models.PartWorkshop
-		if err := r.DB.Where("id = ?", id).First(&part).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &part, nil
+func (r *Repository) GetElementByID(id string, workplaceID string) (*models.Product, error) {
+	var product models.Product
+	if err := r.DB.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&product).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return &product, nil
 }
 
-func (r *Repository) GetElementsByIdentifier(identifier string, workplace string) (*[]models.ProductLaundry, *[]models.PartWorkshop, error) {
-	if workplace == "laundry" {
-		var product []models.ProductLaundry
-		if err := r.DB.Where("identifier LIKE ?", "%"+identifier+"%").Find(&product).Error; err != nil {
-			return nil, nil, err
-		}
-		return &product, nil, nil
-	} else if workplace == "workshop" {
-		var part []models.PartWorkshop
-		if err := r.DB.Where("identifier LIKE ?", "%"+identifier+"%").Find(&part).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &part, nil
+func (r *Repository) GetElementsByIdentifier(identifier string, workplaceID string) (*[]models.Product, error) {
+	var products []models.Product
+	if err := r.DB.Where("workplace_id = ? AND "+ilike("identifier"), workplaceID, likePattern(identifier)).Find(&products).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return &products, nil
 }
 
-func (r *Repository) GetAllElementsByName(name string, workplace string) (*[]models.ProductLaundry, *[]models.PartWorkshop, error) {
-	if workplace == "laundry" {
-		var products []models.ProductLaundry
-		if err := r.DB.Where("name LIKE ?", "%"+name+"%").Find(&products).Error; err != nil {
-			return nil, nil, err
-		}
-		return &products, nil, nil
-	} else if workplace == "workshop" {
-		var parts []models.PartWorkshop
-		if err := r.DB.Where("name LIKE ?", "%"+name+"%").Find(&parts).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &parts, nil
+func (r *Repository) GetElementByIdentifierEq(identifier string, workplaceID string, excludeID string) (bool, error) {
+	return taken(r.DB, &models.Product{}, excludeID, "identifier = ? AND workplace_id = ?", identifier, workplaceID)
+}
+
+func (r *Repository) GetAllElementsByName(name string, workplaceID string) (*[]models.Product, error) {
+	var products []models.Product
+	if err := r.DB.Where("workplace_id = ? AND "+ilike("name"), workplaceID, likePattern(name)).Find(&products).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return &products, nil
 }
 
-func (r *Repository) GetAllElements(workplace string) (*[]models.ProductLaundry, *[]models.PartWorkshop, error) {
-	if workplace == "laundry" {
-		var products []models.ProductLaundry
-		if err := r.DB.Find(&products).Error; err != nil {
-			return nil, nil, err
-		}
-		return &products, nil, nil
-	} else if workplace == "workshop" {
-		var parts []models.PartWorkshop
-		if err := r.DB.Find(&parts).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &parts, nil
+var productList = listSpec{
+	fields: map[string]listField{
+		"identifier": {column: "identifier", filter: filterContains},
+		"name":       {column: "name", filter: filterContains},
+		"stock":      {column: "stock"},
+		"created_at": {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "name",
+}
+
+func (r *Repository) GetAllElements(workplaceID string, params *models.ListParams) (*[]models.Product, int64, error) {
+	var products []models.Product
+	query := r.DB.Model(&models.Product{}).Where("workplace_id = ?", workplaceID)
+	total, err := paginate(query, productList, params, &products)
+	if err != nil {
+		return nil, 0, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return &products, total, nil
 }
 
-func (r *Repository) CreateElement(element *models.ProductCreate, workplace string) (string, error) {
+func (r *Repository) CreateElement(element *models.ProductCreate, workplaceID string, actor models.Actor) (string, error) {
 	newID := uuid.NewString()
-	switch workplace {
-	case "laundry":
-		if err := r.DB.Create(&models.ProductLaundry{
-			ID:         newID,
-			Identifier: element.Identifier,
-			Name:       element.Name,
-			Stock: 0,
-		}).Error; err != nil {
-			return "", err
-		}
-		return newID, nil
-	case "workshop":
-		if err := r.DB.Create(&models.PartWorkshop{
-			ID:         newID,
-			Identifier: element.Identifier,
-			Name:       element.Name,
-		}).Error; err != nil {
-			return "", err
-		}
-		return newID, nil
-	default:
-		return "", fmt.Errorf("tipo de movimiento no soportado")
+	err := audited[models.Product](r.DB, actor, models.AuditCreate, "product", newID, func(tx *gorm.DB) error {
+		return tx.Create(&models.Product{
+			ID:          newID,
+			WorkplaceID: workplaceID,
+			Identifier:  element.Identifier,
+			Name:        element.Name,
+			Stock:       0,
+		}).Error
+	})
+	if err != nil {
+		return "", err
 	}
+	return newID, nil
 }
 
-func (r *Repository) UpdateElement(element *models.ProductUpdate, workplace string) error {
-	switch workplace {
-	case "laundry":
-		if err := r.DB.Model(&models.ProductLaundry{}).Where("id = ?", element.ID).Updates(&models.ProductLaundry{
-			Identifier: element.Identifier,
-			Name:       element.Name,
-		}).Error; err != nil {
+func (r *Repository) UpdateElement(element *models.ProductUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.Product](r.DB, actor, models.AuditUpdate, "product", element.ID, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND workplace_id = ?", element.ID, workplaceID).First(&models.Product{}).Error; err != nil {
 			return err
 		}
-		return nil
-	case "workshop":
-		if err := r.DB.Model(&models.PartWorkshop{}).Where("id = ?", element.ID).Updates(&models.PartWorkshop{
-			Identifier: element.Identifier,
-			Name:       element.Name,
-		}).Error; err != nil {
+		if err := bumpVersion(tx, &models.Product{}, element.ID, element.Version); err != nil {
 			return err
 		}
-		return nil
-	default:
-		return fmt.Errorf("tipo de movimiento no soportado")
-	}
+		return tx.Model(&models.Product{}).Where("id = ? AND workplace_id = ?", element.ID, workplaceID).Updates(&models.Product{
+			Identifier: element.Identifier,
+			Name:       element.Name,
+		}).Error
+	})
 }
 
-func (r *Repository) UpdateStock(stock int32, id string, workplace string) error {
-	switch workplace {
-	case "laundry":
-		if err := r.DB.Model(&models.ProductLaundry{}).
-			Where("id = ?", id).
-			Update("stock", stock).Error; err != nil {
-			return err
-		}
-		return nil
-	case "workshop":
-		if err := r.DB.Model(&models.PartWorkshop{}).
-			Where("id = ?", id).
-			Update("stock", stock).Error; err != nil {
-			return err
+// ErrStockBelowReserved indica un ajuste que dejaría el stock por debajo de
+// lo reservado en órdenes de trabajo.
+var ErrStockBelowReserved = errors.New("el stock no puede ser menor a lo reservado en órdenes de trabajo")
+
+// ErrProductReserved indica un producto con unidades reservadas en órdenes de
+// trabajo, que no se puede eliminar hasta liberarlas.
+var ErrProductReserved = errors.New("el producto tiene unidades reservadas en órdenes de trabajo")
+
+func (r *Repository) UpdateStock(stock int32, id string, workplaceID string, actor models.Actor) error {
+	return r.changeStock(id, workplaceID, actor, map[string]interface{}{"stock": stock, "version": gorm.Expr("version + 1")},
+		stockBelowReserved, "? >= reserved", stock)
+}
+
+func (r *Repository) AddToStock(id string, cantidad int32, workplaceID string, actor models.Actor) error {
+	return r.changeStock(id, workplaceID, actor, map[string]interface{}{"stock": gorm.Expr("stock + ?", cantidad), "version": gorm.Expr("version + 1")},
+		nil, "")
+}
+
+func (r *Repository) SubtractFromStockToStock(id string, cantidad int32, workplaceID string, actor models.Actor) error {
+	return r.changeStock(id, workplaceID, actor, map[string]interface{}{"stock": gorm.Expr("stock - ?", cantidad), "version": gorm.Expr("version + 1")},
+		insufficient, "stock - reserved >= ?", cantidad)
+}
+
+// changeStock aplica columns al producto y registra el ajuste en el AuditLog.
+// guard, si no está vacío, va en el mismo UPDATE para que una reserva
+// concurrente no deje el stock por debajo de lo reservado; si no se cumple,
+// conflict arma el error a partir del producto.
+func (r *Repository) changeStock(id string, workplaceID string, actor models.Actor, columns map[string]interface{}, conflict func(*models.Product) error, guard string, args ...interface{}) error {
+	return audited[models.Product](r.DB, actor, models.AuditUpdate, "product", id, func(tx *gorm.DB) error {
+		query := tx.Model(&models.Product{}).Where("id = ? AND workplace_id = ?", id, workplaceID)
+		if guard != "" {
+			query = query.Where(guard, args...)
+		}
+		result := query.UpdateColumns(columns)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return productConflict(tx, id, workplaceID, conflict)
 		}
 		return nil
-	default:
-		return fmt.Errorf("tipo de movimiento no soportado")
-	}
+	})
 }
 
-func (r *Repository) AddToStock(id string, cantidad int32, workplace string) error {
-	switch workplace {
-	case "laundry":
-			return r.DB.Model(&models.ProductLaundry{}).
-					Where("id = ?", id).
-					UpdateColumn("stock", gorm.Expr("stock + ?", cantidad)).Error
-	case "workshop":
-			return r.DB.Model(&models.PartWorkshop{}).
-					Where("id = ?", id).
-					UpdateColumn("stock", gorm.Expr("stock + ?", cantidad)).Error
-	default:
-			return fmt.Errorf("tipo de movimiento no soportado")
+// productConflict distingue, tras un UPDATE o DELETE condicionado que no tocó
+// filas, un producto inexistente de uno que no cumplía la condición.
+func productConflict(tx *gorm.DB, id string, workplaceID string, conflict func(*models.Product) error) error {
+	var product models.Product
+	if err := tx.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&product).Error; err != nil {
+		return err
+	}
+	if conflict == nil {
+		return gorm.ErrRecordNotFound
 	}
+	return conflict(&product)
 }
 
-func (r *Repository) SubtractFromStockToStock(id string, cantidad int32, workplace string) error {
-	switch workplace {
-	case "laundry":
-			return r.DB.Model(&models.ProductLaundry{}).
-					Where("id = ?", id).
-					UpdateColumn("stock", gorm.Expr("stock - ?", cantidad)).Error
-	case "workshop":
-			return r.DB.Model(&models.PartWorkshop{}).
-					Where("id = ?", id).
-					UpdateColumn("stock", gorm.Expr("stock - ?", cantidad)).Error
-	default:
-			return fmt.Errorf("tipo de movimiento no soportado")
-	}
+func stockBelowReserved(product *models.Product) error {
+	return fmt.Errorf("%w: hay %d reservadas", ErrStockBelowReserved, product.Reserved)
 }
 
-func (r *Repository) DeleteElement(id string, workplace string) error {
-	switch workplace {
-	case "laundry":
-		if err := r.DB.Where("id = ?", id).Delete(&models.ProductLaundry{}).Error; err != nil {
-			return err
+func productReserved(product *models.Product) error {
+	return fmt.Errorf("%w: hay %d reservadas", ErrProductReserved, product.Reserved)
+}
+
+func (r *Repository) DeleteElement(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.Product](r.DB, actor, models.AuditDelete, "product", id, func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND workplace_id = ? AND reserved = 0", id, workplaceID).Delete(&models.Product{})
+		if result.Error != nil {
+			return result.Error
 		}
-	case "workshop":
-		if err := r.DB.Where("id = ?", id).Delete(&models.PartWorkshop{}).Error; err != nil {
-			return err
+		if result.RowsAffected == 0 {
+			return productConflict(tx, id, workplaceID, productReserved)
 		}
-	default:
-		return fmt.Errorf("tipo de movimiento no soportado")
-	}
-	return nil
+		return nil
+	})
 }
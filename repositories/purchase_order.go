@@ -1,102 +1,69 @@
 package repositories
 
 import (
-	"fmt"
+	"time"
 
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-func (r *Repository) GetPurchaseOrderByID(id string, workplace string) (*models.PurchaseOrderLaundry, *models.PurchaseOrderWorkshop, error) {
-	if workplace == "laundry" {
-		var purchaseOrder models.PurchaseOrderLaundry
-		if err := r.DB.Where("id = ?", id).First(&purchaseOrder).Error; err != nil {
-			return nil, nil, err
-		}
-		return &purchaseOrder, nil, nil
-	} else if workplace == "workshop" {
-		var purchaseOrder models.PurchaseOrderWorkshop
-		if err := r.DB.Where("id = ?", id).First(&purchaseOrder).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &purchaseOrder, nil
+func (r *Repository) GetPurchaseOrderByID(id string, workplaceID string) (*models.PurchaseOrder, error) {
+	var purchaseOrder models.PurchaseOrder
+	if err := r.DB.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&purchaseOrder).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return &purchaseOrder, nil
 }
 
-func (r *Repository) GetAllPurchaseOrders(workplace string) (*[]models.PurchaseOrderLaundry, *[]models.PurchaseOrderWorkshop, error) {
-	if workplace == "laundry" {
-		var purchaseOrders []models.PurchaseOrderLaundry
-		if err := r.DB.Find(&purchaseOrders).Error; err != nil {
-			return nil, nil, err
-		}
-		return &purchaseOrders, nil, nil
-	} else if workplace == "workshop" {
-		var purchaseOrders []models.PurchaseOrderWorkshop
-		if err := r.DB.Find(&purchaseOrders).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &purchaseOrders, nil
+var purchaseOrderList = listSpec{
+	fields: map[string]listField{
+		"order_number": {column: "order_number", filter: filterContains},
+		"order_date":   {column: "order_date", filter: filterDate},
+		"supplier_id":  {column: "supplier_id", filter: filterEquals},
+		"amount":       {column: "amount"},
+		"created_at":   {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "-order_date",
+}
+
+func (r *Repository) GetAllPurchaseOrders(workplaceID string, params *models.ListParams) (*[]models.PurchaseOrder, int64, error) {
+	var purchaseOrders []models.PurchaseOrder
+	query := r.DB.Model(&models.PurchaseOrder{}).Where("workplace_id = ?", workplaceID)
+	total, err := paginate(query, purchaseOrderList, params, &purchaseOrders)
+	if err != nil {
+		return nil, 0, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return &purchaseOrders, total, nil
 }
 
-func (r *Repository) CreatePurchaseOrder(purchaseOrder *models.PurchaseOrderCreate, workplace string) (string, error) {
+func (r *Repository) CreatePurchaseOrder(purchaseOrder *models.PurchaseOrderCreate, workplaceID string, actor models.Actor) (string, error) {
 	newID := uuid.NewString()
-	err := r.DB.Transaction(func(tx *gorm.DB) error {
-		switch workplace {
-		case "laundry":
-			if err := tx.Create(&models.PurchaseOrderLaundry{
-				ID:          newID,
-				OrderNumber: purchaseOrder.OrderNumber,
-				OrderDate:   purchaseOrder.OrderDate,
-				Amount:      purchaseOrder.Amount,
-				SupplierID:  purchaseOrder.SupplierID,
-			}).Error; err != nil {
-				return err
-			}
-			for _, element := range purchaseOrder.PurchaseProductCreates {
-				if err := tx.Create(&models.PurchaseProductLaundry{
-					ID:              uuid.NewString(),
-					ProductID:       element.ProductID,
-					PurchaseOrderID: newID,
-					ExpiredAt:       element.ExpiredAt,
-					UnitPrice:       element.UnitPrice,
-					Quantity:        element.Quantity,
-					TotalPrice:      element.UnitPrice * float32(element.Quantity),
-				}).Error; err != nil {
-					return err
-				}
-			}
-			return nil
-		case "workshop":
-			if err := tx.Create(&models.PurchaseOrderWorkshop{
-				ID:          newID,
-				OrderNumber: purchaseOrder.OrderNumber,
-				OrderDate:   purchaseOrder.OrderDate,
-				Amount:      purchaseOrder.Amount,
-				SupplierID:  purchaseOrder.SupplierID,
+	err := audited[models.PurchaseOrder](r.DB, actor, models.AuditCreate, "purchase_order", newID, func(tx *gorm.DB) error {
+		if err := tx.Create(&models.PurchaseOrder{
+			ID:          newID,
+			WorkplaceID: workplaceID,
+			OrderNumber: purchaseOrder.OrderNumber,
+			OrderDate:   purchaseOrder.OrderDate,
+			Amount:      purchaseOrder.Amount,
+			SupplierID:  purchaseOrder.SupplierID,
+		}).Error; err != nil {
+			return err
+		}
+		for _, element := range purchaseOrder.PurchaseProductCreates {
+			if err := tx.Create(&models.PurchaseProduct{
+				ID:              uuid.NewString(),
+				ProductID:       element.ProductID,
+				PurchaseOrderID: newID,
+				ExpiredAt:       element.ExpiredAt,
+				UnitPrice:       element.UnitPrice,
+				Quantity:        element.Quantity,
+				TotalPrice:      element.UnitPrice * float32(element.Quantity),
 			}).Error; err != nil {
 				return err
 			}
-			for _, element := range purchaseOrder.PurchaseProductCreates {
-				if err := tx.Create(&models.PurchasePartWorkshop{
-					ID:              uuid.NewString(),
-					PartID:          element.ProductID,
-					PurchaseOrderID: newID,
-					ExpiredAt:       element.ExpiredAt,
-					UnitPrice:       element.UnitPrice,
-					Quantity:        element.Quantity,
-					TotalPrice:      element.UnitPrice * float32(element.Quantity),
-				}).Error; err != nil {
-					return err
-				}
-			}
-			return nil
-		default:
-			return fmt.Errorf("tipo de movimiento no soportado")
 		}
+		return nil
 	})
 	if err != nil {
 		return "", err
@@ -104,197 +71,92 @@ func (r *Repository) CreatePurchaseOrder(purchaseOrder *models.PurchaseOrderCrea
 	return newID, nil
 }
 
-// func (r *Repository) UpdatePurchaseOrder(purchaseOrder *models.PurchaseOrderUpdate, workplace string) error {
-// 	return r.DB.Transaction(func(tx *gorm.DB) error {
-// 		switch workplace {
-// 		case "laundry":
-// 			if err := r.DB.Where("id = ?", purchaseOrder.ID).Updates(&models.PurchaseOrderLaundry{
-// 				OrderNumber: purchaseOrder.OrderNumber,
-// 				OrderDate: purchaseOrder.OrderDate,
-// 				Amount: purchaseOrder.Amount,
-// 				SupplierID: purchaseOrder.SupplierID,
-// 			}).Error; err != nil {
-// 				return err
-// 			}
-
-// 			return nil
-// 		case "workshop":
-// 			if err := r.DB.Where("id = ?", purchaseOrder.ID).Updates(&models.PurchaseOrderWorkshop{
-// 				OrderNumber: purchaseOrder.OrderNumber,
-// 				OrderDate: purchaseOrder.OrderDate,
-// 				Amount: purchaseOrder.Amount,
-// 				SupplierID: purchaseOrder.SupplierID,
-// 			}).Error; err != nil {
-// 				return err
-// 			}
-// 			return nil
-// 		default:
-// 			return fmt.Errorf("tipo de movimiento no soportado")
-// 		}
-// 	})
-// }
+func (r *Repository) UpdatePurchaseOrder(purchaseOrder *models.PurchaseOrderUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.PurchaseOrder](r.DB, actor, models.AuditUpdate, "purchase_order", purchaseOrder.ID, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND workplace_id = ?", purchaseOrder.ID, workplaceID).First(&models.PurchaseOrder{}).Error; err != nil {
+			return err
+		}
+		if err := bumpVersion(tx, &models.PurchaseOrder{}, purchaseOrder.ID, purchaseOrder.Version); err != nil {
+			return err
+		}
 
-func (r *Repository) UpdatePurchaseOrder(purchaseOrder *models.PurchaseOrderUpdate, workplace string) error {
-	return r.DB.Transaction(func(tx *gorm.DB) error {
-		switch workplace {
-		case "laundry":
-			if err := tx.Where("id = ?", purchaseOrder.ID).Updates(&models.PurchaseOrderLaundry{
-				OrderNumber: purchaseOrder.OrderNumber,
-				OrderDate:   purchaseOrder.OrderDate,
-				Amount:      purchaseOrder.Amount,
-				SupplierID:  purchaseOrder.SupplierID,
-			}).Error; err != nil {
-				return err
-			}
+		if err := tx.Where("id = ? AND workplace_id = ?", purchaseOrder.ID, workplaceID).Updates(&models.PurchaseOrder{
+			OrderNumber: purchaseOrder.OrderNumber,
+			OrderDate:   purchaseOrder.OrderDate,
+			Amount:      purchaseOrder.Amount,
+			SupplierID:  purchaseOrder.SupplierID,
+		}).Error; err != nil {
+			return err
+		}
 
-			var existingProducts []models.PurchaseProductLaundry
-			if err := tx.Where("purchase_order_id = ?", purchaseOrder.ID).Find(&existingProducts).Error; err != nil {
-				return err
-			}
-			existingIDs := map[string]bool{}
-			for _, p := range existingProducts {
-				existingIDs[p.ID] = true
-			}
+		var existingProducts []models.PurchaseProduct
+		if err := tx.Where("purchase_order_id = ?", purchaseOrder.ID).Find(&existingProducts).Error; err != nil {
+			return err
+		}
+		existingIDs := map[string]bool{}
+		for _, p := range existingProducts {
+			existingIDs[p.ID] = true
+		}
 
-			receivedIDs := map[string]bool{}
-			for _, prod := range purchaseOrder.PurchaseProductUpdates {
-				receivedIDs[prod.ID] = true
-			}
+		receivedIDs := map[string]bool{}
+		for _, prod := range purchaseOrder.PurchaseProductUpdates {
+			receivedIDs[prod.ID] = true
+		}
 
-			for _, p := range existingProducts {
-				if !receivedIDs[p.ID] {
-					if err := tx.Delete(&models.PurchaseProductLaundry{}, "id = ?", p.ID).Error; err != nil {
-						return err
-					}
+		for _, p := range existingProducts {
+			if !receivedIDs[p.ID] {
+				if err := tx.Unscoped().Delete(&models.PurchaseProduct{}, "id = ?", p.ID).Error; err != nil {
+					return err
 				}
 			}
+		}
 
-			for _, prod := range purchaseOrder.PurchaseProductUpdates {
-				if prod.ID == "" || !existingIDs[prod.ID] {
-					newProd := models.PurchaseProductLaundry{
-						ID:              uuid.NewString(),
-						ProductID:       prod.ProductID,
-						PurchaseOrderID: purchaseOrder.ID,
-						ExpiredAt:       prod.ExpiredAt,
-						UnitPrice:       prod.UnitPrice,
-						Quantity:        prod.Quantity,
-						TotalPrice:      prod.UnitPrice * float32(prod.Quantity),
-					}
-					if err := tx.Create(&newProd).Error; err != nil {
-						return err
-					}
-				} else {
-					if err := tx.Model(&models.PurchaseProductLaundry{}).
-						Where("id = ?", prod.ID).
-						Updates(map[string]interface{}{
-							"product_id":  prod.ProductID,
-							"expired_at":  prod.ExpiredAt,
-							"unit_price":  prod.UnitPrice,
-							"quantity":    prod.Quantity,
-							"total_price": prod.UnitPrice * float32(prod.Quantity),
-						}).Error; err != nil {
-						return err
-					}
+		for _, prod := range purchaseOrder.PurchaseProductUpdates {
+			if prod.ID == "" || !existingIDs[prod.ID] {
+				newProd := models.PurchaseProduct{
+					ID:              uuid.NewString(),
+					ProductID:       prod.ProductID,
+					PurchaseOrderID: purchaseOrder.ID,
+					ExpiredAt:       prod.ExpiredAt,
+					UnitPrice:       prod.UnitPrice,
+					Quantity:        prod.Quantity,
+					TotalPrice:      prod.UnitPrice * float32(prod.Quantity),
 				}
-			}
-			return nil
-		case "workshop":
-			if err := tx.Where("id = ?", purchaseOrder.ID).Updates(&models.PurchaseOrderWorkshop{
-				OrderNumber: purchaseOrder.OrderNumber,
-				OrderDate:   purchaseOrder.OrderDate,
-				Amount:      purchaseOrder.Amount,
-				SupplierID:  purchaseOrder.SupplierID,
-			}).Error; err != nil {
-				return err
-			}
-
-			var existingProducts []models.PurchasePartWorkshop
-			if err := tx.Where("purchase_order_id = ?", purchaseOrder.ID).Find(&existingProducts).Error; err != nil {
-				return err
-			}
-			existingIDs := map[string]bool{}
-			for _, p := range existingProducts {
-				existingIDs[p.ID] = true
-			}
-
-			receivedIDs := map[string]bool{}
-			for _, prod := range purchaseOrder.PurchaseProductUpdates {
-				receivedIDs[prod.ID] = true
-			}
-
-			for _, p := range existingProducts {
-				if !receivedIDs[p.ID] {
-					if err := tx.Delete(&models.PurchasePartWorkshop{}, "id = ?", p.ID).Error; err != nil {
-						return err
-					}
+				if err := tx.Create(&newProd).Error; err != nil {
+					return err
 				}
-			}
-
-			for _, prod := range purchaseOrder.PurchaseProductUpdates {
-				if prod.ID == "" || !existingIDs[prod.ID] {
-					newProd := models.PurchasePartWorkshop{
-						ID:              uuid.NewString(),
-						PartID:       prod.ProductID,
-						PurchaseOrderID: purchaseOrder.ID,
-						ExpiredAt:       prod.ExpiredAt,
-						UnitPrice:       prod.UnitPrice,
-						Quantity:        prod.Quantity,
-						TotalPrice:      prod.UnitPrice * float32(prod.Quantity),
-					}
-					if err := tx.Create(&newProd).Error; err != nil {
-						return err
-					}
-				} else {
-					if err := tx.Model(&models.PurchasePartWorkshop{}).
-						Where("id = ?", prod.ID).
-						Updates(map[string]interface{}{
-							"part_id":  prod.ProductID,
-							"expired_at":  prod.ExpiredAt,
-							"unit_price":  prod.UnitPrice,
-							"quantity":    prod.Quantity,
-							"total_price": prod.UnitPrice * float32(prod.Quantity),
-						}).Error; err != nil {
-						return err
-					}
+			} else {
+				if err := tx.Model(&models.PurchaseProduct{}).
+					Where("id = ?", prod.ID).
+					Updates(map[string]interface{}{
+						"product_id":  prod.ProductID,
+						"expired_at":  prod.ExpiredAt,
+						"unit_price":  prod.UnitPrice,
+						"quantity":    prod.Quantity,
+						"total_price": prod.UnitPrice * float32(prod.Quantity),
+					}).Error; err != nil {
+					return err
 				}
 			}
-			return nil
-		default:
-			return fmt.Errorf("tipo de movimiento no soportado")
 		}
+		return nil
 	})
 }
 
-func (r *Repository) DeletePurchaseOrderByID(id string, workplace string) error {
-	return r.DB.Transaction(func(tx *gorm.DB) error {
-		switch workplace {
-		case "laundry":
-			if err := tx.Where("purchase_order_id = ?", id).Delete(&models.PurchaseProductLaundry{}).Error; err != nil {
-				return err
-			}
-			if err := tx.Where("id = ?", id).Delete(&models.PurchaseOrderLaundry{}).Error; err != nil {
-				return err
-			}
-		case "workshop":
-			if err := tx.Where("purchase_order_id = ?", id).Delete(&models.PurchasePartWorkshop{}).Error; err != nil {
-				return err
-			}
-			if err := tx.Where("id = ?", id).Delete(&models.PurchaseOrderWorkshop{}).Error; err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("tipo de espacio no soportado")
+// DeletePurchaseOrderByID manda la orden y sus líneas a la papelera con el
+// mismo deleted_at, igual que DeleteClient con los vehículos.
+func (r *Repository) DeletePurchaseOrderByID(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.PurchaseOrder](r.DB, actor, models.AuditDelete, "purchase_order", id, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&models.PurchaseOrder{}).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.Model(&models.PurchaseProduct{}).Where("purchase_order_id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PurchaseOrder{}).Where("id = ? AND workplace_id = ?", id, workplaceID).Update("deleted_at", now).Error; err != nil {
+			return err
 		}
 		return nil
 	})
 }
-
-// var order PurchaseOrderWorkshop
-
-// err := db.Preload("Supplier").
-//           Preload("PurchaseParts.PartWorkshop").
-//           First(&order, "id = ?", someID).Error
-
-// if err != nil {
-//     // manejar error
-// }
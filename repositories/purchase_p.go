@@ -1,137 +1,85 @@
 package repositories
 
 import (
-	"fmt"
-
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-func (r *Repository) GetPurchaseElementByID(id string, workplace string) (*models.PurchaseProductLaundry, *models.PurchasePartWorkshop, error) {
-	if workplace == "laundry" {
-		var purchaseProduct models.PurchaseProductLaundry
-		if err := r.DB.Where("id = ?", id).First(&purchaseProduct).Error; err != nil {
-			return nil, nil, err
-		}
-		return &purchaseProduct, nil, nil
-	} else if workplace == "workshop" {
-		var purchasePart models.PurchasePartWorkshop
-		if err := r.DB.Where("id = ?", id).First(&purchasePart).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &purchasePart, nil
+// Los productos de una orden no guardan el workplace, se filtran por el de su orden de compra
+func purchaseOrdersOfWorkplace(db *gorm.DB, workplaceID string) *gorm.DB {
+	return db.Model(&models.PurchaseOrder{}).Select("id").Where("workplace_id = ?", workplaceID)
+}
+
+func (r *Repository) GetPurchaseElementByID(id string, workplaceID string) (*models.PurchaseProduct, error) {
+	var purchaseProduct models.PurchaseProduct
+	if err := r.DB.Where("id = ? AND purchase_order_id IN (?)", id, purchaseOrdersOfWorkplace(r.DB, workplaceID)).First(&purchaseProduct).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return &purchaseProduct, nil
 }
 
-func (r *Repository) GetPurchaseElementByPurchaseID(purchaseID string, workplace string) (*[]models.PurchaseProductLaundry, *[]models.PurchasePartWorkshop, error) {
-	if workplace == "laundry" {
-		var purchaseProduct []models.PurchaseProductLaundry
-		if err := r.DB.Where("purchase_order_id = ?", purchaseID).Find(&purchaseProduct).Error; err != nil {
-			return nil, nil, err
-		}
-		return &purchaseProduct, nil, nil
-	} else if workplace == "workshop" {
-		var purchasePart []models.PurchasePartWorkshop
-		if err := r.DB.Where("purchase_order_id = ?", purchaseID).Find(&purchasePart).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &purchasePart, nil
+func (r *Repository) GetPurchaseElementByPurchaseID(purchaseID string, workplaceID string) (*[]models.PurchaseProduct, error) {
+	var purchaseProducts []models.PurchaseProduct
+	if err := r.DB.Where("purchase_order_id = ? AND purchase_order_id IN (?)", purchaseID, purchaseOrdersOfWorkplace(r.DB, workplaceID)).Find(&purchaseProducts).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return &purchaseProducts, nil
 }
 
-func (r *Repository) GetAllPurchaseElements(workplace string) ([]models.PurchaseProductLaundry, []models.PurchasePartWorkshop, error) {
-	if workplace == "laundry" {
-		var purchaseProducts []models.PurchaseProductLaundry
-		if err := r.DB.Find(&purchaseProducts).Error; err != nil {
-			return nil, nil, err
-		}
-		return purchaseProducts, nil, nil
-	} else if workplace == "workshop" {
-		var purchaseParts []models.PurchasePartWorkshop
-		if err := r.DB.Find(&purchaseParts).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, purchaseParts, nil
+func (r *Repository) GetAllPurchaseElements(workplaceID string) ([]models.PurchaseProduct, error) {
+	var purchaseProducts []models.PurchaseProduct
+	if err := r.DB.Where("purchase_order_id IN (?)", purchaseOrdersOfWorkplace(r.DB, workplaceID)).Find(&purchaseProducts).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	return purchaseProducts, nil
 }
 
-func (r *Repository) CreatePurchaseElement(element *models.PurchaseProductCreate, workplace string) (string, error) {
+func (r *Repository) CreatePurchaseElement(element *models.PurchaseProductCreate, workplaceID string, actor models.Actor) (string, error) {
 	newID := uuid.NewString()
-	switch workplace {
-	case "laundry":
-		if err := r.DB.Create(&models.PurchaseProductLaundry{
-			ID: newID,
-			ProductID: element.ProductID,
-			ExpiredAt: element.ExpiredAt,
-			UnitPrice: element.UnitPrice,
-			Quantity: element.Quantity,
-			TotalPrice: element.UnitPrice * float32(element.Quantity),
-		}).Error; err != nil {
-			return "", err
+	err := audited[models.PurchaseProduct](r.DB, actor, models.AuditCreate, "purchase_product", newID, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND workplace_id = ?", element.PurchaseOrderID, workplaceID).First(&models.PurchaseOrder{}).Error; err != nil {
+			return err
 		}
-		return newID, nil
-	case "workshop":
-		if err := r.DB.Create(&models.PurchasePartWorkshop{
+		return tx.Create(&models.PurchaseProduct{
 			ID: newID,
-			PartID: element.ProductID,
+			ProductID: element.ProductID,
+			PurchaseOrderID: element.PurchaseOrderID,
 			ExpiredAt: element.ExpiredAt,
 			UnitPrice: element.UnitPrice,
 			Quantity: element.Quantity,
 			TotalPrice: element.UnitPrice * float32(element.Quantity),
-		}).Error; err != nil {
-			return "", err
-		}
-		return newID, nil
-	default:
-		return "", fmt.Errorf("tipo de movimiento no soportado")
+		}).Error
+	})
+	if err != nil {
+		return "", err
 	}
+	return newID, nil
 }
 
-func (r *Repository) UpdatePurchaseElement(element *models.PurchaseProductUpdate, workplace string) error {
-	switch workplace {
-	case "laundry":
-		if err := r.DB.Where("id = ?", element.ID).Updates(&models.PurchaseProductLaundry{
-			ProductID: element.ProductID,
-			ExpiredAt: element.ExpiredAt,
-			UnitPrice: element.UnitPrice,
-			Quantity: element.Quantity,
-			TotalPrice: element.UnitPrice * float32(element.Quantity),
-		}).Error; err != nil {
+func (r *Repository) UpdatePurchaseElement(element *models.PurchaseProductUpdate, workplaceID string, actor models.Actor) error {
+	if _, err := r.GetPurchaseElementByID(element.ID, workplaceID); err != nil {
+		return err
+	}
+	return audited[models.PurchaseProduct](r.DB, actor, models.AuditUpdate, "purchase_product", element.ID, func(tx *gorm.DB) error {
+		if err := bumpVersion(tx, &models.PurchaseProduct{}, element.ID, element.Version); err != nil {
 			return err
 		}
-		return nil
-	case "workshop":
-		if err := r.DB.Where("id = ?", element.ID).Updates(&models.PurchasePartWorkshop{
-			PartID: element.ProductID,
+		return tx.Where("id = ?", element.ID).Updates(&models.PurchaseProduct{
+			ProductID: element.ProductID,
 			ExpiredAt: element.ExpiredAt,
 			UnitPrice: element.UnitPrice,
 			Quantity: element.Quantity,
 			TotalPrice: element.UnitPrice * float32(element.Quantity),
-		}).Error; err != nil {
-			return err
-		}
-		return nil
-	default:
-		return fmt.Errorf("tipo de movimiento no soportado")
-	}
+		}).Error
+	})
 }
 
-func (r *Repository) DeletePurchaseElementByID(id string, workplace string) error {
-	if workplace == "laundry" {
-		var purchaseProduct models.PurchaseProductLaundry
-		if err := r.DB.Where("id = ?", id).Delete(&purchaseProduct).Error; err != nil {
-			return err
-		}
-	} else if workplace == "workshop" {
-		var purchasePart models.PurchasePartWorkshop
-		if err := r.DB.Where("id = ?", id).Delete(&purchasePart).Error; err != nil {
-			return err
-		}
-	} else {
-		return fmt.Errorf("tipo de movimiento no soportado")
+func (r *Repository) DeletePurchaseElementByID(id string, workplaceID string, actor models.Actor) error {
+	if _, err := r.GetPurchaseElementByID(id, workplaceID); err != nil {
+		return err
 	}
-	return nil
-}
\ No newline at end of file
+	return audited[models.PurchaseProduct](r.DB, actor, models.AuditDelete, "purchase_product", id, func(tx *gorm.DB) error {
+		return tx.Where("id = ?", id).Delete(&models.PurchaseProduct{}).Error
+	})
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Helpers para armar condiciones que funcionan igual en SQLite, PostgreSQL y MySQL.
+
+// dayBounds devuelve el inicio del día de t y el inicio del día siguiente,
+// para filtrar por fecha con un rango en lugar de DATE(columna).
+func dayBounds(t time.Time) (time.Time, time.Time) {
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 0, 1)
+}
+
+// nextDay devuelve el día siguiente a una fecha YYYY-MM-DD con el mismo formato.
+func nextDay(date string) (string, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", err
+	}
+	return day.AddDate(0, 0, 1).Format("2006-01-02"), nil
+}
+
+// ilike arma una búsqueda por "contiene" sin distinguir mayúsculas, ya que
+// LIKE es case-sensitive en PostgreSQL y no en SQLite ni MySQL. El escape es
+// explícito porque SQLite no tiene uno por defecto, y no es "\" porque MySQL
+// lo interpreta dentro del literal.
+func ilike(column string) string {
+	return "LOWER(" + column + ") LIKE ? ESCAPE '!'"
+}
+
+// likeEscaper hace que %, _ y el propio escape se busquen literalmente.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// likePattern es el patrón que acompaña a ilike.
+func likePattern(value string) string {
+	return "%" + likeEscaper.Replace(strings.ToLower(value)) + "%"
+}
+
+// taken indica si otra fila, incluso una en la papelera, ya ocupa un valor de
+// clave única. excludeID es la propia fila al actualizar y vacío al crear.
+func taken(db *gorm.DB, model interface{}, excludeID string, query string, args ...interface{}) (bool, error) {
+	q := db.Unscoped().Model(model).Where(query, args...)
+	if excludeID != "" {
+		q = q.Where("id <> ?", excludeID)
+	}
+	var count int64
+	if err := q.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -7,5 +7,3 @@ import (
 type Repository struct {
 	DB *gorm.DB
 }
-
-var Repo *Repository
@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/database"
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/google/uuid"
+)
+
+// testRepositories abre un Repository por cada backend disponible: siempre
+// SQLite en un archivo temporal y, si está definida, la base de TEST_URI_DB
+// (por ejemplo postgres://... o mysql://...).
+func testRepositories(t *testing.T) map[string]*Repository {
+	t.Helper()
+	uris := map[string]string{
+		"sqlite": "sqlite://" + filepath.Join(t.TempDir(), "test.db"),
+	}
+	if uri := os.Getenv("TEST_URI_DB"); uri != "" {
+		uris["TEST_URI_DB"] = uri
+	}
+
+	repos := make(map[string]*Repository, len(uris))
+	for name, uri := range uris {
+		db, err := database.Connect(uri)
+		if err != nil {
+			t.Fatalf("%s: no se pudo conectar: %v", name, err)
+		}
+		t.Cleanup(func() { database.CloseDB(db) })
+		if err := database.Migrate(db); err != nil {
+			t.Fatalf("%s: no se pudieron aplicar las migraciones: %v", name, err)
+		}
+		repos[name] = &Repository{DB: db}
+	}
+	return repos
+}
+
+func TestGetExpenseToday(t *testing.T) {
+	for name, repo := range testRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			workplaceID := uuid.NewString()
+			supplierID, err := repo.CreateSupplier(&models.SupplierCreate{Name: "Proveedor"}, workplaceID, models.Actor{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			movementTypeID, err := repo.CreateMovementType(&models.MovementTypeCreate{Name: "Compra"}, workplaceID, models.Actor{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			now := time.Now()
+			for _, createdAt := range []time.Time{now, now.AddDate(0, 0, -2)} {
+				expense := models.Expense{
+					ID:             uuid.NewString(),
+					WorkplaceID:    workplaceID,
+					Details:        "Gasto",
+					SupplierID:     supplierID,
+					MovementTypeID: movementTypeID,
+					Amount:         100,
+					CreatedAt:      createdAt,
+				}
+				if err := repo.DB.Create(&expense).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expenses, err := repo.GetExpenseToday(workplaceID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(*expenses) != 1 {
+				t.Errorf("se esperaba 1 gasto de hoy, se obtuvieron %d", len(*expenses))
+			}
+		})
+	}
+}
+
+func TestGetAttendancesByDate(t *testing.T) {
+	for name, repo := range testRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			workplaceID := uuid.NewString()
+			employeeID, err := repo.CreateEmployee(&models.EmployeeCreate{Name: "Juan"}, workplaceID, models.Actor{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, date := range []string{"2024-02-28", "2024-02-29", "2024-03-01"} {
+				_, err := repo.CreateAttendance(&models.AttendanceCreate{
+					EmployeeID: employeeID,
+					Attendance: "presente",
+					Hours:      8,
+					Date:       date,
+					Amount:     10,
+				}, workplaceID, models.Actor{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			attendances, err := repo.GetAttendancesByDate("2024-02-28", "2024-02-29", workplaceID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(*attendances) != 2 {
+				t.Errorf("se esperaban 2 asistencias, se obtuvieron %d", len(*attendances))
+			}
+		})
+	}
+}
+
+func TestSearchIsCaseInsensitive(t *testing.T) {
+	for name, repo := range testRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			workplaceID := uuid.NewString()
+			if _, err := repo.CreateSupplier(&models.SupplierCreate{Name: "Repuestos Del Norte"}, workplaceID, models.Actor{}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := repo.CreateEmployee(&models.EmployeeCreate{Name: "María GÓMEZ"}, workplaceID, models.Actor{}); err != nil {
+				t.Fatal(err)
+			}
+
+			suppliers, err := repo.GetSupplierByName("del norte", workplaceID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(*suppliers) != 1 {
+				t.Errorf("se esperaba 1 proveedor, se obtuvieron %d", len(*suppliers))
+			}
+
+			employees, err := repo.GetEmployeeByName("MARÍA", workplaceID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(*employees) != 1 {
+				t.Errorf("se esperaba 1 empleado, se obtuvieron %d", len(*employees))
+			}
+		})
+	}
+}
+
+func TestSearchEscapesWildcards(t *testing.T) {
+	for name, repo := range testRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			workplaceID := uuid.NewString()
+			for _, supplierName := range []string{"50% Descuentos", "Repuestos 5000", "Gomeria_Sur!"} {
+				if _, err := repo.CreateSupplier(&models.SupplierCreate{Name: supplierName}, workplaceID, models.Actor{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for query, expected := range map[string]int{"50%": 1, "5_0": 0, "%": 1, "_sur!": 1, "s_5": 0} {
+				suppliers, err := repo.GetSupplierByName(query, workplaceID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(*suppliers) != expected {
+					t.Errorf("%q: se esperaban %d proveedores, se obtuvieron %d", query, expected, len(*suppliers))
+				}
+			}
+		})
+	}
+}
@@ -12,14 +12,16 @@ func (r *Repository) GetAllRoles(roleName string, workplace string) (*[]models.R
 	}
 
 	var allRoles []models.Role
-	if workplace == "laundry" {
-		if err := r.DB.Where("hierarchy > ? AND workplace = ?", currentRole.Hierarchy, "laundry").Find(&allRoles).Error; err != nil {
-			return nil, err
-		}
-	} else if workplace == "workshop" {
-		if err := r.DB.Where("hierarchy > ? AND workplace = ?", currentRole.Hierarchy, "workshop").Find(&allRoles).Error; err != nil {
-			return nil, err
-		}
+	if err := r.DB.Where("hierarchy > ? AND workplace = ?", currentRole.Hierarchy, workplace).Find(&allRoles).Error; err != nil {
+		return nil, err
 	}
 	return &allRoles, nil
-}
\ No newline at end of file
+}
+
+func (r *Repository) GetRoleByName(name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.DB.Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+// Search busca en clientes y vehículos (globales) y en los productos y
+// proveedores del workplace. En SQLite usa el índice FTS5 (ver
+// database/search.go) y ordena por relevancia; en PostgreSQL/MySQL, o en
+// SQLite compilado sin FTS5, usa LIKE.
+func (r *Repository) Search(query string, workplaceID string, limit int) ([]models.SearchHit, error) {
+	if r.DB.Migrator().HasTable("search_index") {
+		return r.searchIndex(query, workplaceID, limit)
+	}
+	return r.searchLike(query, workplaceID, limit)
+}
+
+func (r *Repository) searchIndex(query string, workplaceID string, limit int) ([]models.SearchHit, error) {
+	hits := []models.SearchHit{}
+	match := matchExpression(query)
+	if match == "" {
+		return hits, nil
+	}
+	// bm25 lleva un peso por columna: el título pesa más que el resto
+	err := r.DB.Raw(`SELECT entity_type AS type, entity_id AS id, title, content AS subtitle,
+			-bm25(search_index, 0, 0, 0, 10.0, 1.0) AS score
+		FROM search_index
+		WHERE search_index MATCH ? AND (workplace_id = '' OR workplace_id = ?)
+		ORDER BY score DESC
+		LIMIT ?`, match, workplaceID, limit).Scan(&hits).Error
+	if err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// matchExpression arma la consulta FTS5: cada palabra como frase entre
+// comillas (así "-" o ":" no se interpretan como operadores) y con prefijo,
+// para que "per" encuentre "Pérez".
+func matchExpression(query string) string {
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		word = strings.ReplaceAll(word, `"`, "")
+		if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue
+		}
+		terms = append(terms, `"`+word+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// searchLike es la búsqueda sin índice: no ordena por relevancia ni ignora
+// acentos.
+func (r *Repository) searchLike(query string, workplaceID string, limit int) ([]models.SearchHit, error) {
+	pattern := likePattern(strings.TrimSpace(query))
+	hits := []models.SearchHit{}
+
+	var clients []models.Client
+	if err := r.DB.Where(ilike("first_name")+" OR "+ilike("last_name")+" OR "+ilike("c_ui_l")+" OR "+ilike("dni")+" OR "+ilike("email"),
+		pattern, pattern, pattern, pattern, pattern).Limit(limit).Find(&clients).Error; err != nil {
+		return nil, err
+	}
+	for _, client := range clients {
+		hits = append(hits, models.SearchHit{
+			Type:     models.SearchTypeClient,
+			ID:       client.ID,
+			Title:    client.FirstName + " " + client.LastName,
+			Subtitle: strings.Join([]string{client.CUIL, client.DNI, client.Email}, " "),
+		})
+	}
+
+	var vehicles []models.Vehicle
+	if err := r.DB.Where(ilike("domain")+" OR "+ilike("brand")+" OR "+ilike("model"),
+		pattern, pattern, pattern).Limit(limit).Find(&vehicles).Error; err != nil {
+		return nil, err
+	}
+	for _, vehicle := range vehicles {
+		hits = append(hits, models.SearchHit{
+			Type:     models.SearchTypeVehicle,
+			ID:       vehicle.ID,
+			Title:    vehicle.Domain,
+			Subtitle: vehicle.Brand + " " + vehicle.Model,
+		})
+	}
+
+	var products []models.Product
+	if err := r.DB.Where("workplace_id = ? AND ("+ilike("name")+" OR "+ilike("identifier")+")",
+		workplaceID, pattern, pattern).Limit(limit).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	for _, product := range products {
+		hits = append(hits, models.SearchHit{
+			Type:     models.SearchTypeProduct,
+			ID:       product.ID,
+			Title:    product.Name,
+			Subtitle: product.Identifier,
+		})
+	}
+
+	var suppliers []models.Supplier
+	if err := r.DB.Where("workplace_id = ? AND ("+ilike("name")+" OR "+ilike("email")+")",
+		workplaceID, pattern, pattern).Limit(limit).Find(&suppliers).Error; err != nil {
+		return nil, err
+	}
+	for _, supplier := range suppliers {
+		hits = append(hits, models.SearchHit{
+			Type:     models.SearchTypeSupplier,
+			ID:       supplier.ID,
+			Title:    supplier.Name,
+			Subtitle: supplier.Email,
+		})
+	}
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
@@ -1,136 +1,91 @@
 package repositories
 
 import (
-	"errors"
-	"fmt"
+	"time"
 
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-func (r *Repository) GetServiceByID(id string, workplace string) (*models.ServiceLaundry, *models.ServiceWorkshop, error) {
-	if workplace == "laundry" {
-		var service models.ServiceLaundry
-		if err := r.DB.Where("id = ?", id).First(&service).Error; err != nil {
-			return nil, nil, err
-		}
-		return &service, nil, nil
-	} else if workplace == "workshop" {
-		var service models.ServiceWorkshop
-		if err := r.DB.Where("id = ?", id).First(&service).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &service, nil
+func (r *Repository) GetServiceByID(id string, workplaceID string) (*models.Service, error) {
+	var service models.Service
+	if err := r.DB.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&service).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	services := []models.Service{service}
+	if err := setCurrentPrices(r.DB, services); err != nil {
+		return nil, err
+	}
+	return &services[0], nil
 }
 
-func (r *Repository) GetServiceByName(name string, workplace string) (bool, error) {
-	if workplace == "laundry" {
-		var service models.ServiceLaundry
-		if err := r.DB.Where("name = ?", name).First(&service).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return false, nil
-			}
-			return false, err
-		}
-		return true, nil
-	} else if workplace == "workshop" {
-		var service models.ServiceWorkshop
-		if err := r.DB.Where("name = ?", name).First(&service).Error; err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return false, nil
-			}
-			return false, err
-		}
-		return true, nil
-	}
-	return false, fmt.Errorf("tipo de movimiento no soportado")
+// Incluye los registros en la papelera: el índice único de la base también
+// los cuenta.
+func (r *Repository) GetServiceByName(name string, workplaceID string, excludeID string) (bool, error) {
+	return taken(r.DB, &models.Service{}, excludeID, "name = ? AND workplace_id = ?", name, workplaceID)
 }
 
-func (r *Repository) GetAllServices(workplace string) (*[]models.ServiceLaundry, *[]models.ServiceWorkshop, error) {
-	if workplace == "laundry" {
-		var services []models.ServiceLaundry
-		if err := r.DB.Find(&services).Error; err != nil {
-			return nil, nil, err
-		}
-		return &services, nil, nil
-	} else if workplace == "workshop" {
-		var services []models.ServiceWorkshop
-		if err := r.DB.Find(&services).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &services, nil
+var serviceList = listSpec{
+	fields: map[string]listField{
+		"name":       {column: "name", filter: filterContains},
+		"created_at": {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "name",
+}
+
+func (r *Repository) GetAllServices(workplaceID string, params *models.ListParams) (*[]models.Service, int64, error) {
+	var services []models.Service
+	query := r.DB.Model(&models.Service{}).Where("workplace_id = ?", workplaceID)
+	total, err := paginate(query, serviceList, params, &services)
+	if err != nil {
+		return nil, 0, err
 	}
-	return nil, nil, fmt.Errorf("tipo de movimiento no soportado")
+	if err := setCurrentPrices(r.DB, services); err != nil {
+		return nil, 0, err
+	}
+	return &services, total, nil
 }
 
-func (r *Repository) CreateService(service *models.ServiceCreate, workplace string) (string, error) {
+func (r *Repository) CreateService(service *models.ServiceCreate, workplaceID string, actor models.Actor) (string, error) {
 	newID := uuid.NewString()
-	switch workplace {
-	case "laundry":
-		if err := r.DB.Create(&models.ServiceLaundry{
-			ID: newID,
-			Name: service.Name,
-		}).Error; err != nil {
-			return "", err
-		}
-		return newID, nil
-	case "workshop":
-		if err := r.DB.Create(&models.ServiceWorkshop{
+	err := audited[models.Service](r.DB, actor, models.AuditCreate, "service", newID, func(tx *gorm.DB) error {
+		if err := tx.Create(&models.Service{
 			ID: newID,
+			WorkplaceID: workplaceID,
 			Name: service.Name,
 		}).Error; err != nil {
-			return "", err
+			return err
 		}
-		return newID, nil
-	default:
-		return "", fmt.Errorf("tipo de movimiento no soportado")
+		return tx.Create(&models.ServicePrice{
+			ID:            uuid.NewString(),
+			ServiceID:     newID,
+			Price:         service.Price,
+			EffectiveFrom: time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return "", err
 	}
+	return newID, nil
 }
 
-func (r *Repository) UpdateService(service *models.ServiceUpdate, workplace string) error {
-	switch workplace {
-	case "laundry":
-		if err := r.DB.Where("id = ?", service.ID).First(&models.ServiceLaundry{}).Error; err != nil {
+func (r *Repository) UpdateService(service *models.ServiceUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.Service](r.DB, actor, models.AuditUpdate, "service", service.ID, func(tx *gorm.DB) error {
+		var s models.Service
+		if err := tx.Where("id = ? AND workplace_id = ?", service.ID, workplaceID).First(&s).Error; err != nil {
 			return err
 		}
-		s := models.ServiceLaundry{
-			ID: service.ID,
-			Name: service.Name,
-		}
-		if err := r.DB.Save(&s).Error; err != nil {
-			return err
-		}
-	case "workshop":
-		if err := r.DB.Where("id = ?", service.ID).First(&models.ServiceWorkshop{}).Error; err != nil {
-			return err
-		}
-		s := models.ServiceWorkshop{
-			ID: service.ID,
-			Name: service.Name,
-		}
-		if err := r.DB.Save(&s).Error; err != nil {
+		if err := bumpVersion(tx, &models.Service{}, service.ID, service.Version); err != nil {
 			return err
 		}
-	default:
-		return fmt.Errorf("tipo de movimiento no soportado")
-	}
-	return nil
+		s.Name = service.Name
+		return tx.Omit("version").Save(&s).Error
+	})
 }
 
-func (r *Repository) DeleteServiceByID(id string, workplace string) error {
-	if workplace == "laundry" {
-		if err := r.DB.Where("id = ?", id).Delete(&models.ServiceLaundry{}).Error; err != nil {
-			return err
-		}
-	} else if workplace == "workshop" {
-		if err := r.DB.Where("id = ?", id).Delete(&models.ServiceWorkshop{}).Error; err != nil {
-			return err
-		}
-	} else {
-		return fmt.Errorf("tipo de movimiento no soportado")
-	}
-	return nil
-}
\ No newline at end of file
+func (r *Repository) DeleteServiceByID(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.Service](r.DB, actor, models.AuditDelete, "service", id, func(tx *gorm.DB) error {
+		return deleteScoped(tx, &models.Service{}, id, workplaceID)
+	})
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrServiceNotPriced indica un servicio de un ingreso que no es del
+// workplace o que no tiene precio vigente.
+var ErrServiceNotPriced = errors.New("servicio inexistente o sin precio vigente")
+
+// ErrDuplicatePrice indica que el servicio ya tiene un precio con la misma
+// vigencia para la misma categoría.
+var ErrDuplicatePrice = errors.New("el servicio ya tiene un precio desde esa fecha")
+
+// ErrCategoryPricesDisabled indica un precio por categoría en un workplace
+// que no los usa.
+var ErrCategoryPricesDisabled = errors.New("el lugar de trabajo no tiene precios por categoría de vehículo")
+
+// categoryPrices indica si el workplace cobra según la categoría del vehículo.
+func categoryPrices(tx *gorm.DB, workplaceID string) (bool, error) {
+	var workplace models.Workplace
+	if err := tx.Select("category_prices").Where("id = ?", workplaceID).First(&workplace).Error; err != nil {
+		return false, err
+	}
+	return workplace.CategoryPrices, nil
+}
+
+// servicePriceAt devuelve el precio del servicio vigente en at para un
+// vehículo de la categoría dada: el de la categoría si hay uno vigente y si no
+// el general. Los workplaces sin precios por categoría cobran siempre el
+// general. El servicio tiene que ser del workplace y no estar en la papelera.
+func servicePriceAt(tx *gorm.DB, serviceID string, workplaceID string, category string, at time.Time) (float32, error) {
+	categories := []string{""}
+	if category != "" {
+		enabled, err := categoryPrices(tx, workplaceID)
+		if err != nil {
+			return 0, err
+		}
+		if enabled {
+			categories = []string{category, ""}
+		}
+	}
+	for _, category := range categories {
+		var prices []float32
+		err := tx.Model(&models.ServicePrice{}).
+			Joins("JOIN services ON services.id = service_prices.service_id").
+			Where("service_prices.service_id = ? AND services.workplace_id = ? AND services.deleted_at IS NULL", serviceID, workplaceID).
+			Where("service_prices.vehicle_category = ? AND service_prices.effective_from <= ?", category, at).
+			Order("service_prices.effective_from desc").
+			Limit(1).
+			Pluck("service_prices.price", &prices).Error
+		if err != nil {
+			return 0, err
+		}
+		if len(prices) > 0 {
+			return prices[0], nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrServiceNotPriced, serviceID)
+}
+
+// setCurrentPrices completa en cada servicio el precio general vigente y los
+// vigentes por categoría.
+func setCurrentPrices(db *gorm.DB, services []models.Service) error {
+	if len(services) == 0 {
+		return nil
+	}
+	ids := make([]string, len(services))
+	for i, service := range services {
+		ids[i] = service.ID
+	}
+
+	var prices []models.ServicePrice
+	if err := db.Where("service_id IN ? AND effective_from <= ?", ids, time.Now()).Order("effective_from").Find(&prices).Error; err != nil {
+		return err
+	}
+	// ordenados por vigencia, el último de cada servicio y categoría es el vigente
+	current := map[string]map[string]float32{}
+	for _, price := range prices {
+		if current[price.ServiceID] == nil {
+			current[price.ServiceID] = map[string]float32{}
+		}
+		current[price.ServiceID][price.VehicleCategory] = price.Price
+	}
+	for i := range services {
+		categoryPrices := map[string]float32{}
+		for category, price := range current[services[i].ID] {
+			if category == "" {
+				services[i].Price = &price
+			} else {
+				categoryPrices[category] = price
+			}
+		}
+		services[i].CategoryPrices = categoryPrices
+	}
+	return nil
+}
+
+func (r *Repository) GetServicePrices(serviceID string, workplaceID string) ([]models.ServicePrice, error) {
+	if err := r.DB.Where("id = ? AND workplace_id = ?", serviceID, workplaceID).First(&models.Service{}).Error; err != nil {
+		return nil, err
+	}
+	var prices []models.ServicePrice
+	if err := r.DB.Where("service_id = ?", serviceID).Order("vehicle_category, effective_from desc").Find(&prices).Error; err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// CreateServicePrice agrega un precio al historial del servicio. Los precios
+// no se modifican ni se borran: un cambio es un precio nuevo.
+func (r *Repository) CreateServicePrice(serviceID string, price *models.ServicePriceCreate, workplaceID string, actor models.Actor) (string, error) {
+	newID := uuid.NewString()
+	err := audited[models.ServicePrice](r.DB, actor, models.AuditCreate, "service_price", newID, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND workplace_id = ?", serviceID, workplaceID).First(&models.Service{}).Error; err != nil {
+			return err
+		}
+		if price.VehicleCategory != "" {
+			enabled, err := categoryPrices(tx, workplaceID)
+			if err != nil {
+				return err
+			}
+			if !enabled {
+				return ErrCategoryPricesDisabled
+			}
+		}
+		var count int64
+		if err := tx.Model(&models.ServicePrice{}).Where("service_id = ? AND vehicle_category = ? AND effective_from = ?", serviceID, price.VehicleCategory, *price.EffectiveFrom).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrDuplicatePrice
+		}
+		return tx.Create(&models.ServicePrice{
+			ID:              newID,
+			ServiceID:       serviceID,
+			VehicleCategory: price.VehicleCategory,
+			Price:           price.Price,
+			EffectiveFrom:   *price.EffectiveFrom,
+		}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return newID, nil
+}
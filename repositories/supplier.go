@@ -1,161 +1,84 @@
 package repositories
 
 import (
-	"errors"
-	"fmt"
-
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-func (r *Repository) GetSupplierByID(id string, workplace string) (*models.SupplierLaundry, *models.SupplierWorkshop, error) {
-	if workplace == "laundry" {
-		var supplier models.SupplierLaundry
-		if err := r.DB.Where("id = ?", id).First(&supplier).Error; err != nil {
-			return nil, nil, err
-		}
-		return &supplier, nil, nil
-	} else if workplace == "workshop" {
-		var supplier models.SupplierWorkshop
-		if err := r.DB.Where("id = ?", id).First(&supplier).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &supplier, nil
+func (r *Repository) GetSupplierByID(id string, workplaceID string) (*models.Supplier, error) {
+	var supplier models.Supplier
+	if err := r.DB.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&supplier).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de espacio no soportado")
+	return &supplier, nil
 }
 
-func (r *Repository) GetAllSuppliers(workplace string) ([]models.SupplierLaundry, []models.SupplierWorkshop, error) {
-	if workplace == "laundry" {
-		var suppliers []models.SupplierLaundry
-		if err := r.DB.Find(&suppliers).Error; err != nil {
-			return nil, nil, err
-		}
-		return suppliers, nil, nil
-	}
-	if workplace == "workshop" {
-		var suppliers []models.SupplierWorkshop
-		if err := r.DB.Find(&suppliers).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, suppliers, nil
-	}
-	return nil, nil, fmt.Errorf("tipo de espacio no soportado")
+var supplierList = listSpec{
+	fields: map[string]listField{
+		"name":       {column: "name", filter: filterContains},
+		"phone":      {column: "phone", filter: filterContains},
+		"email":      {column: "email", filter: filterContains},
+		"created_at": {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "name",
 }
 
-func (r *Repository) CreateSupplier(supplierCreate *models.SupplierCreate, workplaceType string) (string, error) {
-	var supplierID string
-	switch workplaceType {
-	case "laundry":
-			supplier := models.SupplierLaundry{
-					ID:      uuid.NewString(),
-					Name:    supplierCreate.Name,
-					Address: supplierCreate.Address,
-					Phone:   supplierCreate.Phone,
-					Email:   supplierCreate.Email,
-			}
-			if err := r.DB.Create(&supplier).Error; err != nil {
-					return "", err
-			}
-			supplierID = supplier.ID
-	case "workshop":
-			supplier := models.SupplierWorkshop{
-					ID:      uuid.NewString(),
-					Name:    supplierCreate.Name,
-					Address: supplierCreate.Address,
-					Phone:   supplierCreate.Phone,
-					Email:   supplierCreate.Email,
-			}
-			if err := r.DB.Create(&supplier).Error; err != nil {
-					return "", err
-			}
-			supplierID = supplier.ID
-	default:
-			return "", errors.New("tipo de workplace no soportado")
+func (r *Repository) GetAllSuppliers(workplaceID string, params *models.ListParams) ([]models.Supplier, int64, error) {
+	var suppliers []models.Supplier
+	query := r.DB.Model(&models.Supplier{}).Where("workplace_id = ?", workplaceID)
+	total, err := paginate(query, supplierList, params, &suppliers)
+	if err != nil {
+		return nil, 0, err
 	}
-
-	return supplierID, nil
+	return suppliers, total, nil
 }
 
-func (r *Repository) UpdateSupplier(supplierUpdate *models.SupplierUpdate, workplace string) error {
-	switch workplace {
-	case "laundry":
-		var supplierLaundry models.SupplierLaundry
-		if err := r.DB.Where("id = ?", supplierUpdate.ID).First(&supplierLaundry).Error; err != nil {
-			return err
-		}
-		supplierLaundry.Name = supplierUpdate.Name
-		supplierLaundry.Address = supplierUpdate.Address
-		supplierLaundry.Phone = supplierUpdate.Phone
-		supplierLaundry.Email = supplierUpdate.Email
-		if err := r.DB.Save(&supplierLaundry).Error; err != nil {
-			return err
-		}
-	case "workshop":
-		var supplierWorkshop models.SupplierWorkshop
-		if err := r.DB.Where("id = ?", supplierUpdate.ID).First(&supplierWorkshop).Error; err != nil {
-			return err
-		}
-		supplierWorkshop.Name = supplierUpdate.Name
-		supplierWorkshop.Address = supplierUpdate.Address
-		supplierWorkshop.Phone = supplierUpdate.Phone
-		supplierWorkshop.Email = supplierUpdate.Email
-		if err := r.DB.Save(&supplierWorkshop).Error; err != nil {
-			return err
-		}
-	default:
-			return errors.New("tipo de workplace no soportado")
+func (r *Repository) CreateSupplier(supplierCreate *models.SupplierCreate, workplaceID string, actor models.Actor) (string, error) {
+	supplier := models.Supplier{
+		ID:          uuid.NewString(),
+		WorkplaceID: workplaceID,
+		Name:        supplierCreate.Name,
+		Address:     supplierCreate.Address,
+		Phone:       supplierCreate.Phone,
+		Email:       supplierCreate.Email,
+	}
+	err := audited[models.Supplier](r.DB, actor, models.AuditCreate, "supplier", supplier.ID, func(tx *gorm.DB) error {
+		return tx.Create(&supplier).Error
+	})
+	if err != nil {
+		return "", err
 	}
-	return nil
+	return supplier.ID, nil
 }
 
-func (r *Repository) DeleteSupplier(supplier interface{}) error {
-	switch s := supplier.(type) {
-	case *models.SupplierLaundry:
-		if err := r.DB.Delete(s).Error; err != nil {
+func (r *Repository) UpdateSupplier(supplierUpdate *models.SupplierUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.Supplier](r.DB, actor, models.AuditUpdate, "supplier", supplierUpdate.ID, func(tx *gorm.DB) error {
+		var supplier models.Supplier
+		if err := tx.Where("id = ? AND workplace_id = ?", supplierUpdate.ID, workplaceID).First(&supplier).Error; err != nil {
 			return err
 		}
-	case *models.SupplierWorkshop:
-		if err := r.DB.Delete(s).Error; err != nil {
+		if err := bumpVersion(tx, &models.Supplier{}, supplierUpdate.ID, supplierUpdate.Version); err != nil {
 			return err
 		}
-	default:
-		return fmt.Errorf("tipo de espacio no soportado")
-	}
-	return nil
+		supplier.Name = supplierUpdate.Name
+		supplier.Address = supplierUpdate.Address
+		supplier.Phone = supplierUpdate.Phone
+		supplier.Email = supplierUpdate.Email
+		return tx.Omit("version").Save(&supplier).Error
+	})
 }
 
-func (r *Repository) DeleteSupplierByID(id string, workplace string) error {
-	if workplace == "laundry" {
-		var supplier models.SupplierLaundry
-		if err := r.DB.Where("id = ?", id).Delete(&supplier).Error; err != nil {
-			return err
-		}
-	} else if workplace == "workshop" {
-		var supplier models.SupplierWorkshop
-		if err := r.DB.Where("id = ?", id).Delete(&supplier).Error; err != nil {
-			return err
-		}
-	} else {
-		return fmt.Errorf("tipo de espacio no soportado")
-	}
-	return nil
+func (r *Repository) DeleteSupplierByID(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.Supplier](r.DB, actor, models.AuditDelete, "supplier", id, func(tx *gorm.DB) error {
+		return deleteScoped(tx, &models.Supplier{}, id, workplaceID)
+	})
 }
 
-func (r *Repository) GetSupplierByName(name string, workplace string) (*[]models.SupplierLaundry, *[]models.SupplierWorkshop, error) {
-	if workplace == "laundry" {
-		var supplier []models.SupplierLaundry
-		if err := r.DB.Where("name LIKE ?", "%"+name +"%").Find(&supplier).Error; err != nil {
-			return nil, nil, err
-		}
-		return &supplier, nil, nil
-	} else if workplace == "workshop" {
-		var supplier []models.SupplierWorkshop
-		if err := r.DB.Where("name LIKE ?", "%"+name +"%").Find(&supplier).Error; err != nil {
-			return nil, nil, err
-		}
-		return nil, &supplier, nil
+func (r *Repository) GetSupplierByName(name string, workplaceID string) (*[]models.Supplier, error) {
+	var suppliers []models.Supplier
+	if err := r.DB.Where("workplace_id = ? AND "+ilike("name"), workplaceID, likePattern(name)).Find(&suppliers).Error; err != nil {
+		return nil, err
 	}
-	return nil, nil, fmt.Errorf("tipo de espacio no soportado")
+	return &suppliers, nil
 }
-
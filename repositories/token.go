@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.DB.Create(token).Error
+}
+
+func (r *Repository) GetRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.DB.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken revoca old y guarda next en una transacción. Falla con
+// gorm.ErrRecordNotFound si old ya estaba revocado, así dos refresh
+// simultáneos con el mismo token no pueden rotarlo los dos.
+func (r *Repository) RotateRefreshToken(old *models.RefreshToken, next *models.RefreshToken) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Updates(map[string]interface{}{"revoked_at": time.Now(), "replaced_by": next.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(next).Error
+	})
+}
+
+func (r *Repository) RevokeRefreshToken(id string) error {
+	return r.DB.Model(&models.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *Repository) RevokeUserRefreshTokens(userID string) error {
+	return r.DB.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAccessToken agrega el jti a la lista de revocados y de paso limpia
+// los que ya vencieron.
+func (r *Repository) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&models.RevokedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Save(&models.RevokedToken{JTI: jti, ExpiresAt: expiresAt}).Error
+	})
+}
+
+func (r *Repository) IsAccessTokenRevoked(jti string) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,332 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"gorm.io/gorm"
+)
+
+// ErrUnknownTrashEntity indica un tipo de entidad que no tiene papelera.
+var ErrUnknownTrashEntity = errors.New("tipo de entidad sin papelera")
+
+// ErrTrashReferenced indica que otros registros, activos o en la papelera,
+// apuntan al que se quiere purgar.
+var ErrTrashReferenced = errors.New("el registro está referenciado por otros registros, elimínelos definitivamente primero")
+
+// ErrTrashParentDeleted indica que el registro a restaurar depende de otro que
+// sigue en la papelera.
+var ErrTrashParentDeleted = errors.New("el registro depende de otro que está en la papelera, restáurelo primero")
+
+// trashEntity describe una entidad con borrado lógico: cómo crear su modelo y
+// su slice, cómo limitarla al workplace, qué hijos se restauran o purgan con
+// ella, de qué padres depende y quién la referencia. Las entidades globales
+// (clientes y vehículos) no tienen scope.
+type trashEntity struct {
+	model      func() interface{}
+	list       func() interface{}
+	snapshot   func(tx *gorm.DB, entityType, id string) (models.JSONMap, error)
+	fields     map[string]listField
+	table      string
+	scope      func(db *gorm.DB, workplaceID string) *gorm.DB
+	parents    []reference
+	referenced []reference
+	restore    func(tx *gorm.DB, id string) error
+	purge      func(tx *gorm.DB, id string) error
+}
+
+// reference es una columna que guarda el id de otra tabla.
+type reference struct {
+	table  string
+	column string
+}
+
+// checkReferenced falla si alguna fila, incluso borrada, apunta al id. Se
+// consulta la tabla sin modelo para contar también las que están en la
+// papelera: al restaurarlas quedarían huérfanas.
+func checkReferenced(tx *gorm.DB, id string, refs []reference) error {
+	for _, ref := range refs {
+		var count int64
+		if err := tx.Table(ref.table).Where(ref.column+" = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w (%s)", ErrTrashReferenced, ref.table)
+		}
+	}
+	return nil
+}
+
+// checkParents falla si alguno de los padres del registro sigue en la
+// papelera. Las columnas opcionales vacías no se controlan.
+func checkParents(tx *gorm.DB, table, id string, parents []reference) error {
+	for _, parent := range parents {
+		var count int64
+		err := tx.Table(table).
+			Joins("JOIN "+parent.table+" ON "+parent.table+".id = "+table+"."+parent.column).
+			Where(table+".id = ? AND "+parent.table+".deleted_at IS NOT NULL", id).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w (%s)", ErrTrashParentDeleted, parent.table)
+		}
+	}
+	return nil
+}
+
+func byWorkplace(db *gorm.DB, workplaceID string) *gorm.DB {
+	return db.Where("workplace_id = ?", workplaceID)
+}
+
+func trashOf[T any](table string, fields map[string]listField) trashEntity {
+	return trashEntity{
+		model:    func() interface{} { return new(T) },
+		list:     func() interface{} { return new([]T) },
+		snapshot: snapshot[T],
+		fields:   fields,
+		table:    table,
+	}
+}
+
+// restoreChildren devuelve los hijos que se borraron junto con el padre, es
+// decir, los que tienen su mismo deleted_at (ver DeleteClient).
+func restoreChildren(child interface{}, foreignKey, parentTable string) func(tx *gorm.DB, id string) error {
+	return func(tx *gorm.DB, id string) error {
+		return tx.Unscoped().Model(child).
+			Where(foreignKey+" = ? AND deleted_at = (SELECT deleted_at FROM "+parentTable+" WHERE id = ?)", id, id).
+			Update("deleted_at", nil).Error
+	}
+}
+
+func purgeChildren(child interface{}, foreignKey string) func(tx *gorm.DB, id string) error {
+	return func(tx *gorm.DB, id string) error {
+		return tx.Unscoped().Where(foreignKey+" = ?", id).Delete(child).Error
+	}
+}
+
+var trashEntities map[string]trashEntity
+
+func init() {
+	vehicleReferenced := []reference{{"incomes", "vehicle_id"}, {"work_orders", "vehicle_id"}}
+
+	client := trashOf[models.Client]("clients", clientList.fields)
+	client.referenced = []reference{{"incomes", "client_id"}, {"work_orders", "client_id"}}
+	client.restore = restoreChildren(&models.Vehicle{}, "client_id", "clients")
+	client.purge = func(tx *gorm.DB, id string) error {
+		// los vehículos se purgan con el cliente, así que tampoco pueden
+		// estar referenciados
+		var vehicleIDs []string
+		if err := tx.Unscoped().Model(&models.Vehicle{}).Where("client_id = ?", id).Pluck("id", &vehicleIDs).Error; err != nil {
+			return err
+		}
+		for _, vehicleID := range vehicleIDs {
+			if err := checkReferenced(tx, vehicleID, vehicleReferenced); err != nil {
+				return err
+			}
+		}
+		return purgeChildren(&models.Vehicle{}, "client_id")(tx, id)
+	}
+
+	vehicle := trashOf[models.Vehicle]("vehicles", vehicleList.fields)
+	vehicle.parents = []reference{{"clients", "client_id"}}
+	vehicle.referenced = vehicleReferenced
+
+	income := trashOf[models.Income]("incomes", incomeList.fields)
+	income.scope = byWorkplace
+	income.parents = []reference{{"clients", "client_id"}, {"vehicles", "vehicle_id"}, {"employees", "employee_id"}, {"movement_types", "movement_type_id"}}
+	income.referenced = []reference{{"work_orders", "income_id"}}
+	income.purge = purgeChildren(&models.IncomeService{}, "income_id")
+
+	employee := withScope(trashOf[models.Employee]("employees", employeeList.fields))
+	employee.referenced = []reference{{"incomes", "employee_id"}, {"work_orders", "employee_id"}, {"attendances", "employee_id"}}
+
+	expense := withScope(trashOf[models.Expense]("expenses", expenseList.fields))
+	expense.parents = []reference{{"suppliers", "supplier_id"}, {"movement_types", "movement_type_id"}}
+
+	movementType := withScope(trashOf[models.MovementType]("movement_types", movementTypeList.fields))
+	movementType.referenced = []reference{{"incomes", "movement_type_id"}, {"expenses", "movement_type_id"}}
+
+	service := withScope(trashOf[models.Service]("services", serviceList.fields))
+	service.referenced = []reference{{"income_services", "service_id"}}
+	service.purge = purgeChildren(&models.ServicePrice{}, "service_id")
+
+	supplier := withScope(trashOf[models.Supplier]("suppliers", supplierList.fields))
+	supplier.referenced = []reference{{"expenses", "supplier_id"}, {"purchase_orders", "supplier_id"}}
+
+	attendance := withScope(trashOf[models.Attendance]("attendances", attendanceList.fields))
+	attendance.parents = []reference{{"employees", "employee_id"}}
+
+	purchaseOrder := trashOf[models.PurchaseOrder]("purchase_orders", purchaseOrderList.fields)
+	purchaseOrder.scope = byWorkplace
+	purchaseOrder.parents = []reference{{"suppliers", "supplier_id"}}
+	purchaseOrder.restore = restoreChildren(&models.PurchaseProduct{}, "purchase_order_id", "purchase_orders")
+	purchaseOrder.purge = purgeChildren(&models.PurchaseProduct{}, "purchase_order_id")
+
+	purchaseProduct := trashOf[models.PurchaseProduct]("purchase_products", map[string]listField{
+		"purchase_order_id": {column: "purchase_order_id", filter: filterEquals},
+		"product_id":        {column: "product_id", filter: filterEquals},
+		"created_at":        {column: "created_at", filter: filterTime},
+	})
+	purchaseProduct.parents = []reference{{"purchase_orders", "purchase_order_id"}, {"products", "product_id"}}
+	purchaseProduct.scope = func(db *gorm.DB, workplaceID string) *gorm.DB {
+		// la orden puede estar también en la papelera
+		return db.Where("purchase_order_id IN (?)", purchaseOrdersOfWorkplace(db.Session(&gorm.Session{NewDB: true}).Unscoped(), workplaceID))
+	}
+
+	product := withScope(trashOf[models.Product]("products", productList.fields))
+	product.referenced = []reference{{"purchase_products", "product_id"}, {"work_order_parts", "product_id"}}
+	product.purge = func(tx *gorm.DB, id string) error {
+		// se borra acá, condicionado, para no purgar un producto que una orden
+		// de trabajo reservó mientras estaba en la papelera
+		result := tx.Unscoped().Where("id = ? AND reserved = 0", id).Delete(&models.Product{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrProductReserved
+		}
+		return nil
+	}
+
+	workOrder := trashOf[models.WorkOrder]("work_orders", workOrderList.fields)
+	workOrder.scope = byWorkplace
+	workOrder.parents = []reference{{"clients", "client_id"}, {"vehicles", "vehicle_id"}, {"employees", "employee_id"}}
+	workOrder.purge = purgeChildren(&models.WorkOrderTransition{}, "work_order_id")
+
+	trashEntities = map[string]trashEntity{
+		"attendance":       attendance,
+		"client":           client,
+		"employee":         employee,
+		"expense":          expense,
+		"income":           income,
+		"movement_type":    movementType,
+		"product":          product,
+		"purchase_order":   purchaseOrder,
+		"purchase_product": purchaseProduct,
+		"service":          service,
+		"supplier":         supplier,
+		"vehicle":          vehicle,
+		"work_order":       workOrder,
+	}
+}
+
+func withScope(entity trashEntity) trashEntity {
+	entity.scope = byWorkplace
+	return entity
+}
+
+// TrashEntityTypes devuelve los tipos que acepta la papelera.
+func TrashEntityTypes() []string {
+	return slices.Sorted(maps.Keys(trashEntities))
+}
+
+func lookupTrash(entityType string) (trashEntity, error) {
+	entity, ok := trashEntities[entityType]
+	if !ok {
+		return trashEntity{}, ErrUnknownTrashEntity
+	}
+	return entity, nil
+}
+
+// trashed arma la consulta de las filas de la entidad que están en la papelera
+// y son visibles desde el workplace.
+func (entity trashEntity) trashed(db *gorm.DB, workplaceID string) *gorm.DB {
+	query := db.Unscoped().Model(entity.model()).Where(entity.table + ".deleted_at IS NOT NULL")
+	if entity.scope != nil {
+		query = entity.scope(query, workplaceID)
+	}
+	return query
+}
+
+// GetTrash lista lo que está en la papelera para un tipo de entidad, con los
+// mismos filtros que su listado más deleted_at.
+func (r *Repository) GetTrash(entityType string, workplaceID string, params *models.ListParams) (interface{}, int64, error) {
+	entity, err := lookupTrash(entityType)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	fields := maps.Clone(entity.fields)
+	fields["deleted_at"] = listField{column: "deleted_at", filter: filterTime}
+	spec := listSpec{fields: fields, defaultSort: "-deleted_at"}
+
+	items := entity.list()
+	total, err := paginate(entity.trashed(r.DB, workplaceID), spec, params, items)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// RestoreTrash saca un registro de la papelera junto con los hijos que se
+// borraron con él y lo registra en el AuditLog. Si alguno de sus padres sigue
+// en la papelera hay que restaurarlo antes.
+func (r *Repository) RestoreTrash(entityType string, id string, workplaceID string, actor models.Actor) error {
+	entity, err := lookupTrash(entityType)
+	if err != nil {
+		return err
+	}
+
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := entity.trashed(tx, workplaceID).Where(entity.table+".id = ?", id).First(entity.model()).Error; err != nil {
+			return err
+		}
+		before, err := entity.snapshot(tx, entityType, id)
+		if err != nil {
+			return err
+		}
+		if err := checkParents(tx, entity.table, id, entity.parents); err != nil {
+			return err
+		}
+		if entity.restore != nil {
+			if err := entity.restore(tx, id); err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Model(entity.model()).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		after, err := entity.snapshot(tx, entityType, id)
+		if err != nil {
+			return err
+		}
+		return auditChange(tx, actor, models.AuditRestore, entityType, id, before, after)
+	})
+}
+
+// PurgeTrash borra definitivamente un registro que está en la papelera, con
+// sus hijos. No se purga mientras otros registros lo referencien. El AuditLog
+// conserva cómo estaba.
+func (r *Repository) PurgeTrash(entityType string, id string, workplaceID string, actor models.Actor) error {
+	entity, err := lookupTrash(entityType)
+	if err != nil {
+		return err
+	}
+
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := entity.trashed(tx, workplaceID).Where(entity.table+".id = ?", id).First(entity.model()).Error; err != nil {
+			return err
+		}
+		before, err := entity.snapshot(tx, entityType, id)
+		if err != nil {
+			return err
+		}
+		if err := checkReferenced(tx, id, entity.referenced); err != nil {
+			return err
+		}
+		if err := auditChange(tx, actor, models.AuditPurge, entityType, id, before, nil); err != nil {
+			return err
+		}
+		if entity.purge != nil {
+			if err := entity.purge(tx, id); err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("id = ?", id).Delete(entity.model()).Error
+	})
+}
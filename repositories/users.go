@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"errors"
+	"time"
 
 	"github.com/DanielChachagua/GestionCar/models"
 	"gorm.io/gorm"
@@ -22,9 +23,6 @@ func (r *Repository) GetUserByUsername(username string) (*models.User, error) {
 	var user models.User
 	err := r.DB.Where("username = ?", username).First(&user).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
-		}
 		return nil, err
 	}
 
@@ -43,10 +41,98 @@ func (r *Repository) GetUserByUsernameEmail(username string, email string) (bool
 	return true, nil
 }
 
-func (r *Repository) CreateUser(user *models.User) error {
-	err := r.DB.Create(&user).Error
+func (r *Repository) CreateUser(user *models.User, actor models.Actor) error {
+	return audited[models.User](r.DB, actor, models.AuditCreate, "user", user.ID, func(tx *gorm.DB) error {
+		return tx.Create(user).Error
+	})
+}
+
+func (r *Repository) GetAllUsers() (*[]models.User, error) {
+	var users []models.User
+	if err := r.DB.Order("username").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return &users, nil
+}
+
+func (r *Repository) UpdateUserRole(id string, role string, actor models.Actor) error {
+	return audited[models.User](r.DB, actor, models.AuditUpdate, "user", id, func(tx *gorm.DB) error {
+		return tx.Model(&models.User{}).Where("id = ?", id).Update("role", role).Error
+	})
+}
+
+var userList = listSpec{
+	fields: map[string]listField{
+		"username":   {column: "username", filter: filterContains},
+		"first_name": {column: "first_name", filter: filterContains},
+		"last_name":  {column: "last_name", filter: filterContains},
+		"email":      {column: "email", filter: filterContains},
+		"role":       {column: "role", filter: filterEquals},
+		"active":     {column: "active", filter: filterBool},
+		"created_at": {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "username",
+}
+
+func (r *Repository) ListUsers(params *models.ListParams) ([]models.User, int64, error) {
+	var users []models.User
+	total, err := paginate(r.DB.Model(&models.User{}), userList, params, &users)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// ExistsOtherUser indica si un usuario distinto de id ya usa el username o el email.
+func (r *Repository) ExistsOtherUser(id string, username string, email string) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.User{}).Where("id <> ? AND (email = ? OR username = ?)", id, email, username).Count(&count).Error
 	if err != nil {
-		return err
+		return false, err
 	}
-	return nil
-}
\ No newline at end of file
+	return count > 0, nil
+}
+
+func (r *Repository) UpdateUser(user *models.User, actor models.Actor) error {
+	return audited[models.User](r.DB, actor, models.AuditUpdate, "user", user.ID, func(tx *gorm.DB) error {
+		return tx.Model(&models.User{}).Where("id = ?", user.ID).
+			Select("first_name", "last_name", "username", "email").
+			Updates(user).Error
+	})
+}
+
+// SetUserActive activa o desactiva al usuario. Al desactivarlo también se
+// revocan sus refresh tokens, así no puede renovar la sesión.
+func (r *Repository) SetUserActive(id string, active bool, actor models.Actor) error {
+	return audited[models.User](r.DB, actor, models.AuditUpdate, "user", id, func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", id).Update("active", active).Error; err != nil {
+			return err
+		}
+		if active {
+			return nil
+		}
+		return tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", time.Now()).Error
+	})
+}
+
+// DeleteUser borra al usuario con sus refresh tokens y claves de idempotencia.
+func (r *Repository) DeleteUser(id string, actor models.Actor) error {
+	return audited[models.User](r.DB, actor, models.AuditDelete, "user", id, func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&models.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
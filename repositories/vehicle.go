@@ -1,7 +1,6 @@
 package repositories
 
 import (
-	"errors"
 
 	"github.com/DanielChachagua/GestionCar/models"
 	"gorm.io/gorm"
@@ -17,56 +16,76 @@ func (r *Repository) GetVehicleByID(id string) (*models.Vehicle, error) {
 
 func (r *Repository) GetVehicleByDomain(domain string) (*[]models.Vehicle, error) {
 	var vehicles []models.Vehicle
-	if err := r.DB.Preload("Client").Where("domain LIKE ?", "%"+domain+"%").Find(&vehicles).Error; err != nil {
+	if err := r.DB.Preload("Client").Where(ilike("domain"), likePattern(domain)).Find(&vehicles).Error; err != nil {
 		return nil, err
 	}
 	return &vehicles, nil
 }
 
-func (r *Repository) GetVehicleByDomainEq(domain string) (bool, error) {
-	var vehicle models.Vehicle
-	if err := r.DB.Preload("Client").Where("domain = ?", domain).First(&vehicle).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, nil
-		}
-		return false, err
-	}
-	return true, nil
+// Incluye los registros en la papelera: el índice único de la base también
+// los cuenta.
+func (r *Repository) GetVehicleByDomainEq(domain string, excludeID string) (bool, error) {
+	return taken(r.DB, &models.Vehicle{}, excludeID, "domain = ?", domain)
 }
 
-func (r *Repository) CreateVehicle(vehicle *models.Vehicle) (string, error) {
-	if err := r.DB.Create(vehicle).Error; err != nil {
+func (r *Repository) CreateVehicle(vehicle *models.Vehicle, actor models.Actor) (string, error) {
+	err := audited[models.Vehicle](r.DB, actor, models.AuditCreate, "vehicle", vehicle.ID, func(tx *gorm.DB) error {
+		return tx.Create(vehicle).Error
+	})
+	if err != nil {
 		return "", err
 	}
 	return vehicle.ID, nil
 }
 
-func (r *Repository) UpdateVehicle(vehicle *models.Vehicle) error {
-	var existing models.Vehicle
-	if err := r.DB.First(&existing, "id = ?", vehicle.ID).Error; err != nil {
-		return err 
-	}
+func (r *Repository) UpdateVehicle(vehicle *models.Vehicle, actor models.Actor) error {
+	return audited[models.Vehicle](r.DB, actor, models.AuditUpdate, "vehicle", vehicle.ID, func(tx *gorm.DB) error {
+		var existing models.Vehicle
+		if err := tx.First(&existing, "id = ?", vehicle.ID).Error; err != nil {
+			return err
+		}
+		if err := bumpVersion(tx, &models.Vehicle{}, vehicle.ID, vehicle.Version); err != nil {
+			return err
+		}
+		// la versión ya se incrementó: Updates no debe tocarla
+		return tx.Omit("version").Updates(vehicle).Error
+	})
+}
 
-	if err := r.DB.Updates(vehicle).Error; err != nil {
-		return err
-	}
-	return nil
+func (r *Repository) DeleteVehicle(id string, actor models.Actor) error {
+	return audited[models.Vehicle](r.DB, actor, models.AuditDelete, "vehicle", id, func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&models.Vehicle{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
 }
 
-func (r *Repository) DeleteVehicle(id string) error {
-	var vehicle models.Vehicle
-	if err := r.DB.Where("id = ?", id).Delete(&vehicle).Error; err != nil {
-		return err
-	}
-	return nil
+var vehicleList = listSpec{
+	fields: map[string]listField{
+		"domain":     {column: "domain", filter: filterContains},
+		"brand":      {column: "brand", filter: filterContains},
+		"model":      {column: "model", filter: filterContains},
+		"color":      {column: "color", filter: filterContains},
+		"year":       {column: "year", filter: filterEquals},
+		"category":   {column: "category", filter: filterEquals},
+		"client_id":  {column: "client_id", filter: filterEquals},
+		"created_at": {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "domain",
 }
 
-func (r *Repository) GetAllVehicles() ([]models.Vehicle, error) {
+func (r *Repository) GetAllVehicles(params *models.ListParams) ([]models.Vehicle, int64, error) {
 	var vehicles []models.Vehicle
-	if err := r.DB.Find(&vehicles).Error; err != nil {
-		return nil, err
+	total, err := paginate(r.DB.Model(&models.Vehicle{}), vehicleList, params, &vehicles)
+	if err != nil {
+		return nil, 0, err
 	}
-	return vehicles, nil
+	return vehicles, total, nil
 }
 
 func (r *Repository) GetVehicleByClientID(clientID string) (*[]models.Vehicle, error) {
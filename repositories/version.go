@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict indica que el registro cambió desde que el cliente lo leyó.
+var ErrVersionConflict = errors.New("la versión del registro no coincide")
+
+// bumpVersion incrementa la versión del registro id. Si expected no es 0, solo
+// lo hace cuando la versión actual es expected y si no devuelve
+// ErrVersionConflict. expected es 0 solo con If-Match: * (ver ifMatch en
+// controllers) o en actualizaciones internas que no parten de una lectura del
+// cliente. Tiene que correr en la misma transacción que el UPDATE
+// de los datos y antes que él: el UPDATE de la versión toma el lock de la fila,
+// así dos escrituras con la misma versión no pueden pasar las dos.
+func bumpVersion(tx *gorm.DB, model interface{}, id string, expected int64) error {
+	query := tx.Model(model).Where("id = ?", id)
+	if expected != 0 {
+		query = query.Where("version = ?", expected)
+	}
+	result := query.UpdateColumn("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrWorkOrderReference indica un vehículo que no es del cliente de la orden o
+// un empleado que no es del workplace.
+var ErrWorkOrderReference = errors.New("referencia inválida en la orden de trabajo")
+
+// ErrInvalidTransition indica un cambio de estado que la orden no admite.
+var ErrInvalidTransition = errors.New("cambio de estado no permitido")
+
+// ErrWorkOrderDelivered indica que la orden ya se entregó y no se puede tocar.
+var ErrWorkOrderDelivered = errors.New("la orden de trabajo ya fue entregada")
+
+func (r *Repository) GetWorkOrderByID(id string, workplaceID string) (*models.WorkOrder, error) {
+	var workOrder models.WorkOrder
+	err := r.DB.Preload("Transitions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, id")
+	}).Preload("Parts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, id")
+	}).Preload("Parts.Product", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("id = ? AND workplace_id = ?", id, workplaceID).First(&workOrder).Error
+	if err != nil {
+		return nil, err
+	}
+	return &workOrder, nil
+}
+
+var workOrderList = listSpec{
+	fields: map[string]listField{
+		"status":            {column: "status", filter: filterEquals},
+		"client_id":         {column: "client_id", filter: filterEquals},
+		"vehicle_id":        {column: "vehicle_id", filter: filterEquals},
+		"employee_id":       {column: "employee_id", filter: filterEquals},
+		"description":       {column: "description", filter: filterContains},
+		"status_changed_at": {column: "status_changed_at", filter: filterTime},
+		"created_at":        {column: "created_at", filter: filterTime},
+	},
+	defaultSort: "-created_at",
+}
+
+func (r *Repository) GetAllWorkOrders(workplaceID string, params *models.ListParams) (*[]models.WorkOrder, int64, error) {
+	var workOrders []models.WorkOrder
+	query := r.DB.Model(&models.WorkOrder{}).Where("workplace_id = ?", workplaceID)
+	total, err := paginate(query, workOrderList, params, &workOrders)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &workOrders, total, nil
+}
+
+// checkWorkOrderReferences verifica que el vehículo sea del cliente y el
+// empleado, si hay, del workplace.
+func checkWorkOrderReferences(tx *gorm.DB, clientID, vehicleID, employeeID, workplaceID string) error {
+	var count int64
+	if err := tx.Model(&models.Vehicle{}).Where("id = ? AND client_id = ?", vehicleID, clientID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: el vehículo no existe o no es del cliente", ErrWorkOrderReference)
+	}
+	if employeeID == "" {
+		return nil
+	}
+	if err := tx.Model(&models.Employee{}).Where("id = ? AND workplace_id = ?", employeeID, workplaceID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: el empleado no existe", ErrWorkOrderReference)
+	}
+	return nil
+}
+
+// transitionWorkOrder pasa la orden al estado to y registra la transición.
+// La versión ya tiene que estar incrementada.
+func transitionWorkOrder(tx *gorm.DB, workOrder *models.WorkOrder, to string, note string, actor models.Actor) error {
+	if !models.CanTransition(workOrder.Status, to) {
+		return fmt.Errorf("%w: de %s a %s", ErrInvalidTransition, workOrder.Status, to)
+	}
+	now := time.Now()
+	if err := tx.Create(&models.WorkOrderTransition{
+		ID:          uuid.NewString(),
+		WorkOrderID: workOrder.ID,
+		FromStatus:  workOrder.Status,
+		ToStatus:    to,
+		Note:        note,
+		UserID:      actor.UserID,
+		CreatedAt:   now,
+	}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.WorkOrder{}).Where("id = ?", workOrder.ID).Updates(map[string]interface{}{
+		"status":            to,
+		"status_changed_at": now,
+	}).Error
+}
+
+// lockWorkOrder lee la orden del workplace para modificarla e incrementa su
+// versión. Las órdenes entregadas no se modifican.
+func lockWorkOrder(tx *gorm.DB, id string, workplaceID string, version int64) (*models.WorkOrder, error) {
+	var workOrder models.WorkOrder
+	if err := tx.Where("id = ? AND workplace_id = ?", id, workplaceID).First(&workOrder).Error; err != nil {
+		return nil, err
+	}
+	if workOrder.Status == models.WorkOrderDelivered {
+		return nil, ErrWorkOrderDelivered
+	}
+	if err := bumpVersion(tx, &models.WorkOrder{}, id, version); err != nil {
+		return nil, err
+	}
+	return &workOrder, nil
+}
+
+func (r *Repository) CreateWorkOrder(workOrder *models.WorkOrderCreate, workplaceID string, actor models.Actor) (string, error) {
+	newID := uuid.NewString()
+	err := audited[models.WorkOrder](r.DB, actor, models.AuditCreate, "work_order", newID, func(tx *gorm.DB) error {
+		if err := checkWorkOrderReferences(tx, workOrder.ClientID, workOrder.VehicleID, workOrder.EmployeeID, workplaceID); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.Create(&models.WorkOrder{
+			ID:              newID,
+			WorkplaceID:     workplaceID,
+			ClientID:        workOrder.ClientID,
+			VehicleID:       workOrder.VehicleID,
+			EmployeeID:      workOrder.EmployeeID,
+			Description:     workOrder.Description,
+			Status:          models.WorkOrderReceived,
+			StatusChangedAt: now,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.WorkOrderTransition{
+			ID:          uuid.NewString(),
+			WorkOrderID: newID,
+			ToStatus:    models.WorkOrderReceived,
+			UserID:      actor.UserID,
+			CreatedAt:   now,
+		}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return newID, nil
+}
+
+func (r *Repository) UpdateWorkOrder(workOrder *models.WorkOrderUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.WorkOrder](r.DB, actor, models.AuditUpdate, "work_order", workOrder.ID, func(tx *gorm.DB) error {
+		existing, err := lockWorkOrder(tx, workOrder.ID, workplaceID, workOrder.Version)
+		if err != nil {
+			return err
+		}
+		if err := checkWorkOrderReferences(tx, existing.ClientID, existing.VehicleID, workOrder.EmployeeID, workplaceID); err != nil {
+			return err
+		}
+		return tx.Model(&models.WorkOrder{}).Where("id = ?", workOrder.ID).Updates(map[string]interface{}{
+			"employee_id": workOrder.EmployeeID,
+			"description": workOrder.Description,
+			"diagnosis":   workOrder.Diagnosis,
+		}).Error
+	})
+}
+
+func (r *Repository) ChangeWorkOrderStatus(id string, status *models.WorkOrderStatusUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.WorkOrder](r.DB, actor, models.AuditUpdate, "work_order", id, func(tx *gorm.DB) error {
+		workOrder, err := lockWorkOrder(tx, id, workplaceID, status.Version)
+		if err != nil {
+			return err
+		}
+		return transitionWorkOrder(tx, workOrder, status.Status, status.Note, actor)
+	})
+}
+
+// DeliverWorkOrder entrega una orden lista: en la misma transacción descuenta
+// del stock los repuestos reservados, crea el ingreso (con los precios
+// vigentes de los servicios, ver CreateIncome, más los repuestos), lo asocia a
+// la orden y la pasa a entregado. Devuelve el ID del ingreso.
+func (r *Repository) DeliverWorkOrder(id string, deliver *models.WorkOrderDeliver, workplaceID string, actor models.Actor) (string, error) {
+	incomeID := uuid.NewString()
+	err := audited[models.WorkOrder](r.DB, actor, models.AuditUpdate, "work_order", id, func(tx *gorm.DB) error {
+		workOrder, err := lockWorkOrder(tx, id, workplaceID, deliver.Version)
+		if err != nil {
+			return err
+		}
+		if !models.CanTransition(workOrder.Status, models.WorkOrderDelivered) {
+			return fmt.Errorf("%w: de %s a %s", ErrInvalidTransition, workOrder.Status, models.WorkOrderDelivered)
+		}
+
+		details := deliver.Details
+		if details == "" {
+			details = workOrder.Description
+		}
+		income := &models.IncomeCreate{
+			Ticket:         deliver.Ticket,
+			ServicesID:     deliver.ServicesID,
+			Details:        details,
+			ClientID:       workOrder.ClientID,
+			VehicleID:      workOrder.VehicleID,
+			EmployeeID:     workOrder.EmployeeID,
+			MovementTypeID: deliver.MovementTypeID,
+			Discount:       deliver.Discount,
+			DiscountReason: deliver.DiscountReason,
+		}
+		// el ingreso suma los repuestos de la orden asociada, ver incomeTotals
+		if err := tx.Model(&models.WorkOrder{}).Where("id = ?", id).Update("income_id", incomeID).Error; err != nil {
+			return err
+		}
+		err = audited[models.Income](tx, actor, models.AuditCreate, "income", incomeID, func(tx *gorm.DB) error {
+			return createIncome(tx, incomeID, income, workplaceID)
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := consumeWorkOrderParts(tx, id, actor); err != nil {
+			return err
+		}
+		return transitionWorkOrder(tx, workOrder, models.WorkOrderDelivered, deliver.Note, actor)
+	})
+	if err != nil {
+		return "", err
+	}
+	return incomeID, nil
+}
+
+// DeleteWorkOrderByID manda a la papelera una orden que no se entregó ni tiene
+// repuestos reservados; el historial de estados se conserva para poder
+// restaurarla.
+func (r *Repository) DeleteWorkOrderByID(id string, workplaceID string, actor models.Actor) error {
+	return audited[models.WorkOrder](r.DB, actor, models.AuditDelete, "work_order", id, func(tx *gorm.DB) error {
+		if _, err := lockWorkOrder(tx, id, workplaceID, 0); err != nil {
+			return err
+		}
+		var parts int64
+		if err := tx.Model(&models.WorkOrderPart{}).Where("work_order_id = ?", id).Count(&parts).Error; err != nil {
+			return err
+		}
+		if parts > 0 {
+			return ErrWorkOrderHasParts
+		}
+		return deleteScoped(tx, &models.WorkOrder{}, id, workplaceID)
+	})
+}
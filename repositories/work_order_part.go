@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrInsufficientStock indica que el producto no tiene stock disponible (sin
+// reservar) para cubrir la cantidad pedida.
+var ErrInsufficientStock = errors.New("stock insuficiente")
+
+// ErrDuplicatePart indica que el repuesto ya está en la orden: se modifica su
+// línea en vez de agregar otra.
+var ErrDuplicatePart = errors.New("el repuesto ya está cargado en la orden")
+
+// ErrWorkOrderHasParts indica una orden con repuestos reservados, que no se
+// puede eliminar hasta quitarlos.
+var ErrWorkOrderHasParts = errors.New("la orden tiene repuestos cargados, quitalos antes de eliminarla")
+
+// reserveStock reserva quantity unidades del producto, o las libera si es
+// negativa. Solo se reserva lo disponible, stock - reserved, así ninguna
+// entrega deja el stock en negativo.
+func reserveStock(tx *gorm.DB, actor models.Actor, productID string, quantity int32) error {
+	if quantity == 0 {
+		return nil
+	}
+	return audited[models.Product](tx, actor, models.AuditUpdate, "product", productID, func(tx *gorm.DB) error {
+		query := tx.Unscoped().Model(&models.Product{}).Where("id = ?", productID)
+		if quantity > 0 {
+			query = query.Where("stock - reserved >= ?", quantity)
+		}
+		result := query.UpdateColumns(map[string]interface{}{
+			"reserved": gorm.Expr("reserved + ?", quantity),
+			"version":  gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return insufficientStock(tx, productID)
+		}
+		return nil
+	})
+}
+
+// consumeStock descuenta del stock las unidades que estaban reservadas.
+func consumeStock(tx *gorm.DB, actor models.Actor, productID string, quantity int32) error {
+	return audited[models.Product](tx, actor, models.AuditUpdate, "product", productID, func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.Product{}).
+			Where("id = ? AND stock >= ? AND reserved >= ?", productID, quantity, quantity).
+			UpdateColumns(map[string]interface{}{
+				"stock":    gorm.Expr("stock - ?", quantity),
+				"reserved": gorm.Expr("reserved - ?", quantity),
+				"version":  gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return insufficientStock(tx, productID)
+		}
+		return nil
+	})
+}
+
+func insufficientStock(tx *gorm.DB, productID string) error {
+	var product models.Product
+	if err := tx.Unscoped().Select("name", "stock", "reserved").Where("id = ?", productID).First(&product).Error; err != nil {
+		return err
+	}
+	return insufficient(&product)
+}
+
+func insufficient(product *models.Product) error {
+	return fmt.Errorf("%w de %s: quedan %d disponibles", ErrInsufficientStock, product.Name, product.Stock-product.Reserved)
+}
+
+// workOrderPart busca la línea partID de la orden.
+func workOrderPart(tx *gorm.DB, workOrderID string, partID string) (*models.WorkOrderPart, error) {
+	var part models.WorkOrderPart
+	if err := tx.Where("id = ? AND work_order_id = ?", partID, workOrderID).First(&part).Error; err != nil {
+		return nil, err
+	}
+	return &part, nil
+}
+
+// AddWorkOrderPart agrega un repuesto a la orden y reserva su stock.
+func (r *Repository) AddWorkOrderPart(workOrderID string, part *models.WorkOrderPartCreate, workplaceID string, actor models.Actor) (string, error) {
+	newID := uuid.NewString()
+	err := audited[models.WorkOrder](r.DB, actor, models.AuditUpdate, "work_order", workOrderID, func(tx *gorm.DB) error {
+		if _, err := lockWorkOrder(tx, workOrderID, workplaceID, part.Version); err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&models.Product{}).Where("id = ? AND workplace_id = ?", part.ProductID, workplaceID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("%w: el repuesto no existe", ErrWorkOrderReference)
+		}
+		if err := tx.Model(&models.WorkOrderPart{}).Where("work_order_id = ? AND product_id = ?", workOrderID, part.ProductID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrDuplicatePart
+		}
+
+		if err := reserveStock(tx, actor, part.ProductID, part.Quantity); err != nil {
+			return err
+		}
+		return tx.Create(&models.WorkOrderPart{
+			ID:          newID,
+			WorkOrderID: workOrderID,
+			ProductID:   part.ProductID,
+			Quantity:    part.Quantity,
+			Price:       part.Price,
+		}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+	return newID, nil
+}
+
+// UpdateWorkOrderPart cambia la cantidad o el precio de una línea; la reserva
+// se ajusta por la diferencia.
+func (r *Repository) UpdateWorkOrderPart(workOrderID string, part *models.WorkOrderPartUpdate, workplaceID string, actor models.Actor) error {
+	return audited[models.WorkOrder](r.DB, actor, models.AuditUpdate, "work_order", workOrderID, func(tx *gorm.DB) error {
+		if _, err := lockWorkOrder(tx, workOrderID, workplaceID, part.Version); err != nil {
+			return err
+		}
+		existing, err := workOrderPart(tx, workOrderID, part.ID)
+		if err != nil {
+			return err
+		}
+		if err := reserveStock(tx, actor, existing.ProductID, part.Quantity-existing.Quantity); err != nil {
+			return err
+		}
+		return tx.Model(&models.WorkOrderPart{}).Where("id = ?", part.ID).Updates(map[string]interface{}{
+			"quantity": part.Quantity,
+			"price":    part.Price,
+		}).Error
+	})
+}
+
+// RemoveWorkOrderPart quita una línea de la orden y libera su reserva.
+func (r *Repository) RemoveWorkOrderPart(workOrderID string, partID string, version int64, workplaceID string, actor models.Actor) error {
+	return audited[models.WorkOrder](r.DB, actor, models.AuditUpdate, "work_order", workOrderID, func(tx *gorm.DB) error {
+		if _, err := lockWorkOrder(tx, workOrderID, workplaceID, version); err != nil {
+			return err
+		}
+		existing, err := workOrderPart(tx, workOrderID, partID)
+		if err != nil {
+			return err
+		}
+		if err := reserveStock(tx, actor, existing.ProductID, -existing.Quantity); err != nil {
+			return err
+		}
+		return tx.Delete(existing).Error
+	})
+}
+
+// consumeWorkOrderParts descuenta del stock los repuestos de la orden al
+// entregarla.
+func consumeWorkOrderParts(tx *gorm.DB, workOrderID string, actor models.Actor) error {
+	var parts []models.WorkOrderPart
+	if err := tx.Where("work_order_id = ?", workOrderID).Order("product_id").Find(&parts).Error; err != nil {
+		return err
+	}
+	for _, part := range parts {
+		if err := consumeStock(tx, actor, part.ProductID, part.Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
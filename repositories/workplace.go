@@ -2,7 +2,7 @@ package repositories
 
 import (
 	"github.com/DanielChachagua/GestionCar/models"
-	"github.com/DanielChachagua/GestionCar/utils"
+	"gorm.io/gorm"
 )
 
 func (r *Repository) GetWorkplaceByID(id string) (*models.Workplace, error) {
@@ -14,43 +14,38 @@ func (r *Repository) GetWorkplaceByID(id string) (*models.Workplace, error) {
 	return &workplace, nil
 }
 
-func (r *Repository) GetWorkplaceLaundry() (*models.Workplace, error) {
+func (r *Repository) GetWorkplaceByIdentifier(identifier string) (*models.Workplace, error) {
 	var workplace models.Workplace
-		err := r.DB.First(&workplace, "identifier = ?", "laundry").Error
+		err := r.DB.First(&workplace, "identifier = ?", identifier).Error
 	if err != nil {
 		return nil, err
 	}
 	return &workplace, nil
 }
 
-func (r *Repository) GetWorkplaceWorkshop() (*models.Workplace, error) {
-	var workplace models.Workplace
-		err := r.DB.First(&workplace, "identifier = ?", "workshop").Error
-	if err != nil {
+func (r *Repository) GetWorkplaceAll(role string) (*[]models.Workplace, error) {
+	var currentRole models.Role
+	if err := r.DB.Where("name = ?", role).First(&currentRole).Error; err != nil {
 		return nil, err
 	}
-	return &workplace, nil
-}
 
-func (r *Repository) GetWorkplaceAll(role string) (*[]models.Workplace, error) {
 	var workplaces []models.Workplace
-	if utils.Contains([]string{"admin_laundry", "employee_laundry"}, role) {
-		err := r.DB.Where("identifier = ?", "laundry").Find(&workplaces).Error
-		if err != nil {
-			return nil, err
-		}
-		return &workplaces, nil
-	} else if utils.Contains([]string{"admin_workshop", "employee_workshop"}, role) {
-		err := r.DB.Where("identifier = ?", "workshop").Find(&workplaces).Error
-		if err != nil {
-			return nil, err
-		}
-		return &workplaces, nil
-	} else {
-		err := r.DB.Find(&workplaces).Error
-		if err != nil {
-			return nil, err
-		}
-		return &workplaces, nil
+	query := r.DB
+	if currentRole.Workplace != "all" {
+		query = query.Where("identifier = ?", currentRole.Workplace)
+	}
+	if err := query.Find(&workplaces).Error; err != nil {
+		return nil, err
+	}
+	return &workplaces, nil
+}
+
+func (r *Repository) CreateWorkplace(workplace *models.Workplace, actor models.Actor) (string, error) {
+	err := audited[models.Workplace](r.DB, actor, models.AuditCreate, "workplace", workplace.ID, func(tx *gorm.DB) error {
+		return tx.Create(workplace).Error
+	})
+	if err != nil {
+		return "", err
 	}
+	return workplace.ID, nil
 }
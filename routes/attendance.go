@@ -1,18 +1,19 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func AttendanceRoutes(app *fiber.App){
-	att := app.Group("/attendance", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.GetAllAttendances)
-	att.Post("/get_by_date", controllers.GetAllAttendancesByDate)
-	att.Post("/create", controllers.CreateAttendance)
-	att.Put("/update", controllers.UpdateAttendance)
-	att.Get("/get_by_employee/:employee_id", controllers.GetAttendanceByEmployeeID)
-	att.Delete("/delete/:id", controllers.DeleteAttendance)
-	att.Get("/:id", controllers.GetAttendanceByID)
+func AttendanceRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/attendance", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.AttendanceController.GetAllAttendances)
+	att.Post("/get_by_date", dep.AttendanceController.GetAllAttendancesByDate)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermAttendanceCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.AttendanceController.CreateAttendance)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermAttendanceUpdate), dep.AttendanceController.UpdateAttendance)
+	att.Get("/get_by_employee/:employee_id", dep.AttendanceController.GetAttendanceByEmployeeID)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermAttendanceDelete), dep.AttendanceController.DeleteAttendance)
+	att.Get("/:id", dep.AttendanceController.GetAttendanceByID)
 }
\ No newline at end of file
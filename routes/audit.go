@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/DanielChachagua/GestionCar/dependencies"
+	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func AuditRoutes(app *fiber.App, dep *dependencies.Dependency) {
+	audit := app.Group(
+		"/audit",
+		middleware.AuthMiddleware(dep.AuthService),
+		middleware.PermissionMiddleware(dep.PermissionService, models.PermAuditRead),
+	)
+	audit.Get("/get_all", dep.AuditController.AuditGetAll)
+	audit.Get("/:id", dep.AuditController.AuditGetByID)
+}
@@ -1,13 +1,25 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func AuthRoutes(app *fiber.App){
+func AuthRoutes(app *fiber.App, dep *dependencies.Dependency){
 	auth := app.Group("/auth")
-	auth.Post("/login", controllers.AuthLogin)
-	auth.Get("/workplace_login/:workplace_id", middleware.AuthMiddleware(), controllers.AuthWorkplace)
+	auth.Post("/login", dep.AuthController.AuthLogin)
+	auth.Post("/refresh", dep.AuthController.AuthRefresh)
+	auth.Post("/logout", middleware.AuthMiddleware(dep.AuthService), dep.AuthController.AuthLogout)
+	auth.Post("/change_password", middleware.AuthMiddleware(dep.AuthService), dep.PasswordController.ChangePassword)
+	auth.Post("/forgot_password", dep.PasswordController.ForgotPassword)
+	auth.Post("/reset_password", dep.PasswordController.ResetPassword)
+	auth.Get(
+		"/login_history",
+		middleware.AuthMiddleware(dep.AuthService),
+		middleware.PermissionMiddleware(dep.PermissionService, models.PermLoginHistoryRead),
+		dep.AuthController.LoginHistory,
+	)
+	auth.Get("/workplace_login/:workplace_id", middleware.AuthMiddleware(dep.AuthService), dep.AuthController.AuthWorkplace)
 }
\ No newline at end of file
@@ -1,17 +1,18 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func ClientRoutes(app *fiber.App){
-	att := app.Group("/client", middleware.AuthMiddleware())
-	att.Get("/get_all", controllers.ClientGetAll)
-	att.Get("/get_by_name", controllers.ClientGetByName)
-	att.Post("/create", controllers.CreateClient)
-	att.Put("/update", controllers.ClientUpdate)
-	att.Delete("/delete/:id", controllers.ClientDelete)
-	att.Get("/:id", controllers.ClientGetByID)
+func ClientRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/client", middleware.AuthMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.ClientController.ClientGetAll)
+	att.Get("/get_by_name", dep.ClientController.ClientGetByName)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermClientCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.ClientController.CreateClient)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermClientUpdate), dep.ClientController.ClientUpdate)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermClientDelete), dep.ClientController.ClientDelete)
+	att.Get("/:id", dep.ClientController.ClientGetByID)
 }
\ No newline at end of file
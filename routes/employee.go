@@ -1,17 +1,18 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func EmployeeRoutes(app *fiber.App){
-	att := app.Group("/employee", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.GetAllEmployees)
-	att.Get("/get_by_name", controllers.GetEmployeeByName)
-	att.Post("/create", controllers.CreateEmployee)
-	att.Put("/update", controllers.UpdateEmployee)
-	att.Delete("/delete/:id", controllers.DeleteEmployee)
-	att.Get("/:id", controllers.GetEmployeeByID)
+func EmployeeRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/employee", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.EmployeeController.GetAllEmployees)
+	att.Get("/get_by_name", dep.EmployeeController.GetEmployeeByName)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermEmployeeCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.EmployeeController.CreateEmployee)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermEmployeeUpdate), dep.EmployeeController.UpdateEmployee)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermEmployeeDelete), dep.EmployeeController.DeleteEmployee)
+	att.Get("/:id", dep.EmployeeController.GetEmployeeByID)
 }
\ No newline at end of file
@@ -1,17 +1,18 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func ExpenseRoutes(app *fiber.App){
-	att := app.Group("/expense", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.GetAllExpenses)
-	att.Get("/get_today", controllers.GetExpenseToday)
-	att.Post("/create", controllers.CreateExpense)
-	att.Put("/update", controllers.UpdateExpense)
-	att.Delete("/delete/:id", controllers.DeleteExpense)
-	att.Get("/:id", controllers.GetExpenseByID)
+func ExpenseRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/expense", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.ExpenseController.GetAllExpenses)
+	att.Get("/get_today", dep.ExpenseController.GetExpenseToday)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermExpenseCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.ExpenseController.CreateExpense)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermExpenseUpdate), dep.ExpenseController.UpdateExpense)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermExpenseDelete), dep.ExpenseController.DeleteExpense)
+	att.Get("/:id", dep.ExpenseController.GetExpenseByID)
 }
\ No newline at end of file
@@ -1,17 +1,18 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func IncomeRoutes(app *fiber.App){
-	att := app.Group("/income", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.GetAllIncomes)
-	att.Get("/get_today", controllers.GetIncomeToday)
-	att.Post("/create", controllers.CreateIncome)
-	att.Put("/update", controllers.UpdateIncome)
-	att.Delete("/delete/:id", controllers.DeleteIncome)
-	att.Get("/:id", controllers.GetIncomeByID)
+func IncomeRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/income", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.IncomeController.GetAllIncomes)
+	att.Get("/get_today", dep.IncomeController.GetIncomeToday)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermIncomeCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.IncomeController.CreateIncome)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermIncomeUpdate), dep.IncomeController.UpdateIncome)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermIncomeDelete), dep.IncomeController.DeleteIncome)
+	att.Get("/:id", dep.IncomeController.GetIncomeByID)
 }
\ No newline at end of file
@@ -1,16 +1,17 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func MovementRoutes(app *fiber.App){
-	att := app.Group("/movement", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.GetAllMovementTypes)
-	att.Post("/create", controllers.MovementTypeCreate)
-	att.Put("/update", controllers.MovementTypeUpdate)
-	att.Delete("/delete/:id", controllers.MovementTypeDelete)
-	att.Get("/:id", controllers.GetMovementTypeByID)
+func MovementRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/movement", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.MovementTypeController.GetAllMovementTypes)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermMovementTypeCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.MovementTypeController.MovementTypeCreate)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermMovementTypeUpdate), dep.MovementTypeController.MovementTypeUpdate)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermMovementTypeDelete), dep.MovementTypeController.MovementTypeDelete)
+	att.Get("/:id", dep.MovementTypeController.GetMovementTypeByID)
 }
\ No newline at end of file
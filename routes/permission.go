@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/DanielChachagua/GestionCar/dependencies"
+	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func PermissionRoutes(app *fiber.App, dep *dependencies.Dependency) {
+	permission := app.Group(
+		"/permission",
+		middleware.AuthMiddleware(dep.AuthService),
+		middleware.PermissionMiddleware(dep.PermissionService, models.PermPermissionManage),
+	)
+	permission.Get("/get_all", dep.PermissionController.GetAllPermissions)
+	permission.Get("/role/:role", dep.PermissionController.GetRolePermissions)
+	permission.Put("/role/:role", dep.PermissionController.UpdateRolePermissions)
+}
@@ -1,19 +1,20 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func ProductRoutes(app *fiber.App){
-	att := app.Group("/product", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.ProductGetAll)
-	att.Get("/get_by_name", controllers.ProductGetByName)
-	att.Get("/get_by_identifier", controllers.ProductGetByIdentifier)
-	att.Post("/create", controllers.ProductCreate)
-	att.Put("/update", controllers.ProductUpdate)
-	att.Put("/update_stock/:id", controllers.ProductUpdateStock)
-	att.Delete("/delete/:id", controllers.ProductDelete)
-	att.Get("/:id", controllers.ProductGetByID)
+func ProductRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/product", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.ProductController.ProductGetAll)
+	att.Get("/get_by_name", dep.ProductController.ProductGetByName)
+	att.Get("/get_by_identifier", dep.ProductController.ProductGetByIdentifier)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermProductCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.ProductController.ProductCreate)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermProductUpdate), dep.ProductController.ProductUpdate)
+	att.Put("/update_stock/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermProductStockAdjust), dep.ProductController.ProductUpdateStock)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermProductDelete), dep.ProductController.ProductDelete)
+	att.Get("/:id", dep.ProductController.ProductGetByID)
 }
\ No newline at end of file
@@ -1,16 +1,17 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func PurchaseOrderRoutes(app *fiber.App){
-	att := app.Group("/purchase_order", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.PurchaseOrderGetAll)
-	att.Post("/create", controllers.PurchaseOrderCreate)
-	att.Put("/update", controllers.PurchaseOrderUpdate)
-	att.Delete("/delete/:id", controllers.PurchaseOrderDelete)
-	att.Get("/:id", controllers.PurchaseOrderGetByID)
+func PurchaseOrderRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/purchase_order", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.PurchaseOrderController.PurchaseOrderGetAll)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermPurchaseOrderCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.PurchaseOrderController.PurchaseOrderCreate)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermPurchaseOrderUpdate), dep.PurchaseOrderController.PurchaseOrderUpdate)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermPurchaseOrderDelete), dep.PurchaseOrderController.PurchaseOrderDelete)
+	att.Get("/:id", dep.PurchaseOrderController.PurchaseOrderGetByID)
 }
\ No newline at end of file
@@ -1,16 +1,17 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func PurchaseProductRoutes(app *fiber.App){
-	att := app.Group("/purchase_product", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_purchase/:purchase_id", controllers.PurchaseProductGetAllByPurhcaseID)
-	att.Post("/create", controllers.PurchaseProductCreate)
-	att.Put("/update", controllers.PurchaseProductUpdate)
-	att.Delete("/delete/:id", controllers.PurchaseProductDelete)
-	att.Get("/:id", controllers.PurchaseProductGetByID)
+func PurchaseProductRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/purchase_product", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_purchase/:purchase_id", dep.PurchaseProductController.PurchaseProductGetAllByPurhcaseID)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermPurchaseOrderUpdate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.PurchaseProductController.PurchaseProductCreate)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermPurchaseOrderUpdate), dep.PurchaseProductController.PurchaseProductUpdate)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermPurchaseOrderUpdate), dep.PurchaseProductController.PurchaseProductDelete)
+	att.Get("/:id", dep.PurchaseProductController.PurchaseProductGetByID)
 }
\ No newline at end of file
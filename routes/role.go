@@ -1,12 +1,12 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
 	"github.com/gofiber/fiber/v2"
 )
 
-func RoleRoutes(app *fiber.App){
-	att := app.Group("/role", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.GetRolesWorkplace)
+func RoleRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/role", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.RoleController.GetRolesWorkplace)
 }
\ No newline at end of file
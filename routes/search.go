@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/DanielChachagua/GestionCar/dependencies"
+	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SearchRoutes(app *fiber.App, dep *dependencies.Dependency) {
+	app.Get("/search", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService), dep.SearchController.Search)
+}
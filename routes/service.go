@@ -1,16 +1,19 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func ServiceRoutes(app *fiber.App){
-	att := app.Group("/service", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.ServiceGetAll)
-	att.Post("/create", controllers.ServiceCreate)
-	att.Put("/update", controllers.ServiceUpdate)
-	att.Delete("/delete/:id", controllers.ServiceDeleteByID)
-	att.Get("/:id", controllers.ServiceGetByID)
+func ServiceRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/service", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.ServiceController.ServiceGetAll)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermServiceCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.ServiceController.ServiceCreate)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermServiceUpdate), dep.ServiceController.ServiceUpdate)
+	att.Get("/prices/:id", dep.ServiceController.ServicePrices)
+	att.Post("/set_price/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermServiceUpdate), dep.ServiceController.ServiceSetPrice)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermServiceDelete), dep.ServiceController.ServiceDeleteByID)
+	att.Get("/:id", dep.ServiceController.ServiceGetByID)
 }
\ No newline at end of file
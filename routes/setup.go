@@ -1,22 +1,30 @@
 package routes
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"github.com/DanielChachagua/GestionCar/dependencies"
+	"github.com/gofiber/fiber/v2"
+)
 
-func SetupRoutes(app *fiber.App) {
-	AttendanceRoutes(app)
-	AuthRoutes(app)
-	ClientRoutes(app)
-	EmployeeRoutes(app)
-	ExpenseRoutes(app)
-	IncomeRoutes(app)
-	MovementRoutes(app)
-	ProductRoutes(app)
-	PurchaseOrderRoutes(app)
-	PurchaseProductRoutes(app)
-	RoleRoutes(app)
-	ServiceRoutes(app)
-	SupplierRoutes(app)
-	UserRoutes(app)
-	VehicleRoutes(app)
-	WorkplaceRoutes(app)
+func SetupRoutes(app *fiber.App, dep *dependencies.Dependency) {
+	AttendanceRoutes(app, dep)
+	AuditRoutes(app, dep)
+	AuthRoutes(app, dep)
+	ClientRoutes(app, dep)
+	EmployeeRoutes(app, dep)
+	ExpenseRoutes(app, dep)
+	IncomeRoutes(app, dep)
+	MovementRoutes(app, dep)
+	PermissionRoutes(app, dep)
+	ProductRoutes(app, dep)
+	PurchaseOrderRoutes(app, dep)
+	PurchaseProductRoutes(app, dep)
+	RoleRoutes(app, dep)
+	SearchRoutes(app, dep)
+	ServiceRoutes(app, dep)
+	SupplierRoutes(app, dep)
+	TrashRoutes(app, dep)
+	UserRoutes(app, dep)
+	VehicleRoutes(app, dep)
+	WorkOrderRoutes(app, dep)
+	WorkplaceRoutes(app, dep)
 }
\ No newline at end of file
@@ -1,17 +1,18 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func SupplierRoutes(app *fiber.App){
-	att := app.Group("/supplier", middleware.AuthMiddleware(), middleware.WorkplaceMiddleware())
-	att.Get("/get_all", controllers.SupplierGetAll)
-	att.Get("/get_by_name", controllers.SupplierGetByName)
-	att.Post("/create", controllers.SupplierCreate)
-	att.Put("/update", controllers.SupplierUpdate)
-	att.Delete("/delete/:id", controllers.SupplierDeleteByID)
-	att.Get("/:id", controllers.SupplierGetByID)
+func SupplierRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/supplier", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.SupplierController.SupplierGetAll)
+	att.Get("/get_by_name", dep.SupplierController.SupplierGetByName)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermSupplierCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.SupplierController.SupplierCreate)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermSupplierUpdate), dep.SupplierController.SupplierUpdate)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermSupplierDelete), dep.SupplierController.SupplierDeleteByID)
+	att.Get("/:id", dep.SupplierController.SupplierGetByID)
 }
\ No newline at end of file
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/DanielChachagua/GestionCar/dependencies"
+	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TrashRoutes(app *fiber.App, dep *dependencies.Dependency) {
+	trash := app.Group(
+		"/trash",
+		middleware.AuthMiddleware(dep.AuthService),
+		middleware.WorkplaceMiddleware(dep.AuthService),
+		middleware.PermissionMiddleware(dep.PermissionService, models.PermTrashManage),
+	)
+	trash.Get("/:entity", dep.TrashController.GetTrash)
+	trash.Put("/:entity/restore/:id", dep.TrashController.RestoreTrash)
+	trash.Delete("/:entity/purge/:id", dep.TrashController.PurgeTrash)
+}
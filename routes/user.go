@@ -1,17 +1,27 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func UserRoutes(app *fiber.App) {
-	auth := app.Group("/user")
+func UserRoutes(app *fiber.App, dep *dependencies.Dependency) {
+	auth := app.Group("/user", middleware.AuthMiddleware(dep.AuthService))
+	auth.Get("/me", dep.UserController.GetMe)
+	auth.Put("/me", dep.UserController.UpdateMe)
+	auth.Get("/get_all", middleware.PermissionMiddleware(dep.PermissionService, models.PermUserRead), dep.UserController.GetAllUsers)
 	auth.Post(
 		"/create", 
-		middleware.AuthMiddleware(), 
-		middleware.RoleAuthMiddleware([]string{"super_admin","admin"}), 
-		controllers.CreateUser,
+		middleware.PermissionMiddleware(dep.PermissionService, models.PermUserCreate), 
+		middleware.IdempotencyMiddleware(dep.IdempotencyService),
+		dep.UserController.CreateUser,
 	)
-}
\ No newline at end of file
+	auth.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermUserUpdate), dep.UserController.UpdateUser)
+	auth.Put("/update_role/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermUserUpdate), dep.UserController.UpdateUserRole)
+	auth.Put("/activate/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermUserUpdate), dep.UserController.ActivateUser)
+	auth.Put("/deactivate/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermUserUpdate), dep.UserController.DeactivateUser)
+	auth.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermUserDelete), dep.UserController.DeleteUser)
+	auth.Get("/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermUserRead), dep.UserController.GetUserByID)
+}
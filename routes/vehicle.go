@@ -1,18 +1,20 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func VehicleRoutes(app *fiber.App){
-	att := app.Group("/vehicle", middleware.AuthMiddleware())
-	att.Get("/get_all", controllers.VehicleGetAll)
-	att.Get("/get_by_domain", controllers.VehicleGetByDomain)
-	att.Post("/create", controllers.VehicleCreate)
-	att.Put("/update", controllers.VehicleUpdate)
-	att.Get("/get_by_client/:client_id", controllers.VehicleGetByClientID)
-	att.Delete("/delete/:id", controllers.VehicleDelete)
-	att.Get("/:id", controllers.VehicleGetByID)
+func VehicleRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/vehicle", middleware.AuthMiddleware(dep.AuthService))
+	att.Get("/get_all", dep.VehicleController.VehicleGetAll)
+	att.Get("/get_by_domain", dep.VehicleController.VehicleGetByDomain)
+	att.Get("/categories", dep.VehicleController.VehicleCategories)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermVehicleCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.VehicleController.VehicleCreate)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermVehicleUpdate), dep.VehicleController.VehicleUpdate)
+	att.Get("/get_by_client/:client_id", dep.VehicleController.VehicleGetByClientID)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermVehicleDelete), dep.VehicleController.VehicleDelete)
+	att.Get("/:id", dep.VehicleController.VehicleGetByID)
 }
\ No newline at end of file
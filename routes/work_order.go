@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/DanielChachagua/GestionCar/dependencies"
+	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func WorkOrderRoutes(app *fiber.App, dep *dependencies.Dependency){
+	att := app.Group("/work_order", middleware.AuthMiddleware(dep.AuthService), middleware.WorkplaceMiddleware(dep.AuthService), middleware.WorkOrdersMiddleware())
+	att.Get("/get_all", dep.WorkOrderController.GetAllWorkOrders)
+	att.Post("/create", middleware.PermissionMiddleware(dep.PermissionService, models.PermWorkOrderCreate), middleware.IdempotencyMiddleware(dep.IdempotencyService), dep.WorkOrderController.CreateWorkOrder)
+	att.Put("/update", middleware.PermissionMiddleware(dep.PermissionService, models.PermWorkOrderUpdate), dep.WorkOrderController.UpdateWorkOrder)
+	att.Put("/status/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermWorkOrderUpdate), dep.WorkOrderController.ChangeWorkOrderStatus)
+	att.Post("/deliver/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermWorkOrderDeliver), dep.WorkOrderController.DeliverWorkOrder)
+	att.Post("/add_part/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermWorkOrderUpdate), dep.WorkOrderController.AddWorkOrderPart)
+	att.Put("/update_part/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermWorkOrderUpdate), dep.WorkOrderController.UpdateWorkOrderPart)
+	att.Delete("/remove_part/:id/:part_id", middleware.PermissionMiddleware(dep.PermissionService, models.PermWorkOrderUpdate), dep.WorkOrderController.RemoveWorkOrderPart)
+	att.Delete("/delete/:id", middleware.PermissionMiddleware(dep.PermissionService, models.PermWorkOrderDelete), dep.WorkOrderController.DeleteWorkOrder)
+	att.Get("/:id", dep.WorkOrderController.GetWorkOrderByID)
+}
\ No newline at end of file
@@ -1,12 +1,20 @@
 package routes
 
 import (
-	"github.com/DanielChachagua/GestionCar/controllers"
+	"github.com/DanielChachagua/GestionCar/dependencies"
 	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/gofiber/fiber/v2"
 )
 
-func WorkplaceRoutes(app *fiber.App){
+func WorkplaceRoutes(app *fiber.App, dep *dependencies.Dependency){
 	auth := app.Group("/workplace")
-	auth.Get("/get_all", middleware.AuthMiddleware(), controllers.GetWorkplaces)
+	auth.Get("/get_all", middleware.AuthMiddleware(dep.AuthService), dep.WorkplaceController.GetWorkplaces)
+	auth.Post(
+		"/create",
+		middleware.AuthMiddleware(dep.AuthService),
+		middleware.PermissionMiddleware(dep.PermissionService, models.PermWorkplaceCreate),
+		middleware.IdempotencyMiddleware(dep.IdempotencyService),
+		dep.WorkplaceController.CreateWorkplace,
+	)
 }
\ No newline at end of file
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"github.com/DanielChachagua/GestionCar/config"
+	"github.com/DanielChachagua/GestionCar/dependencies"
+	"github.com/DanielChachagua/GestionCar/middleware"
+	"github.com/DanielChachagua/GestionCar/routes"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/swagger"
+	"gorm.io/gorm"
+)
+
+// New arma la aplicación Fiber completa sobre una base ya migrada.
+// La usan main.go y las pruebas end-to-end.
+func New(db *gorm.DB, cfg *config.Config) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: middleware.ErrorHandler,
+		// c.IP() toma la IP de ProxyHeader solo si la conexión viene de un
+		// proxy de confianza; el login la usa para limitar intentos.
+		ProxyHeader:             cfg.ProxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          cfg.TrustedProxies,
+		EnableIPValidation:      true,
+	})
+
+	app.Use(cors.New(cors.Config{
+		AllowOrigins:     "*",
+		AllowMethods:     "*",
+		AllowHeaders:     "*",
+		AllowCredentials: false,
+	}))
+
+	dep := dependencies.NewDependency(db, cfg)
+
+	app.Use(middleware.LoggingMiddleware)
+
+	routes.SetupRoutes(app, dep)
+
+	app.Get("/swagger/*", swagger.HandlerDefault)
+
+	return app
+}
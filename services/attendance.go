@@ -8,67 +8,80 @@ import (
 	"gorm.io/gorm"
 )
 
-func GetAttendanceByID(id string, workplace string) (*models.AttendanceLaundry, *models.AttendanceWorkshop, error) {
-	attendanceLaundry, attendanceWorkshop, err := repositories.Repo.GetAttendanceByID(id, workplace)
+type AttendanceService struct {
+	repo repositories.AttendanceRepository
+}
+
+func NewAttendanceService(repo repositories.AttendanceRepository) *AttendanceService {
+	return &AttendanceService{
+		repo: repo,
+	}
+}
+
+func (s *AttendanceService) GetAttendanceByID(id string, workplaceID string) (*models.Attendance, error) {
+	attendance, err := s.repo.GetAttendanceByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Empleado no encontrado", err)
+			return nil, models.NotFound("Empleado no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return attendanceLaundry, attendanceWorkshop, nil
+	return attendance, nil
 }
 
-func GetAllAttendances(workplace string) (*[]models.AttendanceLaundry, *[]models.AttendanceWorkshop, error) {
-	attendancesLaundry, attendancesWorkshop, err := repositories.Repo.GetAllAttendances(workplace)
+func (s *AttendanceService) GetAllAttendances(workplaceID string, params *models.ListParams) (*[]models.Attendance, *models.Pagination, error) {
+	attendances, total, err := s.repo.GetAllAttendances(workplaceID, params)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, nil, listError(err, "Error al buscar asistencias")
 	}
-	return attendancesLaundry, attendancesWorkshop, nil
+	return attendances, models.NewPagination(params, total), nil
 }
 
-func GetAllAttendancesByDate(date_start string, date_end string, workplace string) (*[]models.AttendanceLaundry, *[]models.AttendanceWorkshop, error) {
-	attendancesLaundry, attendancesWorkshop, err := repositories.Repo.GetAttendancesByDate(date_start, date_end, workplace)
+func (s *AttendanceService) GetAllAttendancesByDate(date_start string, date_end string, workplaceID string) (*[]models.Attendance, error) {
+	attendances, err := s.repo.GetAttendancesByDate(date_start, date_end, workplaceID)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return attendancesLaundry, attendancesWorkshop, nil
+	return attendances, nil
 }
 
-func GetAttendanceByEmployeeID(employeeID string, workplace string) (*[]models.AttendanceLaundry, *[]models.AttendanceWorkshop, error) {
-	attendancesLaundry, attendancesWorkshop, err := repositories.Repo.GetAttendanceByEmployeeID(employeeID, workplace)
+func (s *AttendanceService) GetAttendanceByEmployeeID(employeeID string, workplaceID string) (*[]models.Attendance, error) {
+	attendances, err := s.repo.GetAttendanceByEmployeeID(employeeID, workplaceID)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return attendancesLaundry, attendancesWorkshop, nil
+	return attendances, nil
 }
 
-func CreateAttendance(attendance *models.AttendanceCreate, workplace string) (string, error) {
-	id, err := repositories.Repo.CreateAttendance(attendance, workplace)
+func (s *AttendanceService) CreateAttendance(attendance *models.AttendanceCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.CreateAttendance(attendance, workplaceID, actor)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return "", models.Internal("Error al actualizar cliente", err)
 	}
 	return id, nil
 }
 
-func UpdateAttendance(attendance *models.AttendanceUpdate, workplace string) error {
-	err := repositories.Repo.UpdateAttendance(attendance, workplace)
+func (s *AttendanceService) UpdateAttendance(attendance *models.AttendanceUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdateAttendance(attendance, workplaceID, actor)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.Attendance, error) { return s.repo.GetAttendanceByID(attendance.ID, workplaceID) })
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 	return nil
 }
 
-func DeleteAttendance(id string, workplace string) error {
-	err := repositories.Repo.DeleteAttendance(id, workplace)
+func (s *AttendanceService) DeleteAttendance(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeleteAttendance(id, workplaceID, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/repositories"
+	"gorm.io/gorm"
+)
+
+type AuditService struct {
+	repo repositories.AuditLogRepository
+}
+
+func NewAuditService(repo repositories.AuditLogRepository) *AuditService {
+	return &AuditService{
+		repo: repo,
+	}
+}
+
+func (s *AuditService) AuditGetAll(params *models.ListParams) (*[]models.AuditLog, *models.Pagination, error) {
+	audits, total, err := s.repo.ListAuditLogs(params)
+	if err != nil {
+		return nil, nil, listError(err, "Error al buscar la auditoría")
+	}
+	return &audits, models.NewPagination(params, total), nil
+}
+
+func (s *AuditService) AuditGetByID(id string) (*models.AuditLog, error) {
+	audit, err := s.repo.GetAuditLogByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.NotFound("Registro de auditoría no encontrado", err)
+		}
+		return nil, models.Internal("Error al buscar el registro de auditoría", err)
+	}
+	return audit, nil
+}
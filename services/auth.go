@@ -2,84 +2,379 @@ package services
 
 import (
 	"errors"
+	"sync"
+	"time"
 
+	"github.com/DanielChachagua/GestionCar/config"
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/DanielChachagua/GestionCar/repositories"
 	"github.com/DanielChachagua/GestionCar/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-func AuthLogin(username, password string) (string, error) {
-	user, err := repositories.Repo.GetUserByUsername(username)
+type AuthService struct {
+	users      repositories.UserRepository
+	workplaces repositories.WorkplaceRepository
+	roles      repositories.RoleRepository
+	tokens     repositories.TokenRepository
+	attempts   repositories.LoginAttemptRepository
+	auth       config.Auth
+	login      config.Login
+}
+
+func NewAuthService(users repositories.UserRepository, workplaces repositories.WorkplaceRepository, roles repositories.RoleRepository, tokens repositories.TokenRepository, attempts repositories.LoginAttemptRepository, auth config.Auth, login config.Login) *AuthService {
+	return &AuthService{
+		users:      users,
+		workplaces: workplaces,
+		roles:      roles,
+		tokens:     tokens,
+		attempts:   attempts,
+		auth:       auth,
+		login:      login,
+	}
+}
+
+// LoginClient identifica desde dónde se intenta el login.
+type LoginClient struct {
+	IP        string
+	UserAgent string
+}
+
+// dummyPasswordHash se compara cuando el usuario no existe, así la respuesta
+// tarda lo mismo y no delata qué usernames están registrados.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := utils.HashPassword(uuid.NewString())
+	return hash
+})
+
+// AuthLogin valida las credenciales y registra el intento en el historial.
+// Usuario inexistente y contraseña incorrecta dan el mismo error. Tras
+// config.Login.MaxAttempts fallos para el username (o MaxAttemptsPerIP para
+// la IP) se rechaza todo intento hasta que pase el bloqueo.
+func (s *AuthService) AuthLogin(username, password string, client LoginClient) (*models.AuthTokens, error) {
+	attempt := &models.LoginAttempt{
+		ID:        uuid.NewString(),
+		Username:  username,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+	}
+
+	retryAfter, err := s.loginLockout(username, client.IP)
+	if err != nil {
+		return nil, err
+	}
+	if retryAfter > 0 {
+		attempt.Result = models.LoginLocked
+		if err := s.recordLogin(attempt); err != nil {
+			return nil, err
+		}
+		return nil, models.TooManyRequests("Demasiados intentos fallidos, probá de nuevo más tarde", retryAfter, nil)
+	}
+
+	user, err := s.users.GetUserByUsername(username)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		utils.CheckPasswordHash(password, dummyPasswordHash())
+		attempt.Result = models.LoginInvalidCredentials
+	case err != nil:
+		return nil, models.Internal("Error al buscar usuario", err)
+	case !utils.CheckPasswordHash(password, user.Password):
+		attempt.UserID = &user.ID
+		attempt.Result = models.LoginInvalidCredentials
+	case !user.Active:
+		attempt.UserID = &user.ID
+		attempt.Result = models.LoginInactive
+	default:
+		attempt.UserID = &user.ID
+		attempt.Result = models.LoginSucceeded
+		attempt.Success = true
+	}
+	if err := s.recordLogin(attempt); err != nil {
+		return nil, err
+	}
+
+	// un usuario desactivado recibe la misma respuesta que una contraseña
+	// incorrecta, para no confirmar que la que probó es la correcta
+	switch attempt.Result {
+	case models.LoginInvalidCredentials, models.LoginInactive:
+		return nil, models.Unauthorized("Credenciales incorrectas", nil)
+	}
+
+	refreshToken, record, err := s.newRefreshToken(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokens.CreateRefreshToken(record); err != nil {
+		return nil, models.Internal("Error al guardar el refresh token", err)
+	}
+
+	return s.authTokens(user, refreshToken)
+}
+
+// AuthLoginHistory lista el historial de intentos de login.
+func (s *AuthService) AuthLoginHistory(params *models.ListParams) (*[]models.LoginAttempt, *models.Pagination, error) {
+	attempts, total, err := s.attempts.ListLoginAttempts(params)
+	if err != nil {
+		return nil, nil, listError(err, "Error al buscar el historial de login")
+	}
+	return &attempts, models.NewPagination(params, total), nil
+}
+
+// loginLockout devuelve cuánto falta para que se levante el bloqueo del
+// username o de la IP, o 0 si ninguno está bloqueado.
+func (s *AuthService) loginLockout(username, ip string) (time.Duration, error) {
+	now := time.Now()
+	since := now.Add(-s.login.Window)
+
+	var retryAfter time.Duration
+	check := func(count int64, last *time.Time, max int) {
+		if count < int64(max) || last == nil {
+			return
+		}
+		if remaining := last.Add(s.login.Lockout).Sub(now); remaining > retryAfter {
+			retryAfter = remaining
+		}
+	}
+
+	count, last, err := s.attempts.UsernameLoginFailures(username, since)
+	if err != nil {
+		return 0, models.Internal("Error al buscar los intentos de login", err)
+	}
+	check(count, last, s.login.MaxAttempts)
+
+	count, last, err = s.attempts.IPLoginFailures(ip, since)
+	if err != nil {
+		return 0, models.Internal("Error al buscar los intentos de login", err)
+	}
+	check(count, last, s.login.MaxAttemptsPerIP)
+
+	return retryAfter, nil
+}
+
+func (s *AuthService) recordLogin(attempt *models.LoginAttempt) error {
+	if err := s.attempts.CreateLoginAttempt(attempt); err != nil {
+		return models.Internal("Error al registrar el intento de login", err)
+	}
+	return nil
+}
+
+// AuthRefresh canjea un refresh token por un access token nuevo y rota el
+// refresh token: el presentado queda revocado. Presentar uno ya revocado
+// indica que se filtró, así que se revocan todos los del usuario.
+func (s *AuthService) AuthRefresh(refreshToken string) (*models.AuthTokens, error) {
+	current, err := s.tokens.GetRefreshTokenByHash(utils.HashToken(refreshToken))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", models.ErrorResponse(404, "Usuario no encontrado", err)
+			return nil, models.Unauthorized("Refresh token inválido", err)
 		}
-		return "", models.ErrorResponse(500, "Error al  buscar usuario", err)
+		return nil, models.Internal("Error al buscar el refresh token", err)
 	}
 
-	if !utils.CheckPasswordHash(password, user.Password) {
-		return "", models.ErrorResponse(401, "Credenciales incorrectas", nil)
+	if current.RevokedAt != nil {
+		if err := s.tokens.RevokeUserRefreshTokens(current.UserID); err != nil {
+			return nil, models.Internal("Error al revocar los refresh tokens", err)
+		}
+		return nil, models.Unauthorized("Refresh token revocado", nil)
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, models.Unauthorized("Refresh token vencido", nil)
 	}
 
-	token, err := utils.GenerateUserToken(user)
+	user, err := s.users.GetUserByID(current.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.Unauthorized("Usuario no encontrado", err)
+		}
+		return nil, models.Internal("Error al buscar usuario", err)
+	}
+	if !user.Active {
+		return nil, models.Unauthorized("Usuario desactivado", nil)
+	}
 
+	nextToken, next, err := s.newRefreshToken(user.ID)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al generar token", err)
+		return nil, err
+	}
+	if err := s.tokens.RotateRefreshToken(current, next); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// otro refresh con el mismo token ganó la carrera
+			return nil, models.Unauthorized("Refresh token revocado", err)
+		}
+		return nil, models.Internal("Error al rotar el refresh token", err)
 	}
 
-	return token, nil
+	return s.authTokens(user, nextToken)
+}
+
+// AuthLogout revoca el access token de claims hasta que venza y, si viene,
+// el refresh token del mismo usuario.
+func (s *AuthService) AuthLogout(claims jwt.MapClaims, refreshToken string) error {
+	jti, _ := claims["jti"].(string)
+	exp, err := claims.GetExpirationTime()
+	if jti == "" || err != nil || exp == nil {
+		return models.Unauthorized("Token inválido", err)
+	}
+	if err := s.tokens.RevokeAccessToken(jti, exp.Time); err != nil {
+		return models.Internal("Error al revocar el token", err)
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+	record, err := s.tokens.GetRefreshTokenByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return models.Internal("Error al buscar el refresh token", err)
+	}
+	if userID, _ := claims["id"].(string); record.UserID != userID {
+		return models.Forbidden("El refresh token no pertenece al usuario", nil)
+	}
+	if err := s.tokens.RevokeRefreshToken(record.ID); err != nil {
+		return models.Internal("Error al revocar el refresh token", err)
+	}
+	return nil
+}
+
+// CheckTokenRevoked rechaza los access tokens revocados por logout.
+func (s *AuthService) CheckTokenRevoked(claims jwt.MapClaims) error {
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return models.Unauthorized("Token inválido", nil)
+	}
+	revoked, err := s.tokens.IsAccessTokenRevoked(jti)
+	if err != nil {
+		return models.Internal("Error al verificar el token", err)
+	}
+	if revoked {
+		return models.Unauthorized("Token revocado", nil)
+	}
+	return nil
+}
+
+func (s *AuthService) newRefreshToken(userID string) (string, *models.RefreshToken, error) {
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", nil, models.Internal("Error al generar el refresh token", err)
+	}
+	return token, &models.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(s.auth.RefreshTokenTTL),
+	}, nil
+}
+
+func (s *AuthService) authTokens(user *models.User, refreshToken string) (*models.AuthTokens, error) {
+	accessToken, err := utils.GenerateUserToken(user, s.auth.SecretKey, s.auth.AccessTokenTTL)
+	if err != nil {
+		return nil, models.Internal("Error al generar token", err)
+	}
+	return &models.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.auth.AccessTokenTTL.Seconds()),
+	}, nil
 }
 
-func AuthWorkplace(id string) (string, error) {
-	workplace, err := repositories.Repo.GetWorkplaceByID(id)
+// AuthWorkplace emite el token del workplace para user si su rol tiene acceso
+// a él (Role.Workplace igual al identifier del workplace o "all").
+func (s *AuthService) AuthWorkplace(user *models.User, id string) (string, error) {
+	workplace, err := s.workplaces.GetWorkplaceByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", models.ErrorResponse(404, "Lugar de trabajo no encontrado", err)
+			return "", models.NotFound("Lugar de trabajo no encontrado", err)
 		}
-		return "", models.ErrorResponse(500, "Error al buscar lugar de trabajo", err)
+		return "", models.Internal("Error al buscar lugar de trabajo", err)
 	}
 
-	token, err := utils.GenerateWorkplaceToken(workplace)
+	if err := s.workplaceAccess(user, workplace); err != nil {
+		return "", err
+	}
+
+	token, err := utils.GenerateWorkplaceToken(workplace, user.ID, s.auth.SecretKeyWorkplace, s.auth.WorkplaceTokenTTL)
 
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al generar token", err)
+		return "", models.Internal("Error al generar token", err)
 	}
 
 	return token, nil
 }
 
-func CurrentUser(userId string) (*models.User, error) {
-	user, err := repositories.Repo.GetUserByID(userId)
+// workplaceAccess falla si el rol de user no tiene acceso al workplace.
+func (s *AuthService) workplaceAccess(user *models.User, workplace *models.Workplace) error {
+	role, err := s.roles.GetRoleByName(user.Role)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, models.ErrorResponse(404, "Usuario no encontrado", err)
+			return models.Forbidden("Rol no encontrado", err)
 		}
-		return nil, models.ErrorResponse(500, "Error al buscar usuario", err)
+		return models.Internal("Error al buscar rol", err)
+	}
+	if role.Workplace != "all" && role.Workplace != workplace.Identifier {
+		return models.Forbidden("El rol no tiene acceso a este lugar de trabajo", nil)
+	}
+	return nil
+}
+
+func (s *AuthService) VerifyUserToken(token string) (jwt.Claims, error) {
+	return utils.VerifyToken(token, s.auth.SecretKey)
+}
+
+func (s *AuthService) VerifyWorkplaceToken(token string) (jwt.Claims, error) {
+	return utils.VerifyWorkplaceToken(token, s.auth.SecretKeyWorkplace)
+}
+
+// CurrentUser devuelve el usuario del token. Si fue eliminado o desactivado
+// el token deja de servir.
+func (s *AuthService) CurrentUser(userId string) (*models.User, error) {
+	user, err := s.users.GetUserByID(userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.Unauthorized("Usuario no encontrado", err)
+		}
+		return nil, models.Internal("Error al buscar usuario", err)
+	}
+	if !user.Active {
+		return nil, models.Unauthorized("Usuario desactivado", nil)
 	}
 
 	return user, nil
 }
 
-func CurrentWorkplace(workplaceId string) (*models.Workplace, error) {
-	workplace, err := repositories.Repo.GetWorkplaceByID(workplaceId)
+// CurrentWorkplace devuelve el workplace del token. Como el token dura más que
+// un cambio de rol o una desactivación, en cada request se vuelve a controlar
+// que user siga activo y que su rol tenga acceso al workplace.
+func (s *AuthService) CurrentWorkplace(user *models.User, workplaceId string) (*models.Workplace, error) {
+	if !user.Active {
+		return nil, models.Unauthorized("Usuario desactivado", nil)
+	}
+
+	workplace, err := s.workplaces.GetWorkplaceByID(workplaceId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, models.ErrorResponse(404, "Usuario no encontrado", err)
+			return nil, models.NotFound("Workplace no encontrado", err)
 		}
-		return nil, models.ErrorResponse(500, "Error al buscar usuario", err)
+		return nil, models.Internal("Error al buscar workplace", err)
+	}
+
+	if err := s.workplaceAccess(user, workplace); err != nil {
+		return nil, err
 	}
 
 	return workplace, nil
 }
 
 // func GetWorkplaceByRole(role string) (*models.Workplace, error) {
-// 	workplace, err := repositories.Repo.GetWorkplaceByRole(role)
+// 	workplace, err := s.workplaces.GetWorkplaceByRole(role)
 // 	if err != nil {
 // 		if errors.Is(err, gorm.ErrRecordNotFound) {
-// 			return nil, models.ErrorResponse(404, "Rol no encontrado", err)
+// 			return nil, models.NotFound("Rol no encontrado", err)
 // 		}
-// 		return nil, models.ErrorResponse(500, "Error al buscar rol", err)
+// 		return nil, models.Internal("Error al buscar rol", err)
 // 	}
 
 // 	return workplace, nil
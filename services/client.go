@@ -9,78 +9,110 @@ import (
 	"gorm.io/gorm"
 )
 
-func ClientCreate(clientCreate *models.ClientCreate) (string, error) {
-	client, err := repositories.Repo.CreateClient(&models.Client{
+type ClientService struct {
+	repo repositories.ClientRepository
+}
+
+func NewClientService(repo repositories.ClientRepository) *ClientService {
+	return &ClientService{
+		repo: repo,
+	}
+}
+
+func (s *ClientService) ClientCreate(clientCreate *models.ClientCreate, actor models.Actor) (string, error) {
+	exist, err := s.repo.GetClientByDocument(clientCreate.CUIL, clientCreate.DNI, "")
+	if err != nil {
+		return "", models.Internal("Error al buscar cliente", err)
+	}
+
+	if exist {
+		return "", models.Conflict("El CUIL o DNI ya existe (puede estar en la papelera)", nil)
+	}
+
+	client, err := s.repo.CreateClient(&models.Client{
 		ID: uuid.NewString(),
 		FirstName: clientCreate.FirstName,
 		LastName:  clientCreate.LastName,		
 		CUIL:      clientCreate.CUIL,
 		DNI:       clientCreate.DNI,
 		Email:     clientCreate.Email,
-	})
+	}, actor)
 
 	if err != nil {
-		return "", err
+		return "", models.Internal("Error al crear cliente", err)
 	}
 
 	return client, nil
 }
 
-func ClientGetAll() (*[]models.Client, error) {
-	clients, err := repositories.Repo.GetAllClients()
+func (s *ClientService) ClientGetAll(params *models.ListParams) (*[]models.Client, *models.Pagination, error) {
+	clients, total, err := s.repo.GetAllClients(params)
 	if err != nil {
-		return nil, models.ErrorResponse(500, "Error al buscar los clientes", err)
+		return nil, nil, listError(err, "Error al buscar los clientes")
 	}
-	return &clients, nil
+	return &clients, models.NewPagination(params, total), nil
 }
 
-func ClientGetByID(id string) (*models.Client, error) {
-	client, err := repositories.Repo.GetClientByID(id)
+func (s *ClientService) ClientGetByID(id string) (*models.Client, error) {
+	client, err := s.repo.GetClientByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, models.ErrorResponse(404, "Cliente no encontrado", err)
+			return nil, models.NotFound("Cliente no encontrado", err)
 		}
-		return nil, models.ErrorResponse(500, "Error al eliminar cliente", err)
+		return nil, models.Internal("Error al eliminar cliente", err)
 	}
 	return client, nil
 }
 
-func ClientGetByName(name string) (*[]models.Client, error) {
-	client, err := repositories.Repo.GetClientByName(name)
+func (s *ClientService) ClientGetByName(name string) (*[]models.Client, error) {
+	client, err := s.repo.GetClientByName(name)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, models.ErrorResponse(404, "Cliente no encontrado", err)
+			return nil, models.NotFound("Cliente no encontrado", err)
 		}
-		return nil, models.ErrorResponse(500, "Error al eliminar cliente", err)
+		return nil, models.Internal("Error al eliminar cliente", err)
 	}
 	return client, nil
 }
 
-func ClientUpdate(clientUpdate *models.ClientUpdate) (string, error) {
-	err := repositories.Repo.UpdateClient(&models.Client{
+func (s *ClientService) ClientUpdate(clientUpdate *models.ClientUpdate, actor models.Actor) (string, error) {
+	exist, err := s.repo.GetClientByDocument(clientUpdate.CUIL, clientUpdate.DNI, clientUpdate.ID)
+	if err != nil {
+		return "", models.Internal("Error al buscar cliente", err)
+	}
+
+	if exist {
+		return "", models.Conflict("El CUIL o DNI ya existe (puede estar en la papelera)", nil)
+	}
+
+	err = s.repo.UpdateClient(&models.Client{
 		ID: clientUpdate.ID,
 		FirstName: clientUpdate.FirstName,
 		LastName:  clientUpdate.LastName,		
 		CUIL:      clientUpdate.CUIL,
 		DNI:       clientUpdate.DNI,
 		Email:     clientUpdate.Email,
-	})
+		Version:   clientUpdate.Version,
+	}, actor)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return "", versionConflict(err, func() (*models.Client, error) { return s.repo.GetClientByID(clientUpdate.ID) })
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", models.ErrorResponse(404, "Cliente no encontrado", err)
+			return "", models.NotFound("Cliente no encontrado", err)
 		}
-		return "", models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return "", models.Internal("Error al actualizar cliente", err)
 	}
 	return clientUpdate.ID, nil
 }
 
-func ClientDelete(id string) (string, error) {
-	err := repositories.Repo.DeleteClient(id)
+func (s *ClientService) ClientDelete(id string, actor models.Actor) (string, error) {
+	err := s.repo.DeleteClient(id, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", models.ErrorResponse(404, "Cliente no encontrado", err)
+			return "", models.NotFound("Cliente no encontrado", err)
 		}
-		return "", models.ErrorResponse(500, "Error al eliminar cliente", err)
+		return "", models.Internal("Error al eliminar cliente", err)
 	}
 	return id, nil
 }
\ No newline at end of file
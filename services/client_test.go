@@ -0,0 +1,84 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"gorm.io/gorm"
+)
+
+// fakeClientRepository guarda los clientes en memoria.
+type fakeClientRepository struct {
+	clients map[string]models.Client
+}
+
+func (f *fakeClientRepository) GetClientByID(id string) (*models.Client, error) {
+	client, ok := f.clients[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &client, nil
+}
+
+func (f *fakeClientRepository) GetClientByName(name string) (*[]models.Client, error) {
+	return &[]models.Client{}, nil
+}
+
+func (f *fakeClientRepository) GetAllClients(params *models.ListParams) ([]models.Client, int64, error) {
+	clients := make([]models.Client, 0, len(f.clients))
+	for _, client := range f.clients {
+		clients = append(clients, client)
+	}
+	return clients, int64(len(clients)), nil
+}
+
+func (f *fakeClientRepository) GetClientByDocument(cuil string, dni string, excludeID string) (bool, error) {
+	for _, client := range f.clients {
+		if client.ID != excludeID && (client.CUIL == cuil || client.DNI == dni) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeClientRepository) CreateClient(client *models.Client, actor models.Actor) (string, error) {
+	f.clients[client.ID] = *client
+	return client.ID, nil
+}
+
+func (f *fakeClientRepository) UpdateClient(client *models.Client, actor models.Actor) error {
+	if _, ok := f.clients[client.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	f.clients[client.ID] = *client
+	return nil
+}
+
+func (f *fakeClientRepository) DeleteClient(id string, actor models.Actor) error {
+	delete(f.clients, id)
+	return nil
+}
+
+func TestClientService(t *testing.T) {
+	service := NewClientService(&fakeClientRepository{clients: map[string]models.Client{}})
+
+	id, err := service.ClientCreate(&models.ClientCreate{FirstName: "Juan", LastName: "Pérez"}, models.Actor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := service.ClientGetByID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.LastName != "Pérez" {
+		t.Errorf("apellido = %s, se esperaba Pérez", client.LastName)
+	}
+
+	_, err = service.ClientGetByID("no-existe")
+	var errResp *models.ErrorStruc
+	if !errors.As(err, &errResp) || errResp.StatusCode != 404 {
+		t.Errorf("se esperaba un error 404, se obtuvo %v", err)
+	}
+}
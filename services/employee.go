@@ -8,59 +8,72 @@ import (
 	"gorm.io/gorm"
 )
 
-func GetEmployeeByID(id string, workplace string) (*models.EmployeeLaundry, *models.EmployeeWorkshop, error) {
-	employeeLaundry, employeeWorkshop, err := repositories.Repo.GetEmployeeByID(id, workplace)
+type EmployeeService struct {
+	repo repositories.EmployeeRepository
+}
+
+func NewEmployeeService(repo repositories.EmployeeRepository) *EmployeeService {
+	return &EmployeeService{
+		repo: repo,
+	}
+}
+
+func (s *EmployeeService) GetEmployeeByID(id string, workplaceID string) (*models.Employee, error) {
+	employee, err := s.repo.GetEmployeeByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Empleado no encontrado", err)
+			return nil, models.NotFound("Empleado no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return employeeLaundry, employeeWorkshop, nil
+	return employee, nil
 }
 
-func GetEmployeeByName(name string, workplace string) (*[]models.EmployeeLaundry, *[]models.EmployeeWorkshop, error) {
-	employeesLaundry, employeesWorkshop, err := repositories.Repo.GetEmployeeByName(name, workplace)
+func (s *EmployeeService) GetEmployeeByName(name string, workplaceID string) (*[]models.Employee, error) {
+	employees, err := s.repo.GetEmployeeByName(name, workplaceID)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al obtener clientes", err)
+		return nil, models.Internal("Error al obtener clientes", err)
 	}
-	return employeesLaundry, employeesWorkshop, nil
+	return employees, nil
 }
 
-func GetAllEmployees(workplace string) (*[]models.EmployeeLaundry, *[]models.EmployeeWorkshop, error) {
-	employeesLaundry, employeesWorkshop, err := repositories.Repo.GetAllEmployees(workplace)
+func (s *EmployeeService) GetAllEmployees(workplaceID string, params *models.ListParams) (*[]models.Employee, *models.Pagination, error) {
+	employees, total, err := s.repo.GetAllEmployees(workplaceID, params)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, nil, listError(err, "Error al buscar empleados")
 	}
-	return employeesLaundry, employeesWorkshop, nil
+	return employees, models.NewPagination(params, total), nil
 }
 
-func CreateEmployee(employee *models.EmployeeCreate, workplace string) (string, error) {
-	id, err := repositories.Repo.CreateEmployee(employee, workplace)
+func (s *EmployeeService) CreateEmployee(employee *models.EmployeeCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.CreateEmployee(employee, workplaceID, actor)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return "", models.Internal("Error al actualizar cliente", err)
 	}
 	return id, nil
 }
 
-func UpdateEmployee(employee *models.EmployeeUpdate, workplace string) error {
-	err := repositories.Repo.UpdateEmployee(employee, workplace)
+func (s *EmployeeService) UpdateEmployee(employee *models.EmployeeUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdateEmployee(employee, workplaceID, actor)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.Employee, error) { return s.repo.GetEmployeeByID(employee.ID, workplaceID) })
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 	return nil
 }
 
-func DeleteEmployee(id string, workplace string) error {
-	err := repositories.Repo.DeleteEmployee(id, workplace)
+func (s *EmployeeService) DeleteEmployee(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeleteEmployee(id, workplaceID, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 	return nil
 }
\ No newline at end of file
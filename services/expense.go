@@ -8,61 +8,72 @@ import (
 	"gorm.io/gorm"
 )
 
-func GetExpenseByID(id string, workplace string) (*models.ExpenseLaundry, *models.ExpenseWorkshop, error) {
-	laundries, workshops, err := repositories.Repo.GetExpenseByID(id, workplace)
+type ExpenseService struct {
+	repo repositories.ExpenseRepository
+}
+
+func NewExpenseService(repo repositories.ExpenseRepository) *ExpenseService {
+	return &ExpenseService{
+		repo: repo,
+	}
+}
+
+func (s *ExpenseService) GetExpenseByID(id string, workplaceID string) (*models.Expense, error) {
+	expense, err := s.repo.GetExpenseByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Movimiento no encontrado", err)
+			return nil, models.NotFound("Movimiento no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al buscar movimiento", err)
+		return nil, models.Internal("Error al buscar movimiento", err)
 	}
 
-	return laundries, workshops, nil
+	return expense, nil
 }
 
-func GetAllExpenses(workplace string) (*[]models.ExpenseLaundry, *[]models.ExpenseWorkshop, error) {
-	laundries, workshops, err := repositories.Repo.GetAllExpenses(workplace)
-	
+func (s *ExpenseService) GetAllExpenses(workplaceID string, params *models.ListParams) (*[]models.Expense, *models.Pagination, error) {
+	expenses, total, err := s.repo.GetAllExpenses(workplaceID, params)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al buscar movimientos", err)
+		return nil, nil, listError(err, "Error al buscar movimientos")
 	}
-
-	return laundries, workshops, nil
+	return expenses, models.NewPagination(params, total), nil
 }
 
-func GetExpenseToday(workplace string) (*[]models.ExpenseLaundry, *[]models.ExpenseWorkshop, error) {
-	laundries, workshops, err := repositories.Repo.GetExpenseToday(workplace)
+func (s *ExpenseService) GetExpenseToday(workplaceID string) (*[]models.Expense, error) {
+	expenses, err := s.repo.GetExpenseToday(workplaceID)
 	
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al buscar movimientos", err)
+		return nil, models.Internal("Error al buscar movimientos", err)
 	}
 
-	return laundries, workshops, nil
+	return expenses, nil
 }
 
-func CreateExpense(expense *models.ExpenseCreate, workplace string) (string, error) {
-	laundryID, err := repositories.Repo.CreateExpense(expense, "laundry")
+func (s *ExpenseService) CreateExpense(expense *models.ExpenseCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.CreateExpense(expense, workplaceID, actor)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al crear movimiento", err)
+		return "", models.Internal("Error al crear movimiento", err)
 	}
-	return laundryID, nil
+	return id, nil
 }
 
-func UpdateExpense(expense *models.ExpenseUpdate, workplace string) error {
-	err := repositories.Repo.UpdateExpense(expense, workplace)
+func (s *ExpenseService) UpdateExpense(expense *models.ExpenseUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdateExpense(expense, workplaceID, actor)
 	if err != nil {
-		return models.ErrorResponse(500, "Error al actualizar movimiento", err)
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.Expense, error) { return s.repo.GetExpenseByID(expense.ID, workplaceID) })
+		}
+		return models.Internal("Error al actualizar movimiento", err)
 	}
 	return nil
 }
 
-func DeleteExpense(id string, workplace string) error {
-	err := repositories.Repo.DeleteExpenseByID(id, workplace)
+func (s *ExpenseService) DeleteExpense(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeleteExpenseByID(id, workplaceID, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Movimiento no encontrado", err)
+			return models.NotFound("Movimiento no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al eliminar movimiento", err)
+		return models.Internal("Error al eliminar movimiento", err)
 	}
 	return nil
 }
\ No newline at end of file
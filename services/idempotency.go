@@ -0,0 +1,84 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxIdempotencyKeyLength = 255
+	// una key vencida se puede volver a usar como si fuera nueva
+	idempotencyKeyTTL = 24 * time.Hour
+)
+
+type IdempotencyService struct {
+	repo repositories.IdempotencyRepository
+}
+
+func NewIdempotencyService(repo repositories.IdempotencyRepository) *IdempotencyService {
+	return &IdempotencyService{
+		repo: repo,
+	}
+}
+
+// Begin registra la primera request hecha con record.Key. Si la key ya se usó
+// para la misma request y terminó, devuelve el registro guardado para repetir
+// su respuesta; si sigue en curso o se usó con otra request, devuelve error.
+func (s *IdempotencyService) Begin(record *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	if len(record.Key) > maxIdempotencyKeyLength {
+		return nil, models.BadRequest("Idempotency-Key no puede superar los 255 caracteres", nil)
+	}
+
+	existing, err := s.repo.GetIdempotencyKey(record.UserID, record.Key)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, models.Internal("Error al buscar la Idempotency-Key", err)
+	}
+	if existing != nil && time.Since(existing.CreatedAt) > idempotencyKeyTTL {
+		if err := s.repo.DeleteIdempotencyKey(existing.ID); err != nil {
+			return nil, models.Internal("Error al eliminar la Idempotency-Key vencida", err)
+		}
+		existing = nil
+	}
+
+	if existing == nil {
+		record.ID = uuid.NewString()
+		record.CompletedAt = nil
+		if err := s.repo.CreateIdempotencyKey(record); err != nil {
+			// otro reintento con la misma key la registró primero
+			if _, getErr := s.repo.GetIdempotencyKey(record.UserID, record.Key); getErr == nil {
+				return nil, models.Conflict("La request original con esta Idempotency-Key todavía se está procesando", err)
+			}
+			return nil, models.Internal("Error al registrar la Idempotency-Key", err)
+		}
+		return nil, nil
+	}
+
+	if existing.RequestHash != record.RequestHash {
+		return nil, models.Validation("La Idempotency-Key ya se usó con otra request", nil)
+	}
+	if existing.CompletedAt == nil {
+		return nil, models.Conflict("La request original con esta Idempotency-Key todavía se está procesando", nil)
+	}
+	return existing, nil
+}
+
+// Complete guarda la respuesta de la request para repetirla en los reintentos.
+func (s *IdempotencyService) Complete(id string, statusCode int, contentType string, body []byte) error {
+	if err := s.repo.CompleteIdempotencyKey(id, statusCode, contentType, body); err != nil {
+		return models.Internal("Error al guardar la respuesta de la Idempotency-Key", err)
+	}
+	return nil
+}
+
+// Abort libera la key de una request que falló, para que se pueda reintentar.
+func (s *IdempotencyService) Abort(id string) error {
+	if err := s.repo.DeleteIdempotencyKey(id); err != nil {
+		return models.Internal("Error al liberar la Idempotency-Key", err)
+	}
+	return nil
+}
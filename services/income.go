@@ -8,61 +8,87 @@ import (
 	"gorm.io/gorm"
 )
 
-func GetIncomeByID(id string, workplace string) (*models.IncomeLaundry, *models.IncomeWorkshop, error) {
-	laundries, workshops, err := repositories.Repo.GetIncomeByID(id, workplace)
+type IncomeService struct {
+	repo repositories.IncomeRepository
+}
+
+func NewIncomeService(repo repositories.IncomeRepository) *IncomeService {
+	return &IncomeService{
+		repo: repo,
+	}
+}
+
+func (s *IncomeService) GetIncomeByID(id string, workplaceID string) (*models.Income, error) {
+	income, err := s.repo.GetIncomeByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Movimiento no encontrado", err)
+			return nil, models.NotFound("Movimiento no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al buscar movimiento", err)
+		return nil, models.Internal("Error al buscar movimiento", err)
 	}
 
-	return laundries, workshops, nil
+	return income, nil
 }
 
-func GetAllIncomes(workplace string) (*[]models.IncomeLaundry, *[]models.IncomeWorkshop, error) {
-	laundries, workshops, err := repositories.Repo.GetAllIncomes(workplace)
-	
+func (s *IncomeService) GetAllIncomes(workplaceID string, params *models.ListParams) (*[]models.Income, *models.Pagination, error) {
+	incomes, total, err := s.repo.GetAllIncomes(workplaceID, params)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al buscar movimientos", err)
+		return nil, nil, listError(err, "Error al buscar movimientos")
 	}
-
-	return laundries, workshops, nil
+	return incomes, models.NewPagination(params, total), nil
 }
 
-func GetIncomeToday(workplace string) (*[]models.IncomeLaundry, *[]models.IncomeWorkshop, error) {
-	laundries, workshops, err := repositories.Repo.GetIncomeToday(workplace)
+func (s *IncomeService) GetIncomeToday(workplaceID string) (*[]models.Income, error) {
+	incomes, err := s.repo.GetIncomeToday(workplaceID)
 	
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al buscar movimientos", err)
+		return nil, models.Internal("Error al buscar movimientos", err)
 	}
 
-	return laundries, workshops, nil
+	return incomes, nil
 }
 
-func CreateIncome(expense *models.IncomeCreate, workplace string) (string, error) {
-	laundryID, err := repositories.Repo.CreateIncome(expense, workplace)
+// incomeError traduce los errores de vehículo, precios y descuentos al cargar
+// un ingreso; el resto es un error interno con msg.
+func incomeError(err error, msg string) error {
+	if errors.Is(err, repositories.ErrIncomeFromWorkOrder) {
+		return models.Conflict(err.Error(), err)
+	}
+	if errors.Is(err, repositories.ErrServiceNotPriced) || errors.Is(err, repositories.ErrDiscountExceedsSubtotal) || errors.Is(err, repositories.ErrIncomeVehicle) {
+		return models.BadRequest(err.Error(), err)
+	}
+	return models.Internal(msg, err)
+}
+
+func (s *IncomeService) CreateIncome(expense *models.IncomeCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.CreateIncome(expense, workplaceID, actor)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al crear movimiento", err)
+		return "", incomeError(err, "Error al crear movimiento")
 	}
-	return laundryID, nil
+	return id, nil
 }
 
-func UpdateIncome(expense *models.IncomeUpdate, workplace string) error {
-	err := repositories.Repo.UpdateIncome(expense, workplace)
+func (s *IncomeService) UpdateIncome(expense *models.IncomeUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdateIncome(expense, workplaceID, actor)
 	if err != nil {
-		return models.ErrorResponse(500, "Error al actualizar movimiento", err)
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.Income, error) { return s.repo.GetIncomeByID(expense.ID, workplaceID) })
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.NotFound("Movimiento no encontrado", err)
+		}
+		return incomeError(err, "Error al actualizar movimiento")
 	}
 	return nil
 }
 
-func DeleteIncome(id string, workplace string) error {
-	err := repositories.Repo.DeleteIncomeByID(id, workplace)
+func (s *IncomeService) DeleteIncome(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeleteIncomeByID(id, workplaceID, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Movimiento no encontrado", err)
+			return models.NotFound("Movimiento no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al eliminar movimiento", err)
+		return incomeError(err, "Error al eliminar movimiento")
 	}
 	return nil
 }
\ No newline at end of file
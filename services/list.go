@@ -0,0 +1,17 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/repositories"
+)
+
+// listError traduce el error de un listado: un sort o filtro no admitido es
+// un error del cliente, cualquier otro es interno.
+func listError(err error, message string) error {
+	if errors.Is(err, repositories.ErrInvalidListParams) {
+		return models.BadRequest(err.Error(), err)
+	}
+	return models.Internal(message, err)
+}
@@ -8,53 +8,66 @@ import (
 	"gorm.io/gorm"
 )
 
-func MovementTypeCreate(movementType *models.MovementTypeCreate, workplace string) (string, error) {
-	id, err := repositories.Repo.CreateMovementType(movementType, workplace)
+type MovementTypeService struct {
+	repo repositories.MovementTypeRepository
+}
+
+func NewMovementTypeService(repo repositories.MovementTypeRepository) *MovementTypeService {
+	return &MovementTypeService{
+		repo: repo,
+	}
+}
+
+func (s *MovementTypeService) MovementTypeCreate(movementType *models.MovementTypeCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.CreateMovementType(movementType, workplaceID, actor)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return "", models.Internal("Error al actualizar cliente", err)
 	}
 	return id, nil
 }
 
-func MovementTypeUpdate(movementType *models.MovementTypeUpdate, workplace string) error {
-	err := repositories.Repo.UpdateMovementType(movementType, workplace)
+func (s *MovementTypeService) MovementTypeUpdate(movementType *models.MovementTypeUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdateMovementType(movementType, workplaceID, actor)
 
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.MovementType, error) { return s.repo.GetMovementTypeByID(movementType.ID, workplaceID) })
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 
 	return nil
 }
 
-func MovementTypeDelete(id string, workplace string) error {
-	err := repositories.Repo.DeleteMovementType(id, workplace)
+func (s *MovementTypeService) MovementTypeDelete(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeleteMovementType(id, workplaceID, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 	return nil
 }
 
-func GetMovementTypeByID(id string, workplace string) (*models.MovementTypeLaundry, *models.MovementTypeWorkshop, error) {
-	movementTypeLaundry, movementTypeWorkshop, err := repositories.Repo.GetMovementTypeByID(id, workplace)
+func (s *MovementTypeService) GetMovementTypeByID(id string, workplaceID string) (*models.MovementType, error) {
+	movementType, err := s.repo.GetMovementTypeByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Empleado no encontrado", err)
+			return nil, models.NotFound("Empleado no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return movementTypeLaundry, movementTypeWorkshop, nil
+	return movementType, nil
 }
 
-func GetAllMovementTypes(isIncome bool ,workplace string) (*[]models.MovementTypeLaundry, *[]models.MovementTypeWorkshop, error) {
-	movementTypesLaundry, movementTypesWorkshop, err := repositories.Repo.GetAllMovementTypes(isIncome, workplace)
+func (s *MovementTypeService) GetAllMovementTypes(isIncome bool, workplaceID string, params *models.ListParams) (*[]models.MovementType, *models.Pagination, error) {
+	movementTypes, total, err := s.repo.GetAllMovementTypes(isIncome, workplaceID, params)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, nil, listError(err, "Error al buscar tipos de movimiento")
 	}
-	return movementTypesLaundry, movementTypesWorkshop, nil
-}
\ No newline at end of file
+	return movementTypes, models.NewPagination(params, total), nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/DanielChachagua/GestionCar/config"
+	"github.com/DanielChachagua/GestionCar/mailer"
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/repositories"
+	"github.com/DanielChachagua/GestionCar/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PasswordService struct {
+	repo     repositories.PasswordResetRepository
+	mailer   mailer.Mailer
+	resetTTL time.Duration
+	resetURL string
+}
+
+func NewPasswordService(repo repositories.PasswordResetRepository, mailer mailer.Mailer, auth config.Auth, mail config.Mail) *PasswordService {
+	return &PasswordService{
+		repo:     repo,
+		mailer:   mailer,
+		resetTTL: auth.PasswordResetTTL,
+		resetURL: mail.ResetURL,
+	}
+}
+
+// ChangePassword cambia la contraseña de user si current es la actual. Las
+// demás sesiones no pueden renovarse: sus refresh tokens quedan revocados.
+func (s *PasswordService) ChangePassword(user *models.User, current string, password string, actor models.Actor) error {
+	if !utils.CheckPasswordHash(current, user.Password) {
+		return models.BadRequest("La contraseña actual es incorrecta", nil)
+	}
+	if current == password {
+		return models.BadRequest("La contraseña nueva debe ser distinta de la actual", nil)
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return models.Internal("Error al hashear la contraseña", err)
+	}
+	if err := s.repo.ChangePassword(user.ID, hash, actor); err != nil {
+		return models.Internal("Error al cambiar la contraseña", err)
+	}
+	return nil
+}
+
+// ForgotPassword envía por correo un token de recuperación al usuario con ese
+// email. Si no existe o está desactivado no hace nada, para no revelar qué
+// emails están registrados. Por lo mismo todo se hace en segundo plano: la
+// respuesta y su demora son iguales exista o no, y los errores solo se loguean.
+func (s *PasswordService) ForgotPassword(email string) {
+	go func() {
+		if err := s.sendResetToken(email); err != nil {
+			log.Printf("Recuperación de contraseña para %s: %v", email, err)
+		}
+	}()
+}
+
+func (s *PasswordService) sendResetToken(email string) error {
+	user, err := s.repo.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("buscar el usuario: %w", err)
+	}
+	if !user.Active {
+		return nil
+	}
+
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("generar el token: %w", err)
+	}
+	err = s.repo.CreatePasswordResetToken(&models.PasswordResetToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(s.resetTTL),
+	})
+	if err != nil {
+		return fmt.Errorf("guardar el token: %w", err)
+	}
+
+	if err := s.mailer.Send(s.resetMessage(user, token)); err != nil {
+		return fmt.Errorf("enviar el correo: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword cambia la contraseña con un token de recuperación vigente y
+// sin usar. El token y los demás pendientes del usuario quedan usados.
+func (s *PasswordService) ResetPassword(token string, password string) error {
+	reset, err := s.repo.GetPasswordResetTokenByHash(utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.BadRequest("Token de recuperación inválido o vencido", err)
+		}
+		return models.Internal("Error al buscar el token de recuperación", err)
+	}
+	if reset.UsedAt != nil || time.Now().After(reset.ExpiresAt) {
+		return models.BadRequest("Token de recuperación inválido o vencido", nil)
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return models.Internal("Error al hashear la contraseña", err)
+	}
+	if err := s.repo.ResetPassword(reset, hash); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.BadRequest("Token de recuperación inválido o vencido", err)
+		}
+		return models.Internal("Error al cambiar la contraseña", err)
+	}
+	return nil
+}
+
+func (s *PasswordService) resetMessage(user *models.User, token string) mailer.Message {
+	link := token
+	if s.resetURL != "" {
+		separator := "?"
+		if strings.Contains(s.resetURL, "?") {
+			separator = "&"
+		}
+		link = s.resetURL + separator + "token=" + url.QueryEscape(token)
+	}
+	body := fmt.Sprintf("Hola %s,\n\n"+
+		"Recibimos un pedido para restablecer tu contraseña de GestionCar. Para elegir una nueva usá:\n\n"+
+		"%s\n\n"+
+		"Vence en %s y sirve una sola vez. Si no lo pediste podés ignorar este correo.\n",
+		user.FirstName, link, s.resetTTL)
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "Recuperación de contraseña",
+		Body:    body,
+	}
+}
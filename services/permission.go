@@ -0,0 +1,110 @@
+package services
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/repositories"
+	"gorm.io/gorm"
+)
+
+type PermissionService struct {
+	permissions repositories.PermissionRepository
+	roles       repositories.RoleRepository
+}
+
+func NewPermissionService(permissions repositories.PermissionRepository, roles repositories.RoleRepository) *PermissionService {
+	return &PermissionService{
+		permissions: permissions,
+		roles:       roles,
+	}
+}
+
+func (s *PermissionService) GetAllPermissions() ([]models.Permission, error) {
+	permissions, err := s.permissions.GetAllPermissions()
+	if err != nil {
+		return nil, models.Internal("Error al buscar los permisos", err)
+	}
+	return permissions, nil
+}
+
+func (s *PermissionService) GetRolePermissions(roleName string) (*models.RolePermissions, error) {
+	role, err := s.getRole(roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.permissions.GetRolePermissions(role.ID)
+	if err != nil {
+		return nil, models.Internal("Error al buscar los permisos del rol", err)
+	}
+	return &models.RolePermissions{Role: role.Name, Permissions: permissions}, nil
+}
+
+// UpdateRolePermissions reemplaza los permisos de un rol. Siguiendo la
+// jerarquía, user solo puede modificar roles por debajo del suyo y asignar
+// permisos que su rol ya tiene.
+func (s *PermissionService) UpdateRolePermissions(user *models.User, roleName string, permissions []string) (*models.RolePermissions, error) {
+	current, err := s.getRole(user.Role)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.getRole(roleName)
+	if err != nil {
+		return nil, err
+	}
+	if role.Hierarchy <= current.Hierarchy {
+		return nil, models.Forbidden("Solo se pueden modificar los permisos de roles de menor jerarquía", nil)
+	}
+
+	permissions = append([]string{}, slices.Compact(slices.Sorted(slices.Values(permissions)))...)
+
+	catalog, err := s.permissions.GetAllPermissions()
+	if err != nil {
+		return nil, models.Internal("Error al buscar los permisos", err)
+	}
+	for _, permission := range permissions {
+		if !slices.ContainsFunc(catalog, func(p models.Permission) bool { return p.Name == permission }) {
+			return nil, models.BadRequest("Permiso inexistente: "+permission, nil)
+		}
+	}
+
+	if len(permissions) > 0 {
+		ok, err := s.permissions.RoleHasPermissions(current.Name, permissions)
+		if err != nil {
+			return nil, models.Internal("Error al verificar los permisos", err)
+		}
+		if !ok {
+			return nil, models.Forbidden("No se pueden asignar permisos que el rol propio no tiene", nil)
+		}
+	}
+
+	if err := s.permissions.SetRolePermissions(role.ID, permissions, models.Actor{UserID: user.ID}); err != nil {
+		return nil, models.Internal("Error al guardar los permisos del rol", err)
+	}
+	return &models.RolePermissions{Role: role.Name, Permissions: permissions}, nil
+}
+
+// Authorize falla con 403 si el rol de user no tiene todos los permisos.
+func (s *PermissionService) Authorize(user *models.User, permissions ...string) error {
+	ok, err := s.permissions.RoleHasPermissions(user.Role, slices.Compact(slices.Sorted(slices.Values(permissions))))
+	if err != nil {
+		return models.Internal("Error al verificar los permisos", err)
+	}
+	if !ok {
+		return models.Forbidden("Acceso denegado", nil)
+	}
+	return nil
+}
+
+func (s *PermissionService) getRole(name string) (*models.Role, error) {
+	role, err := s.roles.GetRoleByName(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.NotFound("Rol no encontrado", err)
+		}
+		return nil, models.Internal("Error al buscar rol", err)
+	}
+	return role, nil
+}
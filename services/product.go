@@ -8,109 +8,143 @@ import (
 	"gorm.io/gorm"
 )
 
-func ProductGetByID(id string, workplace string) (*models.ProductLaundry, *models.PartWorkshop, error) {
-	product, part, err := repositories.Repo.GetElementByID(id, workplace)
+type ProductService struct {
+	repo repositories.ProductRepository
+}
+
+func NewProductService(repo repositories.ProductRepository) *ProductService {
+	return &ProductService{
+		repo: repo,
+	}
+}
+
+func (s *ProductService) ProductGetByID(id string, workplaceID string) (*models.Product, error) {
+	product, err := s.repo.GetElementByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Elemento no encontrado", err)
+			return nil, models.NotFound("Elemento no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return product, part, nil
+	return product, nil
 }
 
-func ProductGetByIdentifier(identifier string, workplace string) (*[]models.ProductLaundry, *[]models.PartWorkshop, error) {
-	product, part, err := repositories.Repo.GetElementsByIdentifier(identifier, workplace)
+func (s *ProductService) ProductGetByIdentifier(identifier string, workplaceID string) (*[]models.Product, error) {
+	product, err := s.repo.GetElementsByIdentifier(identifier, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Elemento no encontrado", err)
+			return nil, models.NotFound("Elemento no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return product, part, nil
+	return product, nil
 }
 
-func ProductGetAll(workplace string) (*[]models.ProductLaundry, *[]models.PartWorkshop, error) {
-	product, part, err := repositories.Repo.GetAllElements(workplace)
+func (s *ProductService) ProductGetAll(workplaceID string, params *models.ListParams) (*[]models.Product, *models.Pagination, error) {
+	products, total, err := s.repo.GetAllElements(workplaceID, params)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Elemento no encontrado", err)
-		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, nil, listError(err, "Error al buscar productos")
 	}
-	return product, part, nil
+	return products, models.NewPagination(params, total), nil
 }
 
-func ProductGetByName(name string, workplace string) (*[]models.ProductLaundry, *[]models.PartWorkshop, error) {
-	product, part, err := repositories.Repo.GetAllElementsByName(name, workplace)
+func (s *ProductService) ProductGetByName(name string, workplaceID string) (*[]models.Product, error) {
+	product, err := s.repo.GetAllElementsByName(name, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Elemento no encontrado", err)
+			return nil, models.NotFound("Elemento no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al obtener productos", err)
+		return nil, models.Internal("Error al obtener productos", err)
 	}
-	return product, part, nil
+	return product, nil
 }
 
-func ProductCreate(product *models.ProductCreate, workplace string) (string, error) {
-	id, err := repositories.Repo.CreateElement(product, workplace)
+func (s *ProductService) ProductCreate(product *models.ProductCreate, workplaceID string, actor models.Actor) (string, error) {
+	exist, err := s.repo.GetElementByIdentifierEq(product.Identifier, workplaceID, "")
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al crear producto", err)
+		return "", models.Internal("Error al buscar producto", err)
+	}
+
+	if exist {
+		return "", models.Conflict("El identificador ya existe (puede estar en la papelera)", nil)
+	}
+
+	id, err := s.repo.CreateElement(product, workplaceID, actor)
+	if err != nil {
+		return "", models.Internal("Error al crear producto", err)
 	}
 	return id, nil
 }
 
-func ProductUpdate(product *models.ProductUpdate, workplace string) error {
-	err := repositories.Repo.UpdateElement(product, workplace)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Elemento no encontrado", err)
+func (s *ProductService) ProductUpdate(product *models.ProductUpdate, workplaceID string, actor models.Actor) error {
+	if product.Identifier != "" {
+		exist, err := s.repo.GetElementByIdentifierEq(product.Identifier, workplaceID, product.ID)
+		if err != nil {
+			return models.Internal("Error al buscar producto", err)
+		}
+
+		if exist {
+			return models.Conflict("El identificador ya existe (puede estar en la papelera)", nil)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
 	}
-	return nil
-}
 
-func ProductUpdateStock(id string, stock *models.StockUpdate, method string, workplace string) error {
-	product, part, err := repositories.Repo.GetElementByID(id, workplace)
+	err := s.repo.UpdateElement(product, workplaceID, actor)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.Product, error) { return s.repo.GetElementByID(product.ID, workplaceID) })
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Elemento no encontrado", err)
+			return models.NotFound("Elemento no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar stock", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
+	return nil
+}
+
+func (s *ProductService) ProductUpdateStock(id string, stock *models.StockUpdate, method string, workplaceID string, actor models.Actor) error {
+	var err error
 	switch method {
 	case "update":
 		if stock.Stock < 0 {
-			return models.ErrorResponse(400, "El stock no puede ser negativo", nil)
+			return models.Validation("El stock no puede ser negativo", nil)
 		}
-		return repositories.Repo.UpdateStock(stock.Stock, id, workplace)
+		err = s.repo.UpdateStock(stock.Stock, id, workplaceID, actor)
 	case "add":
 		if stock.Stock <= 0{
-			return models.ErrorResponse(400, "El stock debe ser mayor a 0", nil)
+			return models.Validation("El stock debe ser mayor a 0", nil)
 		}
-		return repositories.Repo.AddToStock(id, stock.Stock, workplace)
+		err = s.repo.AddToStock(id, stock.Stock, workplaceID, actor)
 	case "subtract":
 		if stock.Stock <= 0{
-			return models.ErrorResponse(400, "El stock debe ser mayor a 0", nil)
-		}
-		if (part != nil && part.Stock < stock.Stock) || (product != nil && product.Stock < stock.Stock) {
-			return models.ErrorResponse(400, "El stock no puede ser negativo", nil)
+			return models.Validation("El stock debe ser mayor a 0", nil)
 		}
-		return repositories.Repo.SubtractFromStockToStock(id, stock.Stock, workplace)
+		err = s.repo.SubtractFromStockToStock(id, stock.Stock, workplaceID, actor)
 	
 	default:
-		return models.ErrorResponse(500, "Método de actualización no soportado", err)
+		return models.BadRequest("Método de actualización no soportado", nil)
 	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.NotFound("Elemento no encontrado", err)
+		}
+		if errors.Is(err, repositories.ErrStockBelowReserved) || errors.Is(err, repositories.ErrInsufficientStock) {
+			return models.Conflict(err.Error(), err)
+		}
+		return models.Internal("Error al actualizar stock", err)
+	}
+	return nil
 }
 
-func ProductDelete(id string, workplace string) error {
-	err := repositories.Repo.DeleteElement(id, workplace)
+func (s *ProductService) ProductDelete(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeleteElement(id, workplaceID, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Producto no encontrado", err)
+			return models.NotFound("Producto no encontrado", err)
+		}
+		if errors.Is(err, repositories.ErrProductReserved) {
+			return models.Conflict(err.Error(), err)
 		}
-		return models.ErrorResponse(500, "Error al eliminar producto", err)
+		return models.Internal("Error al eliminar producto", err)
 	}
 	return nil
 }
\ No newline at end of file
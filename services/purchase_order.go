@@ -8,54 +8,64 @@ import (
 	"gorm.io/gorm"
 )
 
-func PurchaseOrderGetByID(id string, workplace string) (*models.PurchaseOrderLaundry, *models.PurchaseOrderWorkshop, error) {
-	purchaseOrderLaundry, purchaseOrderWorkshop, err := repositories.Repo.GetPurchaseOrderByID(id, workplace)
+type PurchaseOrderService struct {
+	repo repositories.PurchaseOrderRepository
+}
+
+func NewPurchaseOrderService(repo repositories.PurchaseOrderRepository) *PurchaseOrderService {
+	return &PurchaseOrderService{
+		repo: repo,
+	}
+}
+
+func (s *PurchaseOrderService) PurchaseOrderGetByID(id string, workplaceID string) (*models.PurchaseOrder, error) {
+	purchaseOrder, err := s.repo.GetPurchaseOrderByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Empleado no encontrado", err)
+			return nil, models.NotFound("Empleado no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return purchaseOrderLaundry, purchaseOrderWorkshop, nil
+	return purchaseOrder, nil
 }
 
-func PurchaseOrderGetAll(workplace string) (*[]models.PurchaseOrderLaundry, *[]models.PurchaseOrderWorkshop, error) {
-	purchaseOrderLaundry, purchaseOrderWorkshop, err := repositories.Repo.GetAllPurchaseOrders(workplace)
+func (s *PurchaseOrderService) PurchaseOrderGetAll(workplaceID string, params *models.ListParams) (*[]models.PurchaseOrder, *models.Pagination, error) {
+	purchaseOrders, total, err := s.repo.GetAllPurchaseOrders(workplaceID, params)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Empleado no encontrado", err)
-		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, nil, listError(err, "Error al buscar órdenes de compra")
 	}
-	return purchaseOrderLaundry, purchaseOrderWorkshop, nil
+	return purchaseOrders, models.NewPagination(params, total), nil
 }
 
-func PurchaseOrderCreate(purchaseOrder *models.PurchaseOrderCreate, workplace string) (string, error) {
-	id, err := repositories.Repo.CreatePurchaseOrder(purchaseOrder, workplace)
+func (s *PurchaseOrderService) PurchaseOrderCreate(purchaseOrder *models.PurchaseOrderCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.CreatePurchaseOrder(purchaseOrder, workplaceID, actor)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return "", models.Internal("Error al actualizar cliente", err)
 	}
 	return id, nil
 }
 
-func PurchaseOrderUpdate(purchaseOrder *models.PurchaseOrderUpdate, workplace string) error {
-	err := repositories.Repo.UpdatePurchaseOrder(purchaseOrder, workplace)
+func (s *PurchaseOrderService) PurchaseOrderUpdate(purchaseOrder *models.PurchaseOrderUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdatePurchaseOrder(purchaseOrder, workplaceID, actor)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.PurchaseOrder, error) { return s.repo.GetPurchaseOrderByID(purchaseOrder.ID, workplaceID) })
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 	return nil
 }
 
-func PurchaseOrderDelete(id string, workplace string) error {
-	err := repositories.Repo.DeletePurchaseOrderByID(id, workplace)
+func (s *PurchaseOrderService) PurchaseOrderDelete(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeletePurchaseOrderByID(id, workplaceID, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 	return nil
 }
\ No newline at end of file
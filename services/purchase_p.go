@@ -8,54 +8,67 @@ import (
 	"gorm.io/gorm"
 )
 
-func PurchaseProductCreate(purchaseOrder *models.PurchaseProductCreate, workplace string) (string, error) {
-	id, err := repositories.Repo.CreatePurchaseElement(purchaseOrder, workplace)
+type PurchaseProductService struct {
+	repo repositories.PurchaseProductRepository
+}
+
+func NewPurchaseProductService(repo repositories.PurchaseProductRepository) *PurchaseProductService {
+	return &PurchaseProductService{
+		repo: repo,
+	}
+}
+
+func (s *PurchaseProductService) PurchaseProductCreate(purchaseOrder *models.PurchaseProductCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.CreatePurchaseElement(purchaseOrder, workplaceID, actor)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return "", models.Internal("Error al actualizar cliente", err)
 	}
 	return id, nil
 }
 
-func PurchaseProductUpdate(purchaseOrder *models.PurchaseProductUpdate, workplace string) error {
-	err := repositories.Repo.UpdatePurchaseElement(purchaseOrder, workplace)
+func (s *PurchaseProductService) PurchaseProductUpdate(purchaseOrder *models.PurchaseProductUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdatePurchaseElement(purchaseOrder, workplaceID, actor)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.PurchaseProduct, error) { return s.repo.GetPurchaseElementByID(purchaseOrder.ID, workplaceID) })
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 	return nil
 }
 
-func PurchaseProductDelete(id string, workplace string) error {
-	err := repositories.Repo.DeletePurchaseElementByID(id, workplace)
+func (s *PurchaseProductService) PurchaseProductDelete(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeletePurchaseElementByID(id, workplaceID, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Empleado no encontrado", err)
+			return models.NotFound("Empleado no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return models.Internal("Error al actualizar cliente", err)
 	}
 	return nil
 }
 
-func PurchaseProductGetByID(id string, workplace string) (*models.PurchaseProductLaundry, *models.PurchasePartWorkshop, error) {
-	purchaseOrderLaundry, purchaseOrderWorkshop, err := repositories.Repo.GetPurchaseElementByID(id, workplace)
+func (s *PurchaseProductService) PurchaseProductGetByID(id string, workplaceID string) (*models.PurchaseProduct, error) {
+	purchaseOrder, err := s.repo.GetPurchaseElementByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Empleado no encontrado", err)
+			return nil, models.NotFound("Empleado no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return purchaseOrderLaundry, purchaseOrderWorkshop, nil
+	return purchaseOrder, nil
 }
 
-func PurchaseProductGetAllByPurhcaseID(purchaseID string, workplace string) (*[]models.PurchaseProductLaundry, *[]models.PurchasePartWorkshop, error) {
-	purchaseOrderLaundry, purchaseOrderWorkshop, err := repositories.Repo.GetPurchaseElementByPurchaseID(purchaseID, workplace)
+func (s *PurchaseProductService) PurchaseProductGetAllByPurhcaseID(purchaseID string, workplaceID string) (*[]models.PurchaseProduct, error) {
+	purchaseOrder, err := s.repo.GetPurchaseElementByPurchaseID(purchaseID, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Empleado no encontrado", err)
+			return nil, models.NotFound("Empleado no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al actualizar cliente", err)
+		return nil, models.Internal("Error al actualizar cliente", err)
 	}
-	return purchaseOrderLaundry, purchaseOrderWorkshop, nil
+	return purchaseOrder, nil
 }
\ No newline at end of file
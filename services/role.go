@@ -5,10 +5,20 @@ import (
 	"github.com/DanielChachagua/GestionCar/repositories"
 )
 
-func GetRoleAll(role string,workplace string) (*[]models.Role, error) {
-	roles, err := repositories.Repo.GetAllRoles(role, workplace)
+type RoleService struct {
+	repo repositories.RoleRepository
+}
+
+func NewRoleService(repo repositories.RoleRepository) *RoleService {
+	return &RoleService{
+		repo: repo,
+	}
+}
+
+func (s *RoleService) GetRoleAll(role string,workplace string) (*[]models.Role, error) {
+	roles, err := s.repo.GetAllRoles(role, workplace)
 	if err != nil {
-		return nil, models.ErrorResponse(500, "Error al buscar los roles", err)
+		return nil, models.Internal("Error al buscar los roles", err)
 	}
 	return roles, nil
 }
\ No newline at end of file
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/repositories"
+)
+
+const minSearchLength = 2
+
+type SearchService struct {
+	repo repositories.SearchRepository
+}
+
+func NewSearchService(repo repositories.SearchRepository) *SearchService {
+	return &SearchService{
+		repo: repo,
+	}
+}
+
+func (s *SearchService) Search(query string, workplaceID string, limit int) ([]models.SearchHit, error) {
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) < minSearchLength {
+		return nil, models.Validation("La búsqueda debe tener al menos 2 caracteres", nil)
+	}
+
+	hits, err := s.repo.Search(query, workplaceID, limit)
+	if err != nil {
+		return nil, models.Internal("Error al buscar", err)
+	}
+	return hits, nil
+}
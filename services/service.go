@@ -2,66 +2,127 @@ package services
 
 import (
 	"errors"
+	"time"
 
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/DanielChachagua/GestionCar/repositories"
 	"gorm.io/gorm"
 )
 
-func ServiceCreate(service *models.ServiceCreate, workplace string) (string, error) {
-	exist, err := repositories.Repo.GetServiceByName(service.Name, workplace)
+type ServiceService struct {
+	repo repositories.ServiceRepository
+}
+
+func NewServiceService(repo repositories.ServiceRepository) *ServiceService {
+	return &ServiceService{
+		repo: repo,
+	}
+}
+
+func (s *ServiceService) ServiceCreate(service *models.ServiceCreate, workplaceID string, actor models.Actor) (string, error) {
+	exist, err := s.repo.GetServiceByName(service.Name, workplaceID, "")
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al buscar servicio", err)
+		return "", models.Internal("Error al buscar servicio", err)
 	}
 
 	if exist {
-		return "", models.ErrorResponse(400, "El servicio ya existe", nil)
+		return "", models.Conflict("El servicio ya existe (puede estar en la papelera)", nil)
 	}
 
-	id, err := repositories.Repo.CreateService(service, workplace)
+	id, err := s.repo.CreateService(service, workplaceID, actor)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al crear servicio", err)
+		return "", models.Internal("Error al crear servicio", err)
 	}
 	return id, nil
 }
 
-func ServiceUpdate(service *models.ServiceUpdate, workplace string) error {
-	err := repositories.Repo.UpdateService(service, workplace)
+func (s *ServiceService) ServiceUpdate(service *models.ServiceUpdate, workplaceID string, actor models.Actor) error {
+	exist, err := s.repo.GetServiceByName(service.Name, workplaceID, service.ID)
 	if err != nil {
+		return models.Internal("Error al buscar servicio", err)
+	}
+
+	if exist {
+		return models.Conflict("El servicio ya existe (puede estar en la papelera)", nil)
+	}
+
+	err = s.repo.UpdateService(service, workplaceID, actor)
+	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.Service, error) { return s.repo.GetServiceByID(service.ID, workplaceID) })
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Servicio no encontrado", err)
+			return models.NotFound("Servicio no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al buscar servicio", err)
+		return models.Internal("Error al buscar servicio", err)
 	}
 	return nil
 }
 
-func ServiceDeleteByID(id string, workplace string) error {
-	err := repositories.Repo.DeleteServiceByID(id, workplace)
+func (s *ServiceService) ServiceDeleteByID(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeleteServiceByID(id, workplaceID, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Servicio no encontrado", err)
+			return models.NotFound("Servicio no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al buscar servicio", err)
+		return models.Internal("Error al buscar servicio", err)
 	}
 	return nil
 }
 
-func ServiceGetAll(workplace string) (*[]models.ServiceLaundry, *[]models.ServiceWorkshop, error) {
-	servicesLaundry, servicesWorkshop, err := repositories.Repo.GetAllServices(workplace)
+func (s *ServiceService) ServiceGetAll(workplaceID string, params *models.ListParams) (*[]models.Service, *models.Pagination, error) {
+	services, total, err := s.repo.GetAllServices(workplaceID, params)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al obtener servicios", err)
+		return nil, nil, listError(err, "Error al obtener servicios")
 	}
-	return servicesLaundry, servicesWorkshop, nil
+	return services, models.NewPagination(params, total), nil
 }
 
-func ServiceGetByID(id string, workplace string) (*models.ServiceLaundry, *models.ServiceWorkshop, error) {
-	serviceLaundry, serviceWorkshop, err := repositories.Repo.GetServiceByID(id, workplace)
+func (s *ServiceService) ServiceGetByID(id string, workplaceID string) (*models.Service, error) {
+	service, err := s.repo.GetServiceByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Servicio no encontrado", err)
+			return nil, models.NotFound("Servicio no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al buscar servicio", err)
+		return nil, models.Internal("Error al buscar servicio", err)
 	}
-	return serviceLaundry, serviceWorkshop, nil
-}
\ No newline at end of file
+	return service, nil
+}
+
+func (s *ServiceService) ServicePrices(id string, workplaceID string) (*[]models.ServicePrice, error) {
+	prices, err := s.repo.GetServicePrices(id, workplaceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.NotFound("Servicio no encontrado", err)
+		}
+		return nil, models.Internal("Error al buscar los precios del servicio", err)
+	}
+	return &prices, nil
+}
+
+// ServiceSetPrice agrega un precio al servicio, vigente desde ahora o desde
+// la fecha indicada. No se admiten fechas pasadas: el historial muestra lo que
+// rigió y los ingresos ya cobrados no se recalculan.
+func (s *ServiceService) ServiceSetPrice(id string, price *models.ServicePriceCreate, workplaceID string, actor models.Actor) (string, error) {
+	now := time.Now()
+	if price.EffectiveFrom == nil {
+		price.EffectiveFrom = &now
+	} else if price.EffectiveFrom.Before(now) {
+		return "", models.BadRequest("La vigencia del precio no puede ser anterior a ahora", nil)
+	}
+
+	priceID, err := s.repo.CreateServicePrice(id, price, workplaceID, actor)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", models.NotFound("Servicio no encontrado", err)
+		}
+		if errors.Is(err, repositories.ErrDuplicatePrice) {
+			return "", models.Conflict("El servicio ya tiene un precio desde esa fecha", err)
+		}
+		if errors.Is(err, repositories.ErrCategoryPricesDisabled) {
+			return "", models.BadRequest("El lugar de trabajo no tiene precios por categoría de vehículo", err)
+		}
+		return "", models.Internal("Error al cargar el precio del servicio", err)
+	}
+	return priceID, nil
+}
@@ -8,56 +8,69 @@ import (
 	"gorm.io/gorm"
 )
 
-func SupplierCreate(supplier *models.SupplierCreate, workplace string) (string, error) {
-	id, err := repositories.Repo.CreateSupplier(supplier, workplace)
+type SupplierService struct {
+	repo repositories.SupplierRepository
+}
+
+func NewSupplierService(repo repositories.SupplierRepository) *SupplierService {
+	return &SupplierService{
+		repo: repo,
+	}
+}
+
+func (s *SupplierService) SupplierCreate(supplier *models.SupplierCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.CreateSupplier(supplier, workplaceID, actor)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al crear proveedor", err)
+		return "", models.Internal("Error al crear proveedor", err)
 	}
 	return id, nil
 }
 
-func SupplierGetAll(workplace string) (*[]models.SupplierLaundry, *[]models.SupplierWorkshop, error) {
-	suppliersLaundry, suppliersWorkshop, err := repositories.Repo.GetAllSuppliers(workplace)
+func (s *SupplierService) SupplierGetAll(workplaceID string, params *models.ListParams) (*[]models.Supplier, *models.Pagination, error) {
+	suppliers, total, err := s.repo.GetAllSuppliers(workplaceID, params)
 	if err != nil {
-		return nil, nil, models.ErrorResponse(500, "Error al buscar los proveedores", err)
+		return nil, nil, listError(err, "Error al buscar los proveedores")
 	}
-	return &suppliersLaundry, &suppliersWorkshop,nil
+	return &suppliers, models.NewPagination(params, total), nil
 }
 
-func SupplierGetByID(id string, workplace string) (*models.SupplierLaundry, *models.SupplierWorkshop, error) {
-	supplierLaundry, supplierWorkshop, err := repositories.Repo.GetSupplierByID(id, workplace)
+func (s *SupplierService) SupplierGetByID(id string, workplaceID string) (*models.Supplier, error) {
+	supplier, err := s.repo.GetSupplierByID(id, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Proveedor no encontrado", err)
+			return nil, models.NotFound("Proveedor no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al buscar proveedor", err)
+		return nil, models.Internal("Error al buscar proveedor", err)
 	}
-	return supplierLaundry, supplierWorkshop, nil
+	return supplier, nil
 }
 
-func SupplierGetByName(name string, workplace string) (*[]models.SupplierLaundry, *[]models.SupplierWorkshop, error) {
-	supplierLaundry, supplierWorkshop, err := repositories.Repo.GetSupplierByName(name, workplace)
+func (s *SupplierService) SupplierGetByName(name string, workplaceID string) (*[]models.Supplier, error) {
+	supplier, err := s.repo.GetSupplierByName(name, workplaceID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, models.ErrorResponse(404, "Proveedor no encontrado", err)
+			return nil, models.NotFound("Proveedor no encontrado", err)
 		}
-		return nil, nil, models.ErrorResponse(500, "Error al buscar proveedor", err)
+		return nil, models.Internal("Error al buscar proveedor", err)
 	}
-	return supplierLaundry, supplierWorkshop, nil
+	return supplier, nil
 }
 
-func SupplierDeleteByID(id string, workplace string) error {
-	err := repositories.Repo.DeleteSupplierByID(id, workplace)
+func (s *SupplierService) SupplierDeleteByID(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeleteSupplierByID(id, workplaceID, actor)
 	if err != nil {
-		return models.ErrorResponse(500, "Error al eliminar proveedor", err)
+		return models.Internal("Error al eliminar proveedor", err)
 	}
 	return nil
 }
 
-func SupplierUpdate(supplierUpdate *models.SupplierUpdate, workplace string) error {
-	err := repositories.Repo.UpdateSupplier(supplierUpdate, workplace)
+func (s *SupplierService) SupplierUpdate(supplierUpdate *models.SupplierUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdateSupplier(supplierUpdate, workplaceID, actor)
 	if err != nil {
-		return models.ErrorResponse(500, "Error al actualizar proveedor", err)
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.Supplier, error) { return s.repo.GetSupplierByID(supplierUpdate.ID, workplaceID) })
+		}
+		return models.Internal("Error al actualizar proveedor", err)
 	}
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/repositories"
+	"gorm.io/gorm"
+)
+
+type TrashService struct {
+	repo repositories.TrashRepository
+}
+
+func NewTrashService(repo repositories.TrashRepository) *TrashService {
+	return &TrashService{
+		repo: repo,
+	}
+}
+
+func trashError(err error, msg string) error {
+	if errors.Is(err, repositories.ErrUnknownTrashEntity) {
+		return models.BadRequest("Tipo de entidad inválido, se espera uno de: "+strings.Join(repositories.TrashEntityTypes(), ", "), err)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.NotFound("Registro no encontrado en la papelera", err)
+	}
+	if errors.Is(err, repositories.ErrTrashReferenced) || errors.Is(err, repositories.ErrTrashParentDeleted) {
+		return models.Conflict(err.Error(), err)
+	}
+	if errors.Is(err, repositories.ErrProductReserved) {
+		return models.Conflict(err.Error(), err)
+	}
+	return listError(err, msg)
+}
+
+func (s *TrashService) GetTrash(entityType string, workplaceID string, params *models.ListParams) (interface{}, *models.Pagination, error) {
+	items, total, err := s.repo.GetTrash(entityType, workplaceID, params)
+	if err != nil {
+		return nil, nil, trashError(err, "Error al obtener la papelera")
+	}
+	return items, models.NewPagination(params, total), nil
+}
+
+func (s *TrashService) RestoreTrash(entityType string, id string, workplaceID string, actor models.Actor) error {
+	if err := s.repo.RestoreTrash(entityType, id, workplaceID, actor); err != nil {
+		return trashError(err, "Error al restaurar el registro")
+	}
+	return nil
+}
+
+func (s *TrashService) PurgeTrash(entityType string, id string, workplaceID string, actor models.Actor) error {
+	if err := s.repo.PurgeTrash(entityType, id, workplaceID, actor); err != nil {
+		return trashError(err, "Error al eliminar definitivamente el registro")
+	}
+	return nil
+}
@@ -1,25 +1,44 @@
 package services
 
 import (
+	"errors"
+
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/DanielChachagua/GestionCar/repositories"
 	"github.com/DanielChachagua/GestionCar/utils"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-func UserCreate(user *models.UserCreate) (string, error) {
+type UserService struct {
+	repo  repositories.UserRepository
+	roles repositories.RoleRepository
+}
+
+func NewUserService(repo repositories.UserRepository, roles repositories.RoleRepository) *UserService {
+	return &UserService{
+		repo:  repo,
+		roles: roles,
+	}
+}
+
+func (s *UserService) UserCreate(caller *models.User, user *models.UserCreate) (string, error) {
+	if err := s.checkAssignableRole(caller, user.Role); err != nil {
+		return "", err
+	}
+
 	// Check if the user already exists
-	existingUser, err := repositories.Repo.GetUserByUsernameEmail(user.Username, user.Email)
+	existingUser, err := s.repo.GetUserByUsernameEmail(user.Username, user.Email)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al buscar el usuario", err)
+		return "", models.Internal("Error al buscar el usuario", err)
 	}
 	if existingUser {
-		return "", models.ErrorResponse(400, "El username o el email ya existe", nil)
+		return "", models.Conflict("El username o el email ya existe", nil)
 	}
 
 	pass, err := utils.HashPassword(user.Password)
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al hashear la contraseña", err)
+		return "", models.Internal("Error al hashear la contraseña", err)
 	}
 	// Create the new user
 	newUser := &models.User{
@@ -31,10 +50,162 @@ func UserCreate(user *models.UserCreate) (string, error) {
 		Username: user.Username,
 		Password: pass,
 	}
-	err = repositories.Repo.CreateUser(newUser)
+	err = s.repo.CreateUser(newUser, models.Actor{UserID: caller.ID})
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al crear el usuario", err)
+		return "", models.Internal("Error al crear el usuario", err)
 	}
 
 	return newUser.ID, nil
-}
\ No newline at end of file
+}
+
+func (s *UserService) UserGetAll(params *models.ListParams) (*[]models.UserDTO, *models.Pagination, error) {
+	users, total, err := s.repo.ListUsers(params)
+	if err != nil {
+		return nil, nil, listError(err, "Error al buscar los usuarios")
+	}
+
+	dtos := make([]models.UserDTO, 0, len(users))
+	for _, user := range users {
+		dtos = append(dtos, *user.DTO())
+	}
+	return &dtos, models.NewPagination(params, total), nil
+}
+
+func (s *UserService) UserGetByID(id string) (*models.UserDTO, error) {
+	user, err := s.getUser(id)
+	if err != nil {
+		return nil, err
+	}
+	return user.DTO(), nil
+}
+
+// UserUpdate actualiza el perfil. Cada usuario puede editar el suyo; el de
+// otro solo si su rol está por debajo del rol de caller.
+func (s *UserService) UserUpdate(caller *models.User, update *models.UserUpdate) (*models.UserDTO, error) {
+	user, err := s.getUser(update.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID != caller.ID {
+		if err := s.checkManageable(caller, user); err != nil {
+			return nil, err
+		}
+	}
+
+	exists, err := s.repo.ExistsOtherUser(user.ID, update.Username, update.Email)
+	if err != nil {
+		return nil, models.Internal("Error al buscar el usuario", err)
+	}
+	if exists {
+		return nil, models.Conflict("El username o el email ya existe", nil)
+	}
+
+	user.FirstName = update.FirstName
+	user.LastName = update.LastName
+	user.Username = update.Username
+	user.Email = update.Email
+	if err := s.repo.UpdateUser(user, models.Actor{UserID: caller.ID}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.NotFound("Usuario no encontrado", err)
+		}
+		return nil, models.Internal("Error al actualizar el usuario", err)
+	}
+	return s.UserGetByID(user.ID)
+}
+
+// UserUpdateRole cambia el rol de un usuario de menor jerarquía que caller
+// por otro rol también de menor jerarquía.
+func (s *UserService) UserUpdateRole(caller *models.User, id string, role string) (*models.UserDTO, error) {
+	user, err := s.getUser(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkManageable(caller, user); err != nil {
+		return nil, err
+	}
+	if err := s.checkAssignableRole(caller, role); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateUserRole(user.ID, role, models.Actor{UserID: caller.ID}); err != nil {
+		return nil, models.Internal("Error al cambiar el rol", err)
+	}
+	return s.UserGetByID(user.ID)
+}
+
+// UserSetActive activa o desactiva la cuenta. Un usuario desactivado no puede
+// loguearse ni usar los tokens que ya tenía.
+func (s *UserService) UserSetActive(caller *models.User, id string, active bool) (*models.UserDTO, error) {
+	user, err := s.getUser(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkManageable(caller, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetUserActive(user.ID, active, models.Actor{UserID: caller.ID}); err != nil {
+		return nil, models.Internal("Error al actualizar el usuario", err)
+	}
+	return s.UserGetByID(user.ID)
+}
+
+func (s *UserService) UserDelete(caller *models.User, id string) error {
+	user, err := s.getUser(id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkManageable(caller, user); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteUser(user.ID, models.Actor{UserID: caller.ID}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.NotFound("Usuario no encontrado", err)
+		}
+		return models.Internal("Error al eliminar el usuario", err)
+	}
+	return nil
+}
+
+func (s *UserService) getUser(id string) (*models.User, error) {
+	user, err := s.repo.GetUserByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.NotFound("Usuario no encontrado", err)
+		}
+		return nil, models.Internal("Error al buscar el usuario", err)
+	}
+	return user, nil
+}
+
+// checkManageable exige que user tenga un rol por debajo del de caller, como
+// los que devuelve GetAllRoles. Nadie se administra a sí mismo por acá.
+func (s *UserService) checkManageable(caller *models.User, user *models.User) error {
+	if caller.ID == user.ID {
+		return models.Forbidden("No se puede modificar el propio usuario", nil)
+	}
+	return s.checkAssignableRole(caller, user.Role)
+}
+
+// checkAssignableRole exige que role exista y esté por debajo del rol de caller.
+func (s *UserService) checkAssignableRole(caller *models.User, role string) error {
+	current, err := s.roles.GetRoleByName(caller.Role)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Forbidden("Rol no encontrado", err)
+		}
+		return models.Internal("Error al buscar rol", err)
+	}
+	target, err := s.roles.GetRoleByName(role)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.BadRequest("Rol inexistente: "+role, err)
+		}
+		return models.Internal("Error al buscar rol", err)
+	}
+	if target.Hierarchy <= current.Hierarchy {
+		return models.Forbidden("Solo se pueden administrar usuarios de roles de menor jerarquía", nil)
+	}
+	return nil
+}
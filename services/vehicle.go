@@ -9,97 +9,124 @@ import (
 	"gorm.io/gorm"
 )
 
-func VehicleCreate(vehicleCreate *models.VehicleCreate) (string , error) {
-	exist, err := repositories.Repo.GetVehicleByDomainEq(vehicleCreate.Domain)
+type VehicleService struct {
+	repo repositories.VehicleRepository
+}
+
+func NewVehicleService(repo repositories.VehicleRepository) *VehicleService {
+	return &VehicleService{
+		repo: repo,
+	}
+}
+
+func (s *VehicleService) VehicleCreate(vehicleCreate *models.VehicleCreate, actor models.Actor) (string , error) {
+	exist, err := s.repo.GetVehicleByDomainEq(vehicleCreate.Domain, "")
 	if err != nil {
-		return "", models.ErrorResponse(500, "Error al buscar el vehiculo", err)
+		return "", models.Internal("Error al buscar el vehiculo", err)
 	}
 
 	if exist {
-		return "", models.ErrorResponse(400, "El dominio ya existe", nil)
+		return "", models.Conflict("El dominio ya existe (puede estar en la papelera)", nil)
 	}
 
-	vehicle, err := repositories.Repo.CreateVehicle(&models.Vehicle{
+	vehicle, err := s.repo.CreateVehicle(&models.Vehicle{
 		ID: uuid.NewString(),
 		Domain:   vehicleCreate.Domain,
 		Brand:    vehicleCreate.Brand,
 		Model:    vehicleCreate.Model,
 		Color:    vehicleCreate.Color,
 		Year:     vehicleCreate.Year,
+		Category: vehicleCreate.Category,
 		ClientID: vehicleCreate.ClientID,
-	})
+	}, actor)
 
 	if err != nil {
-		models.ErrorResponse(500, "Error al crear el vehiculo", err)
+		return "", models.Internal("Error al crear el vehiculo", err)
 	}
 
 	return vehicle, nil
 }
 
-func VehicleGetAll() (*[]models.Vehicle, error) {
-	vehicles, err := repositories.Repo.GetAllVehicles()
+func (s *VehicleService) VehicleGetAll(params *models.ListParams) (*[]models.Vehicle, *models.Pagination, error) {
+	vehicles, total, err := s.repo.GetAllVehicles(params)
 	if err != nil {
-		return nil, models.ErrorResponse(500, "Error al buscar los vehiculos", err)
+		return nil, nil, listError(err, "Error al buscar los vehiculos")
 	}
-	return &vehicles, nil
+	return &vehicles, models.NewPagination(params, total), nil
 }
 
-func VehicleGetByID(id string) (*models.Vehicle, error) {
-	vehicle, err := repositories.Repo.GetVehicleByID(id)
+func (s *VehicleService) VehicleGetByID(id string) (*models.Vehicle, error) {
+	vehicle, err := s.repo.GetVehicleByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, models.ErrorResponse(404, "Vehiculo no encontrado", err)
+			return nil, models.NotFound("Vehiculo no encontrado", err)
 		}
-		return nil, models.ErrorResponse(500, "Error al buscar usuario", err)
+		return nil, models.Internal("Error al buscar el vehiculo", err)
 	}
 	return vehicle, nil
 }
 
-func VehicleGetByDomain(domain string) (*[]models.Vehicle, error) {
-	vehicle, err := repositories.Repo.GetVehicleByDomain(domain)
+func (s *VehicleService) VehicleGetByDomain(domain string) (*[]models.Vehicle, error) {
+	vehicle, err := s.repo.GetVehicleByDomain(domain)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, models.ErrorResponse(404, "Vehiculo no encontrado", err)
+			return nil, models.NotFound("Vehiculo no encontrado", err)
 		}
-		return nil, models.ErrorResponse(500, "Error al buscar usuario", err)
+		return nil, models.Internal("Error al buscar el vehiculo", err)
 	}
 	return vehicle, nil
 }
 
-func VehicleGetByClientID(clientID string) (*[]models.Vehicle, error) {
-	vehicles, err := repositories.Repo.GetVehicleByClientID(clientID)
+func (s *VehicleService) VehicleGetByClientID(clientID string) (*[]models.Vehicle, error) {
+	vehicles, err := s.repo.GetVehicleByClientID(clientID)
 	if err != nil {
-		return nil, models.ErrorResponse(500, "Error al buscar los vehiculos", err)
+		return nil, models.Internal("Error al buscar los vehiculos", err)
 	}
 	return vehicles, nil
 }
 
-func VehicleUpdate(vehicleUpdate *models.VehicleUpdate) error {
-	err := repositories.Repo.UpdateVehicle(&models.Vehicle{
+func (s *VehicleService) VehicleUpdate(vehicleUpdate *models.VehicleUpdate, actor models.Actor) error {
+	if vehicleUpdate.Domain != "" {
+		exist, err := s.repo.GetVehicleByDomainEq(vehicleUpdate.Domain, vehicleUpdate.ID)
+		if err != nil {
+			return models.Internal("Error al buscar el vehiculo", err)
+		}
+
+		if exist {
+			return models.Conflict("El dominio ya existe (puede estar en la papelera)", nil)
+		}
+	}
+
+	err := s.repo.UpdateVehicle(&models.Vehicle{
 		ID:       vehicleUpdate.ID,
 		Domain:   vehicleUpdate.Domain,		
 		Brand:    vehicleUpdate.Brand,
 		Model:    vehicleUpdate.Model,
 		Color:    vehicleUpdate.Color,
 		Year:     vehicleUpdate.Year,
-	})
+		Category: vehicleUpdate.Category,
+		Version:  vehicleUpdate.Version,
+	}, actor)
 
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return versionConflict(err, func() (*models.Vehicle, error) { return s.repo.GetVehicleByID(vehicleUpdate.ID) })
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Vehiculo no encontrado", err)
+			return models.NotFound("Vehiculo no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al eliminar cliente", err)
+		return models.Internal("Error al actualizar el vehiculo", err)
 	}
 	return nil
 }
 
-func VehicleDelete(id string) (error) {
-	err := repositories.Repo.DeleteVehicle(id)
+func (s *VehicleService) VehicleDelete(id string, actor models.Actor) (error) {
+	err := s.repo.DeleteVehicle(id, actor)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.ErrorResponse(404, "Vehiculo no encontrado", err)
+			return models.NotFound("Vehiculo no encontrado", err)
 		}
-		return models.ErrorResponse(500, "Error al eliminar cliente", err)
+		return models.Internal("Error al eliminar el vehiculo", err)
 	}
 	return nil
 }
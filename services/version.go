@@ -0,0 +1,15 @@
+package services
+
+import (
+	"github.com/DanielChachagua/GestionCar/models"
+)
+
+// versionConflict arma el 409 de una escritura hecha sobre una versión vieja.
+// get trae el estado actual, que viaja en el body de la respuesta.
+func versionConflict[T any](err error, get func() (T, error)) error {
+	current, getErr := get()
+	if getErr != nil {
+		return models.Internal("Error al obtener el registro", getErr)
+	}
+	return models.VersionConflict("El registro fue modificado por otro usuario, recargá los datos y volvé a intentar", current, err)
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/DanielChachagua/GestionCar/models"
+	"github.com/DanielChachagua/GestionCar/repositories"
+	"gorm.io/gorm"
+)
+
+type WorkOrderService struct {
+	repo repositories.WorkOrderRepository
+}
+
+func NewWorkOrderService(repo repositories.WorkOrderRepository) *WorkOrderService {
+	return &WorkOrderService{
+		repo: repo,
+	}
+}
+
+func (s *WorkOrderService) GetWorkOrderByID(id string, workplaceID string) (*models.WorkOrder, error) {
+	workOrder, err := s.repo.GetWorkOrderByID(id, workplaceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.NotFound("Orden de trabajo no encontrada", err)
+		}
+		return nil, models.Internal("Error al buscar orden de trabajo", err)
+	}
+	return workOrder, nil
+}
+
+func (s *WorkOrderService) GetAllWorkOrders(workplaceID string, params *models.ListParams) (*[]models.WorkOrder, *models.Pagination, error) {
+	workOrders, total, err := s.repo.GetAllWorkOrders(workplaceID, params)
+	if err != nil {
+		return nil, nil, listError(err, "Error al buscar órdenes de trabajo")
+	}
+	return workOrders, models.NewPagination(params, total), nil
+}
+
+// workOrderError traduce los errores comunes a las escrituras sobre una orden
+// existente; el resto pasa por incomeError, que cubre también la entrega.
+func (s *WorkOrderService) workOrderError(err error, id string, workplaceID string, msg string) error {
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return versionConflict(err, func() (*models.WorkOrder, error) { return s.repo.GetWorkOrderByID(id, workplaceID) })
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.NotFound("Orden de trabajo o repuesto no encontrado", err)
+	}
+	if errors.Is(err, repositories.ErrInvalidTransition) || errors.Is(err, repositories.ErrWorkOrderDelivered) ||
+		errors.Is(err, repositories.ErrDuplicatePart) || errors.Is(err, repositories.ErrWorkOrderHasParts) {
+		return models.Conflict(err.Error(), err)
+	}
+	if errors.Is(err, repositories.ErrWorkOrderReference) || errors.Is(err, repositories.ErrInsufficientStock) {
+		return models.BadRequest(err.Error(), err)
+	}
+	return incomeError(err, msg)
+}
+
+func (s *WorkOrderService) CreateWorkOrder(workOrder *models.WorkOrderCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.CreateWorkOrder(workOrder, workplaceID, actor)
+	if err != nil {
+		if errors.Is(err, repositories.ErrWorkOrderReference) {
+			return "", models.BadRequest(err.Error(), err)
+		}
+		return "", models.Internal("Error al crear orden de trabajo", err)
+	}
+	return id, nil
+}
+
+func (s *WorkOrderService) UpdateWorkOrder(workOrder *models.WorkOrderUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdateWorkOrder(workOrder, workplaceID, actor)
+	if err != nil {
+		return s.workOrderError(err, workOrder.ID, workplaceID, "Error al actualizar orden de trabajo")
+	}
+	return nil
+}
+
+func (s *WorkOrderService) ChangeWorkOrderStatus(id string, status *models.WorkOrderStatusUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.ChangeWorkOrderStatus(id, status, workplaceID, actor)
+	if err != nil {
+		return s.workOrderError(err, id, workplaceID, "Error al cambiar el estado de la orden de trabajo")
+	}
+	return nil
+}
+
+func (s *WorkOrderService) DeliverWorkOrder(id string, deliver *models.WorkOrderDeliver, workplaceID string, actor models.Actor) (string, error) {
+	incomeID, err := s.repo.DeliverWorkOrder(id, deliver, workplaceID, actor)
+	if err != nil {
+		return "", s.workOrderError(err, id, workplaceID, "Error al entregar orden de trabajo")
+	}
+	return incomeID, nil
+}
+
+func (s *WorkOrderService) DeleteWorkOrder(id string, workplaceID string, actor models.Actor) error {
+	err := s.repo.DeleteWorkOrderByID(id, workplaceID, actor)
+	if err != nil {
+		return s.workOrderError(err, id, workplaceID, "Error al eliminar orden de trabajo")
+	}
+	return nil
+}
+
+func (s *WorkOrderService) AddWorkOrderPart(workOrderID string, part *models.WorkOrderPartCreate, workplaceID string, actor models.Actor) (string, error) {
+	id, err := s.repo.AddWorkOrderPart(workOrderID, part, workplaceID, actor)
+	if err != nil {
+		return "", s.workOrderError(err, workOrderID, workplaceID, "Error al agregar repuesto")
+	}
+	return id, nil
+}
+
+func (s *WorkOrderService) UpdateWorkOrderPart(workOrderID string, part *models.WorkOrderPartUpdate, workplaceID string, actor models.Actor) error {
+	err := s.repo.UpdateWorkOrderPart(workOrderID, part, workplaceID, actor)
+	if err != nil {
+		return s.workOrderError(err, workOrderID, workplaceID, "Error al actualizar repuesto")
+	}
+	return nil
+}
+
+func (s *WorkOrderService) RemoveWorkOrderPart(workOrderID string, partID string, version int64, workplaceID string, actor models.Actor) error {
+	err := s.repo.RemoveWorkOrderPart(workOrderID, partID, version, workplaceID, actor)
+	if err != nil {
+		return s.workOrderError(err, workOrderID, workplaceID, "Error al quitar repuesto")
+	}
+	return nil
+}
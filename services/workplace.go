@@ -3,12 +3,45 @@ package services
 import (
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/DanielChachagua/GestionCar/repositories"
+	"github.com/google/uuid"
 )
 
-func GetWorkplaceAll(role string) (*[]models.Workplace, error) {
-	workplaces, err := repositories.Repo.GetWorkplaceAll(role)
+type WorkplaceService struct {
+	repo repositories.WorkplaceRepository
+}
+
+func NewWorkplaceService(repo repositories.WorkplaceRepository) *WorkplaceService {
+	return &WorkplaceService{
+		repo: repo,
+	}
+}
+
+func (s *WorkplaceService) GetWorkplaceAll(role string) (*[]models.Workplace, error) {
+	workplaces, err := s.repo.GetWorkplaceAll(role)
 	if err != nil {
-		return nil, models.ErrorResponse(500, "Error al buscar los lugares de trabajo", err)
+		return nil, models.Internal("Error al buscar los lugares de trabajo", err)
 	}
 	return workplaces, nil
-}
\ No newline at end of file
+}
+
+func (s *WorkplaceService) CreateWorkplace(workplaceCreate *models.WorkplaceCreate, actor models.Actor) (string, error) {
+	exist, err := s.repo.GetWorkplaceByIdentifier(workplaceCreate.Identifier)
+	if err == nil && exist != nil {
+		return "", models.Conflict("El identificador ya existe", nil)
+	}
+
+	id, err := s.repo.CreateWorkplace(&models.Workplace{
+		ID:             uuid.NewString(),
+		Name:           workplaceCreate.Name,
+		Address:        workplaceCreate.Address,
+		Phone:          workplaceCreate.Phone,
+		Email:          workplaceCreate.Email,
+		Identifier:     workplaceCreate.Identifier,
+		WorkOrders:     workplaceCreate.WorkOrders,
+		CategoryPrices: workplaceCreate.CategoryPrices,
+	}, actor)
+	if err != nil {
+		return "", models.Internal("Error al crear el lugar de trabajo", err)
+	}
+	return id, nil
+}
@@ -1,23 +1,34 @@
 package utils
 
 import (
-	"os"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"strings"
+	"time"
 
 	"github.com/DanielChachagua/GestionCar/models"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-func GenerateUserToken(user *models.User) (string, error) {
+// GenerateUserToken firma el access token del usuario. Lleva exp, iat y un
+// jti único para poder revocarlo en el logout.
+func GenerateUserToken(user *models.User, secret string, ttl time.Duration) (string, error) {
+	now := time.Now()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"id": user.ID,
 		"first_name": user.FirstName,
 		"last_name":  user.LastName,
 		"username":  user.Username,
 		"role": user.Role,
+		"iat": now.Unix(),
+		"exp": now.Add(ttl).Unix(),
+		"jti": uuid.NewString(),
 	})
 
-	t, err := token.SignedString([]byte(os.Getenv("SECRET_KEY")))
+	t, err := token.SignedString([]byte(secret))
 	if err != nil {
 		return "", err
 	}
@@ -25,11 +36,13 @@ func GenerateUserToken(user *models.User) (string, error) {
 	return t, nil
 }
 
-func VerifyToken(tokenString string) (jwt.Claims, error) {
+// VerifyToken valida firma, algoritmo y vencimiento. Los tokens sin exp
+// (emitidos antes de que vencieran) se rechazan.
+func VerifyToken(tokenString string, secret string) (jwt.Claims, error) {
 	cleanToken := CleanToken(tokenString)
 	token, err := jwt.Parse(cleanToken, func(token *jwt.Token) (interface{}, error) {
-		return []byte(os.Getenv("SECRET_KEY")), nil
-	})
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
 	if err != nil {
 		return nil, err
 	}
@@ -45,12 +58,19 @@ func CleanToken(bearerToken string) string {
 	return bearerToken
 }
 
-func GenerateWorkplaceToken(workplace *models.Workplace) (string, error) {
+// GenerateWorkplaceToken firma el token del workplace atado al usuario que lo
+// pidió: WorkplaceMiddleware lo rechaza si lo presenta otro usuario.
+func GenerateWorkplaceToken(workplace *models.Workplace, userID string, secret string, ttl time.Duration) (string, error) {
+	now := time.Now()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"id": workplace.ID,
+		"user_id": userID,
+		"iat": now.Unix(),
+		"exp": now.Add(ttl).Unix(),
+		"jti": uuid.NewString(),
 	})
 
-	t, err := token.SignedString([]byte(os.Getenv("SECRET_KEY_WORKPLACE")))
+	t, err := token.SignedString([]byte(secret))
 	if err != nil {
 		return "", err
 	}
@@ -58,14 +78,24 @@ func GenerateWorkplaceToken(workplace *models.Workplace) (string, error) {
 	return t, nil
 }
 
-func VerifyWorkplaceToken(tokenString string) (jwt.Claims, error) {
-	cleanToken := CleanToken(tokenString)
-	token, err := jwt.Parse(cleanToken, func(token *jwt.Token) (interface{}, error) {
-		return []byte(os.Getenv("SECRET_KEY_WORKPLACE")), nil
-	})
-	if err != nil {
-		return nil, err
+func VerifyWorkplaceToken(tokenString string, secret string) (jwt.Claims, error) {
+	return VerifyToken(tokenString, secret)
+}
+
+// GenerateOpaqueToken devuelve un token opaco (refresh token, token de
+// recuperación) y el hash con el que se guarda: en la base nunca queda el
+// token en claro.
+func GenerateOpaqueToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
 	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashToken(token), nil
+}
 
-	return token.Claims, nil
-}
\ No newline at end of file
+// HashToken es el hash con el que se buscan los tokens opacos guardados.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}